@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectTemplateSpec defines a parameterized manifest.
+type ObjectTemplateSpec struct {
+	// Template is a Go template of a YAML manifest, rendered for every Object
+	// referencing this ObjectTemplate. The parameters are available as
+	// {{ .name }}, and rendering fails if a parameter has no value. Like
+	// manifestYAML, it may contain multiple documents.
+	Template string `json:"template"`
+	// Defaults are the default values of the template parameters.
+	// +optional
+	Defaults map[string]string `json:"defaults,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ObjectTemplate is a reusable, parameterized manifest that Objects can
+// refer to instead of repeating the manifest.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,kubernetes}
+type ObjectTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ObjectTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectTemplateList contains a list of ObjectTemplate
+type ObjectTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectTemplate `json:"items"`
+}
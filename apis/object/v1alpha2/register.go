@@ -45,6 +45,15 @@ var (
 	ObjectGroupVersionKind = SchemeGroupVersion.WithKind(ObjectKind)
 )
 
+// ObjectTemplate type metadata.
+var (
+	ObjectTemplateKind             = reflect.TypeOf(ObjectTemplate{}).Name()
+	ObjectTemplateGroupKind        = schema.GroupKind{Group: Group, Kind: ObjectTemplateKind}.String()
+	ObjectTemplateKindAPIVersion   = ObjectTemplateKind + "." + SchemeGroupVersion.String()
+	ObjectTemplateGroupVersionKind = SchemeGroupVersion.WithKind(ObjectTemplateKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Object{}, &ObjectList{})
+	SchemeBuilder.Register(&ObjectTemplate{}, &ObjectTemplateList{})
 }
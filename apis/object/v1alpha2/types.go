@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"errors"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,6 +47,21 @@ type DependsOn struct {
 	// Namespace of the referenced object.
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// MatchFields are values fields of the referenced object must have, e.g.
+	// status.phase Active, for the dependency to be satisfied. The Object
+	// waits until they all do, as it waits for the referenced object to
+	// exist.
+	// +optional
+	MatchFields []MatchField `json:"matchFields,omitempty"`
+}
+
+// A MatchField is a value a field of a referenced object must have.
+type MatchField struct {
+	// FieldPath of the referenced object, e.g. status.phase.
+	FieldPath string `json:"fieldPath"`
+	// Value the field path must have. Values that are not strings are
+	// compared by their JSON representation, e.g. true or 3.
+	Value string `json:"value"`
 }
 
 // PatchesFrom refers to an object by Name, Kind, APIVersion, etc., and patch
@@ -56,6 +73,21 @@ type PatchesFrom struct {
 	FieldPath *string `json:"fieldPath"`
 }
 
+// PatchesFromConnectionSecret refers to a managed resource by Name, Kind,
+// APIVersion, etc., and patch a key of its connection secret.
+type PatchesFromConnectionSecret struct {
+	DependsOn `json:",inline"`
+	// Key of the connection secret of the managed resource whose value is to
+	// be used as input.
+	Key string `json:"key"`
+}
+
+// PatchesFromValue refers to a value of spec.forProvider.valuesFrom.
+type PatchesFromValue struct {
+	// Name of the value.
+	Name string `json:"name"`
+}
+
 // Reference refers to an Object or arbitrary Kubernetes resource and optionally
 // patch values from that resource to the current Object.
 type Reference struct {
@@ -67,37 +99,1024 @@ type Reference struct {
 	// Kubernetes resource, and also patch fields from this object.
 	// +optional
 	*PatchesFrom `json:"patchesFrom,omitempty"`
+	// PatchesFromConnectionSecret is used to declare dependency on another
+	// managed resource, and also patch the value of a key of its connection
+	// secret. The value is read on every reconcile, so the manifest is updated
+	// when the secret rotates. ToFieldPath is required.
+	// +optional
+	*PatchesFromConnectionSecret `json:"patchesFromConnectionSecret,omitempty"`
+	// PatchesFromValue patches a value of spec.forProvider.valuesFrom.
+	// ToFieldPath is required.
+	// +optional
+	PatchesFromValue *PatchesFromValue `json:"patchesFromValue,omitempty"`
 	// ToFieldPath is the path of the field on the resource whose value will
 	// be changed with the result of transforms. Leave empty if you'd like to
 	// propagate to the same path as patchesFrom.fieldPath.
 	// +optional
 	ToFieldPath *string `json:"toFieldPath,omitempty"`
+	// PatchTo defines what toFieldPath refers to. With Manifest, it is a
+	// field of the manifest. With Metadata, it is a label or annotation of
+	// the Object itself, e.g. labels[example.org/env], so that values
+	// resolved from dependencies can label the Object. With
+	// ConnectionSecret, it is a key of the connection secret of the Object.
+	// +optional
+	// +kubebuilder:validation:Enum=Manifest;Metadata;ConnectionSecret
+	// +kubebuilder:default=Manifest
+	PatchTo PatchTarget `json:"patchTo,omitempty"`
+	// Policy of the reference. A Required reference blocks the Object until
+	// its resource, field or connection secret key exists. An Optional
+	// reference whose resource, field or key is missing is skipped, so that
+	// the manifest is applied without its patch, and recorded in
+	// status.atProvider.skippedReferences.
+	// +optional
+	// +kubebuilder:validation:Enum=Required;Optional
+	// +kubebuilder:default=Required
+	Policy ReferencePolicy `json:"policy,omitempty"`
 }
 
+// A ReferencePolicy determines whether a reference blocks an Object.
+type ReferencePolicy string
+
+const (
+	// ReferencePolicyRequired means the Object is blocked until the
+	// reference resolves.
+	ReferencePolicyRequired ReferencePolicy = "Required"
+	// ReferencePolicyOptional means the reference is skipped while it does
+	// not resolve.
+	ReferencePolicyOptional ReferencePolicy = "Optional"
+)
+
+// A PatchTarget is what a reference patches to.
+type PatchTarget string
+
+const (
+	// PatchToManifest patches to a field of the manifest.
+	PatchToManifest PatchTarget = "Manifest"
+	// PatchToMetadata patches to a label or annotation of the Object.
+	PatchToMetadata PatchTarget = "Metadata"
+	// PatchToConnectionSecret patches to a key of the connection secret of
+	// the Object.
+	PatchToConnectionSecret PatchTarget = "ConnectionSecret"
+)
+
 // ObjectParameters are the configurable fields of a Object.
+// +kubebuilder:validation:XValidation:rule="has(self.manifest) || has(self.manifestYAML) || has(self.templateRef) || has(self.manifestFrom)",message="either manifest, manifestYAML, templateRef or manifestFrom must be set"
 type ObjectParameters struct {
-	// Raw JSON representation of the kubernetes object to be created.
+	// Raw JSON representation of the kubernetes object to be created. A v1
+	// List manifest manages each of its items.
 	// +kubebuilder:validation:EmbeddedResource
 	// +kubebuilder:pruning:PreserveUnknownFields
-	Manifest runtime.RawExtension `json:"manifest"`
+	// +optional
+	Manifest runtime.RawExtension `json:"manifest,omitempty"`
+	// ManifestYAML is a YAML representation of the kubernetes object to be
+	// created, as an alternative to manifest. It may contain multiple
+	// documents, in which case each of them is managed as an item of a v1
+	// List. If set, it takes precedence over manifest.
+	// +optional
+	ManifestYAML string `json:"manifestYAML,omitempty"`
+	// TemplateRef refers to an ObjectTemplate whose template is rendered with
+	// the supplied parameters to produce the manifest, as an alternative to
+	// manifest and manifestYAML. If set, it takes precedence over both.
+	// +optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+	// ManifestFrom selects the manifest from keys of ConfigMaps or Secrets
+	// on the control plane, as an alternative to manifest, manifestYAML and
+	// templateRef for manifests too large to be stored in the Object. The
+	// values of the keys are concatenated in order, so that a manifest may
+	// be split across several ConfigMaps or Secrets, and parsed like
+	// manifestYAML. If set, it takes precedence over all of them. To keep
+	// the Object small as well, only the apiVersion, kind, metadata and
+	// status of the remote objects are recorded in status.atProvider.manifest.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	ManifestFrom []ManifestSource `json:"manifestFrom,omitempty"`
+	// ValuesFrom are named values read from ConfigMaps, Secrets or fields of
+	// resources, e.g. other Objects. They are available as parameters of
+	// templateRef, overriding the defaults of the ObjectTemplate but not the
+	// parameters, and to references patching from them with
+	// patchesFromValue, so that a single source can feed many patches.
+	// Values whose source does not exist are unset.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ValuesFrom []ValueSource `json:"valuesFrom,omitempty"`
+	// KubeconfigSecretRef refers to a key of a Secret holding the kubeconfig
+	// of the target cluster, to target it without a provider config, e.g.
+	// for clusters that come and go too often to manage provider configs for
+	// them. The kubeconfig may hold the endpoint of the target cluster and a
+	// service account token. If set, the provider config of the Object is
+	// ignored, and so are its defaults, attribution and concurrency budget.
+	// +optional
+	KubeconfigSecretRef *xpv1.SecretKeySelector `json:"kubeconfigSecretRef,omitempty"`
+	// UpdatePolicy defines what to do when the remote object cannot be updated
+	// to match the manifest. With RecreateOnImmutableError, the remote object
+	// is deleted and created again if the update is rejected because it
+	// changes an immutable field.
+	// +optional
+	// +kubebuilder:validation:Enum=Default;RecreateOnImmutableError
+	// +kubebuilder:default=Default
+	UpdatePolicy UpdatePolicy `json:"updatePolicy,omitempty"`
+	// UpdateStrategy defines how the remote object is updated to match the
+	// manifest. Patch merges the manifest into the remote object, while
+	// Replace overwrites the remote object with the manifest, wiping any field
+	// not present in it. It is ignored if server-side apply is enabled.
+	// +optional
+	// +kubebuilder:validation:Enum=Patch;Replace
+	// +kubebuilder:default=Patch
+	UpdateStrategy UpdateStrategy `json:"updateStrategy,omitempty"`
+	// OptimisticConcurrency pins the patches of the remote objects to the
+	// resourceVersion they were observed with, so that changes made to them
+	// by others in the meantime are not blindly overwritten. Patches that
+	// conflict are retried with a fresh read of the remote object. It only
+	// applies to the Patch update strategy, and is ignored if server-side
+	// apply, which detects conflicting fields by itself, is enabled.
+	// +optional
+	OptimisticConcurrency *OptimisticConcurrency `json:"optimisticConcurrency,omitempty"`
+	// IgnorePresets are named sets of well-known fields that are mutated on
+	// the remote object by other controllers, and that should not be
+	// considered when deciding whether the remote object is up-to-date.
+	// +optional
+	IgnorePresets []IgnorePreset `json:"ignorePresets,omitempty"`
+	// IgnoreFields are the field paths, e.g. spec.ports[*].nodePort, that
+	// should not be considered when deciding whether the remote object is
+	// up-to-date.
+	// +optional
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+	// CompareLiveOnlyFields considers fields that only exist on the remote
+	// object, and not in its desired state, when deciding whether the remote
+	// object is up-to-date. By default, such fields, e.g. populated by the API
+	// server or other controllers, are not considered to prevent update loops.
+	// It only has an effect with server-side dry-run, since otherwise the
+	// observed state of the remote object never contains such fields.
+	// +optional
+	CompareLiveOnlyFields bool `json:"compareLiveOnlyFields,omitempty"`
+	// Normalization ignores mutations of the remote object by mutating
+	// webhooks of the target cluster, e.g. injected sidecar containers or
+	// default tolerations, when deciding whether it is up-to-date.
+	// +optional
+	Normalization *Normalization `json:"normalization,omitempty"`
+	// TargetOwnerRef refers to another Object, targeting the same cluster,
+	// whose remote object should own the remote object of this Object on the
+	// target cluster, so that it is garbage collected along with its owner.
+	// +optional
+	TargetOwnerRef *TargetOwnerReference `json:"targetOwnerRef,omitempty"`
+	// ProtectTarget adds a provider-owned finalizer to the remote object, so
+	// that it is not removed from the target cluster when deleted out of band.
+	// The finalizer is removed by the provider when the Object is deleted.
+	// +optional
+	ProtectTarget bool `json:"protectTarget,omitempty"`
+	// ObservationMode defines what is observed of the remote object. With
+	// Metadata, only its metadata is read, which saves memory and network for
+	// large remote objects whose payload is irrelevant, e.g. big ConfigMaps.
+	// The remote object is then considered up-to-date as long as it exists,
+	// so Metadata is meant for Objects that only observe their remote object.
+	// +optional
+	// +kubebuilder:validation:Enum=Full;Metadata
+	// +kubebuilder:default=Full
+	ObservationMode ObservationMode `json:"observationMode,omitempty"`
+	// ConflictPolicy defines what to do when server-side apply conflicts with
+	// other field managers of the remote object, e.g. other Objects applying
+	// different manifests to the same remote object. Force takes ownership of
+	// the conflicting fields, while Fail reports the conflicting fields and
+	// their current owners instead of applying. Priority takes ownership of
+	// the fields managed by Objects with a lower priority than this Object,
+	// or by field managers other than Objects, and fails otherwise. The
+	// conflicts are reported in status.atProvider.fieldConflicts. It is
+	// ignored unless server-side apply is enabled.
+	// +optional
+	// +kubebuilder:validation:Enum=Force;Fail;Priority
+	// +kubebuilder:default=Force
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+	// DryRun computes what would be created or changed on the target cluster
+	// and publishes it to status.atProvider.plan, without changing the target
+	// cluster. A dry-run Object is never ready, and deleting it leaves its
+	// remote objects alone.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// ApplySchedule limits when changes to the desired state, and drift of
+	// the remote object, are applied to an existing remote object. The remote
+	// object is still observed at every poll, and pending changes are reported
+	// in the ChangesPending condition. Remote objects that do not exist are
+	// created right away.
+	// +optional
+	ApplySchedule *ApplySchedule `json:"applySchedule,omitempty"`
+	// RevisionHistoryLimit is the number of distinct manifests that were
+	// applied to the remote object to keep in status.atProvider.revisions.
+	// The Object can be rolled back to any of them by annotating it with
+	// kubernetes.crossplane.io/rollback-to-revision. Defaults to 0, i.e. no
+	// revisions are kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// ApplyHistoryLimit is the number of successful applies to the remote
+	// objects to keep in status.atProvider.applyHistory, each recording the
+	// user the provider applied as, the resourceVersion of the remote object
+	// before and after the apply, and the paths it changed. An event is
+	// recorded on the Object for every one of them. Defaults to 0, i.e.
+	// applies are not recorded.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ApplyHistoryLimit *int32 `json:"applyHistoryLimit,omitempty"`
+	// NotifyFieldChanges are the paths of the fields of the remote object,
+	// e.g. status.phase, a change of whose value is recorded as an event on
+	// the Object, with the value before and after the change. Only Objects
+	// that observe their remote object without managing it, i.e. whose only
+	// management policy is Observe, record these events.
+	// +listType=set
+	// +optional
+	NotifyFieldChanges []string `json:"notifyFieldChanges,omitempty"`
+	// APIVersionPolicy determines which apiVersion the manifest is applied
+	// with. Declared, the default, always applies the declared apiVersion.
+	// PreferredServed applies the version preferred by the target cluster
+	// among those serving the kind of the manifest instead, if the target
+	// cluster does not serve the declared apiVersion anymore, e.g. a
+	// PodDisruptionBudget declared as policy/v1beta1 is applied as policy/v1.
+	// The manifest is not otherwise converted, and the substitutions are
+	// reported in status.atProvider.migratedAPIVersions.
+	// +kubebuilder:validation:Enum=Declared;PreferredServed
+	// +optional
+	APIVersionPolicy APIVersionPolicy `json:"apiVersionPolicy,omitempty"`
+	// WaitForRemoteDependents holds the deletion of a remote Namespace while
+	// resources other than its defaults remain in it, and of a remote
+	// CustomResourceDefinition while custom resources of it remain, so that
+	// they are not left terminating until their dependents are deleted. The
+	// remaining dependents are reported in the DependentsRemain condition.
+	// Annotate the Object with kubernetes.crossplane.io/force-delete=true to
+	// delete its remote object anyway.
+	// +optional
+	WaitForRemoteDependents bool `json:"waitForRemoteDependents,omitempty"`
+	// WaitForCRD holds the creation and updates of a custom resource until
+	// the CustomResourceDefinition of its kind is established on the target
+	// cluster, e.g. when it is applied by another Object at the same time,
+	// without declaring a dependency on that Object.
+	// +optional
+	WaitForCRD bool `json:"waitForCRD,omitempty"`
+	// OwnershipPolicy protects remote objects that belong to someone else,
+	// e.g. due to a name collision, from being taken over. Enforce labels the
+	// remote objects with the UID of this Object, and refuses to update a
+	// remote object that is not labeled as owned by it, reporting the
+	// OwnershipConflict condition instead. Adopt does the same, but takes over
+	// remote objects that are not labeled as owned by any Object. The
+	// ownership of remote objects is not checked if it is unset.
+	// +optional
+	// +kubebuilder:validation:Enum=Enforce;Adopt
+	OwnershipPolicy OwnershipPolicy `json:"ownershipPolicy,omitempty"`
+	// Subresource of the remote object to apply the manifest to, instead of
+	// the remote object itself, e.g. to reflect the state of an external
+	// system into the status of a custom resource. Only the fields of the
+	// manifest outside its metadata are applied, e.g. status for the status
+	// subresource, or spec.replicas for the scale subresource. The remote
+	// object must already exist, and it is neither created nor deleted by
+	// the Object. The manifest must not be a List.
+	// +optional
+	// +kubebuilder:validation:Enum=status;scale
+	Subresource Subresource `json:"subresource,omitempty"`
+}
+
+// OwnershipPolicy determines whether a remote object must be owned by an Object
+// to be updated by it.
+type OwnershipPolicy string
+
+const (
+	// OwnershipPolicyEnforce means a remote object is only updated if it is
+	// labeled as owned by the Object.
+	OwnershipPolicyEnforce OwnershipPolicy = "Enforce"
+	// OwnershipPolicyAdopt means a remote object is only updated if it is
+	// labeled as owned by the Object, or by no Object at all.
+	OwnershipPolicyAdopt OwnershipPolicy = "Adopt"
+)
+
+// Subresource is a subresource of a remote object.
+type Subresource string
+
+const (
+	// SubresourceStatus is the status subresource.
+	SubresourceStatus Subresource = "status"
+	// SubresourceScale is the scale subresource.
+	SubresourceScale Subresource = "scale"
+)
+
+// ApplySchedule limits when changes are applied to a remote object. Changes
+// are applied only once both the interval and one of the windows allow it.
+// This includes creating a remote object again that was deleted out of band.
+type ApplySchedule struct {
+	// Interval is the minimum time between two applies of the manifest.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Windows are the maintenance windows changes are applied in. Changes are
+	// applied at any time if there are none.
+	// +optional
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// A Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type Weekday string
+
+// A MaintenanceWindow is a recurring period of time.
+type MaintenanceWindow struct {
+	// Days of the week the window starts on. The window starts every day if
+	// there are none.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+	// Start is the time of day the window starts at, as HH:MM.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window, e.g. 4h.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone of the start time, as an IANA time zone name, e.g.
+	// Europe/Helsinki.
+	// +optional
+	// +kubebuilder:default=UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// TemplateReference refers to an ObjectTemplate and supplies the values of its
+// parameters.
+type TemplateReference struct {
+	// Name of the ObjectTemplate.
+	Name string `json:"name"`
+	// Parameters are the values of the template parameters, overriding their
+	// defaults in the ObjectTemplate.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// A ManifestSource selects a part of a manifest stored in a ConfigMap or
+// Secret.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type ManifestSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *ManifestKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret.
+	// +optional
+	SecretKeyRef *ManifestKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// A ManifestKeySelector selects a key of a ConfigMap or Secret.
+type ManifestKeySelector struct {
+	// Name of the ConfigMap or Secret.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap or Secret.
+	Namespace string `json:"namespace"`
+	// Key whose value is selected.
+	Key string `json:"key"`
+}
+
+// OptimisticConcurrency configures how patches that conflict with changes of
+// the remote object since it was read are retried.
+type OptimisticConcurrency struct {
+	// Retries is how many times a conflicting patch is retried with a fresh
+	// read of the remote object, before the update fails and the remote
+	// object is observed again on the next reconcile.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries int32 `json:"retries,omitempty"`
+}
+
+// A ValueSource is a named value read from a key of a ConfigMap or Secret, or
+// from a field of a resource.
+// +kubebuilder:validation:XValidation:rule="[has(self.configMapKeyRef), has(self.secretKeyRef), has(self.fieldRef)].filter(x, x).size() == 1",message="exactly one of configMapKeyRef, secretKeyRef or fieldRef must be set"
+type ValueSource struct {
+	// Name of the value.
+	Name string `json:"name"`
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *ManifestKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret.
+	// +optional
+	SecretKeyRef *ManifestKeySelector `json:"secretKeyRef,omitempty"`
+	// FieldRef selects a field of a resource, e.g. another Object.
+	// +optional
+	FieldRef *PatchesFrom `json:"fieldRef,omitempty"`
+}
+
+// TargetOwnerReference refers to an Object whose remote object is the owner of
+// another remote object on the target cluster.
+type TargetOwnerReference struct {
+	// Name of the Object whose remote object is the owner.
+	Name string `json:"name"`
+	// Controller marks the owner as the managing controller.
+	// +optional
+	Controller *bool `json:"controller,omitempty"`
+	// BlockOwnerDeletion prevents the owner from being deleted in the
+	// foreground until the owned remote object is deleted.
+	// +optional
+	BlockOwnerDeletion *bool `json:"blockOwnerDeletion,omitempty"`
+}
+
+// Normalization ignores mutations of the remote object by mutating webhooks.
+type Normalization struct {
+	// StripRules remove matching list items, e.g. injected sidecar
+	// containers, from both the remote object and its desired state before
+	// they are compared.
+	// +optional
+	StripRules []StripRule `json:"stripRules,omitempty"`
+	// DryRunEquivalence decides whether the remote object is up-to-date by
+	// comparing it with a server-side dry-run of the patch that would be
+	// applied, so that fields populated by mutating webhooks do not show up
+	// as a diff. It is implied if server-side dry-run is enabled for the
+	// provider, and ignored if server-side apply is enabled.
+	// +optional
+	DryRunEquivalence bool `json:"dryRunEquivalence,omitempty"`
+}
+
+// A StripRule removes the items of a list whose field matches one of its
+// values.
+type StripRule struct {
+	// Path of the list, e.g. spec.template.spec.containers. It may contain
+	// wildcards.
+	Path string `json:"path"`
+	// Field of the list items to match.
+	// +optional
+	// +kubebuilder:default=name
+	Field string `json:"field,omitempty"`
+	// Values of the field of the items to remove. They may be shell
+	// patterns, e.g. istio-*.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
 }
 
+// IgnorePreset is a named set of fields that should not be considered when
+// deciding whether the remote object is up-to-date.
+// +kubebuilder:validation:Enum=HPA-managed;Service-allocated;Webhook-injected;Istio-injected;Default-tolerations
+type IgnorePreset string
+
+const (
+	// IgnorePresetHPAManaged ignores the replicas, which are scaled by a
+	// HorizontalPodAutoscaler.
+	IgnorePresetHPAManaged IgnorePreset = "HPA-managed"
+	// IgnorePresetServiceAllocated ignores the cluster IPs, IP families and
+	// node ports allocated to a Service by the API server.
+	IgnorePresetServiceAllocated IgnorePreset = "Service-allocated"
+	// IgnorePresetWebhookInjected ignores the CA bundles injected into webhook
+	// configurations, CRD conversion webhooks and APIServices, e.g. by the
+	// cert-manager CA injector.
+	IgnorePresetWebhookInjected IgnorePreset = "Webhook-injected"
+	// IgnorePresetIstioInjected ignores the sidecar and init containers,
+	// volumes, labels and annotations injected into pods and pod templates by
+	// the Istio sidecar injector.
+	IgnorePresetIstioInjected IgnorePreset = "Istio-injected"
+	// IgnorePresetDefaultTolerations ignores the not-ready and unreachable
+	// tolerations added to pods and pod templates by the
+	// DefaultTolerationSeconds admission plugin.
+	IgnorePresetDefaultTolerations IgnorePreset = "Default-tolerations"
+)
+
+// ObservationMode defines what is observed of the remote object.
+type ObservationMode string
+
+const (
+	// ObservationModeFull means the whole remote object is observed.
+	ObservationModeFull ObservationMode = "Full"
+	// ObservationModeMetadata means only the metadata of the remote object,
+	// e.g. its labels and resource version, is observed.
+	ObservationModeMetadata ObservationMode = "Metadata"
+)
+
+// ConflictPolicy defines what to do when server-side apply conflicts with
+// other field managers.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyForce means the conflicting fields are taken over.
+	ConflictPolicyForce ConflictPolicy = "Force"
+	// ConflictPolicyFail means the apply fails, leaving the conflicting
+	// fields to their current owners.
+	ConflictPolicyFail ConflictPolicy = "Fail"
+	// ConflictPolicyPriority means the conflicting fields are taken over if
+	// they are managed by Objects with a lower priority, or by field managers
+	// other than Objects, and the apply fails otherwise.
+	ConflictPolicyPriority ConflictPolicy = "Priority"
+)
+
+// UpdateStrategy defines how the remote object is updated.
+type UpdateStrategy string
+
+const (
+	// UpdateStrategyPatch means the manifest is merged into the remote object.
+	UpdateStrategyPatch UpdateStrategy = "Patch"
+	// UpdateStrategyReplace means the remote object is replaced with the
+	// manifest.
+	UpdateStrategyReplace UpdateStrategy = "Replace"
+)
+
+// UpdatePolicy defines what to do when an update of the remote object fails.
+type UpdatePolicy string
+
+const (
+	// UpdatePolicyDefault means a failed update is reported as an error and
+	// retried.
+	UpdatePolicyDefault UpdatePolicy = "Default"
+	// UpdatePolicyRecreateOnImmutableError means the remote object is deleted,
+	// so that it is created again, if an update fails because it changes an
+	// immutable field.
+	UpdatePolicyRecreateOnImmutableError UpdatePolicy = "RecreateOnImmutableError"
+)
+
+// APIVersionPolicy determines which apiVersion a manifest is applied with.
+type APIVersionPolicy string
+
+const (
+	// APIVersionPolicyDeclared means the manifest is applied with its
+	// declared apiVersion.
+	APIVersionPolicyDeclared APIVersionPolicy = "Declared"
+	// APIVersionPolicyPreferredServed means the manifest is applied with the
+	// preferred version served by the target cluster for its kind, if its
+	// declared apiVersion is not served.
+	APIVersionPolicyPreferredServed APIVersionPolicy = "PreferredServed"
+)
+
 // ObjectObservation are the observable fields of a Object.
 type ObjectObservation struct {
 	// Raw JSON representation of the remote object.
 	// +kubebuilder:validation:EmbeddedResource
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Manifest runtime.RawExtension `json:"manifest,omitempty"`
+	// CompressedManifest is the gzip compressed JSON representation of the
+	// remote object, recorded if it is larger than the maximum observed
+	// manifest size of the provider. Manifest then only records the
+	// apiVersion, kind, metadata and status of the remote object.
+	// +optional
+	CompressedManifest []byte `json:"compressedManifest,omitempty"`
+	// ManifestTruncated is true if the remote object is larger than the
+	// maximum observed manifest size of the provider even when compressed,
+	// in which case manifest only records its apiVersion, kind, metadata and
+	// status, and compressedManifest is not recorded.
+	// +optional
+	ManifestTruncated bool `json:"manifestTruncated,omitempty"`
+	// LastAppliedHash is the hash of the desired state, management policies
+	// and ignored fields that were last confirmed to be up-to-date with the
+	// remote object. It is used to skip comparing an unchanged desired state
+	// with an unchanged remote object. It is not recorded for Objects that
+	// only observe their remote objects.
+	// +optional
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
+	// ObservedResourceVersion is the resourceVersion of the remote object at
+	// the last observation. It is used along with lastAppliedHash, and is
+	// recorded even if the manifest is compressed or truncated.
+	// +optional
+	ObservedResourceVersion string `json:"observedResourceVersion,omitempty"`
+	// LastAppliedTime is the last time the manifest was successfully applied
+	// to the remote object.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+	// LastFailedHash is the hash of the desired state that last failed to be
+	// applied with a permanent error. It is not applied again until it
+	// changes, or until a while after LastFailedTime.
+	// +optional
+	LastFailedHash string `json:"lastFailedHash,omitempty"`
+	// LastFailedTime is the last time the manifest failed to be applied with
+	// a permanent error.
+	// +optional
+	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
+	// ReadinessHash is the hash of the desired state the remote object has
+	// been becoming ready with since ReadinessStartTime, if a readiness
+	// timeout is set and it is not ready.
+	// +optional
+	ReadinessHash string `json:"readinessHash,omitempty"`
+	// ReadinessStartTime is when the remote object was first observed not
+	// ready with the desired state of ReadinessHash.
+	// +optional
+	ReadinessStartTime *metav1.Time `json:"readinessStartTime,omitempty"`
+	// LastObservedTime is the last time the remote object was successfully
+	// observed.
+	// +optional
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+	// LastDriftDetectedTime is the last time the remote object was found to
+	// be changed out of band, while the desired state did not change since
+	// they were last in sync.
+	// +optional
+	LastDriftDetectedTime *metav1.Time `json:"lastDriftDetectedTime,omitempty"`
+	// LastDriftCorrectedTime is the last time a drift of the remote object
+	// was corrected by applying the manifest.
+	// +optional
+	LastDriftCorrectedTime *metav1.Time `json:"lastDriftCorrectedTime,omitempty"`
+	// ReconcileHealth tracks the failures of the reconciles of the Object,
+	// to tell chronically failing and flapping Objects apart.
+	// +optional
+	ReconcileHealth *ReconcileHealth `json:"reconcileHealth,omitempty"`
+	// LastReconcileRequests are the requests the last reconcile of the Object
+	// made to the target cluster, by verb, if the Object is annotated with
+	// kubernetes.crossplane.io/record-requests: "true".
+	// +optional
+	LastReconcileRequests []RequestCount `json:"lastReconcileRequests,omitempty"`
+	// Hooks are the observed states of the hooks of the Object.
+	// +optional
+	Hooks *HooksObservation `json:"hooks,omitempty"`
+	// Plan is what the Object would change on the target cluster, if it is a
+	// dry run.
+	// +optional
+	Plan *Plan `json:"plan,omitempty"`
+	// Revisions are the last distinct manifests that were applied to the
+	// remote object, the most recently applied one last.
+	// +optional
+	Revisions []ManifestRevision `json:"revisions,omitempty"`
+	// ApplyHistory are the last successful applies to the remote objects,
+	// the most recent one last.
+	// +optional
+	ApplyHistory []ApplyRecord `json:"applyHistory,omitempty"`
+	// Diff are the fields at which the remote objects differed from their
+	// desired state when they were last observed, if structured diffs are
+	// enabled. Secret data is redacted if the provider sanitizes secrets.
+	// +optional
+	Diff []FieldDiff `json:"diff,omitempty"`
+	// MigratedAPIVersions are the declared apiVersions of the manifest that
+	// are applied with another version served by the target cluster.
+	// +optional
+	MigratedAPIVersions []MigratedAPIVersion `json:"migratedAPIVersions,omitempty"`
+	// Dependents are the Objects referencing this Object, e.g. to depend on
+	// it or to patch fields from it, so that the blast radius of changing it
+	// can be assessed.
+	// +optional
+	Dependents *Dependents `json:"dependents,omitempty"`
+	// FieldConflicts are the fields of the remote objects that are managed
+	// by other field managers too, as found by the last server-side apply,
+	// and how they were resolved according to the conflict policy.
+	// +optional
+	FieldConflicts []FieldConflict `json:"fieldConflicts,omitempty"`
+	// SkippedReferences are the Optional references that were skipped when
+	// the Object was last observed, since their resource, field or key was
+	// missing.
+	// +optional
+	SkippedReferences []SkippedReference `json:"skippedReferences,omitempty"`
+	// RequiredPermissions are the permissions on the target cluster the
+	// credentials of the provider config need for the Object, as derived
+	// from the kinds of its manifest and its management policies, if they
+	// are reported. They can be aggregated into least-privilege Roles.
+	// +optional
+	RequiredPermissions []PermissionRule `json:"requiredPermissions,omitempty"`
+
+	// Children are the observed states of the remote objects of an Object
+	// whose manifest is a List, in the order of its items. Remote objects
+	// that were removed from the List follow until they are pruned from the
+	// target cluster with the next update of the Object.
+	// +optional
+	Children []ChildObservation `json:"children,omitempty"`
+}
+
+// A ChildObservation is the observed state of one of the remote objects of an
+// Object whose manifest is a List.
+type ChildObservation struct {
+	// APIVersion of the remote object.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the remote object.
+	Kind string `json:"kind"`
+	// Namespace of the remote object, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the remote object.
+	Name string `json:"name"`
+	// Synced is true if the remote object is up-to-date with its manifest.
+	Synced bool `json:"synced"`
+	// Ready is true if the remote object is ready according to the readiness
+	// policy of the Object.
+	Ready bool `json:"ready"`
+	// Message explains why the remote object is not ready, if it is not.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// A ManifestRevision is a manifest that was applied to the remote object, as
+// declared before references were resolved.
+type ManifestRevision struct {
+	// Revision number of the manifest. Revision numbers increase with every
+	// distinct manifest that is applied, and are kept when a manifest is
+	// applied again.
+	Revision int64 `json:"revision"`
+	// Hash of the manifest.
+	Hash string `json:"hash"`
+	// AppliedTime is the last time the manifest was applied.
+	AppliedTime metav1.Time `json:"appliedTime"`
+	// Manifest that was applied. Only the hash of manifests loaded from
+	// spec.forProvider.manifestFrom or rendered from
+	// spec.forProvider.templateRef is recorded, as their sources may hold
+	// secrets.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Manifest runtime.RawExtension `json:"manifest,omitempty"`
+	// Redacted is true if the secret data of the manifest was redacted, so
+	// that it cannot be rolled back to.
+	// +optional
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// A FieldDiff is a field at which a remote object differs from its desired
+// state.
+type FieldDiff struct {
+	// Path of the field, e.g. spec.replicas. The fields of the items of a
+	// List are prefixed with their index, e.g. items[0].spec.replicas.
+	Path string `json:"path"`
+	// Live is the JSON encoded value of the field of the remote object. It
+	// is empty if the remote object does not set the field.
+	// +optional
+	Live string `json:"live,omitempty"`
+	// Desired is the JSON encoded desired value of the field. It is empty if
+	// the field is not desired to be set.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+}
+
+// A MigratedAPIVersion is a declared apiVersion of a manifest that is applied
+// with another version served by the target cluster.
+type MigratedAPIVersion struct {
+	// Kind of the migrated remote objects.
+	Kind string `json:"kind"`
+	// From is the declared apiVersion.
+	From string `json:"from"`
+	// To is the served apiVersion the remote objects are applied with.
+	To string `json:"to"`
+}
+
+// A FieldConflict is a field of a remote object that is managed by another
+// field manager too.
+type FieldConflict struct {
+	// Field is the path of the field, as reported by server-side apply.
+	Field string `json:"field"`
+	// Manager is the other field manager of the field.
+	Manager string `json:"manager"`
+	// Object is the name of the Object the other field manager applies for,
+	// if any.
+	// +optional
+	Object string `json:"object,omitempty"`
+	// Resolution of the conflict.
+	Resolution FieldConflictResolution `json:"resolution"`
+}
+
+// FieldConflictResolution is how a field conflict was resolved.
+type FieldConflictResolution string
+
+const (
+	// FieldConflictTakenOver means the field was applied, taking it over
+	// from the other field manager.
+	FieldConflictTakenOver FieldConflictResolution = "TakenOver"
+	// FieldConflictYielded means the field was left to the other field
+	// manager, failing the apply.
+	FieldConflictYielded FieldConflictResolution = "Yielded"
+)
+
+// A SkippedReference is an Optional reference that was skipped.
+type SkippedReference struct {
+	// APIVersion of the referenced resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the referenced resource.
+	Kind string `json:"kind"`
+	// Namespace of the referenced resource, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the referenced resource.
+	Name string `json:"name"`
+	// Message explains why the reference was skipped.
+	Message string `json:"message"`
+}
+
+// A PermissionRule is a permission on the target cluster an Object requires,
+// in the terms of an RBAC policy rule.
+type PermissionRule struct {
+	// APIGroup of the resource. Empty for the core API group.
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+	// Resource the verbs are required on, e.g. deployments or
+	// deployments/scale.
+	Resource string `json:"resource"`
+	// Namespace the verbs are required in. Empty for cluster scoped
+	// resources, and for verbs required in all namespaces, e.g. to watch.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Verbs required on the resource, in alphabetical order.
+	Verbs []string `json:"verbs"`
+}
+
+// Dependents are the Objects referencing an Object.
+type Dependents struct {
+	// Count of the Objects referencing the Object.
+	Count int32 `json:"count"`
+	// Names of the Objects referencing the Object, in alphabetical order, up
+	// to 50 of them.
+	// +optional
+	Names []string `json:"names,omitempty"`
+}
+
+// ReconcileHealth tracks the failures of the reconciles of an Object.
+type ReconcileHealth struct {
+	// FailureStreak is the number of consecutive reconciles that failed.
+	// +optional
+	FailureStreak int32 `json:"failureStreak,omitempty"`
+	// Flaps is the number of times the reconciles alternated between
+	// success and failure since FlapWindowStart.
+	// +optional
+	Flaps int32 `json:"flaps,omitempty"`
+	// FlapWindowStart is when the flaps counted in Flaps started to be
+	// counted. They are counted again from zero once the window elapsed.
+	// +optional
+	FlapWindowStart *metav1.Time `json:"flapWindowStart,omitempty"`
+	// Flapping is whether the reconciles alternated between success and
+	// failure too often within the window. Flapping Objects are reconciled
+	// less often if the provider quarantines them.
+	// +optional
+	Flapping bool `json:"flapping,omitempty"`
+}
+
+// A RequestCount is the number of requests of a verb made to a target cluster.
+type RequestCount struct {
+	// Verb of the requests, e.g. get or patch.
+	Verb string `json:"verb"`
+	// Subresource the requests were made to, if any, e.g. status.
+	// +optional
+	Subresource string `json:"subresource,omitempty"`
+	// Count is the number of requests.
+	Count int32 `json:"count"`
+}
+
+// An ApplyRecord is a successful apply to a remote object.
+type ApplyRecord struct {
+	// Time of the apply.
+	Time metav1.Time `json:"time"`
+	// Actor is the user the provider authenticated to the target cluster as.
+	// +optional
+	Actor string `json:"actor,omitempty"`
+	// Kind of the remote object.
+	Kind string `json:"kind"`
+	// Namespace of the remote object, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the remote object.
+	Name string `json:"name"`
+	// ResourceVersionBefore is the resourceVersion of the remote object
+	// before the apply. It is empty if the remote object was created.
+	// +optional
+	ResourceVersionBefore string `json:"resourceVersionBefore,omitempty"`
+	// ResourceVersionAfter is the resourceVersion of the remote object after
+	// the apply.
+	// +optional
+	ResourceVersionAfter string `json:"resourceVersionAfter,omitempty"`
+	// ChangedPaths are the field paths of the remote object that were changed
+	// by the apply, as far as they are recorded in the observed manifest, up
+	// to 20 of them. They are empty if the remote object was created.
+	// +optional
+	ChangedPaths []string `json:"changedPaths,omitempty"`
+}
+
+// A Plan is what an Object would change on the target cluster.
+type Plan struct {
+	// Action the Object would take on its remote objects.
+	Action PlanAction `json:"action"`
+	// Diff between every remote object that would change and its desired
+	// state. Secret data is redacted if the provider sanitizes secrets.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
+// PlanAction is the action an Object would take on its remote objects.
+type PlanAction string
+
+const (
+	// PlanActionCreate means some remote objects would be created.
+	PlanActionCreate PlanAction = "Create"
+	// PlanActionUpdate means some remote objects would be updated.
+	PlanActionUpdate PlanAction = "Update"
+	// PlanActionNone means the remote objects are up-to-date.
+	PlanActionNone PlanAction = "None"
+)
+
+// HooksObservation are the observed states of the hooks of an Object.
+type HooksObservation struct {
+	// PostCreate is the observed state of the post-create hook.
+	// +optional
+	PostCreate *HookObservation `json:"postCreate,omitempty"`
+	// PreDelete is the observed state of the pre-delete hook.
+	// +optional
+	PreDelete *HookObservation `json:"preDelete,omitempty"`
+}
+
+// HookObservation is the observed state of a hook.
+type HookObservation struct {
+	// Phase of the hook.
+	Phase HookPhase `json:"phase"`
+	// Message describes why the hook failed, if it did.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is when the hook object was applied.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// HookPhase is the phase of a hook.
+type HookPhase string
+
+const (
+	// HookPhaseRunning means the hook object was applied, but did not
+	// complete yet.
+	HookPhaseRunning HookPhase = "Running"
+	// HookPhaseSucceeded means the hook object completed.
+	HookPhaseSucceeded HookPhase = "Succeeded"
+	// HookPhaseFailed means the hook object failed.
+	HookPhaseFailed HookPhase = "Failed"
+)
+
+// Hooks are manifests that are applied to the target cluster at points in
+// the lifecycle of the remote object. The hook manifests must have a name and,
+// if namespaced, a namespace. A Job hook completes once it has a Complete
+// condition with status True and fails once it has a Failed condition with
+// status True. A Pod hook completes or fails once it is in the Succeeded or
+// Failed phase. Hooks of any other kind complete once they exist.
+type Hooks struct {
+	// PostCreate is applied once the remote object is created and up-to-date.
+	// The Object is not ready until it completes. It is only run once, unless
+	// its remote object is deleted after it failed, in which case it is
+	// applied again. Like other changes, it is not applied during pause
+	// windows of the provider config or outside the maintenance windows of
+	// the apply schedule. It is deleted along with the remote object.
+	// +optional
+	PostCreate *Hook `json:"postCreate,omitempty"`
+	// PreDelete is applied once the Object is deleted, before its remote
+	// object. The remote object is not deleted until it completes, or until
+	// it fails or times out and its failure policy is Ignore. It is deleted
+	// along with the remote object.
+	// +optional
+	PreDelete *PreDeleteHook `json:"preDelete,omitempty"`
+}
+
+// A PreDeleteHook is a hook applied before the remote object is deleted, e.g.
+// a Job draining or backing up the remote object.
+type PreDeleteHook struct {
+	Hook `json:",inline"`
+	// Timeout is how long to wait for the hook to complete before it is
+	// considered failed.
+	// +optional
+	// +kubebuilder:default="10m"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy defines what to do when the hook fails or times out.
+	// Abort keeps the remote object, and the Object, until the hook is fixed
+	// or the policy changed, while Ignore deletes the remote object anyway.
+	// +optional
+	// +kubebuilder:validation:Enum=Abort;Ignore
+	// +kubebuilder:default=Abort
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// HookFailurePolicy defines what to do when a hook fails.
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyAbort means the lifecycle step waiting for the hook
+	// does not proceed.
+	HookFailurePolicyAbort HookFailurePolicy = "Abort"
+	// HookFailurePolicyIgnore means the lifecycle step waiting for the hook
+	// proceeds as if it completed.
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
+// A Hook is a manifest applied to the target cluster, e.g. a one-shot Job.
+type Hook struct {
+	// Manifest of the hook object.
+	// +kubebuilder:validation:EmbeddedResource
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Manifest runtime.RawExtension `json:"manifest"`
 }
 
 // A ObjectSpec defines the desired state of a Object.
+// +kubebuilder:validation:XValidation:rule="!(has(self.deletionPolicy) && self.deletionPolicy == 'Orphan' && has(self.managementPolicies) && 'Delete' in self.managementPolicies)",message="deletionPolicy Orphan contradicts the Delete management policy, which deletes the remote object regardless: remove Delete from managementPolicies, or set deletionPolicy to Delete"
+// +kubebuilder:validation:XValidation:rule="!(has(self.managementPolicies) && '*' in self.managementPolicies && size(self.managementPolicies) > 1)",message="the * management policy already includes every action: use either * alone, or the actions to take"
 type ObjectSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ConnectionDetails []ConnectionDetail `json:"connectionDetails,omitempty"`
 	ForProvider       ObjectParameters   `json:"forProvider"`
 	References        []Reference        `json:"references,omitempty"`
 	Readiness         Readiness          `json:"readiness,omitempty"`
+	// ObservedFieldsTo publishes selected fields of the observed remote
+	// object to a ConfigMap on the control plane, e.g. for tooling that does
+	// not consume connection secrets. Unlike connection details, the fields
+	// are not considered sensitive.
+	// +optional
+	ObservedFieldsTo *ObservedFieldsConfigMap `json:"observedFieldsTo,omitempty"`
+	// Finalizer configures the finalizer protecting the Object until its
+	// remote object is deleted.
+	// +optional
+	Finalizer *ObjectFinalizer `json:"finalizer,omitempty"`
+	// ProviderConfigSelector selects the provider config of the Object by its
+	// labels, as an alternative to providerConfigRef, e.g. for compositions
+	// that target a cluster by environment or region rather than by name. The
+	// selected provider config is recorded in providerConfigRef, and only
+	// replaced while it no longer matches. If several provider configs match,
+	// the first one by name is selected.
+	// +optional
+	ProviderConfigSelector *ProviderConfigSelector `json:"providerConfigSelector,omitempty"`
+	// Hooks are applied to the target cluster at points in the lifecycle of
+	// the remote object.
+	// +optional
+	Hooks *Hooks `json:"hooks,omitempty"`
+	// Priority of the Object. While the provider works through the Objects
+	// after it started, Objects with a lower priority are held back for a
+	// while until those with a higher priority were reconciled, successfully
+	// or not.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 	// Watch enables watching the referenced or managed kubernetes resources.
+	// Objects with the Observe management policy only always watch them.
 	//
 	// THIS IS AN ALPHA FIELD. Do not use it in production. It is not honored
 	// unless "watches" feature gate is enabled, and may be changed or removed
@@ -107,6 +1126,13 @@ type ObjectSpec struct {
 	Watch bool `json:"watch,omitempty"`
 }
 
+// A ProviderConfigSelector selects a provider config by its labels.
+type ProviderConfigSelector struct {
+	// MatchLabels are the labels the provider config must have.
+	// +kubebuilder:validation:MinProperties=1
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
 // ReadinessPolicy defines how the Object's readiness condition should be computed.
 type ReadinessPolicy string
 
@@ -123,16 +1149,21 @@ const (
 	// ReadinessPolicyDeriveFromCelQuery means that a cel expression will be used to calculate the overall status.
 	// The cel expression must be provided on the readiness struct.
 	ReadinessPolicyDeriveFromCelQuery ReadinessPolicy = "DeriveFromCelQuery"
+	// ReadinessPolicyMatchConditions means that all match conditions must hold
+	// on the object. The match conditions must be provided on the readiness
+	// struct.
+	ReadinessPolicyMatchConditions ReadinessPolicy = "MatchConditions"
 )
 
 // Readiness defines how the object's readiness condition should be computed,
 // if not specified it will be considered ready as soon as the underlying external
 // resource is considered up-to-date.
 // +kubebuilder:validation:XValidation:rule="self.policy != 'DeriveFromCelQuery' || (self.policy == 'DeriveFromCelQuery' && size(self.celQuery) > 0)",message="celQuery must be set if policy is DeriveFromCelQuery"
+// +kubebuilder:validation:XValidation:rule="self.policy != 'MatchConditions' || (has(self.matchConditions) && size(self.matchConditions) > 0)",message="matchConditions must be set if policy is MatchConditions"
 type Readiness struct {
 	// Policy defines how the Object's readiness condition should be computed.
 	// +optional
-	// +kubebuilder:validation:Enum=SuccessfulCreate;DeriveFromObject;AllTrue;DeriveFromCelQuery
+	// +kubebuilder:validation:Enum=SuccessfulCreate;DeriveFromObject;AllTrue;DeriveFromCelQuery;MatchConditions
 	// +kubebuilder:default=SuccessfulCreate
 	Policy ReadinessPolicy `json:"policy,omitempty"`
 
@@ -145,18 +1176,136 @@ type Readiness struct {
 	//  `object.status.conditions.all(x, x.status == "True")` mimics the behavior of the AllTrue readiness policy
 	//  `object.status.conditions.exists(c, c.type == "condition1" && c.status == "True" )` checks just one condition
 	CelQuery string `json:"celQuery,omitempty"`
+
+	// MatchConditions must all hold on the observed object for it to be
+	// considered ready, if the policy is MatchConditions.
+	// +optional
+	MatchConditions []MatchCondition `json:"matchConditions,omitempty"`
+
+	// Timeout after which an Object whose remote object did not become ready
+	// since its desired state last changed is reported by the Failed
+	// condition with the ReadinessTimeout reason, to tell a remote object
+	// that is still rolling out from one that never will be ready.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// StopOnTimeout stops applying the desired state once the remote object
+	// did not become ready within the timeout, until the desired state
+	// changes. The remote object is still observed, and the failure cleared
+	// if it becomes ready after all.
+	// +optional
+	StopOnTimeout bool `json:"stopOnTimeout,omitempty"`
+
+	// ChildPolicy defines when an Object whose manifest is a List, and which
+	// hence manages several remote objects, is ready. With AllReady, it is
+	// ready only if all of them are ready, while with AnyReady it is ready as
+	// long as one of them is, so that some of them may fail. It is ignored
+	// with the SuccessfulCreate policy.
+	// +optional
+	// +kubebuilder:validation:Enum=AllReady;AnyReady
+	// +kubebuilder:default=AllReady
+	ChildPolicy ChildReadinessPolicy `json:"childPolicy,omitempty"`
+}
+
+// A ChildReadinessPolicy defines when an Object managing several remote
+// objects is ready.
+type ChildReadinessPolicy string
+
+const (
+	// ChildReadinessPolicyAllReady means that all remote objects must be
+	// ready.
+	ChildReadinessPolicyAllReady ChildReadinessPolicy = "AllReady"
+	// ChildReadinessPolicyAnyReady means that at least one remote object
+	// must be ready.
+	ChildReadinessPolicyAnyReady ChildReadinessPolicy = "AnyReady"
+)
+
+// A MatchCondition is either a field path of the observed object that must
+// have a value, or a condition of the observed object that must have a status.
+// +kubebuilder:validation:XValidation:rule="has(self.fieldPath) != has(self.type)",message="exactly one of fieldPath and type must be set"
+type MatchCondition struct {
+	// FieldPath of the observed object, e.g. status.phase.
+	// +optional
+	FieldPath string `json:"fieldPath,omitempty"`
+	// Value the field path must have. Values that are not strings are
+	// compared by their JSON representation, e.g. true or 3.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Type of a condition of the observed object, e.g. Available.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Status the condition must have.
+	// +optional
+	// +kubebuilder:default=True
+	Status v1.ConditionStatus `json:"status,omitempty"`
 }
 
 // ConnectionDetail represents an entry in the connection secret for an Object
 type ConnectionDetail struct {
 	v1.ObjectReference    `json:",inline"`
 	ToConnectionSecretKey string `json:"toConnectionSecretKey,omitempty"`
+	// Template renders the value of the connection detail from the referenced
+	// object with a Go template combining several of its fields, e.g.
+	// https://{{ .status.loadBalancer.ingress[0].ip }}:{{ .spec.ports[0].port }}.
+	// An action that is only a field path is replaced by the value of the
+	// field, which must be set, and the b64dec function decodes base64
+	// encoded values, e.g. the data of a Secret. If set, fieldPath is
+	// ignored.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// An ObjectFinalizer configures the finalizer of an Object.
+type ObjectFinalizer struct {
+	// Name of the finalizer, e.g. for cleanup tooling that recognizes
+	// finalizers by name. Defaults to finalizer.managedresource.crossplane.io.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+	// Disabled adds no finalizers to the Object, nor to the resources it
+	// references, and protects them with no Usages, e.g. for Objects that
+	// only observe their remote objects, so that deleting them, or the
+	// namespaces of the resources they reference, is never blocked by the
+	// provider. The remote objects of Objects without a finalizer are not
+	// deleted along with them.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// An ObservedFieldsConfigMap is a ConfigMap that selected fields of the
+// observed remote object are published to. It is created if it does not
+// exist, and deleted along with the Object.
+type ObservedFieldsConfigMap struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+	// Fields to publish. Fields that are not set on the observed remote
+	// object, e.g. a load balancer IP that is yet to be allocated, are not
+	// published until they are.
+	// +kubebuilder:validation:MinItems=1
+	Fields []ObservedField `json:"fields"`
+}
+
+// An ObservedField is a field of the observed remote object published to a key
+// of a ConfigMap.
+type ObservedField struct {
+	// FieldPath of the field in the observed remote object, e.g.
+	// status.loadBalancer.ingress[0].ip. Fields that are not strings are
+	// published as JSON.
+	FieldPath string `json:"fieldPath"`
+	// Key of the ConfigMap to publish the field to.
+	Key string `json:"key"`
 }
 
 // A ObjectStatus represents the observed state of a Object.
 type ObjectStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          ObjectObservation `json:"atProvider,omitempty"`
+	// ObservedGeneration is the latest metadata.generation of the Object that
+	// was applied to or confirmed to be up-to-date with the remote object.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -170,6 +1319,7 @@ type ObjectStatus struct {
 // +kubebuilder:printcolumn:name="PROVIDERCONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="DEPENDENTS",type="integer",JSONPath=".status.atProvider.dependents.count",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kubernetes}
 // +kubebuilder:storageversion
@@ -193,6 +1343,16 @@ type ObjectList struct {
 // ApplyFromFieldPathPatch patches the "to" resource, using a source field
 // on the "from" resource.
 func (r *Reference) ApplyFromFieldPathPatch(from, to runtime.Object) error {
+	out, err := r.FieldPathValue(from)
+	if err != nil {
+		return err
+	}
+	return r.patchFieldValueToObject(out, to)
+}
+
+// FieldPathValue returns the value of the source field of the "from"
+// resource.
+func (r *Reference) FieldPathValue(from runtime.Object) (any, error) {
 	// Default to patch the same field on the "to" resource.
 	if r.ToFieldPath == nil {
 		r.ToFieldPath = r.PatchesFrom.FieldPath
@@ -200,30 +1360,63 @@ func (r *Reference) ApplyFromFieldPathPatch(from, to runtime.Object) error {
 
 	paved, err := fieldpath.PaveObject(from)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	out, err := paved.GetValue(*r.PatchesFrom.FieldPath)
-	if err != nil {
-		return err
+	return paved.GetValue(*r.PatchesFrom.FieldPath)
+}
+
+// ApplyConnectionSecretPatch patches the supplied value of a connection
+// secret key to the "to" object.
+func (r *Reference) ApplyConnectionSecretPatch(value string, to runtime.Object) error {
+	if r.ToFieldPath == nil {
+		return errors.New("toFieldPath is required to patch from a connection secret")
 	}
+	return r.patchFieldValueToObject(value, to)
+}
 
-	return patchFieldValueToObject(*r.ToFieldPath, out, to)
+// ApplyValuePatch patches the supplied value of the Object to the "to"
+// object.
+func (r *Reference) ApplyValuePatch(value any, to runtime.Object) error {
+	if r.ToFieldPath == nil {
+		return errors.New("toFieldPath is required to patch from a value")
+	}
+	return r.patchFieldValueToObject(value, to)
 }
 
-// patchFieldValueToObject, given a path, value and "to" object, will
-// apply the value to the "to" object at the given path, returning
+// patchFieldValueToObject, given a value and "to" object, will apply the
+// value to the "to" object at the path the reference patches to, returning
 // any errors as they occur.
-func patchFieldValueToObject(path string, value interface{}, to runtime.Object) error {
+func (r *Reference) patchFieldValueToObject(value interface{}, to runtime.Object) error {
 	paved, err := fieldpath.PaveObject(to)
 	if err != nil {
 		return err
 	}
 
-	err = paved.SetValue("spec.forProvider.manifest."+path, value)
+	prefix := "spec.forProvider.manifest."
+	if r.PatchTo == PatchToMetadata {
+		if err := checkMetadataPath(*r.ToFieldPath); err != nil {
+			return err
+		}
+		prefix = "metadata."
+	}
+	err = paved.SetValue(prefix+*r.ToFieldPath, value)
 	if err != nil {
 		return err
 	}
 
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(paved.UnstructuredContent(), to)
 }
+
+// checkMetadataPath returns an error unless the supplied path refers to a
+// label or annotation.
+func checkMetadataPath(path string) error {
+	segments, err := fieldpath.Parse(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) != 2 || (segments[0].Field != "labels" && segments[0].Field != "annotations") {
+		return errors.New("toFieldPath must be a label or annotation to patch to metadata")
+	}
+	return nil
+}
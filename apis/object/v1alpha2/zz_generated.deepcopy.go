@@ -21,61 +21,752 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyRecord) DeepCopyInto(out *ApplyRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.ChangedPaths != nil {
+		in, out := &in.ChangedPaths, &out.ChangedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyRecord.
+func (in *ApplyRecord) DeepCopy() *ApplyRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplySchedule) DeepCopyInto(out *ApplySchedule) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplySchedule.
+func (in *ApplySchedule) DeepCopy() *ApplySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChildObservation) DeepCopyInto(out *ChildObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChildObservation.
+func (in *ChildObservation) DeepCopy() *ChildObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ChildObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionDetail) DeepCopyInto(out *ConnectionDetail) {
 	*out = *in
 	out.ObjectReference = in.ObjectReference
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDetail.
-func (in *ConnectionDetail) DeepCopy() *ConnectionDetail {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDetail.
+func (in *ConnectionDetail) DeepCopy() *ConnectionDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dependents) DeepCopyInto(out *Dependents) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dependents.
+func (in *Dependents) DeepCopy() *Dependents {
+	if in == nil {
+		return nil
+	}
+	out := new(Dependents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependsOn) DeepCopyInto(out *DependsOn) {
+	*out = *in
+	if in.MatchFields != nil {
+		in, out := &in.MatchFields, &out.MatchFields
+		*out = make([]MatchField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependsOn.
+func (in *DependsOn) DeepCopy() *DependsOn {
+	if in == nil {
+		return nil
+	}
+	out := new(DependsOn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldConflict) DeepCopyInto(out *FieldConflict) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldConflict.
+func (in *FieldConflict) DeepCopy() *FieldConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDiff) DeepCopyInto(out *FieldDiff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDiff.
+func (in *FieldDiff) DeepCopy() *FieldDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hook) DeepCopyInto(out *Hook) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
+	if in == nil {
+		return nil
+	}
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookObservation) DeepCopyInto(out *HookObservation) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookObservation.
+func (in *HookObservation) DeepCopy() *HookObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(HookObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hooks) DeepCopyInto(out *Hooks) {
+	*out = *in
+	if in.PostCreate != nil {
+		in, out := &in.PostCreate, &out.PostCreate
+		*out = new(Hook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = new(PreDeleteHook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hooks.
+func (in *Hooks) DeepCopy() *Hooks {
+	if in == nil {
+		return nil
+	}
+	out := new(Hooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HooksObservation) DeepCopyInto(out *HooksObservation) {
+	*out = *in
+	if in.PostCreate != nil {
+		in, out := &in.PostCreate, &out.PostCreate
+		*out = new(HookObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = new(HookObservation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HooksObservation.
+func (in *HooksObservation) DeepCopy() *HooksObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(HooksObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestKeySelector) DeepCopyInto(out *ManifestKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestKeySelector.
+func (in *ManifestKeySelector) DeepCopy() *ManifestKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestRevision) DeepCopyInto(out *ManifestRevision) {
+	*out = *in
+	in.AppliedTime.DeepCopyInto(&out.AppliedTime)
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestRevision.
+func (in *ManifestRevision) DeepCopy() *ManifestRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestSource) DeepCopyInto(out *ManifestSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ManifestKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(ManifestKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestSource.
+func (in *ManifestSource) DeepCopy() *ManifestSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchCondition) DeepCopyInto(out *MatchCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchCondition.
+func (in *MatchCondition) DeepCopy() *MatchCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchField) DeepCopyInto(out *MatchField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchField.
+func (in *MatchField) DeepCopy() *MatchField {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigratedAPIVersion) DeepCopyInto(out *MigratedAPIVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigratedAPIVersion.
+func (in *MigratedAPIVersion) DeepCopy() *MigratedAPIVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(MigratedAPIVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Normalization) DeepCopyInto(out *Normalization) {
+	*out = *in
+	if in.StripRules != nil {
+		in, out := &in.StripRules, &out.StripRules
+		*out = make([]StripRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Normalization.
+func (in *Normalization) DeepCopy() *Normalization {
+	if in == nil {
+		return nil
+	}
+	out := new(Normalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Object) DeepCopyInto(out *Object) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Object.
+func (in *Object) DeepCopy() *Object {
+	if in == nil {
+		return nil
+	}
+	out := new(Object)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Object) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectFinalizer) DeepCopyInto(out *ObjectFinalizer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectFinalizer.
+func (in *ObjectFinalizer) DeepCopy() *ObjectFinalizer {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectFinalizer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectList) DeepCopyInto(out *ObjectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Object, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectList.
+func (in *ObjectList) DeepCopy() *ObjectList {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectObservation) DeepCopyInto(out *ObjectObservation) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.CompressedManifest != nil {
+		in, out := &in.CompressedManifest, &out.CompressedManifest
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailedTime != nil {
+		in, out := &in.LastFailedTime, &out.LastFailedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadinessStartTime != nil {
+		in, out := &in.ReadinessStartTime, &out.ReadinessStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastObservedTime != nil {
+		in, out := &in.LastObservedTime, &out.LastObservedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDriftDetectedTime != nil {
+		in, out := &in.LastDriftDetectedTime, &out.LastDriftDetectedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDriftCorrectedTime != nil {
+		in, out := &in.LastDriftCorrectedTime, &out.LastDriftCorrectedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReconcileHealth != nil {
+		in, out := &in.ReconcileHealth, &out.ReconcileHealth
+		*out = new(ReconcileHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconcileRequests != nil {
+		in, out := &in.LastReconcileRequests, &out.LastReconcileRequests
+		*out = make([]RequestCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(HooksObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(Plan)
+		**out = **in
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]ManifestRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplyHistory != nil {
+		in, out := &in.ApplyHistory, &out.ApplyHistory
+		*out = make([]ApplyRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]FieldDiff, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigratedAPIVersions != nil {
+		in, out := &in.MigratedAPIVersions, &out.MigratedAPIVersions
+		*out = make([]MigratedAPIVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = new(Dependents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FieldConflicts != nil {
+		in, out := &in.FieldConflicts, &out.FieldConflicts
+		*out = make([]FieldConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedReferences != nil {
+		in, out := &in.SkippedReferences, &out.SkippedReferences
+		*out = make([]SkippedReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredPermissions != nil {
+		in, out := &in.RequiredPermissions, &out.RequiredPermissions
+		*out = make([]PermissionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]ChildObservation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectObservation.
+func (in *ObjectObservation) DeepCopy() *ObjectObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectParameters) DeepCopyInto(out *ObjectParameters) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManifestFrom != nil {
+		in, out := &in.ManifestFrom, &out.ManifestFrom
+		*out = make([]ManifestSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValueSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubeconfigSecretRef != nil {
+		in, out := &in.KubeconfigSecretRef, &out.KubeconfigSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.OptimisticConcurrency != nil {
+		in, out := &in.OptimisticConcurrency, &out.OptimisticConcurrency
+		*out = new(OptimisticConcurrency)
+		**out = **in
+	}
+	if in.IgnorePresets != nil {
+		in, out := &in.IgnorePresets, &out.IgnorePresets
+		*out = make([]IgnorePreset, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Normalization != nil {
+		in, out := &in.Normalization, &out.Normalization
+		*out = new(Normalization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetOwnerRef != nil {
+		in, out := &in.TargetOwnerRef, &out.TargetOwnerRef
+		*out = new(TargetOwnerReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplySchedule != nil {
+		in, out := &in.ApplySchedule, &out.ApplySchedule
+		*out = new(ApplySchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ApplyHistoryLimit != nil {
+		in, out := &in.ApplyHistoryLimit, &out.ApplyHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NotifyFieldChanges != nil {
+		in, out := &in.NotifyFieldChanges, &out.NotifyFieldChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectParameters.
+func (in *ObjectParameters) DeepCopy() *ObjectParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSpec) DeepCopyInto(out *ObjectSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	if in.ConnectionDetails != nil {
+		in, out := &in.ConnectionDetails, &out.ConnectionDetails
+		*out = make([]ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Readiness.DeepCopyInto(&out.Readiness)
+	if in.ObservedFieldsTo != nil {
+		in, out := &in.ObservedFieldsTo, &out.ObservedFieldsTo
+		*out = new(ObservedFieldsConfigMap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Finalizer != nil {
+		in, out := &in.Finalizer, &out.Finalizer
+		*out = new(ObjectFinalizer)
+		**out = **in
+	}
+	if in.ProviderConfigSelector != nil {
+		in, out := &in.ProviderConfigSelector, &out.ProviderConfigSelector
+		*out = new(ProviderConfigSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(Hooks)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSpec.
+func (in *ObjectSpec) DeepCopy() *ObjectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectionDetail)
+	out := new(ObjectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DependsOn) DeepCopyInto(out *DependsOn) {
+func (in *ObjectStatus) DeepCopyInto(out *ObjectStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependsOn.
-func (in *DependsOn) DeepCopy() *DependsOn {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStatus.
+func (in *ObjectStatus) DeepCopy() *ObjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DependsOn)
+	out := new(ObjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Object) DeepCopyInto(out *Object) {
+func (in *ObjectTemplate) DeepCopyInto(out *ObjectTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Object.
-func (in *Object) DeepCopy() *Object {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplate.
+func (in *ObjectTemplate) DeepCopy() *ObjectTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(Object)
+	out := new(ObjectTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Object) DeepCopyObject() runtime.Object {
+func (in *ObjectTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -83,31 +774,31 @@ func (in *Object) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectList) DeepCopyInto(out *ObjectList) {
+func (in *ObjectTemplateList) DeepCopyInto(out *ObjectTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Object, len(*in))
+		*out = make([]ObjectTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectList.
-func (in *ObjectList) DeepCopy() *ObjectList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateList.
+func (in *ObjectTemplateList) DeepCopy() *ObjectTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectList)
+	out := new(ObjectTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ObjectList) DeepCopyObject() runtime.Object {
+func (in *ObjectTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -115,80 +806,73 @@ func (in *ObjectList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectObservation) DeepCopyInto(out *ObjectObservation) {
+func (in *ObjectTemplateSpec) DeepCopyInto(out *ObjectTemplateSpec) {
 	*out = *in
-	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectObservation.
-func (in *ObjectObservation) DeepCopy() *ObjectObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSpec.
+func (in *ObjectTemplateSpec) DeepCopy() *ObjectTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectObservation)
+	out := new(ObjectTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectParameters) DeepCopyInto(out *ObjectParameters) {
+func (in *ObservedField) DeepCopyInto(out *ObservedField) {
 	*out = *in
-	in.Manifest.DeepCopyInto(&out.Manifest)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectParameters.
-func (in *ObjectParameters) DeepCopy() *ObjectParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedField.
+func (in *ObservedField) DeepCopy() *ObservedField {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectParameters)
+	out := new(ObservedField)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectSpec) DeepCopyInto(out *ObjectSpec) {
+func (in *ObservedFieldsConfigMap) DeepCopyInto(out *ObservedFieldsConfigMap) {
 	*out = *in
-	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	if in.ConnectionDetails != nil {
-		in, out := &in.ConnectionDetails, &out.ConnectionDetails
-		*out = make([]ConnectionDetail, len(*in))
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]ObservedField, len(*in))
 		copy(*out, *in)
 	}
-	in.ForProvider.DeepCopyInto(&out.ForProvider)
-	if in.References != nil {
-		in, out := &in.References, &out.References
-		*out = make([]Reference, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	out.Readiness = in.Readiness
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSpec.
-func (in *ObjectSpec) DeepCopy() *ObjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedFieldsConfigMap.
+func (in *ObservedFieldsConfigMap) DeepCopy() *ObservedFieldsConfigMap {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectSpec)
+	out := new(ObservedFieldsConfigMap)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStatus) DeepCopyInto(out *ObjectStatus) {
+func (in *OptimisticConcurrency) DeepCopyInto(out *OptimisticConcurrency) {
 	*out = *in
-	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStatus.
-func (in *ObjectStatus) DeepCopy() *ObjectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptimisticConcurrency.
+func (in *OptimisticConcurrency) DeepCopy() *OptimisticConcurrency {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStatus)
+	out := new(OptimisticConcurrency)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -196,7 +880,7 @@ func (in *ObjectStatus) DeepCopy() *ObjectStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PatchesFrom) DeepCopyInto(out *PatchesFrom) {
 	*out = *in
-	out.DependsOn = in.DependsOn
+	in.DependsOn.DeepCopyInto(&out.DependsOn)
 	if in.FieldPath != nil {
 		in, out := &in.FieldPath, &out.FieldPath
 		*out = new(string)
@@ -214,9 +898,128 @@ func (in *PatchesFrom) DeepCopy() *PatchesFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchesFromConnectionSecret) DeepCopyInto(out *PatchesFromConnectionSecret) {
+	*out = *in
+	in.DependsOn.DeepCopyInto(&out.DependsOn)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchesFromConnectionSecret.
+func (in *PatchesFromConnectionSecret) DeepCopy() *PatchesFromConnectionSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchesFromConnectionSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchesFromValue) DeepCopyInto(out *PatchesFromValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchesFromValue.
+func (in *PatchesFromValue) DeepCopy() *PatchesFromValue {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchesFromValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionRule) DeepCopyInto(out *PermissionRule) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionRule.
+func (in *PermissionRule) DeepCopy() *PermissionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Plan) DeepCopyInto(out *Plan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plan.
+func (in *Plan) DeepCopy() *Plan {
+	if in == nil {
+		return nil
+	}
+	out := new(Plan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeleteHook) DeepCopyInto(out *PreDeleteHook) {
+	*out = *in
+	in.Hook.DeepCopyInto(&out.Hook)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeleteHook.
+func (in *PreDeleteHook) DeepCopy() *PreDeleteHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeleteHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigSelector) DeepCopyInto(out *ProviderConfigSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSelector.
+func (in *ProviderConfigSelector) DeepCopy() *ProviderConfigSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Readiness) DeepCopyInto(out *Readiness) {
 	*out = *in
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Readiness.
@@ -229,19 +1032,48 @@ func (in *Readiness) DeepCopy() *Readiness {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileHealth) DeepCopyInto(out *ReconcileHealth) {
+	*out = *in
+	if in.FlapWindowStart != nil {
+		in, out := &in.FlapWindowStart, &out.FlapWindowStart
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileHealth.
+func (in *ReconcileHealth) DeepCopy() *ReconcileHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reference) DeepCopyInto(out *Reference) {
 	*out = *in
 	if in.DependsOn != nil {
 		in, out := &in.DependsOn, &out.DependsOn
 		*out = new(DependsOn)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PatchesFrom != nil {
 		in, out := &in.PatchesFrom, &out.PatchesFrom
 		*out = new(PatchesFrom)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PatchesFromConnectionSecret != nil {
+		in, out := &in.PatchesFromConnectionSecret, &out.PatchesFromConnectionSecret
+		*out = new(PatchesFromConnectionSecret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PatchesFromValue != nil {
+		in, out := &in.PatchesFromValue, &out.PatchesFromValue
+		*out = new(PatchesFromValue)
+		**out = **in
+	}
 	if in.ToFieldPath != nil {
 		in, out := &in.ToFieldPath, &out.ToFieldPath
 		*out = new(string)
@@ -258,3 +1090,130 @@ func (in *Reference) DeepCopy() *Reference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestCount) DeepCopyInto(out *RequestCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestCount.
+func (in *RequestCount) DeepCopy() *RequestCount {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedReference) DeepCopyInto(out *SkippedReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedReference.
+func (in *SkippedReference) DeepCopy() *SkippedReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StripRule) DeepCopyInto(out *StripRule) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StripRule.
+func (in *StripRule) DeepCopy() *StripRule {
+	if in == nil {
+		return nil
+	}
+	out := new(StripRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetOwnerReference) DeepCopyInto(out *TargetOwnerReference) {
+	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlockOwnerDeletion != nil {
+		in, out := &in.BlockOwnerDeletion, &out.BlockOwnerDeletion
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetOwnerReference.
+func (in *TargetOwnerReference) DeepCopy() *TargetOwnerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetOwnerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValueSource) DeepCopyInto(out *ValueSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ManifestKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(ManifestKeySelector)
+		**out = **in
+	}
+	if in.FieldRef != nil {
+		in, out := &in.FieldRef, &out.FieldRef
+		*out = new(PatchesFrom)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueSource.
+func (in *ValueSource) DeepCopy() *ValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
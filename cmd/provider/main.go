@@ -17,14 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"go.uber.org/zap/zapcore"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -40,8 +43,12 @@ import (
 
 	"github.com/crossplane-contrib/provider-kubernetes/apis"
 	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/certs"
 	object "github.com/crossplane-contrib/provider-kubernetes/internal/controller"
+	objectcontroller "github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/events"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/features"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/shard"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -62,11 +69,39 @@ func main() {
 		pollJitterPercentage    = app.Flag("poll-jitter-percentage", "Percentage of jitter to apply to poll interval. It cannot be negative, and must be less than 100.").Default("10").Uint()
 		leaderElection          = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").Envar("LEADER_ELECTION").Bool()
 		maxReconcileRate        = app.Flag("max-reconcile-rate", "The number of concurrent reconciliations that may be running at one time.").Default("100").Int()
-		sanitizeSecrets         = app.Flag("sanitize-secrets", "when enabled, redacts Secret data from Object status").Default("false").Envar("SANITIZE_SECRETS").Bool()
+		sanitizeSecrets         = app.Flag("sanitize-secrets", "when enabled, redacts the data of Secrets, and the values of secret-like keys, e.g. password or token, of other kinds, from Object status, events and debug logs").Default("false").Envar("SANITIZE_SECRETS").Bool()
+		referenceCacheTTL       = app.Flag("reference-cache-ttl", "How long resources referenced by Objects are cached, so that a resource referenced by many Objects is not read for every one of them. Patches from referenced resources may be delayed by up to this duration. Caching is disabled if zero.").Default("0s").Envar("REFERENCE_CACHE_TTL").Duration()
+		maxObservedManifestSize = app.Flag("max-observed-manifest-size", "The size above which the remote objects observed by Objects are recorded gzip compressed in their status, next to their apiVersion, kind, metadata and status, so that huge remote objects, e.g. CRDs, do not keep the status of their Objects from being persisted. Remote objects too large even when compressed are only recorded partially. No limit is applied if zero.").Default("512KiB").Envar("MAX_OBSERVED_MANIFEST_SIZE").Bytes()
+		connectionWhenReady     = app.Flag("publish-connection-details-when-ready", "Only publish the connection details of Objects to their connection secrets once they are ready, so that consumers do not pick up half-populated connection details of remote objects that are still being provisioned.").Default("false").Envar("PUBLISH_CONNECTION_DETAILS_WHEN_READY").Bool()
+		backfillWindow          = app.Flag("startup-backfill-window", "Spread the first reconciles of the Objects that exist when the provider starts over this window, every Object at a stable offset derived from its name, so that a restart does not reconcile all of them against their target clusters at once. Objects created after the provider started are reconciled right away. Objects are reconciled right away if zero.").Default("0s").Envar("STARTUP_BACKFILL_WINDOW").Duration()
+		quarantineInterval      = app.Flag("flap-quarantine-interval", "Reconcile Objects whose reconciles keep alternating between success and failure no more often than at this interval, so that a few flapping Objects do not take a disproportionate share of the reconcile workers. Flapping Objects are reconciled like any other if zero.").Default("0s").Envar("FLAP_QUARANTINE_INTERVAL").Duration()
+		ignoreFields            = app.Flag("ignore-field", "A field path, e.g. metadata.annotations[kubectl.kubernetes.io/last-applied-configuration], ignored on the remote objects of all Objects when deciding whether they are up to date, in addition to the fields ignored by the Objects and their provider configs. May be repeated.").Strings()
+		eventDedupWindow        = app.Flag("event-dedup-window", "How long identical events of a resource are recorded only once, so that a flapping Object does not flood the API server with events. The first occurrence is recorded right away, and the last one at the end of the window. Events are not deduplicated if zero.").Default("0s").Envar("EVENT_DEDUP_WINDOW").Duration()
+		eventRate               = app.Flag("event-rate", "How many distinct events per second are recorded at most for a resource. Events are not rate limited if zero.").Default("0").Envar("EVENT_RATE").Float64()
+		eventBurst              = app.Flag("event-burst", "How many distinct events of a resource are recorded at once before they are rate limited by --event-rate.").Default("10").Envar("EVENT_BURST").Int()
+		orphanScanInterval      = app.Flag("orphan-scan-interval", "How often the target clusters of provider configs with attribution are scanned for remote objects of deleted Objects, which are reported as events of the provider config. Scanning is disabled if zero.").Default("0s").Envar("ORPHAN_SCAN_INTERVAL").Duration()
+		orphanCleanup           = app.Flag("orphan-cleanup", "Delete the remote objects found by the orphan scanner rather than only reporting them. Only the orphans of provider configs with an attribution identity are deleted, and not during the pause windows of their provider configs. The remote objects of Objects deleted with the Orphan deletion policy are released rather than taken for orphans.").Default("false").Envar("ORPHAN_CLEANUP").Bool()
+		watchNamespace          = app.Flag("watch-namespace", "Only reconcile the Objects and ObservedObjectCollections of claims in this namespace, i.e. those labeled "+object.LabelKeyClaimNamespace+" with it, so that several providers can share a control plane, e.g. one per tenant or environment. All of them are reconciled if empty.").Default("").Envar("WATCH_NAMESPACE").String()
+		watchSelector           = app.Flag("watch-selector", "Only reconcile the Objects, ObservedObjectCollections and ProviderConfigs matching this label selector, e.g. tenant=a, so that several providers can share a control plane. The Objects of ObservedObjectCollections must be labeled alike through their templates. All of them are reconciled if empty.").Default("").Envar("WATCH_SELECTOR").String()
+		shardCount              = app.Flag("shard-count", "The number of shards the Objects and ObservedObjectCollections are split into, each reconciled by the replicas of the provider with its shard index, so that large installations can scale reconcile throughput horizontally. A resource belongs to the shard of its "+shard.LabelKey+" label modulo the shard count, or else of the hash of its name.").Default("1").Envar("SHARD_COUNT").Int()
+		shardIndex              = app.Flag("shard-index", "The shard reconciled by this replica of the provider, from 0 to --shard-count minus 1. Derived from the ordinal of the hostname, e.g. of a StatefulSet pod, if negative.").Default("-1").Envar("SHARD_INDEX").Int()
 
-		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("true").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
-		enableWatches            = app.Flag("enable-watches", "Enable support for watching resources.").Default("false").Envar("ENABLE_WATCHES").Bool()
-		enableServerSideApply    = app.Flag("enable-server-side-apply", "Enable server side apply to sync object manifests to k8s API.").Default("false").Envar("ENABLE_SERVER_SIDE_APPLY").Bool()
+		webhookPort            = app.Flag("webhook-port", "The port the webhook server of the provider listens on.").Default("9443").Envar("WEBHOOK_PORT").Int()
+		webhookCertDir         = app.Flag("webhook-cert-dir", "The directory of the certificate and key of the webhook server, e.g. a mounted cert-manager Secret. Defaults to $"+webhookTLSCertDirEnvVar+", $"+tlsServerCertDirEnvVar+" or "+tlsServerCertDir+", in that order.").Default("").Envar("WEBHOOK_CERT_DIR").String()
+		webhookTLSMinVersion   = app.Flag("webhook-tls-min-version", "The minimum TLS version of the webhook server. One of 1.2 or 1.3.").Default("1.2").Envar("WEBHOOK_TLS_MIN_VERSION").Enum("1.2", "1.3")
+		webhookTLSCipherSuites = app.Flag("webhook-tls-cipher-suite", "A TLS cipher suite of the webhook server by its Go name, e.g. TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256. May be repeated. The default cipher suites of Go are used if none are given. Ignored by TLS 1.3.").Strings()
+
+		enableManagementPolicies  = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("true").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		enableWatches             = app.Flag("enable-watches", "Enable support for watching resources.").Default("false").Envar("ENABLE_WATCHES").Bool()
+		enableServerSideApply     = app.Flag("enable-server-side-apply", "Enable server side apply to sync object manifests to k8s API.").Default("false").Envar("ENABLE_SERVER_SIDE_APPLY").Bool()
+		enableServerSideDryRun    = app.Flag("enable-server-side-dry-run", "Enable server side dry-run to compare object manifests with the live state in k8s API. Ignored if server side apply is enabled.").Default("false").Envar("ENABLE_SERVER_SIDE_DRY_RUN").Bool()
+		enableUsages              = app.Flag("enable-usages", "Enable protecting the cluster scoped resources referenced by Objects from deletion with Crossplane Usages. Requires Usages to be enabled in Crossplane.").Default("false").Envar("ENABLE_USAGES").Bool()
+		enableProtobuf            = app.Flag("enable-protobuf", "Enable reading objects of built-in kinds from target clusters with protobuf encoding. Fields unknown to the provider's version of the built-in types are not observed.").Default("false").Envar("ENABLE_PROTOBUF").Bool()
+		enableSchemaValidation    = app.Flag("enable-schema-validation", "Enable validating object manifests against the OpenAPI schemas published by target clusters before applying them.").Default("false").Envar("ENABLE_SCHEMA_VALIDATION").Bool()
+		enableStructuredDiff      = app.Flag("enable-structured-diff", "Enable reporting the fields at which the remote objects of Objects differ from their desired state in status.atProvider.diff, e.g. for GitOps tooling.").Default("false").Envar("ENABLE_STRUCTURED_DIFF").Bool()
+		enableRequiredPermissions = app.Flag("enable-required-permissions", "Enable reporting the permissions on their target clusters Objects require in status.atProvider.requiredPermissions, e.g. to generate least-privilege Roles for the credentials of provider configs.").Default("false").Envar("ENABLE_REQUIRED_PERMISSIONS").Bool()
+		enforceTenantCredentials  = app.Flag("enforce-tenant-credentials", "Only let the Objects of claims, i.e. those labeled crossplane.io/claim-namespace, use provider configs that read their credentials, identity, scoped identity and tunnel secrets from secrets in the namespace of their claim only, rather than e.g. from the injected identity or the filesystem of the provider, so that tenants can safely bring their own provider configs and credentials. Objects of no claim may use any provider config.").Default("false").Envar("ENFORCE_TENANT_CREDENTIALS").Bool()
+		enableFeatures            = app.Flag("enable-feature", "Enable a feature by the name of its feature flag. May be repeated. One of: "+strings.Join(features.Names(), ", ")+".").Strings()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -84,31 +119,68 @@ func main() {
 	if *pollJitterPercentage >= 100 {
 		kingpin.Fatalf("invalid --poll-jitter-percentage %v must be less than 100", *pollJitterPercentage)
 	}
+	enabledFeatures := make([]feature.Flag, 0, len(*enableFeatures))
+	for _, name := range *enableFeatures {
+		f, ok := features.Lookup(name)
+		if !ok {
+			kingpin.Fatalf("invalid --enable-feature %q must be one of %s", name, strings.Join(features.Names(), ", "))
+		}
+		enabledFeatures = append(enabledFeatures, f)
+	}
 	pollJitter := time.Duration(float64(*pollInterval) * (float64(*pollJitterPercentage) / 100.0))
 	log.Debug("Starting",
 		"sync-interval", syncInterval.String(),
 		"poll-interval", pollInterval.String(),
 		"poll-jitter", pollJitter.String(),
-		"max-reconcile-rate", *maxReconcileRate)
+		"max-reconcile-rate", *maxReconcileRate,
+		"watch-namespace", *watchNamespace,
+		"watch-selector", *watchSelector)
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
 	// Get the TLS certs directory from the environment variable if set
 	// In older XP versions we used WEBHOOK_TLS_CERT_DIR, in newer versions
-	// we use TLS_SERVER_CERTS_DIR. If neither are set, use the default.
-	var certDir string
-	certDir = os.Getenv(webhookTLSCertDirEnvVar)
+	// we use TLS_SERVER_CERTS_DIR. If neither are set, use the default. The
+	// webhook-cert-dir flag, or its WEBHOOK_CERT_DIR environment variable,
+	// takes precedence over both.
+	certDir := *webhookCertDir
+	if certDir == "" {
+		certDir = os.Getenv(webhookTLSCertDirEnvVar)
+	}
 	if certDir == "" {
 		certDir = os.Getenv(tlsServerCertDirEnvVar)
 		if certDir == "" {
 			certDir = tlsServerCertDir
 		}
 	}
+	webhookTLSOpts, err := certs.TLSOptions(*webhookTLSMinVersion, *webhookTLSCipherSuites)
+	kingpin.FatalIfError(err, "Cannot configure webhook TLS")
+
+	// The events recorded by all controllers go through the recorders of the
+	// event broadcaster of the manager.
+	var eventBroadcaster record.EventBroadcaster
+	if eo := (events.Options{DedupWindow: *eventDedupWindow, Rate: *eventRate, Burst: *eventBurst}); eo.Enabled() {
+		eventBroadcaster = events.NewBroadcaster(eo)
+	}
+
+	hostname, _ := os.Hostname()
+	sh, err := shard.New(*shardIndex, *shardCount, hostname)
+	kingpin.FatalIfError(err, "Cannot determine the shard of this replica")
+	leaderElectionID := "crossplane-leader-election-provider-kubernetes"
+	if sh.Enabled() {
+		// The replicas of every shard elect a leader of their own.
+		leaderElectionID = fmt.Sprintf("%s-shard-%d", leaderElectionID, sh.Index)
+		log.Info("Sharding enabled", "shard-index", sh.Index, "shard-count", sh.Count)
+	}
+
+	watchRestriction, err := object.WatchRestriction(*watchNamespace, *watchSelector)
+	kingpin.FatalIfError(err, "Cannot restrict the reconciled resources")
 
 	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{
 		Cache: cache.Options{
 			SyncPeriod: syncInterval,
+			ByObject:   watchRestriction,
 		},
 
 		// controller-runtime uses both ConfigMaps and Leases for leader
@@ -119,13 +191,18 @@ func main() {
 		// server. Switching to Leases only and longer leases appears to
 		// alleviate this.
 		LeaderElection:             *leaderElection,
-		LeaderElectionID:           "crossplane-leader-election-provider-kubernetes",
+		LeaderElectionID:           leaderElectionID,
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		// The webhook server reloads the tls.crt and tls.key in certDir as
+		// they are rotated underneath it.
 		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    *webhookPort,
 			CertDir: certDir,
+			TLSOpts: webhookTLSOpts,
 		}),
+		EventBroadcaster: eventBroadcaster, //nolint:staticcheck // The broadcaster lives as long as the provider.
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
@@ -166,13 +243,71 @@ func main() {
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaServerSideApply)
 	}
 
+	if *enableServerSideDryRun {
+		o.Features.Enable(features.EnableAlphaServerSideDryRun)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaServerSideDryRun)
+	}
+
+	if *enableUsages {
+		o.Features.Enable(features.EnableAlphaUsages)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaUsages)
+	}
+
+	if *enableProtobuf {
+		o.Features.Enable(features.EnableAlphaProtobuf)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaProtobuf)
+	}
+
+	if *enableSchemaValidation {
+		o.Features.Enable(features.EnableAlphaSchemaValidation)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaSchemaValidation)
+	}
+
+	if *enableStructuredDiff {
+		o.Features.Enable(features.EnableAlphaStructuredDiff)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaStructuredDiff)
+	}
+
+	if *enableRequiredPermissions {
+		o.Features.Enable(features.EnableAlphaRequiredPermissions)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaRequiredPermissions)
+	}
+
+	if *enforceTenantCredentials {
+		o.Features.Enable(features.EnableAlphaTenantCredentials)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaTenantCredentials)
+	}
+
+	for _, f := range enabledFeatures {
+		o.Features.Enable(f)
+		log.Info("Feature enabled", "flag", f)
+	}
+
 	// NOTE(lsviben): We are registering the conversion webhook with v1alpha1
 	// Object. As far as I can see and based on some tests, it doesn't matter
 	// which version we use here. Leaving it as v1alpha1 as it will be easy to
 	// notice and remove when we drop support for v1alpha1.
 	kingpin.FatalIfError(ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Object{}).Complete(), "Cannot create Object webhook")
 
-	kingpin.FatalIfError(object.Setup(mgr, o, *sanitizeSecrets, pollJitter, *pollJitterPercentage), "Cannot setup controller")
+	so := object.SetupOptions{
+		Object: objectcontroller.SetupOptions{
+			SanitizeSecrets:         *sanitizeSecrets,
+			PollJitterPercentage:    *pollJitterPercentage,
+			ReferenceCacheTTL:       *referenceCacheTTL,
+			MaxObservedManifestSize: int64(*maxObservedManifestSize),
+			ConnectionWhenReady:     *connectionWhenReady,
+			BackfillWindow:          *backfillWindow,
+			QuarantineInterval:      *quarantineInterval,
+			IgnoreFields:            *ignoreFields,
+			Shard:                   sh,
+		},
+		PollJitter: pollJitter,
+	}
+	kingpin.FatalIfError(object.Setup(mgr, o, so), "Cannot setup controller")
+	// Orphans are scanned for across all shards, so only by the first one.
+	if *orphanScanInterval > 0 && sh.Index == 0 {
+		kingpin.FatalIfError(object.SetupOrphanScanner(mgr, o, *orphanScanInterval, *orphanCleanup), "Cannot setup orphan scanner")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
 
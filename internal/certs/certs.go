@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs configures the TLS of the webhook server of the provider. The
+// certificates themselves are served, and reloaded as they are rotated, e.g.
+// by cert-manager or by Crossplane in its webhook TLS secret, by the
+// certwatcher of controller-runtime the webhook server starts.
+package certs
+
+import (
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errUnknownTLSVersion = "unknown TLS version %q, must be one of 1.2 or 1.3"
+	errUnknownCipher     = "unknown TLS cipher suite %q"
+)
+
+// TLSOptions returns the options of the TLS config of a webhook server with
+// the supplied minimum TLS version and cipher suites. The default cipher
+// suites of Go are used if none are supplied.
+func TLSOptions(minVersion string, cipherSuites []string) ([]func(*tls.Config), error) {
+	v, err := tlsVersion(minVersion)
+	if err != nil {
+		return nil, err
+	}
+	ciphers, err := cipherSuiteIDs(cipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	return []func(*tls.Config){func(cfg *tls.Config) {
+		cfg.MinVersion = v
+		cfg.CipherSuites = ciphers
+	}}, nil
+}
+
+func tlsVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, errors.Errorf(errUnknownTLSVersion, v)
+}
+
+func cipherSuiteIDs(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	byName := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		byName[s.Name] = s.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, n := range names {
+		id, ok := byName[n]
+		if !ok {
+			return nil, errors.Errorf(errUnknownCipher, n)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestTLSOptions(t *testing.T) {
+	type want struct {
+		minVersion uint16
+		ciphers    []uint16
+		err        error
+	}
+	cases := map[string]struct {
+		reason       string
+		minVersion   string
+		cipherSuites []string
+		want         want
+	}{
+		"Defaults": {
+			reason: "TLS 1.2 and the default cipher suites of Go should be used by default.",
+			want:   want{minVersion: tls.VersionTLS12},
+		},
+		"TLS13": {
+			reason:     "The supplied minimum TLS version should be used.",
+			minVersion: "1.3",
+			want:       want{minVersion: tls.VersionTLS13},
+		},
+		"CipherSuites": {
+			reason:       "The supplied cipher suites should be used.",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			want:         want{minVersion: tls.VersionTLS12, ciphers: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}},
+		},
+		"UnknownVersion": {
+			reason:     "An unknown TLS version should be rejected.",
+			minVersion: "1.0",
+			want:       want{err: errors.Errorf(errUnknownTLSVersion, "1.0")},
+		},
+		"UnknownCipherSuite": {
+			reason:       "An unknown cipher suite should be rejected.",
+			cipherSuites: []string{"TLS_COOL"},
+			want:         want{err: errors.Errorf(errUnknownCipher, "TLS_COOL")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts, err := TLSOptions(tc.minVersion, tc.cipherSuites)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nTLSOptions(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			cfg := &tls.Config{} //nolint:gosec // The options set the minimum version.
+			for _, o := range opts {
+				o(cfg)
+			}
+			if cfg.MinVersion != tc.want.minVersion {
+				t.Errorf("\n%s\nTLSOptions(...): want minimum version %x, got %x", tc.reason, tc.want.minVersion, cfg.MinVersion)
+			}
+			if diff := cmp.Diff(tc.want.ciphers, cfg.CipherSuites); diff != "" {
+				t.Errorf("\n%s\nTLSOptions(...): -want cipher suites, +got cipher suites:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
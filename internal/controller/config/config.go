@@ -26,10 +26,14 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/features"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
 )
 
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
+// their current usage, creating their scoped identities, verifying the
+// permissions of their credentials and importing the resources of their target
+// clusters they ask for.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
@@ -38,14 +42,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		UsageList: v1alpha1.ProviderConfigUsageListGroupVersionKind,
 	}
 
+	log := o.Logger.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := providerconfig.NewReconciler(mgr, of,
-		providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
-		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		providerconfig.WithLogger(log),
+		providerconfig.WithRecorder(recorder))
+	var opts []kubeclient.BuilderOption
+	if o.Features.Enabled(features.EnableAlphaPortForwardTunnels) {
+		opts = append(opts, kubeclient.WithLocalConfig(mgr.GetConfig()))
+	}
+	cb := kubeclient.NewIdentityAwareBuilder(mgr.GetClient(), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.ProviderConfig{}).
 		Watches(&v1alpha1.ProviderConfigUsage{}, &resource.EnqueueRequestForProviderConfig{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, newImportReconciler(newPermissionReconciler(newScopedIdentityReconciler(r, mgr.GetClient(), cb, log, recorder), mgr.GetClient(), cb, log, o.PollInterval), mgr.GetClient(), cb, log, recorder), o.GlobalRateLimiter))
 }
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+)
+
+const (
+	// annotationKeyImport asks for the live resources on the target cluster
+	// of a provider config matching any of the imports it lists as JSON to
+	// be adopted by Objects using it. The annotation is removed once they
+	// are.
+	annotationKeyImport = "kubernetes.crossplane.io/import"
+	// labelKeyImportedBy is the label of Objects adopting live resources
+	// with the name of the provider config they were imported for.
+	labelKeyImportedBy = "kubernetes.crossplane.io/imported-by"
+	// labelKeyObjectUID is the label of remote objects with the UID of the
+	// Object owning them.
+	labelKeyObjectUID = "kubernetes.crossplane.io/object-uid"
+
+	// importedNameHashLength is the number of hex digits of the hash of the
+	// resource the names of imported Objects end with.
+	importedNameHashLength = 16
+
+	reasonImported     event.Reason = "ImportedResources"
+	reasonCannotImport event.Reason = "CannotImportResources"
+
+	errParseImport    = "cannot parse the " + annotationKeyImport + " annotation"
+	errImportSelector = "cannot parse the selector of the import of %s"
+	errListImport     = "cannot list %s to import"
+	errListObjects    = "cannot list the Objects using the provider config"
+	errBuildImported  = "cannot build Object for %s %s"
+	errCreateImported = "cannot create Object %s"
+	errGetImported    = "cannot get Object %s"
+	errImportConflict = "Object %s already exists and does not adopt %s %s"
+	errImportSecret   = "secrets cannot be imported, since their data would be inlined in the manifests of the Objects"
+	errRemoveImport   = "cannot remove the " + annotationKeyImport + " annotation"
+)
+
+// An importRequest selects live resources on the target cluster of a provider
+// config to be adopted by Objects.
+type importRequest struct {
+	// APIVersion of the resources to import.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the resources to import.
+	Kind string `json:"kind"`
+	// Namespace the resources to import are in. Resources in all namespaces
+	// are imported if it is not set.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector the labels of the resources to import must match. All resources
+	// are imported if it is not set.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// An importReconciler adopts live resources on the target clusters of
+// provider configs into fully managed Objects, whose manifests are the live
+// state of the resources, as asked for by their import annotation, after
+// reconciling them with another reconciler.
+type importReconciler struct {
+	inner         reconcile.Reconciler
+	kube          client.Client
+	clientBuilder kubeclient.Builder
+	log           logging.Logger
+	record        event.Recorder
+}
+
+func newImportReconciler(inner reconcile.Reconciler, kube client.Client, cb kubeclient.Builder, log logging.Logger, r event.Recorder) *importReconciler {
+	return &importReconciler{inner: inner, kube: kube, clientBuilder: cb, log: log, record: r}
+}
+
+// Reconcile the supplied request with the inner reconciler, and then import
+// the live resources its provider config asks for, if any. Resources already
+// adopted by an imported Object are skipped, so a failed import can be
+// retried.
+func (r *importReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.inner.Reconcile(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil || meta.WasDeleted(pc) {
+		return res, client.IgnoreNotFound(err)
+	}
+	raw, ok := pc.GetAnnotations()[annotationKeyImport]
+	if !ok {
+		return res, nil
+	}
+
+	created, existing, err := r.importResources(ctx, pc, raw)
+	if err != nil {
+		r.record.Event(pc, event.Warning(reasonCannotImport, err))
+		return res, err
+	}
+	r.log.Info("Imported resources of the target cluster", "providerConfig", pc.GetName(), "created", created, "existing", existing)
+	r.record.Event(pc, event.Normal(reasonImported, fmt.Sprintf("Created %d Objects adopting resources of the target cluster, %d were already adopted", created, existing)))
+
+	p := client.MergeFrom(pc.DeepCopy())
+	meta.RemoveAnnotations(pc, annotationKeyImport)
+	return res, errors.Wrap(r.kube.Patch(ctx, pc, p), errRemoveImport)
+}
+
+// importResources creates an Object for every live resource matching the
+// supplied import annotation of the supplied provider config, returning how
+// many were created and how many already existed.
+func (r *importReconciler) importResources(ctx context.Context, pc *v1alpha1.ProviderConfig, annotation string) (created, existing int, err error) {
+	var imports []importRequest
+	if err := json.Unmarshal([]byte(annotation), &imports); err != nil {
+		return 0, 0, errors.Wrap(err, errParseImport)
+	}
+	k, _, err := r.clientBuilder.KubeForProviderConfig(ctx, pc.Spec)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, errBuildKube)
+	}
+	managed, err := r.managedResources(ctx, pc.GetName())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, i := range imports {
+		if gv, err := schema.ParseGroupVersion(i.APIVersion); err == nil && gv.Group == "" && i.Kind == "Secret" {
+			return created, existing, errors.New(errImportSecret)
+		}
+		l := &unstructured.UnstructuredList{}
+		l.SetAPIVersion(i.APIVersion)
+		l.SetKind(i.Kind + "List")
+		opts := []client.ListOption{client.InNamespace(i.Namespace)}
+		if i.Selector != nil {
+			s, err := metav1.LabelSelectorAsSelector(i.Selector)
+			if err != nil {
+				return created, existing, errors.Wrapf(err, errImportSelector, i.Kind)
+			}
+			opts = append(opts, client.MatchingLabelsSelector{Selector: s})
+		}
+		if err := k.List(ctx, l, opts...); err != nil {
+			return created, existing, errors.Wrapf(err, errListImport, i.Kind)
+		}
+
+		for j := range l.Items {
+			u := &l.Items[j]
+			// Resources controlled by something else on the target
+			// cluster, e.g. the Pods of a ReplicaSet, would be fought
+			// over by their controller and the Object.
+			if metav1.GetControllerOf(u) != nil {
+				continue
+			}
+			// Resources owned by an Object, or that the manifest of an
+			// Object already refers to, are managed already.
+			if _, owned := u.GetLabels()[labelKeyObjectUID]; owned || managed[resourceKey(u)] {
+				existing++
+				continue
+			}
+			obj, err := importedObject(pc.GetName(), u)
+			if err != nil {
+				return created, existing, errors.Wrapf(err, errBuildImported, u.GetKind(), resourceName(u))
+			}
+			err = r.kube.Create(ctx, obj)
+			if kerrors.IsAlreadyExists(err) {
+				if err := r.checkAdopted(ctx, obj.GetName(), u); err != nil {
+					return created, existing, err
+				}
+				existing++
+				continue
+			}
+			if err != nil {
+				return created, existing, errors.Wrapf(err, errCreateImported, obj.GetName())
+			}
+			created++
+		}
+	}
+	return created, existing, nil
+}
+
+// managedResources returns the keys of the resources the manifests of the
+// Objects using the supplied provider config refer to, as returned by
+// resourceKey.
+func (r *importReconciler) managedResources(ctx context.Context, pc string) (map[string]bool, error) {
+	l := &v1alpha2.ObjectList{}
+	if err := r.kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListObjects)
+	}
+	keys := make(map[string]bool)
+	for i := range l.Items {
+		obj := &l.Items[i]
+		if ref := obj.GetProviderConfigReference(); ref == nil || ref.Name != pc {
+			continue
+		}
+		m := &unstructured.Unstructured{}
+		if err := json.Unmarshal(obj.Spec.ForProvider.Manifest.Raw, &m.Object); err != nil {
+			continue
+		}
+		keys[resourceKey(m)] = true
+	}
+	return keys, nil
+}
+
+// resourceKey returns the key of the supplied resource by its kind, namespace
+// and name.
+func resourceKey(u *unstructured.Unstructured) string {
+	return fmt.Sprintf("%v/%s/%s", u.GroupVersionKind(), u.GetNamespace(), u.GetName())
+}
+
+// checkAdopted returns an error unless the existing Object of the supplied
+// name adopts the supplied live resource, i.e. its manifest is of the same
+// kind, namespace and name, so that resources whose imported Objects would get
+// the same name are not silently skipped.
+func (r *importReconciler) checkAdopted(ctx context.Context, name string, u *unstructured.Unstructured) error {
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+		return errors.Wrapf(err, errGetImported, name)
+	}
+	m := &unstructured.Unstructured{}
+	if err := json.Unmarshal(obj.Spec.ForProvider.Manifest.Raw, &m.Object); err != nil ||
+		m.GroupVersionKind() != u.GroupVersionKind() || m.GetNamespace() != u.GetNamespace() || m.GetName() != u.GetName() {
+		return errors.Errorf(errImportConflict, name, u.GetKind(), resourceName(u))
+	}
+	return nil
+}
+
+// importedObject returns an Object using the supplied provider config that
+// adopts the supplied live resource, with its live state as manifest. The name
+// of the Object is derived from the provider config and the resource, so that
+// importing a resource again does not create another Object.
+func importedObject(pc string, u *unstructured.Unstructured) (*v1alpha2.Object, error) {
+	manifest, err := json.Marshal(liveManifest(u).Object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha2.Object{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   importedName(pc, u),
+			Labels: map[string]string{labelKeyImportedBy: pc},
+		},
+		Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &xpv1.Reference{Name: pc},
+			},
+			ForProvider: v1alpha2.ObjectParameters{
+				Manifest:        runtime.RawExtension{Raw: manifest},
+				OwnershipPolicy: v1alpha2.OwnershipPolicyAdopt,
+			},
+		},
+	}, nil
+}
+
+// importedName returns the name of the Object the supplied live resource is
+// imported into for the supplied provider config, i.e. the name of the provider
+// config followed by a hash of the resource. The name of the provider config
+// is shortened as needed for the name to be valid.
+func importedName(pc string, u *unstructured.Unstructured) string {
+	h := sha256.Sum256([]byte(resourceKey(u)))
+	suffix := "-" + hex.EncodeToString(h[:])[:importedNameHashLength]
+	if n := validation.DNS1123SubdomainMaxLength - len(suffix); len(pc) > n {
+		pc = strings.TrimRight(pc[:n], ".-")
+	}
+	return pc + suffix
+}
+
+// liveManifest returns the supplied live resource without its status and the
+// metadata set by the target cluster, i.e. as it could have been declared.
+func liveManifest(u *unstructured.Unstructured) *unstructured.Unstructured {
+	m := &unstructured.Unstructured{Object: runtime.DeepCopyJSON(u.Object)}
+	delete(m.Object, "status")
+	m.Object["metadata"] = map[string]any{}
+	m.SetName(u.GetName())
+	m.SetNamespace(u.GetNamespace())
+	m.SetLabels(u.GetLabels())
+	annotations := u.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	if len(annotations) > 0 {
+		m.SetAnnotations(annotations)
+	}
+	return m
+}
+
+// resourceName returns the namespaced name of the supplied resource, or its
+// name if it is cluster scoped.
+func resourceName(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return u.GetName()
+	}
+	return u.GetNamespace() + "/" + u.GetName()
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+type recordedEvents []event.Event
+
+func (r *recordedEvents) Event(_ runtime.Object, e event.Event) { *r = append(*r, e) }
+
+func (r *recordedEvents) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestImportReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	live := func(name string, mut ...func(u *unstructured.Unstructured)) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":            name,
+				"namespace":       "default",
+				"uid":             "live-uid",
+				"resourceVersion": "7",
+				"labels":          map[string]any{"team": "a"},
+				"annotations":     map[string]any{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+			},
+			"data": map[string]any{"key": "value"},
+		}}
+		for _, m := range mut {
+			m(&u)
+		}
+		return u
+	}
+	// The target cluster has three ConfigMaps of team a, one of which is
+	// controlled by something else and one owned by an Object.
+	target := &test.MockClient{
+		MockList: func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			if lo.Namespace != "default" || lo.LabelSelector.String() != "team=a" {
+				return errors.Errorf("unexpected list options %v", lo)
+			}
+			l.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{
+				live("adopted"),
+				live("controlled", func(u *unstructured.Unstructured) {
+					u.SetOwnerReferences([]metav1.OwnerReference{{Name: "owner", Controller: ptr.To(true)}})
+				}),
+				live("owned", func(u *unstructured.Unstructured) {
+					u.SetLabels(map[string]string{"team": "a", labelKeyObjectUID: "object-uid"})
+				}),
+			}
+			return nil
+		},
+	}
+	managedBy := func(pc, manifest string) v1alpha2.Object {
+		return v1alpha2.Object{Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: pc}},
+			ForProvider:  v1alpha2.ObjectParameters{Manifest: runtime.RawExtension{Raw: []byte(manifest)}},
+		}}
+	}
+	importing := `[{"apiVersion":"v1","kind":"ConfigMap","namespace":"default","selector":{"matchLabels":{"team":"a"}}}]`
+
+	type args struct {
+		annotation *string
+		builder    kubeclient.Builder
+		create     error
+		// existing is the manifest of the Object that already exists, if
+		// creating it fails as such.
+		existing string
+		// objects are the Objects that exist.
+		objects []v1alpha2.Object
+	}
+	type want struct {
+		err      error
+		created  []string
+		patched  bool
+		events   []event.Reason
+		manifest string
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"NotImporting": {
+			reason: "Nothing should be imported if the provider config does not ask for it.",
+		},
+		"Imported": {
+			reason: "An Object adopting every matching resource neither controlled by something else nor owned by an Object should be created, and the annotation removed.",
+			args:   args{annotation: &importing},
+			want: want{
+				created:  []string{"pc-7b8e15dc7aa5a0a3"},
+				patched:  true,
+				events:   []event.Reason{reasonImported},
+				manifest: `{"apiVersion":"v1","data":{"key":"value"},"kind":"ConfigMap","metadata":{"labels":{"team":"a"},"name":"adopted","namespace":"default"}}`,
+			},
+		},
+		"AlreadyImported": {
+			reason: "Resources already adopted by an imported Object should be skipped.",
+			args: args{
+				annotation: &importing,
+				create:     kerrors.NewAlreadyExists(schema.GroupResource{}, "pc"),
+				existing:   `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"adopted","namespace":"default"}}`,
+			},
+			want: want{
+				created: []string{"pc-7b8e15dc7aa5a0a3"},
+				patched: true,
+				events:  []event.Reason{reasonImported},
+			},
+		},
+		"ManagedByObject": {
+			reason: "Resources the manifest of an Object using the provider config refers to should be skipped.",
+			args: args{
+				annotation: &importing,
+				objects:    []v1alpha2.Object{managedBy("pc", `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"adopted","namespace":"default"}}`)},
+			},
+			want: want{
+				patched: true,
+				events:  []event.Reason{reasonImported},
+			},
+		},
+		"ManagedByObjectOfOtherProviderConfig": {
+			reason: "Resources the manifest of an Object using another provider config refers to should be imported, since they live on another target cluster.",
+			args: args{
+				annotation: &importing,
+				objects:    []v1alpha2.Object{managedBy("other", `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"adopted","namespace":"default"}}`)},
+			},
+			want: want{
+				created: []string{"pc-7b8e15dc7aa5a0a3"},
+				patched: true,
+				events:  []event.Reason{reasonImported},
+			},
+		},
+		"NameConflict": {
+			reason: "An existing Object of the same name that adopts another resource should be reported.",
+			args: args{
+				annotation: &importing,
+				create:     kerrors.NewAlreadyExists(schema.GroupResource{}, "pc"),
+				existing:   `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other","namespace":"default"}}`,
+			},
+			want: want{
+				err:     errors.Errorf(errImportConflict, "pc-7b8e15dc7aa5a0a3", "ConfigMap", "default/adopted"),
+				created: []string{"pc-7b8e15dc7aa5a0a3"},
+				events:  []event.Reason{reasonCannotImport},
+			},
+		},
+		"Secret": {
+			reason: "Secrets should not be imported, since their data would be inlined in the manifests of the Objects.",
+			args:   args{annotation: ptr.To(`[{"apiVersion":"v1","kind":"Secret","namespace":"default"}]`)},
+			want: want{
+				err:    errors.New(errImportSecret),
+				events: []event.Reason{reasonCannotImport},
+			},
+		},
+		"Malformed": {
+			reason: "A malformed import annotation should be reported and kept.",
+			args:   args{annotation: ptr.To("nope")},
+			want: want{
+				err:    errors.Wrap(errors.New("invalid character 'o' in literal null (expecting 'u')"), errParseImport),
+				events: []event.Reason{reasonCannotImport},
+			},
+		},
+		"CannotBuildClient": {
+			reason: "An import should be retried if the target cluster cannot be reached.",
+			args: args{annotation: &importing, builder: kubeclient.BuilderFn(func(_ context.Context, _ kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+				return nil, nil, errBoom
+			})},
+			want: want{
+				err:    errors.Wrap(errBoom, errBuildKube),
+				events: []event.Reason{reasonCannotImport},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created []string
+			var manifest string
+			patched := false
+			kube := &test.MockClient{
+				MockList: func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+					l.(*v1alpha2.ObjectList).Items = tc.args.objects
+					return nil
+				},
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if o, ok := obj.(*v1alpha2.Object); ok {
+						o.Spec.ForProvider.Manifest.Raw = []byte(tc.args.existing)
+						return nil
+					}
+					obj.SetName("pc")
+					if tc.args.annotation != nil {
+						obj.SetAnnotations(map[string]string{annotationKeyImport: *tc.args.annotation})
+					}
+					return nil
+				}),
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					o := obj.(*v1alpha2.Object)
+					created = append(created, o.GetName())
+					manifest = string(o.Spec.ForProvider.Manifest.Raw)
+					if o.Spec.ProviderConfigReference.Name != "pc" || o.Spec.ForProvider.OwnershipPolicy != v1alpha2.OwnershipPolicyAdopt || o.GetLabels()[labelKeyImportedBy] != "pc" {
+						t.Errorf("\n%s\nr.Reconcile(...): unexpected Object %v", tc.reason, o)
+					}
+					return tc.args.create
+				},
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					if _, ok := obj.GetAnnotations()[annotationKeyImport]; ok {
+						t.Errorf("\n%s\nr.Reconcile(...): import annotation was not removed", tc.reason)
+					}
+					patched = true
+					return nil
+				},
+			}
+			builder := tc.args.builder
+			if builder == nil {
+				builder = kubeclient.BuilderFn(func(_ context.Context, _ kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+					return target, &rest.Config{}, nil
+				})
+			}
+			events := &recordedEvents{}
+			inner := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, nil
+			})
+			r := newImportReconciler(inner, kube, builder, logging.NewNopLogger(), events)
+
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want created, +got created:\n%s", tc.reason, diff)
+			}
+			if tc.want.manifest != "" {
+				if diff := cmp.Diff(tc.want.manifest, manifest); diff != "" {
+					t.Errorf("\n%s\nr.Reconcile(...): -want manifest, +got manifest:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.patched, patched); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want patched, +got patched:\n%s", tc.reason, diff)
+			}
+			var reasons []event.Reason
+			for _, e := range *events {
+				reasons = append(reasons, e.Reason)
+			}
+			if diff := cmp.Diff(tc.want.events, reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestImportedName(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace("default")
+	u.SetName("adopted")
+
+	cases := map[string]struct {
+		reason string
+		pc     string
+		want   string
+	}{
+		"Short": {
+			reason: "The name should be the name of the provider config followed by a hash of the resource.",
+			pc:     "pc",
+			want:   "pc-7b8e15dc7aa5a0a3",
+		},
+		"Long": {
+			reason: "The name of the provider config should be shortened, without a trailing dash or dot, for the name to be valid.",
+			pc:     strings.Repeat("a", 235) + "-b",
+			want:   strings.Repeat("a", 235) + "-7b8e15dc7aa5a0a3",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := importedName(tc.pc, u)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nimportedName(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if errs := validation.IsDNS1123Subdomain(got); len(errs) > 0 {
+				t.Errorf("\n%s\nimportedName(...): invalid name %q: %v", tc.reason, got, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	// typeMissingPermissions is the condition reporting whether the
+	// credentials of a provider config lack permissions on its target
+	// cluster.
+	typeMissingPermissions xpv1.ConditionType = "MissingPermissions"
+
+	reasonMissingPermissions      xpv1.ConditionReason = "MissingPermissions"
+	reasonPermissionsVerified     xpv1.ConditionReason = "PermissionsVerified"
+	reasonCannotVerifyPermissions xpv1.ConditionReason = "CannotVerifyPermissions"
+
+	errBuildKube             = "cannot build client for the target cluster"
+	errReviewAccess          = "cannot review access to %s"
+	errUpdatePermissionsCond = "cannot update the MissingPermissions condition of the provider config"
+)
+
+// A permissionReconciler verifies the permissions of the credentials of
+// provider configs on their target clusters after reconciling them with
+// another reconciler, whenever their spec changed and at an interval, e.g. to
+// catch rotated credentials. Only the permission checks provider configs
+// declare are verified, since the permissions the credentials need depend on
+// the Objects using them, e.g. least-privilege credentials of a namespace.
+type permissionReconciler struct {
+	inner         reconcile.Reconciler
+	kube          client.Client
+	clientBuilder kubeclient.Builder
+	log           logging.Logger
+	interval      time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	checked map[string]permissionsChecked
+}
+
+type permissionsChecked struct {
+	generation int64
+	at         time.Time
+}
+
+func newPermissionReconciler(inner reconcile.Reconciler, kube client.Client, cb kubeclient.Builder, log logging.Logger, interval time.Duration) *permissionReconciler {
+	return &permissionReconciler{
+		inner:         inner,
+		kube:          kube,
+		clientBuilder: cb,
+		log:           log,
+		interval:      interval,
+		now:           time.Now,
+		checked:       make(map[string]permissionsChecked),
+	}
+}
+
+// Reconcile the supplied request with the inner reconciler, and then verify
+// the permissions of its provider config if they are due to be verified.
+func (r *permissionReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.inner.Reconcile(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil || meta.WasDeleted(pc) {
+		r.forget(req.Name)
+		return res, client.IgnoreNotFound(err)
+	}
+	if r.due(pc) {
+		generation := pc.GetGeneration()
+		var c *xpv1.Condition
+		if len(pc.Spec.PermissionChecks) > 0 {
+			v := r.verify(ctx, pc)
+			c = &v
+		}
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+				return err
+			}
+			if !setPermissionsCondition(pc, c) {
+				return nil
+			}
+			return r.kube.Status().Update(ctx, pc)
+		})
+		if err != nil {
+			return res, errors.Wrap(err, errUpdatePermissionsCond)
+		}
+		r.mu.Lock()
+		r.checked[pc.GetName()] = permissionsChecked{generation: generation, at: r.now()}
+		r.mu.Unlock()
+	}
+
+	if r.interval > 0 && (res.RequeueAfter == 0 || res.RequeueAfter > r.interval) {
+		res.RequeueAfter = r.interval
+	}
+	return res, nil
+}
+
+// due returns true if the permissions of the supplied provider config were
+// not verified since its spec last changed, or not within the interval.
+func (r *permissionReconciler) due(pc *v1alpha1.ProviderConfig) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.checked[pc.GetName()]
+	return !ok || c.generation != pc.GetGeneration() || (r.interval > 0 && r.now().Sub(c.at) >= r.interval)
+}
+
+func (r *permissionReconciler) forget(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checked, name)
+}
+
+// setPermissionsCondition sets the supplied MissingPermissions condition of the
+// supplied provider config, or removes it if there is none, e.g. because the
+// provider config no longer declares permission checks. It returns true if the
+// status of the provider config is to be updated.
+func setPermissionsCondition(pc *v1alpha1.ProviderConfig, c *xpv1.Condition) bool {
+	if c != nil {
+		pc.SetConditions(*c)
+		return true
+	}
+	conditions := pc.Status.Conditions[:0]
+	for _, cond := range pc.Status.Conditions {
+		if cond.Type != typeMissingPermissions {
+			conditions = append(conditions, cond)
+		}
+	}
+	removed := len(conditions) != len(pc.Status.Conditions)
+	pc.Status.Conditions = conditions
+	return removed
+}
+
+// verify the permissions of the credentials of the supplied provider config on
+// its target cluster, returning the MissingPermissions condition reporting
+// the outcome.
+func (r *permissionReconciler) verify(ctx context.Context, pc *v1alpha1.ProviderConfig) xpv1.Condition {
+	c := xpv1.Condition{
+		Type:               typeMissingPermissions,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonPermissionsVerified,
+		ObservedGeneration: pc.GetGeneration(),
+	}
+	missing, err := r.missingPermissions(ctx, pc.Spec)
+	if err != nil {
+		r.log.Debug("Cannot verify the permissions of the provider config", "providerConfig", pc.GetName(), "error", err)
+		c.Status, c.Reason, c.Message = corev1.ConditionUnknown, reasonCannotVerifyPermissions, err.Error()
+		return c
+	}
+	if len(missing) > 0 {
+		r.log.Info("Credentials of the provider config lack permissions on the target cluster", "providerConfig", pc.GetName(), "missing", missing)
+		c.Status, c.Reason = corev1.ConditionTrue, reasonMissingPermissions
+		c.Message = "the credentials are not allowed to " + strings.Join(missing, ", ")
+	}
+	return c
+}
+
+// missingPermissions returns the permissions the credentials of the supplied
+// provider config spec lack on its target cluster.
+func (r *permissionReconciler) missingPermissions(ctx context.Context, spec kconfig.ProviderConfigSpec) ([]string, error) {
+	k, _, err := r.clientBuilder.KubeForProviderConfig(ctx, spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errBuildKube)
+	}
+	var missing []string
+	for _, pc := range spec.PermissionChecks {
+		for _, verb := range pc.Verbs {
+			desc := permissionDescription(pc, verb)
+			review := &authorizationv1.SelfSubjectAccessReview{Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: pc.Namespace,
+					Verb:      verb,
+					Group:     pc.Group,
+					Resource:  pc.Resource,
+				},
+			}}
+			if err := k.Create(ctx, review); err != nil {
+				return nil, errors.Wrapf(err, errReviewAccess, desc)
+			}
+			if !review.Status.Allowed {
+				missing = append(missing, desc)
+			}
+		}
+	}
+	return missing, nil
+}
+
+// permissionDescription describes the supplied verb of the supplied check,
+// e.g. "patch apps/deployments in namespace default".
+func permissionDescription(pc kconfig.PermissionCheck, verb string) string {
+	resource := pc.Resource
+	if pc.Group != "" {
+		resource = pc.Group + "/" + pc.Resource
+	}
+	if pc.Namespace != "" {
+		return fmt.Sprintf("%s %s in namespace %s", verb, resource, pc.Namespace)
+	}
+	return fmt.Sprintf("%s %s", verb, resource)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestPermissionReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	interval := 10 * time.Minute
+	now := time.Now()
+
+	// The target cluster allows everything but deleting deployments.
+	target := &test.MockClient{
+		MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			ra := obj.(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+			obj.(*authorizationv1.SelfSubjectAccessReview).Status.Allowed = ra.Resource != "deployments" || ra.Verb != "delete"
+			return nil
+		},
+	}
+	deployments := []kconfig.PermissionCheck{{Group: "apps", Resource: "deployments", Namespace: "default", Verbs: []string{"get", "delete"}}}
+	configMaps := []kconfig.PermissionCheck{{Resource: "configmaps", Verbs: []string{"get", "list", "watch"}}}
+	reported := xpv1.Condition{Type: typeMissingPermissions, Status: corev1.ConditionTrue, Reason: reasonMissingPermissions, ObservedGeneration: 1}
+
+	type args struct {
+		inner      reconcile.Reconciler
+		builder    kubeclient.Builder
+		checks     []kconfig.PermissionCheck
+		conditions []xpv1.Condition
+		checked    map[string]permissionsChecked
+	}
+	type want struct {
+		result    reconcile.Result
+		err       error
+		updated   bool
+		condition *xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Verified": {
+			reason: "Credentials with every declared permission should be reported not to miss any.",
+			args:   args{checks: configMaps},
+			want: want{
+				result:    reconcile.Result{RequeueAfter: interval},
+				updated:   true,
+				condition: &xpv1.Condition{Type: typeMissingPermissions, Status: corev1.ConditionFalse, Reason: reasonPermissionsVerified, ObservedGeneration: 2},
+			},
+		},
+		"NoChecks": {
+			reason: "Permissions should not be verified nor reported if the provider config declares no permission checks.",
+			args:   args{},
+			want:   want{result: reconcile.Result{RequeueAfter: interval}},
+		},
+		"ChecksRemoved": {
+			reason: "The permissions reported before should no longer be reported once the provider config declares no permission checks.",
+			args:   args{conditions: []xpv1.Condition{reported}},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: interval},
+				updated: true,
+			},
+		},
+		"Missing": {
+			reason: "Credentials lacking declared permissions should be reported with the missing permissions.",
+			args:   args{checks: deployments},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: interval},
+				updated: true,
+				condition: &xpv1.Condition{
+					Type:               typeMissingPermissions,
+					Status:             corev1.ConditionTrue,
+					Reason:             reasonMissingPermissions,
+					Message:            "the credentials are not allowed to delete apps/deployments in namespace default",
+					ObservedGeneration: 2,
+				},
+			},
+		},
+		"CannotVerify": {
+			reason: "Permissions that cannot be verified should be reported as unknown.",
+			args: args{
+				builder: kubeclient.BuilderFn(func(_ context.Context, _ kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+					return nil, nil, errBoom
+				}),
+				checks: configMaps,
+			},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: interval},
+				updated: true,
+				condition: &xpv1.Condition{
+					Type:               typeMissingPermissions,
+					Status:             corev1.ConditionUnknown,
+					Reason:             reasonCannotVerifyPermissions,
+					Message:            errors.Wrap(errBoom, errBuildKube).Error(),
+					ObservedGeneration: 2,
+				},
+			},
+		},
+		"NotDue": {
+			reason: "Permissions verified since the spec last changed and within the interval should not be verified again.",
+			args:   args{checked: map[string]permissionsChecked{"pc": {generation: 2, at: now.Add(-time.Minute)}}},
+			want:   want{result: reconcile.Result{RequeueAfter: interval}},
+		},
+		"InnerError": {
+			reason: "Permissions should not be verified if the provider config could not be reconciled.",
+			args: args{inner: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, errBoom
+			})},
+			want: want{err: errBoom},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *xpv1.Condition
+			updated := false
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					pc := obj.(*v1alpha1.ProviderConfig)
+					pc.SetName("pc")
+					pc.SetGeneration(2)
+					pc.Spec.PermissionChecks = tc.args.checks
+					pc.Status.Conditions = append([]xpv1.Condition(nil), tc.args.conditions...)
+					return nil
+				}),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					updated = true
+					for _, c := range obj.(*v1alpha1.ProviderConfig).Status.Conditions {
+						if c.Type == typeMissingPermissions {
+							got = c.DeepCopy()
+						}
+					}
+					return nil
+				},
+			}
+			inner := tc.args.inner
+			if inner == nil {
+				inner = reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{}, nil
+				})
+			}
+			builder := tc.args.builder
+			if builder == nil {
+				builder = kubeclient.BuilderFn(func(_ context.Context, _ kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+					return target, &rest.Config{}, nil
+				})
+			}
+			r := newPermissionReconciler(inner, kube, builder, logging.NewNopLogger(), interval)
+			r.now = func() time.Time { return now }
+			if tc.args.checked != nil {
+				r.checked = tc.args.checked
+			}
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, res); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if updated != tc.want.updated {
+				t.Errorf("\n%s\nr.Reconcile(...): want updated %t, got %t", tc.reason, tc.want.updated, updated)
+			}
+			if diff := cmp.Diff(tc.want.condition, got, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	// annotationKeyRenewAfter is the annotation of the Secret of a scoped
+	// identity with the time, in RFC 3339 format, after which its token is
+	// renewed.
+	annotationKeyRenewAfter = "kubernetes.crossplane.io/renew-after"
+	// annotationKeyScope is the annotation of the Secret of a scoped identity
+	// with the hash of the scope, i.e. the namespace and rules, it was
+	// created with, so that it is created again once its scope changes.
+	annotationKeyScope = "kubernetes.crossplane.io/scope"
+	// annotationKeyScopedNamespace is the annotation of a provider config
+	// with the namespace of the target cluster its scoped identity was
+	// created in, so that it is deleted from there once it moves or goes.
+	annotationKeyScopedNamespace = "kubernetes.crossplane.io/scoped-identity-namespace"
+
+	// finalizerScopedIdentity keeps provider configs around until their
+	// scoped identities are deleted from their target clusters.
+	finalizerScopedIdentity = "kubernetes.crossplane.io/scoped-identity"
+
+	// scopedIdentityPrefix prefixes the names of the ServiceAccounts, Roles
+	// and RoleBindings of scoped identities with the name of their provider
+	// configs.
+	scopedIdentityPrefix = "provider-kubernetes-"
+	// defaultTokenExpiration is how long the tokens of scoped identities are
+	// valid if their provider configs do not say.
+	defaultTokenExpiration = 24 * time.Hour
+
+	reasonBootstrapped     event.Reason = "BootstrappedScopedIdentity"
+	reasonCannotBootstrap  event.Reason = "CannotBootstrapScopedIdentity"
+	reasonCannotRenewToken event.Reason = "CannotRenewScopedIdentityToken"
+	reasonDeletedScoped    event.Reason = "DeletedScopedIdentity"
+	reasonCannotDelete     event.Reason = "CannotDeleteScopedIdentity"
+
+	errGetScopedSecret   = "cannot get the secret of the scoped identity"
+	errApplyScoped       = "cannot apply %s %s/%s of the scoped identity on the target cluster"
+	errDeleteScoped      = "cannot delete %s %s/%s of the scoped identity from the target cluster"
+	errRecordScoped      = "cannot record the scoped identity on the provider config"
+	errRequestToken      = "cannot request a token for the scoped identity"
+	errReadCA            = "cannot read the certificate authority of the target cluster"
+	errWriteKubeconfig   = "cannot write the kubeconfig of the scoped identity"
+	errApplyScopedSecret = "cannot apply the secret of the scoped identity"
+)
+
+// A scopedIdentityReconciler creates the scoped identities of provider
+// configs that ask for one on their target clusters with their credentials,
+// keeps the tokens of the scoped identities written to their Secrets valid
+// and deletes the scoped identities again once they move, are no longer asked
+// for or their provider configs are deleted, after reconciling them with
+// another reconciler.
+type scopedIdentityReconciler struct {
+	inner         reconcile.Reconciler
+	kube          client.Client
+	clientBuilder kubeclient.Builder
+	log           logging.Logger
+	record        event.Recorder
+	now           func() time.Time
+}
+
+func newScopedIdentityReconciler(inner reconcile.Reconciler, kube client.Client, cb kubeclient.Builder, log logging.Logger, r event.Recorder) *scopedIdentityReconciler {
+	return &scopedIdentityReconciler{inner: inner, kube: kube, clientBuilder: cb, log: log, record: r, now: time.Now}
+}
+
+// Reconcile the supplied request with the inner reconciler, and then create
+// the scoped identity of its provider config or renew its token, if needed.
+// The request is requeued for the token to be renewed in time.
+func (r *scopedIdentityReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.inner.Reconcile(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+	if meta.WasDeleted(pc) || pc.Spec.ScopedIdentity == nil {
+		if err := r.release(ctx, pc); err != nil {
+			r.record.Event(pc, event.Warning(reasonCannotDelete, err))
+			return res, err
+		}
+		return res, nil
+	}
+
+	renewAfter, err := r.mint(ctx, pc)
+	if err != nil {
+		r.record.Event(pc, event.Warning(reasonCannotBootstrap, err))
+		return res, err
+	}
+	if d := renewAfter.Sub(r.now()); d > 0 && (res.RequeueAfter == 0 || res.RequeueAfter > d) {
+		res.RequeueAfter = d
+	}
+	return res, nil
+}
+
+// mint a token for the scoped identity of the supplied provider config if its
+// token is due to be renewed, returning when the token is to be renewed next.
+// The token is renewed with the credentials of the provider config, so that
+// the scoped identity needs no permission to request tokens for itself. The
+// scoped identity is created again if it was never created, its scope changed
+// or its token cannot be renewed.
+func (r *scopedIdentityReconciler) mint(ctx context.Context, pc *v1alpha1.ProviderConfig) (time.Time, error) {
+	si := pc.Spec.ScopedIdentity
+	s := &corev1.Secret{}
+	err := r.kube.Get(ctx, types.NamespacedName{Namespace: si.SecretRef.Namespace, Name: si.SecretRef.Name}, s)
+	if resource.IgnoreNotFound(err) != nil {
+		return time.Time{}, errors.Wrap(err, errGetScopedSecret)
+	}
+	current := err == nil && s.GetAnnotations()[annotationKeyScope] == scopeHash(si)
+	if current {
+		renewAfter, err := time.Parse(time.RFC3339, s.GetAnnotations()[annotationKeyRenewAfter])
+		if err == nil && r.now().Before(renewAfter) {
+			return renewAfter, nil
+		}
+	}
+
+	k, rc, err := r.clientBuilder.KubeForProviderConfig(ctx, kubeclient.BootstrapSpec(pc.Spec))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, errBuildKube)
+	}
+	if current {
+		renewAfter, err := r.issue(ctx, pc, k, rc)
+		if err == nil {
+			r.log.Debug("Renewed the token of the scoped identity", "providerConfig", pc.GetName(), "renewAfter", renewAfter)
+			return renewAfter, nil
+		}
+		r.log.Info("Cannot renew the token of the scoped identity, creating it again", "providerConfig", pc.GetName(), "error", err)
+		r.record.Event(pc, event.Warning(reasonCannotRenewToken, err))
+	}
+
+	// The scoped identity is deleted from the namespace it was created in
+	// before, if it moved, and the namespace it is created in recorded first,
+	// so that it is never left behind on the target cluster.
+	if ns := pc.GetAnnotations()[annotationKeyScopedNamespace]; ns != "" && ns != si.Namespace {
+		if err := deleteScopedIdentity(ctx, k, pc, ns); err != nil {
+			return time.Time{}, err
+		}
+		r.log.Info("Deleted the scoped identity from the namespace it moved from", "providerConfig", pc.GetName(), "namespace", ns)
+	}
+	if !meta.FinalizerExists(pc, finalizerScopedIdentity) || pc.GetAnnotations()[annotationKeyScopedNamespace] != si.Namespace {
+		meta.AddFinalizer(pc, finalizerScopedIdentity)
+		meta.AddAnnotations(pc, map[string]string{annotationKeyScopedNamespace: si.Namespace})
+		if err := r.kube.Update(ctx, pc); err != nil {
+			return time.Time{}, errors.Wrap(err, errRecordScoped)
+		}
+	}
+	if err := bootstrapScopedIdentity(ctx, k, pc); err != nil {
+		return time.Time{}, err
+	}
+	renewAfter, err := r.issue(ctx, pc, k, rc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	r.log.Info("Created the scoped identity on the target cluster", "providerConfig", pc.GetName(), "namespace", si.Namespace, "serviceAccount", scopedIdentityName(pc))
+	r.record.Event(pc, event.Normal(reasonBootstrapped, "Created the scoped identity on the target cluster"))
+	return renewAfter, nil
+}
+
+// release the scoped identity of the supplied provider config, which is
+// deleted or no longer asks for one, by deleting it from the namespace of the
+// target cluster it was created in and removing the finalizer that kept the
+// provider config around. The scoped identity of a deleted provider config is
+// only deleted once the provider config is no longer in use, i.e. its other
+// finalizers are gone.
+func (r *scopedIdentityReconciler) release(ctx context.Context, pc *v1alpha1.ProviderConfig) error {
+	if !meta.FinalizerExists(pc, finalizerScopedIdentity) {
+		return nil
+	}
+	if meta.WasDeleted(pc) && len(pc.GetFinalizers()) > 1 {
+		return nil
+	}
+	if ns := pc.GetAnnotations()[annotationKeyScopedNamespace]; ns != "" {
+		k, _, err := r.clientBuilder.KubeForProviderConfig(ctx, kubeclient.BootstrapSpec(pc.Spec))
+		if err != nil {
+			return errors.Wrap(err, errBuildKube)
+		}
+		if err := deleteScopedIdentity(ctx, k, pc, ns); err != nil {
+			return err
+		}
+		r.log.Info("Deleted the scoped identity from the target cluster", "providerConfig", pc.GetName(), "namespace", ns)
+		r.record.Event(pc, event.Normal(reasonDeletedScoped, "Deleted the scoped identity from the target cluster"))
+	}
+	meta.RemoveFinalizer(pc, finalizerScopedIdentity)
+	meta.RemoveAnnotations(pc, annotationKeyScopedNamespace)
+	return errors.Wrap(resource.IgnoreNotFound(r.kube.Update(ctx, pc)), errRecordScoped)
+}
+
+// issue a token for the scoped identity of the supplied provider config with
+// the supplied client of its target cluster, and write the kubeconfig of the
+// scoped identity to its Secret, returning when the token is to be renewed.
+func (r *scopedIdentityReconciler) issue(ctx context.Context, pc *v1alpha1.ProviderConfig, k client.Client, rc *rest.Config) (time.Time, error) {
+	si := pc.Spec.ScopedIdentity
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: si.Namespace, Name: scopedIdentityName(pc)}}
+	tr := &authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{
+		ExpirationSeconds: ptr.To(int64(tokenExpiration(si).Seconds())),
+	}}
+	issued := r.now()
+	if err := k.SubResource("token").Create(ctx, sa, tr); err != nil {
+		return time.Time{}, errors.Wrap(err, errRequestToken)
+	}
+	kc, err := scopedKubeconfig(rc, tr.Status.Token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// The token is renewed once two thirds of its lifetime elapsed, which
+	// the target cluster may have shortened.
+	lifetime := tokenExpiration(si)
+	if exp := tr.Status.ExpirationTimestamp; !exp.IsZero() {
+		lifetime = exp.Sub(issued)
+	}
+	renewAfter := issued.Add(lifetime * 2 / 3).Truncate(time.Second)
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: si.SecretRef.Namespace,
+			Name:      si.SecretRef.Name,
+			Annotations: map[string]string{
+				annotationKeyScope:      scopeHash(si),
+				annotationKeyRenewAfter: renewAfter.UTC().Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(pc, v1alpha1.ProviderConfigGroupVersionKind))},
+		},
+		Data: map[string][]byte{kubeclient.ScopedIdentityKubeconfigKey: kc},
+	}
+	err = resource.NewAPIUpdatingApplicator(r.kube).Apply(ctx, s, resource.MustBeControllableBy(pc.GetUID()))
+	return renewAfter, errors.Wrap(err, errApplyScopedSecret)
+}
+
+// A scopedObject is one of the objects a scoped identity consists of.
+type scopedObject struct {
+	kind string
+	obj  client.Object
+}
+
+// scopedObjects returns the ServiceAccount, Role and RoleBinding of the scoped
+// identity of the supplied provider config in the supplied namespace.
+func scopedObjects(pc *v1alpha1.ProviderConfig, namespace string) []scopedObject {
+	om := metav1.ObjectMeta{Namespace: namespace, Name: scopedIdentityName(pc)}
+	var rules []rbacv1.PolicyRule
+	if si := pc.Spec.ScopedIdentity; si != nil {
+		rules = si.Rules
+	}
+	return []scopedObject{
+		{kind: "ServiceAccount", obj: &corev1.ServiceAccount{ObjectMeta: *om.DeepCopy()}},
+		{kind: "Role", obj: &rbacv1.Role{ObjectMeta: *om.DeepCopy(), Rules: rules}},
+		{kind: "RoleBinding", obj: &rbacv1.RoleBinding{
+			ObjectMeta: *om.DeepCopy(),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: om.Name},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: om.Namespace, Name: om.Name}},
+		}},
+	}
+}
+
+// bootstrapScopedIdentity creates or updates the ServiceAccount, Role and
+// RoleBinding of the scoped identity of the supplied provider config with the
+// supplied client of its target cluster.
+func bootstrapScopedIdentity(ctx context.Context, k client.Client, pc *v1alpha1.ProviderConfig) error {
+	a := resource.NewAPIPatchingApplicator(k)
+	for _, o := range scopedObjects(pc, pc.Spec.ScopedIdentity.Namespace) {
+		if err := a.Apply(ctx, o.obj); err != nil {
+			return errors.Wrapf(err, errApplyScoped, o.kind, o.obj.GetNamespace(), o.obj.GetName())
+		}
+	}
+	return nil
+}
+
+// deleteScopedIdentity deletes the RoleBinding, Role and ServiceAccount of the
+// scoped identity of the supplied provider config from the supplied namespace
+// with the supplied client of its target cluster.
+func deleteScopedIdentity(ctx context.Context, k client.Client, pc *v1alpha1.ProviderConfig, namespace string) error {
+	objs := scopedObjects(pc, namespace)
+	for i := len(objs) - 1; i >= 0; i-- {
+		o := objs[i]
+		if err := k.Delete(ctx, o.obj); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, errDeleteScoped, o.kind, namespace, o.obj.GetName())
+		}
+	}
+	return nil
+}
+
+// scopedIdentityName returns the name of the ServiceAccount, Role and
+// RoleBinding of the scoped identity of the supplied provider config.
+func scopedIdentityName(pc *v1alpha1.ProviderConfig) string {
+	return scopedIdentityPrefix + pc.GetName()
+}
+
+// tokenExpiration returns how long the tokens of the supplied scoped identity
+// are valid.
+func tokenExpiration(si *kconfig.ScopedIdentity) time.Duration {
+	if si.TokenExpiration == nil || si.TokenExpiration.Duration <= 0 {
+		return defaultTokenExpiration
+	}
+	return si.TokenExpiration.Duration
+}
+
+// scopeHash returns the hash of the scope of the supplied scoped identity.
+func scopeHash(si *kconfig.ScopedIdentity) string {
+	// Marshalling a namespace and policy rules cannot fail.
+	b, _ := json.Marshal(struct {
+		Namespace string              `json:"namespace"`
+		Rules     []rbacv1.PolicyRule `json:"rules"`
+	}{Namespace: si.Namespace, Rules: si.Rules})
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// scopedKubeconfig returns a kubeconfig authenticating to the target cluster
+// of the supplied REST config with the supplied token.
+func scopedKubeconfig(rc *rest.Config, token string) ([]byte, error) {
+	ca := rc.CAData
+	if len(ca) == 0 && rc.CAFile != "" {
+		var err error
+		if ca, err = os.ReadFile(rc.CAFile); err != nil {
+			return nil, errors.Wrap(err, errReadCA)
+		}
+	}
+	c := api.NewConfig()
+	c.Clusters["target"] = &api.Cluster{
+		Server:                   rc.Host,
+		CertificateAuthorityData: ca,
+		TLSServerName:            rc.ServerName,
+		InsecureSkipTLSVerify:    rc.Insecure,
+	}
+	c.AuthInfos["scoped"] = &api.AuthInfo{Token: token}
+	c.Contexts["scoped"] = &api.Context{Cluster: "target", AuthInfo: "scoped"}
+	c.CurrentContext = "scoped"
+	kc, err := clientcmd.Write(*c)
+	return kc, errors.Wrap(err, errWriteKubeconfig)
+}
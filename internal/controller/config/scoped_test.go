@@ -0,0 +1,344 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestScopedIdentityReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	si := &kconfig.ScopedIdentity{
+		Namespace: "apps",
+		Rules:     []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"*"}}},
+		SecretRef: xpv1.SecretReference{Namespace: "crossplane-system", Name: "scoped"},
+	}
+	providerConfig := func(si *kconfig.ScopedIdentity, o ...func(pc *v1alpha1.ProviderConfig)) *v1alpha1.ProviderConfig {
+		pc := &v1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "pc", UID: types.UID("pc-uid")},
+			Spec:       kconfig.ProviderConfigSpec{ScopedIdentity: si},
+		}
+		for _, fn := range o {
+			fn(pc)
+		}
+		return pc
+	}
+	// created marks the scoped identity of a provider config as created in
+	// the supplied namespace.
+	created := func(namespace string) func(pc *v1alpha1.ProviderConfig) {
+		return func(pc *v1alpha1.ProviderConfig) {
+			meta.AddFinalizer(pc, finalizerScopedIdentity)
+			meta.AddAnnotations(pc, map[string]string{annotationKeyScopedNamespace: namespace})
+		}
+	}
+	deleted := func(finalizers ...string) func(pc *v1alpha1.ProviderConfig) {
+		return func(pc *v1alpha1.ProviderConfig) {
+			pc.SetDeletionTimestamp(&metav1.Time{Time: now})
+			for _, f := range finalizers {
+				meta.AddFinalizer(pc, f)
+			}
+		}
+	}
+	secret := func(scope string, renewAfter time.Time) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "crossplane-system",
+			Name:      "scoped",
+			Annotations: map[string]string{
+				annotationKeyScope:      scope,
+				annotationKeyRenewAfter: renewAfter.Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(providerConfig(si), v1alpha1.ProviderConfigGroupVersionKind))},
+		}}
+	}
+
+	// target returns a client of the target cluster recording the kinds it
+	// applies and deletes and the service accounts it issues tokens for. The
+	// first token request fails with the supplied error, if any.
+	target := func(applied, deleted, issued *[]string, tokenErr error) client.Client {
+		return &test.MockClient{
+			MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				switch obj.(type) {
+				case *corev1.ServiceAccount:
+					*applied = append(*applied, "ServiceAccount")
+				case *rbacv1.Role:
+					*applied = append(*applied, "Role")
+				case *rbacv1.RoleBinding:
+					*applied = append(*applied, "RoleBinding")
+				}
+				return nil
+			},
+			MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+				*deleted = append(*deleted, obj.GetNamespace()+"/"+obj.GetName())
+				return nil
+			},
+			MockSubResourceCreate: func(_ context.Context, obj client.Object, sub client.Object, _ ...client.SubResourceCreateOption) error {
+				if tokenErr != nil {
+					err := tokenErr
+					tokenErr = nil
+					return err
+				}
+				*issued = append(*issued, obj.GetNamespace()+"/"+obj.GetName())
+				tr := sub.(*authenticationv1.TokenRequest)
+				tr.Status.Token = "s3cr3t"
+				tr.Status.ExpirationTimestamp = metav1.NewTime(now.Add(time.Duration(*tr.Spec.ExpirationSeconds) * time.Second))
+				return nil
+			},
+		}
+	}
+
+	type args struct {
+		pc     *v1alpha1.ProviderConfig
+		secret *corev1.Secret
+		// tokenErr is the error of the first token request.
+		tokenErr error
+	}
+	type want struct {
+		res     reconcile.Result
+		err     error
+		applied []string
+		deleted []string
+		issued  []string
+		scoped  bool
+		written bool
+		// recorded is the namespace the scoped identity is recorded as
+		// created in once the provider config is updated, if it is.
+		recorded *string
+		events   []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"NoScopedIdentity": {
+			reason: "Nothing should be created for a provider config without a scoped identity.",
+			args:   args{pc: providerConfig(nil)},
+		},
+		"Valid": {
+			reason: "A token that is not due to be renewed should be left alone, and the request requeued when it is.",
+			args:   args{pc: providerConfig(si), secret: secret(scopeHash(si), now.Add(time.Hour))},
+			want:   want{res: reconcile.Result{RequeueAfter: time.Hour}},
+		},
+		"Bootstrapped": {
+			reason: "The scoped identity should be created with the credentials of the provider config if it has no Secret yet.",
+			args:   args{pc: providerConfig(si)},
+			want: want{
+				res:      reconcile.Result{RequeueAfter: 16 * time.Hour},
+				applied:  []string{"ServiceAccount", "Role", "RoleBinding"},
+				issued:   []string{"apps/provider-kubernetes-pc"},
+				written:  true,
+				recorded: ptr.To("apps"),
+				events:   []event.Reason{reasonBootstrapped},
+			},
+		},
+		"Renewed": {
+			reason: "A token that is due to be renewed should be renewed with the credentials of the provider config.",
+			args:   args{pc: providerConfig(si, created("apps")), secret: secret(scopeHash(si), now.Add(-time.Minute))},
+			want: want{
+				res:     reconcile.Result{RequeueAfter: 16 * time.Hour},
+				issued:  []string{"apps/provider-kubernetes-pc"},
+				written: true,
+			},
+		},
+		"CannotRenew": {
+			reason: "The scoped identity should be created again if its token cannot be renewed, e.g. because it was deleted.",
+			args:   args{pc: providerConfig(si, created("apps")), secret: secret(scopeHash(si), now.Add(-time.Minute)), tokenErr: errBoom},
+			want: want{
+				res:     reconcile.Result{RequeueAfter: 16 * time.Hour},
+				applied: []string{"ServiceAccount", "Role", "RoleBinding"},
+				issued:  []string{"apps/provider-kubernetes-pc"},
+				written: true,
+				events:  []event.Reason{reasonCannotRenewToken, reasonBootstrapped},
+			},
+		},
+		"ScopeChanged": {
+			reason: "The scoped identity should be created again if its scope changed, even if its token is valid.",
+			args:   args{pc: providerConfig(si, created("apps")), secret: secret("stale", now.Add(time.Hour))},
+			want: want{
+				res:     reconcile.Result{RequeueAfter: 16 * time.Hour},
+				applied: []string{"ServiceAccount", "Role", "RoleBinding"},
+				issued:  []string{"apps/provider-kubernetes-pc"},
+				written: true,
+				events:  []event.Reason{reasonBootstrapped},
+			},
+		},
+		"NamespaceChanged": {
+			reason: "The scoped identity should be deleted from the namespace it moved from before it is created in the new one.",
+			args:   args{pc: providerConfig(si, created("legacy")), secret: secret("stale", now.Add(time.Hour))},
+			want: want{
+				res:      reconcile.Result{RequeueAfter: 16 * time.Hour},
+				applied:  []string{"ServiceAccount", "Role", "RoleBinding"},
+				deleted:  []string{"legacy/provider-kubernetes-pc", "legacy/provider-kubernetes-pc", "legacy/provider-kubernetes-pc"},
+				issued:   []string{"apps/provider-kubernetes-pc"},
+				written:  true,
+				recorded: ptr.To("apps"),
+				events:   []event.Reason{reasonBootstrapped},
+			},
+		},
+		"NoLongerScoped": {
+			reason: "The scoped identity should be deleted once the provider config no longer asks for one.",
+			args:   args{pc: providerConfig(nil, created("apps"))},
+			want: want{
+				deleted:  []string{"apps/provider-kubernetes-pc", "apps/provider-kubernetes-pc", "apps/provider-kubernetes-pc"},
+				recorded: ptr.To(""),
+				events:   []event.Reason{reasonDeletedScoped},
+			},
+		},
+		"Deleted": {
+			reason: "The scoped identity should be deleted once its provider config is deleted and no longer in use.",
+			args:   args{pc: providerConfig(si, created("apps"), deleted())},
+			want: want{
+				deleted:  []string{"apps/provider-kubernetes-pc", "apps/provider-kubernetes-pc", "apps/provider-kubernetes-pc"},
+				recorded: ptr.To(""),
+				events:   []event.Reason{reasonDeletedScoped},
+			},
+		},
+		"DeletedInUse": {
+			reason: "The scoped identity should be kept while its deleted provider config is still in use.",
+			args:   args{pc: providerConfig(si, created("apps"), deleted("in-use.crossplane.io"))},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var applied, deleted, issued []string
+			var scoped bool
+			var written *corev1.Secret
+			var updated *v1alpha1.ProviderConfig
+			k := target(&applied, &deleted, &issued, tc.args.tokenErr)
+			cb := kubeclient.BuilderFn(func(_ context.Context, spec kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+				rc := &rest.Config{Host: "https://target", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}}
+				if spec.ScopedIdentity != nil {
+					scoped = true
+				}
+				return k, rc, nil
+			})
+			write := func(_ context.Context, obj client.Object) error {
+				switch o := obj.(type) {
+				case *corev1.Secret:
+					written = o
+				case *v1alpha1.ProviderConfig:
+					updated = o
+				}
+				return nil
+			}
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1alpha1.ProviderConfig:
+						tc.args.pc.DeepCopyInto(o)
+					case *corev1.Secret:
+						if tc.args.secret == nil {
+							return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "scoped")
+						}
+						tc.args.secret.DeepCopyInto(o)
+					}
+					return nil
+				},
+				MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error { return write(ctx, obj) },
+				MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error { return write(ctx, obj) },
+			}
+			events := &recordedEvents{}
+			r := newScopedIdentityReconciler(reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, nil
+			}), kube, cb, logging.NewNopLogger(), events)
+			r.now = func() time.Time { return now }
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.res, res); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.applied, applied); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want applied, +got applied:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.issued, issued); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want issued tokens, +got issued tokens:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.scoped, scoped); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want scoped client, +got scoped client:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+			var recorded *string
+			if updated != nil {
+				ns := updated.GetAnnotations()[annotationKeyScopedNamespace]
+				recorded = &ns
+				if diff := cmp.Diff(ns != "", meta.FinalizerExists(updated, finalizerScopedIdentity)); diff != "" {
+					t.Errorf("\n%s\nr.Reconcile(...): -want finalizer, +got finalizer:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.recorded, recorded); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want recorded namespace, +got recorded namespace:\n%s", tc.reason, diff)
+			}
+			var reasons []event.Reason
+			for _, e := range *events {
+				reasons = append(reasons, e.Reason)
+			}
+			if diff := cmp.Diff(tc.want.events, reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.written, written != nil); diff != "" {
+				t.Fatalf("\n%s\nr.Reconcile(...): -want secret written, +got secret written:\n%s", tc.reason, diff)
+			}
+			if written == nil {
+				return
+			}
+			if diff := cmp.Diff(map[string]string{
+				annotationKeyScope:      scopeHash(si),
+				annotationKeyRenewAfter: now.Add(16 * time.Hour).Format(time.RFC3339),
+			}, written.GetAnnotations()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want secret annotations, +got secret annotations:\n%s", tc.reason, diff)
+			}
+			kc := string(written.Data[kubeclient.ScopedIdentityKubeconfigKey])
+			if !strings.Contains(kc, "token: s3cr3t") || !strings.Contains(kc, "server: https://target") {
+				t.Errorf("\n%s\nr.Reconcile(...): the written kubeconfig should authenticate to the target cluster with the token:\n%s", tc.reason, kc)
+			}
+		})
+	}
+}
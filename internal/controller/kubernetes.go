@@ -28,17 +28,39 @@ import (
 	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/observedobjectcollection"
 )
 
+// SetupOptions configure the controllers of the provider beyond the options
+// they share.
+type SetupOptions struct {
+	// Object configures the controller of Objects. Its shard is that of the
+	// ObservedObjectCollections too.
+	Object object.SetupOptions
+	// PollJitter is the jitter of the poll interval of
+	// ObservedObjectCollections.
+	PollJitter time.Duration
+}
+
 // Setup creates all Template controllers with the supplied logger and adds them to
 // the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJitter time.Duration, pollJitterPercentage uint) error {
-	if err := config.Setup(mgr, o); err != nil {
-		return err
+func Setup(mgr ctrl.Manager, o controller.Options, so SetupOptions) error {
+	// ProviderConfigs are not sharded, so they are reconciled, i.e. their
+	// scoped identities created and their target clusters imported, by the
+	// first shard only.
+	if so.Object.Shard.Index == 0 {
+		if err := config.Setup(mgr, o); err != nil {
+			return err
+		}
 	}
-	if err := object.Setup(mgr, o, sanitizeSecrets, pollJitterPercentage); err != nil {
+	if err := object.Setup(mgr, o, so.Object); err != nil {
 		return err
 	}
-	if err := observedobjectcollection.Setup(mgr, o, pollJitter); err != nil {
+	if err := observedobjectcollection.Setup(mgr, o, so.PollJitter, so.Object.Shard); err != nil {
 		return err
 	}
 	return nil
 }
+
+// SetupOrphanScanner adds a scanner for remote objects left behind by deleted
+// Objects to the supplied manager.
+func SetupOrphanScanner(mgr ctrl.Manager, o controller.Options, interval time.Duration, cleanup bool) error {
+	return object.SetupOrphanScanner(mgr, o, interval, cleanup)
+}
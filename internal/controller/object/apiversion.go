@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errDiscoverServedVersions = "cannot discover the resources served by the target cluster for %s"
+	errMarshalMigrated        = "cannot marshal manifest with migrated apiVersions"
+)
+
+// migrateAPIVersions replaces the apiVersion of the manifest of the supplied
+// Object, or of each of its items if it is a List, with the preferred version
+// served by the target cluster for its kind, if the target cluster does not
+// serve it anymore and the Object asks for it, and reports the substitutions
+// in its status.
+func (c *external) migrateAPIVersions(obj *v1alpha2.Object) error {
+	obj.Status.AtProvider.MigratedAPIVersions = nil
+	if obj.Spec.ForProvider.APIVersionPolicy != v1alpha2.APIVersionPolicyPreferredServed || c.discovery == nil {
+		return nil
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(obj.Spec.ForProvider.Manifest.Raw, &u.Object); err != nil {
+		return errors.Wrap(err, errUnmarshalTemplate)
+	}
+	objs := []map[string]any{u.Object}
+	if u.IsList() {
+		objs = nil
+		items, _ := u.Object["items"].([]any)
+		for _, item := range items {
+			if m, ok := item.(map[string]any); ok {
+				objs = append(objs, m)
+			}
+		}
+	}
+
+	migrated := map[schema.GroupVersionKind]string{}
+	for _, o := range objs {
+		item := &unstructured.Unstructured{Object: o}
+		gvk := item.GroupVersionKind()
+		to, ok := migrated[gvk]
+		if !ok {
+			var err error
+			if to, err = servedAPIVersion(c.discovery, gvk); err != nil {
+				return err
+			}
+			migrated[gvk] = to
+			if to != item.GetAPIVersion() {
+				obj.Status.AtProvider.MigratedAPIVersions = append(obj.Status.AtProvider.MigratedAPIVersions, v1alpha2.MigratedAPIVersion{Kind: gvk.Kind, From: item.GetAPIVersion(), To: to})
+			}
+		}
+		item.SetAPIVersion(to)
+	}
+	if len(obj.Status.AtProvider.MigratedAPIVersions) == 0 {
+		return nil
+	}
+	c.logger.Debug("Migrating apiVersions that are not served by the target cluster", "migrated", obj.Status.AtProvider.MigratedAPIVersions)
+
+	raw, err := json.Marshal(u.Object)
+	if err != nil {
+		return errors.Wrap(err, errMarshalMigrated)
+	}
+	obj.Spec.ForProvider.Manifest.Raw = raw
+	return nil
+}
+
+// servedAPIVersion returns the apiVersion of the supplied kind if it is served
+// by the target cluster, or else the preferred version of its group serving
+// the kind. The apiVersion of the supplied kind is returned if no version of
+// its group serves it, so that it fails to be applied as it would otherwise.
+func servedAPIVersion(dc discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (string, error) {
+	declared := gvk.GroupVersion().String()
+	served, err := servesKind(dc, declared, gvk.Kind)
+	if err != nil || served {
+		return declared, err
+	}
+
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return "", errors.Wrapf(err, errDiscoverServedVersions, gvk.Group)
+	}
+	for _, g := range groups.Groups {
+		if g.Name != gvk.Group {
+			continue
+		}
+		for _, v := range append([]metav1.GroupVersionForDiscovery{g.PreferredVersion}, g.Versions...) {
+			if v.GroupVersion == declared {
+				continue
+			}
+			served, err := servesKind(dc, v.GroupVersion, gvk.Kind)
+			if err != nil {
+				return "", err
+			}
+			if served {
+				return v.GroupVersion, nil
+			}
+		}
+	}
+	return declared, nil
+}
+
+// servesKind returns whether the target cluster serves the supplied kind with
+// the supplied apiVersion.
+func servesKind(dc discovery.DiscoveryInterface, apiVersion, kind string) (bool, error) {
+	l, err := dc.ServerResourcesForGroupVersion(apiVersion)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, errDiscoverServedVersions, apiVersion)
+	}
+	for _, r := range l.APIResources {
+		// Subresources are served with the kind of their parent resource.
+		if r.Kind == kind && !strings.Contains(r.Name, "/") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
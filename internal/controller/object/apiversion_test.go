@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestMigrateAPIVersions(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &ktesting.Fake{Resources: []*metav1.APIResourceList{
+		{GroupVersion: "policy/v1", APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget"}, {Name: "poddisruptionbudgets/status", Kind: "PodDisruptionBudget"}}},
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap"}}},
+	}}}
+	pdb := `{"apiVersion":"policy/v1beta1","kind":"PodDisruptionBudget","metadata":{"name":"pdb"},"spec":{"minAvailable":1}}`
+	migrating := func(manifest string) func(obj *v1alpha2.Object) {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.APIVersionPolicy = v1alpha2.APIVersionPolicyPreferredServed
+			obj.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(manifest)}
+		}
+	}
+
+	type want struct {
+		manifest string
+		migrated []v1alpha2.MigratedAPIVersion
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   want
+	}{
+		"Declared": {
+			reason: "The declared apiVersion should be kept if the Object does not migrate apiVersions.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(pdb)}
+			}),
+			want: want{manifest: pdb},
+		},
+		"Served": {
+			reason: "A served apiVersion should be kept.",
+			obj:    kubernetesObject(migrating(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`)),
+			want:   want{manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`},
+		},
+		"Migrated": {
+			reason: "An apiVersion that is not served anymore should be replaced with the preferred served version of its kind, and reported.",
+			obj:    kubernetesObject(migrating(pdb)),
+			want: want{
+				manifest: `{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"pdb"},"spec":{"minAvailable":1}}`,
+				migrated: []v1alpha2.MigratedAPIVersion{{Kind: "PodDisruptionBudget", From: "policy/v1beta1", To: "policy/v1"}},
+			},
+		},
+		"MigratedListItems": {
+			reason: "Every item of a List whose apiVersion is not served anymore should be migrated, and reported once per kind.",
+			obj:    kubernetesObject(migrating(`{"apiVersion":"v1","kind":"List","items":[` + pdb + `,{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}},` + pdb + `]}`)),
+			want: want{
+				manifest: `{"apiVersion":"v1","items":[{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"pdb"},"spec":{"minAvailable":1}},{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}},{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"pdb"},"spec":{"minAvailable":1}}],"kind":"List"}`,
+				migrated: []v1alpha2.MigratedAPIVersion{{Kind: "PodDisruptionBudget", From: "policy/v1beta1", To: "policy/v1"}},
+			},
+		},
+		"NotServed": {
+			reason: "An apiVersion should be kept if no version of its group serves its kind.",
+			obj:    kubernetesObject(migrating(`{"apiVersion":"example.org/v1","kind":"Widget","metadata":{"name":"w"}}`)),
+			want:   want{manifest: `{"apiVersion":"example.org/v1","kind":"Widget","metadata":{"name":"w"}}`},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), discovery: dc}
+			if err := e.migrateAPIVersions(tc.obj); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.manifest, string(tc.obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("\n%s\ne.migrateAPIVersions(...): -want manifest, +got manifest:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.migrated, tc.obj.Status.AtProvider.MigratedAPIVersions); diff != "" {
+				t.Errorf("\n%s\ne.migrateAPIVersions(...): -want migrated, +got migrated:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	reasonAppliedRemoteObject event.Reason = "AppliedRemoteObject"
+
+	// maxChangedPaths is the maximum number of changed paths recorded for
+	// an apply.
+	maxChangedPaths = 20
+
+	errUnmarshalApplied = "cannot unmarshal applied manifest"
+)
+
+// recordApplies records the successful apply of the remote objects of the
+// supplied Object, as observed before the apply, if they existed, and as now
+// recorded in its status, in its apply history and in an event per remote
+// object, if the Object keeps an apply history.
+func (c *external) recordApplies(ctx context.Context, obj *v1alpha2.Object, before []byte) error {
+	limit := 0
+	if l := obj.Spec.ForProvider.ApplyHistoryLimit; l != nil {
+		limit = int(*l)
+	}
+	if limit == 0 {
+		obj.Status.AtProvider.ApplyHistory = nil
+		return nil
+	}
+
+	after, err := observedManifest(obj)
+	if err != nil {
+		return err
+	}
+	afterItems, err := appliedItems(after)
+	if err != nil {
+		return err
+	}
+	beforeItems, err := appliedItems(before)
+	if err != nil {
+		return err
+	}
+
+	actor := c.actor(ctx)
+	now := metav1.Now()
+	for i, a := range afterItems {
+		var b *unstructured.Unstructured
+		if i < len(beforeItems) {
+			b = beforeItems[i]
+		}
+		r := applyRecord(a, b)
+		r.Time = now
+		r.Actor = actor
+		obj.Status.AtProvider.ApplyHistory = append(obj.Status.AtProvider.ApplyHistory, r)
+		c.recorder.Event(obj, event.Normal(reasonAppliedRemoteObject, applyMessage(r)))
+	}
+	if h := obj.Status.AtProvider.ApplyHistory; len(h) > limit {
+		obj.Status.AtProvider.ApplyHistory = h[len(h)-limit:]
+	}
+	return nil
+}
+
+// actor returns the user the provider authenticates to the target cluster as,
+// as reviewed by the target cluster, or as configured if it cannot be reviewed.
+func (c *external) actor(ctx context.Context) string {
+	review := &authenticationv1.SelfSubjectReview{}
+	if err := c.client.Create(ctx, review); err == nil {
+		return review.Status.UserInfo.Username
+	}
+	if c.rest != nil {
+		return c.rest.Username
+	}
+	return ""
+}
+
+// appliedItems returns the remote objects of the supplied observed manifest,
+// i.e. its items if it is a List, or the remote object itself otherwise.
+func appliedItems(raw []byte) ([]*unstructured.Unstructured, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(raw, &u.Object); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalApplied)
+	}
+	if !u.IsList() {
+		return []*unstructured.Unstructured{u}, nil
+	}
+	items, _ := u.Object["items"].([]any)
+	objs := make([]*unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			objs = append(objs, &unstructured.Unstructured{Object: m})
+		}
+	}
+	return objs, nil
+}
+
+// applyRecord returns the record of an apply that resulted in the supplied
+// remote object, which was the supplied one before the apply, if it existed.
+// The record has no time and no actor.
+func applyRecord(after, before *unstructured.Unstructured) v1alpha2.ApplyRecord {
+	r := v1alpha2.ApplyRecord{
+		Kind:                 after.GetKind(),
+		Namespace:            after.GetNamespace(),
+		Name:                 after.GetName(),
+		ResourceVersionAfter: after.GetResourceVersion(),
+	}
+	if before == nil || before.GetName() != after.GetName() || before.GetKind() != after.GetKind() {
+		return r
+	}
+	r.ResourceVersionBefore = before.GetResourceVersion()
+	r.ChangedPaths = changedPaths(before.Object, after.Object)
+	return r
+}
+
+// applyMessage returns the message of the event of the supplied apply.
+func applyMessage(r v1alpha2.ApplyRecord) string {
+	name := r.Name
+	if r.Namespace != "" {
+		name = r.Namespace + "/" + name
+	}
+	if r.ResourceVersionBefore == "" {
+		return fmt.Sprintf("Created %s %s as %q at resourceVersion %s", r.Kind, name, r.Actor, r.ResourceVersionAfter)
+	}
+	changed := "nothing"
+	if len(r.ChangedPaths) > 0 {
+		changed = strings.Join(r.ChangedPaths, ", ")
+	}
+	return fmt.Sprintf("Applied %s %s as %q from resourceVersion %s to %s, changing %s", r.Kind, name, r.Actor, r.ResourceVersionBefore, r.ResourceVersionAfter, changed)
+}
+
+// changedPaths returns the field paths at which the supplied remote objects
+// differ, up to the maximum number of changed paths. The status of the remote
+// objects and the metadata maintained by the API server are ignored.
+func changedPaths(before, after map[string]any) []string {
+	var paths []string
+	walkDiff("", withoutServerFields(before), withoutServerFields(after), func(path string, _, _ any) bool {
+		paths = append(paths, path)
+		return len(paths) < maxChangedPaths
+	})
+	return paths
+}
+
+// withoutServerFields returns a copy of the supplied remote object without the
+// fields that change with every apply.
+func withoutServerFields(o map[string]any) map[string]any {
+	o = (&unstructured.Unstructured{Object: o}).DeepCopy().Object
+	delete(o, "status")
+	for _, f := range []string{"resourceVersion", "generation", "managedFields"} {
+		unstructured.RemoveNestedField(o, "metadata", f)
+	}
+	return o
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRecordApplies(t *testing.T) {
+	before := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default","resourceVersion":"1","labels":{"app.kubernetes.io/name":"a"}},"data":{"a":"1","b":"2"}}`)
+	after := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default","resourceVersion":"2","labels":{"app.kubernetes.io/name":"b"}},"data":{"a":"1","c":"3"}}`)
+	reviewing := &test.MockClient{
+		MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			obj.(*authenticationv1.SelfSubjectReview).Status.UserInfo.Username = "system:serviceaccount:crossplane-system:provider-kubernetes"
+			return nil
+		},
+	}
+	keeping := func(limit int32) func(obj *v1alpha2.Object) {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.ApplyHistoryLimit = ptr.To(limit)
+			obj.Status.AtProvider.Manifest.Raw = after
+		}
+	}
+
+	type want struct {
+		history []v1alpha2.ApplyRecord
+		events  []string
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		before []byte
+		obj    *v1alpha2.Object
+		want   want
+	}{
+		"NotKeepingHistory": {
+			reason: "Applies should not be recorded if the Object does not keep an apply history.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.ApplyHistory = []v1alpha2.ApplyRecord{{Name: "old"}}
+			}),
+		},
+		"Created": {
+			reason: "The creation of a remote object should be recorded without a resourceVersion before or changed paths.",
+			kube:   reviewing,
+			obj:    kubernetesObject(keeping(5)),
+			want: want{
+				history: []v1alpha2.ApplyRecord{{
+					Actor:                "system:serviceaccount:crossplane-system:provider-kubernetes",
+					Kind:                 "ConfigMap",
+					Namespace:            "default",
+					Name:                 "cm",
+					ResourceVersionAfter: "2",
+				}},
+				events: []string{`Created ConfigMap default/cm as "system:serviceaccount:crossplane-system:provider-kubernetes" at resourceVersion 2`},
+			},
+		},
+		"Updated": {
+			reason: "The update of a remote object should be recorded with its resourceVersions and the paths it changed.",
+			kube:   reviewing,
+			before: before,
+			obj:    kubernetesObject(keeping(5)),
+			want: want{
+				history: []v1alpha2.ApplyRecord{{
+					Actor:                 "system:serviceaccount:crossplane-system:provider-kubernetes",
+					Kind:                  "ConfigMap",
+					Namespace:             "default",
+					Name:                  "cm",
+					ResourceVersionBefore: "1",
+					ResourceVersionAfter:  "2",
+					ChangedPaths:          []string{"data.b", "data.c", "metadata.labels[app.kubernetes.io/name]"},
+				}},
+				events: []string{`Applied ConfigMap default/cm as "system:serviceaccount:crossplane-system:provider-kubernetes" from resourceVersion 1 to 2, changing data.b, data.c, metadata.labels[app.kubernetes.io/name]`},
+			},
+		},
+		"HistoryLimit": {
+			reason: "Only the last applies up to the apply history limit should be kept, and the configured user should be the actor if the target cluster cannot review it.",
+			kube:   &test.MockClient{MockCreate: test.NewMockCreateFn(errors.New("boom"))},
+			obj: kubernetesObject(keeping(1), func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.ApplyHistory = []v1alpha2.ApplyRecord{{Name: "old"}}
+			}),
+			want: want{
+				history: []v1alpha2.ApplyRecord{{
+					Actor:                "admin",
+					Kind:                 "ConfigMap",
+					Namespace:            "default",
+					Name:                 "cm",
+					ResourceVersionAfter: "2",
+				}},
+				events: []string{`Created ConfigMap default/cm as "admin" at resourceVersion 2`},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			events := &recordedEvents{}
+			e := &external{
+				client:   resource.ClientApplicator{Client: tc.kube},
+				rest:     &rest.Config{Username: "admin"},
+				recorder: events,
+			}
+			if err := e.recordApplies(context.Background(), tc.obj, tc.before); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.history, tc.obj.Status.AtProvider.ApplyHistory, cmpopts.IgnoreFields(v1alpha2.ApplyRecord{}, "Time")); diff != "" {
+				t.Errorf("\n%s\ne.recordApplies(...): -want history, +got history:\n%s", tc.reason, diff)
+			}
+			var got []string
+			for _, ev := range *events {
+				if ev.Type != event.TypeNormal || ev.Reason != reasonAppliedRemoteObject {
+					t.Errorf("\n%s\ne.recordApplies(...): unexpected event %v", tc.reason, ev)
+				}
+				got = append(got, ev.Message)
+			}
+			if diff := cmp.Diff(tc.want.events, got); diff != "" {
+				t.Errorf("\n%s\ne.recordApplies(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestChangedPaths(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		before map[string]any
+		after  map[string]any
+		want   []string
+	}{
+		"ServerFieldsIgnored": {
+			reason: "The status and the metadata maintained by the API server should not be reported as changed.",
+			before: map[string]any{"metadata": map[string]any{"resourceVersion": "1", "generation": int64(1)}, "status": map[string]any{"ready": false}},
+			after:  map[string]any{"metadata": map[string]any{"resourceVersion": "2", "generation": int64(2)}, "status": map[string]any{"ready": true}},
+		},
+		"Lists": {
+			reason: "Changed items of lists of the same length should be reported, and lists of different lengths as a whole.",
+			before: map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(80)}}, "args": []any{"a"}}},
+			after:  map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(8080)}}, "args": []any{"a", "b"}}},
+			want:   []string{"spec.args", "spec.ports[0].port"},
+		},
+		"Limited": {
+			reason: "No more than the maximum number of changed paths should be reported.",
+			before: map[string]any{},
+			after: func() map[string]any {
+				m := map[string]any{}
+				for i := 0; i < maxChangedPaths+5; i++ {
+					m[string(rune('a'+i))] = int64(i)
+				}
+				return m
+			}(),
+			want: func() []string {
+				p := make([]string, 0, maxChangedPaths)
+				for i := 0; i < maxChangedPaths; i++ {
+					p = append(p, string(rune('a'+i)))
+				}
+				return p
+			}(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := changedPaths(tc.before, tc.after)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nchangedPaths(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	labelKeyObjectUID        = "kubernetes.crossplane.io/object-uid"
+	labelKeyProviderIdentity = "kubernetes.crossplane.io/provider-identity"
+
+	annotationKeyObjectName     = "kubernetes.crossplane.io/object-name"
+	annotationKeyProviderConfig = "kubernetes.crossplane.io/provider-config"
+)
+
+// setAttribution stamps the manifest of the supplied Object, or every item of
+// its List manifest, with the labels and annotations identifying the Object,
+// if the provider config asks for it. Objects that only observe their remote
+// objects leave them untouched.
+func (c *external) setAttribution(obj *v1alpha2.Object) error {
+	a := c.attribution
+	if a == nil || observeOnly(obj) {
+		return nil
+	}
+
+	labels := make(map[string]string, len(a.Labels)+2)
+	for k, v := range a.Labels {
+		labels[k] = v
+	}
+	if uid := obj.GetUID(); uid != "" {
+		labels[labelKeyObjectUID] = string(uid)
+	}
+	if a.Identity != "" {
+		labels[labelKeyProviderIdentity] = a.Identity
+	}
+
+	annotations := make(map[string]string, len(a.Annotations)+2)
+	for k, v := range a.Annotations {
+		annotations[k] = v
+	}
+	annotations[annotationKeyObjectName] = obj.GetName()
+	annotations[annotationKeyProviderConfig] = obj.GetProviderConfigReference().Name
+
+	return updateManifest(obj, func(o *unstructured.Unstructured) error {
+		meta.AddLabels(o, labels)
+		meta.AddAnnotations(o, annotations)
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestSetAttribution(t *testing.T) {
+	type want struct {
+		labels      map[string]string
+		annotations map[string]string
+		err         error
+	}
+	cases := map[string]struct {
+		attribution *kconfig.Attribution
+		obj         *v1alpha2.Object
+		want
+	}{
+		"NotConfigured": {
+			obj:  kubernetesObject(),
+			want: want{},
+		},
+		"ObserveOnly": {
+			attribution: &kconfig.Attribution{},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+			}),
+			want: want{},
+		},
+		"Success": {
+			attribution: &kconfig.Attribution{
+				Identity:    "control-plane",
+				Labels:      map[string]string{"team": "platform"},
+				Annotations: map[string]string{"contact": "platform@example.org"},
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetUID("some-uid")
+			}),
+			want: want{
+				labels: map[string]string{
+					"team":                   "platform",
+					labelKeyObjectUID:        "some-uid",
+					labelKeyProviderIdentity: "control-plane",
+				},
+				annotations: map[string]string{
+					"contact":                   "platform@example.org",
+					annotationKeyObjectName:     testObjectName,
+					annotationKeyProviderConfig: providerName,
+				},
+			},
+		},
+		"SuccessForListItems": {
+			attribution: &kconfig.Attribution{},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one","labels":{"other":"label"}}}]}`)
+				obj.SetUID("some-uid")
+			}),
+			want: want{
+				labels: map[string]string{
+					"other":           "label",
+					labelKeyObjectUID: "some-uid",
+				},
+				annotations: map[string]string{
+					annotationKeyObjectName:     testObjectName,
+					annotationKeyProviderConfig: providerName,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{attribution: tc.attribution}
+			gotErr := e.setAttribution(tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.setAttribution(...): -want error, +got error: %s", diff)
+			}
+			m, err := parseManifest(tc.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.IsList() {
+				_, items, err := children(tc.obj, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				m = items[0]
+			}
+			if diff := cmp.Diff(tc.want.labels, m.GetLabels()); diff != "" {
+				t.Errorf("e.setAttribution(...): -want labels, +got labels: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, m.GetAnnotations()); diff != "" {
+				t.Errorf("e.setAttribution(...): -want annotations, +got annotations: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// A backfillReconciler spreads the first reconciles of the Objects that exist
+// when the provider starts over the backfill window, so that a restart does
+// not reconcile every Object against its target cluster at once. Every Object
+// is deferred to a stable offset into the window derived from its name, so
+// that the Objects are reconciled at an even rate. Objects created after the
+// provider started are reconciled right away.
+type backfillReconciler struct {
+	inner  reconcile.Reconciler
+	kube   client.Reader
+	window time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	// started is when the first reconcile was requested, i.e. once the
+	// caches of the provider were synced.
+	started time.Time
+	// reconciled are the Objects reconciled since the provider started.
+	reconciled map[types.NamespacedName]bool
+}
+
+func newBackfillReconciler(inner reconcile.Reconciler, kube client.Reader, window time.Duration) *backfillReconciler {
+	return &backfillReconciler{
+		inner:      inner,
+		kube:       kube,
+		window:     window,
+		now:        time.Now,
+		reconciled: make(map[types.NamespacedName]bool),
+	}
+}
+
+// Reconcile the supplied request with the inner reconciler, unless its Object
+// is yet to be backfilled.
+func (r *backfillReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if r.window <= 0 {
+		return r.inner.Reconcile(ctx, req)
+	}
+	if d := r.deferral(ctx, req); d > 0 {
+		return reconcile.Result{RequeueAfter: d}, nil
+	}
+	return r.inner.Reconcile(ctx, req)
+}
+
+// deferral returns how long the reconcile of the supplied request is to be
+// deferred, if at all. Any error getting the Object is left to the inner
+// reconciler to deal with.
+func (r *backfillReconciler) deferral(ctx context.Context, req reconcile.Request) time.Duration {
+	now := r.now()
+	r.mu.Lock()
+	if r.started.IsZero() {
+		r.started = now
+	}
+	started := r.started
+	if !now.Before(started.Add(r.window)) {
+		// The backfill is over.
+		r.reconciled = nil
+		r.mu.Unlock()
+		return 0
+	}
+	done := r.reconciled[req.NamespacedName]
+	r.mu.Unlock()
+	if done {
+		return 0
+	}
+
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, req.NamespacedName, obj); err != nil || obj.GetCreationTimestamp().After(started) {
+		return 0
+	}
+	if due := started.Add(backfillOffset(req.NamespacedName, r.window)); now.Before(due) {
+		return due.Sub(now)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reconciled != nil {
+		r.reconciled[req.NamespacedName] = true
+	}
+	return 0
+}
+
+// backfillOffset returns the stable offset into the supplied backfill window
+// of the Object with the supplied name.
+func backfillOffset(name types.NamespacedName, window time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name.String()))
+	return time.Duration(h.Sum64() % uint64(window))
+}
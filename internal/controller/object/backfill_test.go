@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestBackfillReconciler(t *testing.T) {
+	started := time.Now()
+	window := time.Hour
+	// Objects prefixed with new were created after the provider started.
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			created := started.Add(-time.Hour)
+			if strings.HasPrefix(key.Name, "new") {
+				created = started.Add(time.Minute)
+			}
+			obj.(*v1alpha2.Object).SetCreationTimestamp(metav1.NewTime(created))
+			return nil
+		},
+	}
+	existing := types.NamespacedName{Name: "existing"}
+	due := started.Add(backfillOffset(existing, window))
+
+	var reconciled []string
+	r := newBackfillReconciler(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+		reconciled = append(reconciled, req.Name)
+		return reconcile.Result{}, nil
+	}), kube, window)
+
+	steps := []struct {
+		reason   string
+		now      time.Time
+		name     string
+		deferred time.Duration
+	}{
+		{
+			reason:   "Objects that existed when the provider started should be deferred to their offset into the window.",
+			now:      started,
+			name:     existing.Name,
+			deferred: due.Sub(started),
+		},
+		{
+			reason: "Objects created after the provider started should be reconciled right away.",
+			now:    started.Add(time.Second),
+			name:   "new",
+		},
+		{
+			reason: "Objects should be reconciled once their offset into the window is due.",
+			now:    due,
+			name:   existing.Name,
+		},
+		{
+			reason: "Objects that were backfilled already should be reconciled right away.",
+			now:    due,
+			name:   existing.Name,
+		},
+		{
+			reason: "Objects should be reconciled right away once the window is over.",
+			now:    started.Add(window),
+			name:   "other",
+		},
+	}
+	for i, s := range steps {
+		reconciled = nil
+		r.now = func() time.Time { return s.now }
+		got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: s.name}})
+		if err != nil {
+			t.Fatalf("step %d: r.Reconcile(%s): %s", i, s.name, err)
+		}
+		if got.RequeueAfter != s.deferred {
+			t.Errorf("\n%s\nstep %d: r.Reconcile(%s): want requeue after %s, got %s", s.reason, i, s.name, s.deferred, got.RequeueAfter)
+		}
+		if want := s.deferred == 0; want != (len(reconciled) == 1) {
+			t.Errorf("\n%s\nstep %d: r.Reconcile(%s): want reconciled %t, got %v", s.reason, i, s.name, want, reconciled)
+		}
+	}
+}
+
+func TestBackfillOffset(t *testing.T) {
+	window := time.Hour
+	name := types.NamespacedName{Name: "cool-object"}
+	got := backfillOffset(name, window)
+	if got < 0 || got >= window {
+		t.Errorf("backfillOffset(...): want an offset into the window, got %s", got)
+	}
+	if again := backfillOffset(name, window); again != got {
+		t.Errorf("backfillOffset(...): want a stable offset %s, got %s", got, again)
+	}
+}
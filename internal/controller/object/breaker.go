@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	// typeTargetUnreachable is the condition reporting whether the target
+	// cluster of an Object is unreachable.
+	typeTargetUnreachable xpv1.ConditionType = "TargetUnreachable"
+
+	reasonTargetUnreachable xpv1.ConditionReason = "TargetUnreachable"
+	reasonTargetReachable   xpv1.ConditionReason = "TargetReachable"
+
+	errTargetUnreachable        = "target cluster is unreachable, waiting for it to recover"
+	errUpdateProviderConfigCond = "cannot update the TargetUnreachable condition of the provider config"
+
+	// breakerThreshold is the number of consecutive failures to reach a
+	// target cluster after which the circuit breaker of its provider config
+	// trips.
+	breakerThreshold = 3
+	// breakerProbeInterval is the interval at which a target cluster is
+	// probed while the circuit breaker of its provider config is tripped.
+	breakerProbeInterval = 15 * time.Second
+	// breakerProbeTimeout is the timeout of a single probe.
+	breakerProbeTimeout = 5 * time.Second
+)
+
+// A circuitBreaker fails the reconciles of the Objects of a provider config
+// fast once its target cluster was found to be unreachable repeatedly, instead
+// of letting every Object time out on its own. While tripped, it probes the
+// target cluster in the background until it is reachable again.
+type circuitBreaker struct {
+	log logging.Logger
+	// kube reports the reachability of target clusters on their provider
+	// configs, if set.
+	kube          client.Client
+	threshold     int
+	probeInterval time.Duration
+	probe         func(ctx context.Context, rc *rest.Config) error
+
+	mu       sync.Mutex
+	clusters map[string]*clusterReachability
+}
+
+type clusterReachability struct {
+	failures int
+	tripped  bool
+	lastErr  error
+}
+
+func newCircuitBreaker(log logging.Logger, kube client.Client) *circuitBreaker {
+	return &circuitBreaker{
+		log:           log,
+		kube:          kube,
+		threshold:     breakerThreshold,
+		probeInterval: breakerProbeInterval,
+		probe:         probeServerVersion,
+		clusters:      make(map[string]*clusterReachability),
+	}
+}
+
+// Allow returns an error if the circuit breaker of the supplied provider
+// config is tripped.
+func (b *circuitBreaker) Allow(providerConfig string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r, ok := b.clusters[providerConfig]; ok && r.tripped {
+		return errors.Wrap(r.lastErr, errTargetUnreachable)
+	}
+	return nil
+}
+
+// Record records the outcome of a request to the target cluster of the
+// supplied provider config, tripping its circuit breaker if the target cluster
+// was unreachable too many times in a row.
+func (b *circuitBreaker) Record(providerConfig string, rc *rest.Config, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isUnreachable(err) {
+		if r, ok := b.clusters[providerConfig]; ok && !r.tripped {
+			delete(b.clusters, providerConfig)
+		}
+		return
+	}
+	r, ok := b.clusters[providerConfig]
+	if !ok {
+		r = &clusterReachability{}
+		b.clusters[providerConfig] = r
+	}
+	r.failures++
+	r.lastErr = err
+	if r.tripped || r.failures < b.threshold {
+		return
+	}
+	r.tripped = true
+	b.log.Info("Target cluster is unreachable, failing reconciles fast until it recovers", "providerConfig", providerConfig, "error", err)
+	go b.probeUntilReachable(providerConfig, rc)
+}
+
+// Reset forgets the reachability of the target cluster of the supplied
+// provider config, e.g. because the provider config now points to another
+// cluster.
+func (b *circuitBreaker) Reset(providerConfig string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	r, ok := b.clusters[providerConfig]
+	delete(b.clusters, providerConfig)
+	b.mu.Unlock()
+	if ok && r.tripped {
+		go b.setProviderConfigReachability(providerConfig, nil)
+	}
+}
+
+// probeUntilReachable probes the target cluster of the supplied provider
+// config until it is reachable, and then resets its circuit breaker. The
+// provider config reports its target cluster unreachable in the meantime.
+func (b *circuitBreaker) probeUntilReachable(providerConfig string, rc *rest.Config) {
+	b.mu.Lock()
+	r, ok := b.clusters[providerConfig]
+	b.mu.Unlock()
+	if !ok {
+		// The circuit breaker was reset in the meantime.
+		return
+	}
+	b.setProviderConfigReachability(providerConfig, r.lastErr)
+
+	t := time.NewTicker(b.probeInterval)
+	defer t.Stop()
+	for range t.C {
+		if b.wasReset(providerConfig, r) {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), breakerProbeTimeout)
+		err := b.probe(ctx, rc)
+		cancel()
+		if err != nil {
+			b.log.Debug("Target cluster is still unreachable", "providerConfig", providerConfig, "error", err)
+			continue
+		}
+		b.mu.Lock()
+		if b.clusters[providerConfig] != r {
+			b.mu.Unlock()
+			return
+		}
+		delete(b.clusters, providerConfig)
+		b.mu.Unlock()
+		b.log.Info("Target cluster is reachable again", "providerConfig", providerConfig)
+		b.setProviderConfigReachability(providerConfig, nil)
+		return
+	}
+}
+
+// wasReset returns true if the circuit breaker of the supplied provider config
+// was reset since it tripped with the supplied reachability.
+func (b *circuitBreaker) wasReset(providerConfig string, r *clusterReachability) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.clusters[providerConfig] != r
+}
+
+// setProviderConfigReachability reports whether the target cluster of the
+// supplied provider config is unreachable on the provider config.
+func (b *circuitBreaker) setProviderConfigReachability(providerConfig string, err error) {
+	if b.kube == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), breakerProbeTimeout)
+	defer cancel()
+	uerr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pc := &apisv1alpha1.ProviderConfig{}
+		if err := b.kube.Get(ctx, types.NamespacedName{Name: providerConfig}, pc); err != nil {
+			return err
+		}
+		pc.SetConditions(targetReachabilityCondition(err))
+		return b.kube.Status().Update(ctx, pc)
+	})
+	if uerr != nil {
+		b.log.Info(errUpdateProviderConfigCond, "providerConfig", providerConfig, "error", uerr)
+	}
+}
+
+// probeServerVersion probes the target cluster by getting its version.
+func probeServerVersion(ctx context.Context, rc *rest.Config) error {
+	rc = rest.CopyConfig(rc)
+	if d, ok := ctx.Deadline(); ok {
+		rc.Timeout = time.Until(d)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return errors.Wrap(err, errCreateDiscoveryClient)
+	}
+	_, err = dc.ServerVersion()
+	return err
+}
+
+// isUnreachable returns true if the supplied error means the target cluster
+// could not be reached, rather than that it rejected a request.
+func isUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var ne net.Error
+	return errors.As(err, &ne) || kerrors.IsServiceUnavailable(err)
+}
+
+// setTargetReachability reports whether the target cluster of the supplied
+// Object is unreachable. Reachability is only reported if the target cluster
+// was previously reported to be unreachable.
+func setTargetReachability(obj *v1alpha2.Object, err error) {
+	if err != nil || obj.GetCondition(typeTargetUnreachable).Status == v1.ConditionTrue {
+		obj.SetConditions(targetReachabilityCondition(err))
+	}
+}
+
+// targetReachabilityCondition returns the TargetUnreachable condition for the
+// supplied error reaching a target cluster, if any.
+func targetReachabilityCondition(err error) xpv1.Condition {
+	if err != nil {
+		return xpv1.Condition{
+			Type:               typeTargetUnreachable,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonTargetUnreachable,
+			Message:            err.Error(),
+		}
+	}
+	return xpv1.Condition{
+		Type:               typeTargetUnreachable,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonTargetReachable,
+	}
+}
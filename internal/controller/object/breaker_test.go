@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errUnreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	probed := make(chan struct{})
+
+	b := newCircuitBreaker(logging.NewNopLogger(), nil)
+	b.probeInterval = time.Millisecond
+
+	for i := 0; i < breakerThreshold-1; i++ {
+		b.Record(providerName, &rest.Config{}, errUnreachable)
+	}
+	if err := b.Allow(providerName); err != nil {
+		t.Fatalf("b.Allow(...): breaker should not trip before reaching the threshold: %s", err)
+	}
+	b.Record(providerName, &rest.Config{}, errBoom)
+	b.Record(providerName, &rest.Config{}, errUnreachable)
+	if err := b.Allow(providerName); err != nil {
+		t.Fatalf("b.Allow(...): breaker should not trip after non-consecutive failures: %s", err)
+	}
+
+	// The target cluster is reachable again once probed is closed.
+	b.probe = func(_ context.Context, _ *rest.Config) error {
+		<-probed
+		return nil
+	}
+	for i := 0; i < breakerThreshold; i++ {
+		b.Record(providerName, &rest.Config{}, errUnreachable)
+	}
+	if diff := cmp.Diff(errors.Wrap(errUnreachable, errTargetUnreachable), b.Allow(providerName), test.EquateErrors()); diff != "" {
+		t.Fatalf("b.Allow(...): -want error, +got error: %s", diff)
+	}
+	if err := b.Allow("other"); err != nil {
+		t.Fatalf("b.Allow(...): breaker of other provider configs should not trip: %s", err)
+	}
+
+	close(probed)
+	deadline := time.Now().Add(5 * time.Second)
+	for b.Allow(providerName) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("b.Allow(...): breaker should be reset once the target cluster is reachable")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"NoError": {},
+		"OtherError": {
+			err: errBoom,
+		},
+		"NetworkError": {
+			err:  errors.Wrap(&net.OpError{Op: "dial", Err: errBoom}, errGetObject),
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isUnreachable(tc.err); got != tc.want {
+				t.Errorf("isUnreachable(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetTargetReachability(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		err  error
+		want corev1.ConditionStatus
+	}{
+		"Reachable": {
+			obj:  kubernetesObject(),
+			want: corev1.ConditionUnknown,
+		},
+		"Unreachable": {
+			obj:  kubernetesObject(),
+			err:  errBoom,
+			want: corev1.ConditionTrue,
+		},
+		"ReachableAgain": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetConditions(xpv1.Condition{Type: typeTargetUnreachable, Status: corev1.ConditionTrue})
+			}),
+			want: corev1.ConditionFalse,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setTargetReachability(tc.obj, tc.err)
+			if diff := cmp.Diff(tc.want, tc.obj.GetCondition(typeTargetUnreachable).Status); diff != "" {
+				t.Errorf("setTargetReachability(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerProviderConfigReachability(t *testing.T) {
+	errUnreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	reported := make(chan corev1.ConditionStatus, 2)
+
+	b := newCircuitBreaker(logging.NewNopLogger(), &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+		MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+			reported <- obj.(*apisv1alpha1.ProviderConfig).GetCondition(typeTargetUnreachable).Status
+			return nil
+		},
+	})
+	b.probeInterval = time.Millisecond
+	b.probe = func(_ context.Context, _ *rest.Config) error {
+		return nil
+	}
+
+	for i := 0; i < breakerThreshold; i++ {
+		b.Record(providerName, &rest.Config{}, errUnreachable)
+	}
+	for _, want := range []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse} {
+		select {
+		case got := <-reported:
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("b.Record(...): -want provider config condition, +got: %s", diff)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("b.Record(...): provider config condition %s was not reported", want)
+		}
+	}
+}
+
+func TestConnectObserveCircuitBreaker(t *testing.T) {
+	errUnreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	probed := make(chan struct{})
+	defer close(probed)
+
+	b := newCircuitBreaker(logging.NewNopLogger(), nil)
+	b.probeInterval = time.Millisecond
+	b.probe = func(_ context.Context, _ *rest.Config) error {
+		<-probed
+		return nil
+	}
+
+	built := 0
+	c := &connector{
+		logger: logging.NewNopLogger(),
+		kube: &test.MockClient{
+			MockGet: test.NewMockGetFn(nil),
+		},
+		clientBuilder: kubeclient.BuilderFn(func(_ context.Context, _ kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+			built++
+			return &test.MockClient{MockGet: test.NewMockGetFn(errUnreachable)}, &rest.Config{}, nil
+		}),
+		usage:   resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+		breaker: b,
+	}
+
+	for i := 0; i < breakerThreshold; i++ {
+		obj := kubernetesObject()
+		e, err := c.Connect(context.Background(), obj)
+		if err != nil {
+			t.Fatalf("Connect(...): breaker should not trip before reaching the threshold: %s", err)
+		}
+		if _, err := e.Observe(context.Background(), obj); !isUnreachable(err) {
+			t.Fatalf("Observe(...): want unreachable error, got: %v", err)
+		}
+		if diff := cmp.Diff(corev1.ConditionTrue, obj.GetCondition(typeTargetUnreachable).Status); diff != "" {
+			t.Errorf("Observe(...): -want TargetUnreachable status, +got: %s", diff)
+		}
+	}
+
+	obj := kubernetesObject()
+	_, err := c.Connect(context.Background(), obj)
+	if diff := cmp.Diff(errors.Wrap(errors.Wrap(errUnreachable, errGetObject), errTargetUnreachable), err, test.EquateErrors()); diff != "" {
+		t.Errorf("Connect(...): -want error, +got error: %s", diff)
+	}
+	if diff := cmp.Diff(breakerThreshold, built); diff != "" {
+		t.Errorf("Connect(...): a tripped breaker should not build clients: -want, +got: %s", diff)
+	}
+	if diff := cmp.Diff(corev1.ConditionTrue, obj.GetCondition(typeTargetUnreachable).Status); diff != "" {
+		t.Errorf("Connect(...): -want TargetUnreachable status, +got: %s", diff)
+	}
+}
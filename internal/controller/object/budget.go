@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	// budgetRequeueAfter is the delay after which an Object over the
+	// concurrency budget of its provider config is reconciled again.
+	budgetRequeueAfter = time.Second
+)
+
+// A budgetedReconciler limits the number of concurrent reconciles of the
+// Objects of every provider config to its concurrency budget. Objects over the
+// budget are requeued rather than waited for, so that they do not hold
+// reconcile workers that Objects of other provider configs could use.
+type budgetedReconciler struct {
+	inner reconcile.Reconciler
+	kube  client.Reader
+
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+func newBudgetedReconciler(inner reconcile.Reconciler, kube client.Reader) *budgetedReconciler {
+	return &budgetedReconciler{
+		inner:    inner,
+		kube:     kube,
+		inFlight: make(map[string]int),
+	}
+}
+
+// Reconcile the supplied request with the inner reconciler, if the provider
+// config of its Object has budget left.
+func (r *budgetedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	pc, limit := r.budget(ctx, req)
+	if limit == 0 {
+		return r.inner.Reconcile(ctx, req)
+	}
+	if !r.acquire(pc, limit) {
+		return reconcile.Result{RequeueAfter: budgetRequeueAfter + time.Duration(rand.Int63n(int64(budgetRequeueAfter)))}, nil //nolint:gosec // No need for secure randomness
+	}
+	defer r.release(pc)
+	return r.inner.Reconcile(ctx, req)
+}
+
+// budget returns the name and concurrency budget of the provider config of the
+// Object of the supplied request, or a budget of zero if it has no budget, e.g.
+// because it uses an inline kubeconfig rather than a provider config. Any
+// error getting the Object or its provider config is left to the inner
+// reconciler to deal with.
+func (r *budgetedReconciler) budget(ctx context.Context, req reconcile.Request) (string, int) {
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, req.NamespacedName, obj); err != nil {
+		return "", 0
+	}
+	ref := obj.GetProviderConfigReference()
+	if ref == nil || usesInlineKubeconfig(obj) {
+		return "", 0
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return "", 0
+	}
+	return ref.Name, pc.Spec.MaxConcurrentReconciles
+}
+
+func (r *budgetedReconciler) acquire(pc string, limit int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.inFlight[pc] >= limit {
+		return false
+	}
+	r.inFlight[pc]++
+	return true
+}
+
+func (r *budgetedReconciler) release(pc string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.inFlight[pc]--; r.inFlight[pc] <= 0 {
+		delete(r.inFlight, pc)
+	}
+}
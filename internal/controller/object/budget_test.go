@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestBudgetedReconciler(t *testing.T) {
+	// Objects are named after their provider config, which has a budget of
+	// one unless it is named unlimited. The blocking Object uses the limited
+	// provider config.
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha2.Object:
+				pc := key.Name
+				if pc == "blocking" {
+					pc = "limited"
+				}
+				o.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc}
+			case *apisv1alpha1.ProviderConfig:
+				if key.Name != "unlimited" {
+					o.Spec.MaxConcurrentReconciles = 1
+				}
+			}
+			return nil
+		},
+	}
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	reconciled := 0
+	r := newBudgetedReconciler(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if req.Name == "blocking" {
+			close(entered)
+			<-unblock
+			return reconcile.Result{}, nil
+		}
+		reconciled++
+		return reconcile.Result{}, nil
+	}), kube)
+
+	req := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = r.Reconcile(context.Background(), req("blocking"))
+	}()
+	<-entered
+
+	got, err := r.Reconcile(context.Background(), req("limited"))
+	if err != nil {
+		t.Fatalf("r.Reconcile(...): %s", err)
+	}
+	if got.RequeueAfter == 0 || reconciled != 0 {
+		t.Errorf("r.Reconcile(...): Objects over the budget should be requeued without being reconciled")
+	}
+
+	if _, err := r.Reconcile(context.Background(), req("unlimited")); err != nil {
+		t.Fatalf("r.Reconcile(...): %s", err)
+	}
+	if reconciled != 1 {
+		t.Errorf("r.Reconcile(...): Objects of other provider configs should be reconciled")
+	}
+
+	close(unblock)
+	<-done
+	if _, err := r.Reconcile(context.Background(), req("limited")); err != nil {
+		t.Fatalf("r.Reconcile(...): %s", err)
+	}
+	if reconciled != 2 {
+		t.Errorf("r.Reconcile(...): Objects should be reconciled once the budget is released")
+	}
+}
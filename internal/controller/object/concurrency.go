@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errConflictRetriesExhausted = "cannot patch the remote object, which kept changing since it was read after %d retries"
+	errRereadObject             = "cannot read the remote object again after a conflicting patch"
+)
+
+// recordObservedVersion records the resourceVersion the supplied remote object
+// was observed with, so that it can be patched optimistically.
+func (c *external) recordObservedVersion(current *unstructured.Unstructured) {
+	if c.observedVersions == nil {
+		c.observedVersions = make(map[string]string)
+	}
+	c.observedVersions[versionKey(current)] = current.GetResourceVersion()
+}
+
+// versionKey identifies the supplied remote object among the observed ones.
+func versionKey(u *unstructured.Unstructured) string {
+	return u.GroupVersionKind().GroupKind().String() + "/" + u.GetNamespace() + "/" + u.GetName()
+}
+
+// pinned returns true if the patches of the remote objects of the supplied
+// Object are pinned to the resourceVersion they were observed with.
+func (c *external) pinned(obj *v1alpha2.Object) bool {
+	if obj.Spec.ForProvider.OptimisticConcurrency == nil || obj.Spec.ForProvider.UpdateStrategy == v1alpha2.UpdateStrategyReplace {
+		return false
+	}
+	_, ssa := c.syncer.(*SSAResourceSyncer)
+	return !ssa
+}
+
+// updateResource syncs the supplied desired state of a remote object of the
+// supplied Object to update it. If the Object uses optimistic concurrency, the
+// patch is pinned to the resourceVersion the remote object was observed with,
+// and retried with a fresh read of it as long as it conflicts with a change
+// made in the meantime, up to the retries of the Object.
+func (c *external) updateResource(ctx context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	rv, ok := c.observedVersions[versionKey(desired)]
+	if !c.pinned(obj) || !ok {
+		return c.syncer.SyncResource(ctx, obj, desired)
+	}
+
+	retries := obj.Spec.ForProvider.OptimisticConcurrency.Retries
+	for attempt := int32(0); ; attempt++ {
+		pinned := desired.DeepCopy()
+		pinned.SetResourceVersion(rv)
+		current, err := c.syncer.SyncResource(ctx, obj, pinned)
+		if !kerrors.IsConflict(err) {
+			return current, err
+		}
+		if attempt == retries {
+			return nil, errors.Wrapf(err, errConflictRetriesExhausted, retries)
+		}
+		c.logger.Debug("Retrying conflicting patch with a fresh read of the remote object", "attempt", attempt+1, "resourceVersion", rv)
+
+		fresh := &unstructured.Unstructured{}
+		fresh.SetGroupVersionKind(desired.GroupVersionKind())
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, fresh); err != nil {
+			return nil, errors.Wrap(err, errRereadObject)
+		}
+		rv = fresh.GetResourceVersion()
+	}
+}
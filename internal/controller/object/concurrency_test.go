@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestUpdateResource(t *testing.T) {
+	conflict := kerrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", errors.New("changed"))
+
+	type want struct {
+		patchVersions []string
+		err           error
+	}
+	cases := map[string]struct {
+		reason string
+		// churn is how many times the remote object changes after it is
+		// read.
+		churn       int
+		concurrency *v1alpha2.OptimisticConcurrency
+		strategy    v1alpha2.UpdateStrategy
+		want        want
+	}{
+		"NotPinned": {
+			reason: "Patches should not be pinned unless the Object uses optimistic concurrency.",
+			churn:  1,
+			want:   want{patchVersions: []string{""}},
+		},
+		"Replace": {
+			reason:      "Patches should not be pinned with the Replace update strategy.",
+			churn:       1,
+			concurrency: &v1alpha2.OptimisticConcurrency{Retries: 3},
+			strategy:    v1alpha2.UpdateStrategyReplace,
+			want:        want{},
+		},
+		"Unchanged": {
+			reason:      "Patches should be pinned to the observed resourceVersion.",
+			concurrency: &v1alpha2.OptimisticConcurrency{Retries: 3},
+			want:        want{patchVersions: []string{"1"}},
+		},
+		"Retried": {
+			reason:      "Conflicting patches should be retried with a fresh read of the remote object.",
+			churn:       1,
+			concurrency: &v1alpha2.OptimisticConcurrency{Retries: 3},
+			want:        want{patchVersions: []string{"1", "2"}},
+		},
+		"RetriesExhausted": {
+			reason:      "Patches should fail once the remote object kept changing for all retries.",
+			churn:       10,
+			concurrency: &v1alpha2.OptimisticConcurrency{Retries: 2},
+			want: want{
+				patchVersions: []string{"1", "3", "5"},
+				err:           errors.Wrapf(errors.Wrap(CleanErr(errors.Wrap(conflict, "cannot patch object")), errApplyObject), errConflictRetriesExhausted, 2),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The remote object was observed with resourceVersion 1, and
+			// changes on every read until it stops churning, including
+			// those of the applicator.
+			version, churn := 1, tc.churn
+			var patchVersions []string
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					if churn > 0 {
+						version++
+						churn--
+					}
+					obj.SetResourceVersion(strconv.Itoa(version))
+					return nil
+				},
+				MockPatch: func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+					data, _ := p.Data(obj)
+					patched := &unstructured.Unstructured{}
+					_ = json.Unmarshal(data, &patched.Object)
+					patchVersions = append(patchVersions, patched.GetResourceVersion())
+					if rv := patched.GetResourceVersion(); rv != "" && rv != strconv.Itoa(version) {
+						return conflict
+					}
+					return nil
+				},
+				MockUpdate: test.NewMockUpdateFn(nil),
+			}
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.OptimisticConcurrency = tc.concurrency
+				obj.Spec.ForProvider.UpdateStrategy = tc.strategy
+			})
+			desired, err := parseManifest(obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			observed := desired.DeepCopy()
+			observed.SetResourceVersion("1")
+
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{Client: kube, Applicator: resource.NewAPIPatchingApplicator(kube)},
+				syncer: &PatchingResourceSyncer{client: resource.ClientApplicator{Client: kube, Applicator: applicatorFor(obj, kube)}},
+			}
+			e.recordObservedVersion(observed)
+
+			_, err = e.updateResource(context.Background(), obj, desired)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.updateResource(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patchVersions, patchVersions); diff != "" {
+				t.Errorf("\n%s\ne.updateResource(...): -want patched resourceVersions, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
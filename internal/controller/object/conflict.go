@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errFieldConflicts       = "conflicts with other field managers: %s; set spec.forProvider.conflictPolicy to Force to take ownership of these fields, or remove them from the manifest to leave them to their current owners"
+	errGetConflictingObject = "cannot get Object %s managing conflicting fields"
+)
+
+// A conflictCause is a field that server-side apply found to be managed by
+// another field manager.
+type conflictCause struct {
+	field string
+	// owner describes the other field manager, e.g. "kubectl" using apps/v1.
+	owner string
+	// manager is the name of the other field manager, e.g. kubectl.
+	manager string
+}
+
+// conflictManager matches the name of the field manager in the description of
+// the owner of a conflicting field.
+var conflictManager = regexp.MustCompile(`^"([^"]*)"`)
+
+// conflictCauses returns the fields that conflict with other field managers,
+// if the supplied error is a server-side apply conflict.
+func conflictCauses(err error) []conflictCause {
+	if !kerrors.IsConflict(err) {
+		return nil
+	}
+	var status kerrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+	causes := make([]conflictCause, 0, len(status.Status().Details.Causes))
+	for _, c := range status.Status().Details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		// The message of a cause names the owner, e.g. conflict with
+		// "kubectl" using apps/v1.
+		cause := conflictCause{field: c.Field, owner: strings.TrimPrefix(c.Message, "conflict with ")}
+		cause.manager = cause.owner
+		if m := conflictManager.FindStringSubmatch(cause.owner); m != nil {
+			cause.manager = m[1]
+		}
+		causes = append(causes, cause)
+	}
+	return causes
+}
+
+// conflictsError returns an error listing the supplied conflicting fields and
+// their current owners, or nil if there are none.
+func conflictsError(causes []conflictCause) error {
+	if len(causes) == 0 {
+		return nil
+	}
+	conflicts := make([]string, 0, len(causes))
+	for _, c := range causes {
+		conflicts = append(conflicts, fmt.Sprintf("%s (owned by %s)", c.field, c.owner))
+	}
+	return errors.Errorf(errFieldConflicts, strings.Join(conflicts, ", "))
+}
+
+// resolveConflicts returns the supplied conflicting fields of a remote object
+// of the supplied Object, and whether they are taken over according to the
+// conflict policy of the Object. The Objects whose field managers own the
+// fields are read with the supplied client, if the policy depends on their
+// priorities.
+func resolveConflicts(ctx context.Context, local client.Reader, obj *v1alpha2.Object, causes []conflictCause) ([]v1alpha2.FieldConflict, bool, error) {
+	policy := obj.Spec.ForProvider.ConflictPolicy
+	takeOver := policy != v1alpha2.ConflictPolicyFail
+	conflicts := make([]v1alpha2.FieldConflict, 0, len(causes))
+	for _, c := range causes {
+		fc := v1alpha2.FieldConflict{Field: c.field, Manager: c.manager}
+		if name := strings.TrimPrefix(c.manager, ssaFieldOwner("")); name != c.manager {
+			fc.Object = name
+		}
+		conflicts = append(conflicts, fc)
+		if policy != v1alpha2.ConflictPolicyPriority || fc.Object == "" {
+			continue
+		}
+		other := &v1alpha2.Object{}
+		err := local.Get(ctx, types.NamespacedName{Name: fc.Object}, other)
+		if kerrors.IsNotFound(err) {
+			// The fields of deleted Objects are left behind.
+			continue
+		}
+		if err != nil {
+			return nil, false, errors.Wrapf(err, errGetConflictingObject, fc.Object)
+		}
+		if other.Spec.Priority >= obj.Spec.Priority {
+			takeOver = false
+		}
+	}
+	resolution := v1alpha2.FieldConflictYielded
+	if takeOver {
+		resolution = v1alpha2.FieldConflictTakenOver
+	}
+	for i := range conflicts {
+		conflicts[i].Resolution = resolution
+	}
+	return conflicts, takeOver, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// conflict returns a server-side apply conflict with the supplied causes.
+func conflict(causes ...metav1.StatusCause) error {
+	err := kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "test", errors.New("Apply failed with conflicts"))
+	err.ErrStatus.Details.Causes = causes
+	return err
+}
+
+func TestResolveConflicts(t *testing.T) {
+	causes := []conflictCause{
+		{field: ".spec.replicas", owner: `"provider-kubernetes/other" using apps/v1`, manager: "provider-kubernetes/other"},
+		{field: ".spec.paused", owner: `"kubectl"`, manager: "kubectl"},
+	}
+	conflicts := func(r v1alpha2.FieldConflictResolution) []v1alpha2.FieldConflict {
+		return []v1alpha2.FieldConflict{
+			{Field: ".spec.replicas", Manager: "provider-kubernetes/other", Object: "other", Resolution: r},
+			{Field: ".spec.paused", Manager: "kubectl", Resolution: r},
+		}
+	}
+	withConflictPolicy := func(p v1alpha2.ConflictPolicy, priority int32) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.ConflictPolicy = p
+			obj.Spec.Priority = priority
+		}
+	}
+	// The other Object has a priority of 10.
+	local := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*v1alpha2.Object).Spec.Priority = 10
+		return nil
+	})}
+
+	type want struct {
+		conflicts []v1alpha2.FieldConflict
+		takeOver  bool
+		err       error
+	}
+	cases := map[string]struct {
+		reason string
+		local  client.Reader
+		obj    *v1alpha2.Object
+		want   want
+	}{
+		"Force": {
+			reason: "Conflicting fields should be taken over by default.",
+			obj:    kubernetesObject(),
+			want:   want{conflicts: conflicts(v1alpha2.FieldConflictTakenOver), takeOver: true},
+		},
+		"Fail": {
+			reason: "Conflicting fields should be yielded if the Object asks for it.",
+			obj:    kubernetesObject(withConflictPolicy(v1alpha2.ConflictPolicyFail, 0)),
+			want:   want{conflicts: conflicts(v1alpha2.FieldConflictYielded)},
+		},
+		"HigherPriority": {
+			reason: "Conflicting fields should be taken over from Objects with a lower priority.",
+			local:  local,
+			obj:    kubernetesObject(withConflictPolicy(v1alpha2.ConflictPolicyPriority, 20)),
+			want:   want{conflicts: conflicts(v1alpha2.FieldConflictTakenOver), takeOver: true},
+		},
+		"SamePriority": {
+			reason: "Conflicting fields should be yielded to Objects with the same priority.",
+			local:  local,
+			obj:    kubernetesObject(withConflictPolicy(v1alpha2.ConflictPolicyPriority, 10)),
+			want:   want{conflicts: conflicts(v1alpha2.FieldConflictYielded)},
+		},
+		"DeletedObject": {
+			reason: "Conflicting fields should be taken over from Objects that no longer exist.",
+			local:  &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "other"))},
+			obj:    kubernetesObject(withConflictPolicy(v1alpha2.ConflictPolicyPriority, 0)),
+			want:   want{conflicts: conflicts(v1alpha2.FieldConflictTakenOver), takeOver: true},
+		},
+		"GetError": {
+			reason: "Errors getting the conflicting Objects should be returned.",
+			local:  &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			obj:    kubernetesObject(withConflictPolicy(v1alpha2.ConflictPolicyPriority, 0)),
+			want:   want{err: errors.Wrapf(errBoom, errGetConflictingObject, "other")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, takeOver, err := resolveConflicts(context.Background(), tc.local, tc.obj, causes)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nresolveConflicts(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.conflicts, got); diff != "" {
+				t.Errorf("\n%s\nresolveConflicts(...): -want conflicts, +got conflicts:\n%s", tc.reason, diff)
+			}
+			if takeOver != tc.want.takeOver {
+				t.Errorf("\n%s\nresolveConflicts(...): want take over %t, got %t", tc.reason, tc.want.takeOver, takeOver)
+			}
+		})
+	}
+}
+
+func TestSSAResourceSyncerConflicts(t *testing.T) {
+	cause := metav1.StatusCause{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl"`, Field: ".spec.replicas"}
+
+	cases := map[string]struct {
+		reason  string
+		policy  v1alpha2.ConflictPolicy
+		want    error
+		patches int
+	}{
+		"TakenOver": {
+			reason:  "Conflicting fields should be applied again with force, and reported, if they are taken over.",
+			patches: 2,
+		},
+		"Yielded": {
+			reason:  "Conflicting fields should be reported, and fail the apply, if they are yielded.",
+			policy:  v1alpha2.ConflictPolicyFail,
+			want:    errors.Errorf(errFieldConflicts, `.spec.replicas (owned by "kubectl")`),
+			patches: 1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			patches := 0
+			s := &SSAResourceSyncer{client: &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, opts ...client.PatchOption) error {
+				patches++
+				po := &client.PatchOptions{}
+				po.ApplyOptions(opts)
+				if po.Force == nil {
+					return conflict(cause)
+				}
+				return nil
+			}}}
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ConflictPolicy = tc.policy
+			})
+			_, err := s.SyncResource(context.Background(), obj, &unstructured.Unstructured{})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.SyncResource(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if patches != tc.patches {
+				t.Errorf("\n%s\ns.SyncResource(...): want %d patches, got %d", tc.reason, tc.patches, patches)
+			}
+			if len(obj.Status.AtProvider.FieldConflicts) != 1 {
+				t.Errorf("\n%s\ns.SyncResource(...): want the conflict reported, got %v", tc.reason, obj.Status.AtProvider.FieldConflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// A readyConnectionPublisher publishes the connection details of Objects only
+// once they are ready, so that consumers of their connection secrets do not
+// pick up the details of remote objects that are still being provisioned.
+type readyConnectionPublisher struct {
+	managed.ConnectionPublisher
+}
+
+// PublishConnection publishes the supplied connection details if the supplied
+// owner is ready.
+func (p *readyConnectionPublisher) PublishConnection(ctx context.Context, so resource.ConnectionSecretOwner, c managed.ConnectionDetails) (bool, error) {
+	if o, ok := so.(resource.Conditioned); ok && o.GetCondition(xpv1.TypeReady).Status != v1.ConditionTrue {
+		return false, nil
+	}
+	return p.ConnectionPublisher.PublishConnection(ctx, so, c)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReadyConnectionPublisher(t *testing.T) {
+	type want struct {
+		published bool
+		called    bool
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   want
+	}{
+		"NotReady": {
+			reason: "The connection details of an Object that is not ready should not be published.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetConditions(xpv1.Creating())
+			}),
+		},
+		"Ready": {
+			reason: "The connection details of a ready Object should be published.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetConditions(xpv1.Available())
+			}),
+			want: want{published: true, called: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			p := &readyConnectionPublisher{ConnectionPublisher: managed.ConnectionPublisherFns{
+				PublishConnectionFn: func(_ context.Context, _ resource.ConnectionSecretOwner, _ managed.ConnectionDetails) (bool, error) {
+					called = true
+					return true, nil
+				},
+			}}
+			published, err := p.PublishConnection(context.Background(), tc.obj, managed.ConnectionDetails{"url": []byte("https://example.org")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, want{published: published, called: called}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\np.PublishConnection(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
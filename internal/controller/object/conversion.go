@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typeConversionUnavailable is the condition reporting whether the
+	// conversion webhook of the CustomResourceDefinition of the remote
+	// object of an Object is unavailable on the target cluster.
+	typeConversionUnavailable xpv1.ConditionType = "ConversionUnavailable"
+
+	reasonConversionWebhookUnavailable xpv1.ConditionReason = "ConversionWebhookUnavailable"
+	reasonConversionWebhookAvailable   xpv1.ConditionReason = "ConversionWebhookAvailable"
+
+	errConversionUnavailable = "conversion webhook of the target cluster is unavailable, waiting for it to recover"
+
+	// conversionWebhookMessage is how the API server words the failures of
+	// conversion webhooks, e.g. conversion webhook for example.org/v1,
+	// Kind=Widget failed: Post "https://widgets.example.svc:443/convert":
+	// connect: connection refused.
+	conversionWebhookMessage = "conversion webhook for "
+)
+
+// isConversionUnavailable returns true if the supplied error is the failure of
+// the API server of the target cluster to convert a custom resource with its
+// conversion webhook.
+func isConversionUnavailable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), conversionWebhookMessage)
+}
+
+// conversionAvailability returns the supplied error of an observation of the
+// supplied Object, reporting in its conditions whether it failed because the
+// conversion webhook of its remote object is unavailable. Such outages are
+// outside the control of the author of the manifest, so they are neither
+// permanent failures nor counted in the reconcile health of the Object, and
+// the Object is retried with the backoff of the reconciler. Availability is
+// only reported if the webhook was previously reported to be unavailable.
+func conversionAvailability(obj *v1alpha2.Object, err error) error {
+	if isConversionUnavailable(err) {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typeConversionUnavailable,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonConversionWebhookUnavailable,
+			Message:            err.Error(),
+		})
+		return errors.Wrap(err, errConversionUnavailable)
+	}
+	if err == nil && obj.GetCondition(typeConversionUnavailable).Status == v1.ConditionTrue {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typeConversionUnavailable,
+			Status:             v1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonConversionWebhookAvailable,
+		})
+	}
+	return err
+}
+
+// conversionUnavailable returns true if the supplied Object is reported to
+// have failed because the conversion webhook of its remote object is
+// unavailable.
+func conversionUnavailable(obj *v1alpha2.Object) bool {
+	return obj.GetCondition(typeConversionUnavailable).Status == v1.ConditionTrue
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestConversionAvailability(t *testing.T) {
+	errConversion := errors.Wrap(kerrors.NewInternalError(errors.New(`conversion webhook for example.org/v1, Kind=Widget failed: Post "https://widgets.example.svc:443/convert": connect: connection refused`)), errGetObject)
+	errBoom := errors.New("boom")
+	unavailable := func(obj *v1alpha2.Object) {
+		obj.SetConditions(xpv1.Condition{Type: typeConversionUnavailable, Status: v1.ConditionTrue, Reason: reasonConversionWebhookUnavailable})
+	}
+
+	type want struct {
+		err       error
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		err    error
+		want   want
+	}{
+		"Available": {
+			reason: "Nothing should be reported if the conversion webhook was never unavailable.",
+			obj:    kubernetesObject(),
+			want:   want{condition: xpv1.Condition{Type: typeConversionUnavailable, Status: v1.ConditionUnknown}},
+		},
+		"OtherError": {
+			reason: "Errors other than the failure of a conversion webhook should be returned as is.",
+			obj:    kubernetesObject(unavailable),
+			err:    errBoom,
+			want: want{
+				err:       errBoom,
+				condition: xpv1.Condition{Type: typeConversionUnavailable, Status: v1.ConditionTrue, Reason: reasonConversionWebhookUnavailable},
+			},
+		},
+		"Unavailable": {
+			reason: "The failure of a conversion webhook should be reported in a dedicated condition.",
+			obj:    kubernetesObject(),
+			err:    errConversion,
+			want: want{
+				err:       errors.Wrap(errConversion, errConversionUnavailable),
+				condition: xpv1.Condition{Type: typeConversionUnavailable, Status: v1.ConditionTrue, Reason: reasonConversionWebhookUnavailable, Message: errConversion.Error()},
+			},
+		},
+		"Recovered": {
+			reason: "The conversion webhook should be reported available again once an observation succeeds.",
+			obj:    kubernetesObject(unavailable),
+			want:   want{condition: xpv1.Condition{Type: typeConversionUnavailable, Status: v1.ConditionFalse, Reason: reasonConversionWebhookAvailable}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := conversionAvailability(tc.obj, tc.err)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nconversionAvailability(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, tc.obj.GetCondition(typeConversionUnavailable), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nconversionAvailability(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
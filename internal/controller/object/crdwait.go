@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpmeta "github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errCRDNotServed      = "cannot apply %s until its CustomResourceDefinition is served"
+	errCRDNotEstablished = "cannot apply %s until its CustomResourceDefinition %s is established"
+	errMapKind           = "cannot map kind %s to its resource"
+	errGetCRD            = "cannot get CustomResourceDefinition %s"
+)
+
+// waitForCRD returns an error if the supplied Object waits for the
+// CustomResourceDefinitions of the kinds of the supplied manifest, or of its
+// items if it is a List, and any of them is not established on the target
+// cluster yet. Objects being deleted do not wait.
+func (c *external) waitForCRD(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) error {
+	if !obj.Spec.ForProvider.WaitForCRD || xpmeta.WasDeleted(obj) {
+		return nil
+	}
+	manifests := []*unstructured.Unstructured{manifest}
+	if manifest.IsList() {
+		_, items, err := children(obj, manifest)
+		if err != nil {
+			return err
+		}
+		manifests = items
+	}
+	for _, m := range manifests {
+		if err := crdEstablished(ctx, c.client, c.client.RESTMapper(), m.GroupVersionKind()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// crdEstablished returns an error if the supplied kind is not served by the
+// target cluster, or if it is a custom resource whose CustomResourceDefinition
+// is not established. Kinds of the core API group are always served.
+func crdEstablished(ctx context.Context, kube client.Reader, mapper meta.RESTMapper, gvk schema.GroupVersionKind) error {
+	if gvk.Group == "" {
+		return nil
+	}
+	m, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return errors.Errorf(errCRDNotServed, gvk.Kind)
+	}
+	if err != nil {
+		return errors.Wrapf(err, errMapKind, gvk.Kind)
+	}
+
+	name := m.Resource.Resource + "." + gvk.Group
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdKind.WithVersion("v1"))
+	err = kube.Get(ctx, types.NamespacedName{Name: name}, crd)
+	if kerrors.IsNotFound(err) {
+		// The kind is served by the target cluster itself, or by an
+		// aggregated API server.
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, errGetCRD, name)
+	}
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		if c, ok := c.(map[string]any); ok && c["type"] == "Established" && c["status"] == string(v1.ConditionTrue) {
+			return nil
+		}
+	}
+	return errors.Errorf(errCRDNotEstablished, gvk.Kind, name)
+}
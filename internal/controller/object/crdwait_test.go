@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestCRDEstablished(t *testing.T) {
+	errBoom := errors.New("boom")
+	widget := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Widget"}
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(widget, meta.RESTScopeNamespace)
+	mapper.Add(deployment, meta.RESTScopeNamespace)
+
+	withConditions := func(conditions ...any) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			if u.GetKind() != "CustomResourceDefinition" || key.Name != "widgets.example.org" {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return unstructured.SetNestedSlice(u.Object, conditions, "status", "conditions")
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		gvk    schema.GroupVersionKind
+		want   error
+	}{
+		"Core": {
+			reason: "Kinds of the core API group should always be served.",
+			gvk:    schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		},
+		"NotServed": {
+			reason: "A kind the target cluster does not serve should not be established.",
+			gvk:    schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Gadget"},
+			want:   errors.Errorf(errCRDNotServed, "Gadget"),
+		},
+		"BuiltIn": {
+			reason: "A served kind without a CustomResourceDefinition should be established.",
+			get:    withConditions(),
+			gvk:    deployment,
+		},
+		"Established": {
+			reason: "A custom resource whose CustomResourceDefinition is established should be established.",
+			get:    withConditions(map[string]any{"type": "Established", "status": "True"}),
+			gvk:    widget,
+		},
+		"NotEstablished": {
+			reason: "A custom resource whose CustomResourceDefinition is not established yet should not be established.",
+			get:    withConditions(map[string]any{"type": "NamesAccepted", "status": "True"}, map[string]any{"type": "Established", "status": "False"}),
+			gvk:    widget,
+			want:   errors.Errorf(errCRDNotEstablished, "Widget", "widgets.example.org"),
+		},
+		"GetError": {
+			reason: "An error getting the CustomResourceDefinition should be returned.",
+			get:    test.NewMockGetFn(errBoom),
+			gvk:    widget,
+			want:   errors.Wrapf(errBoom, errGetCRD, "widgets.example.org"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := crdEstablished(context.Background(), &test.MockClient{MockGet: tc.get}, mapper, tc.gvk)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncrdEstablished(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	// connectionSecretOfIndex is an index of the managed resources whose
+	// connection secrets a provider config reads its credentials from.
+	connectionSecretOfIndex = "providerConfigsConnectionSecretOf"
+)
+
+var _ client.IndexerFunc = IndexByConnectionSecretOf
+
+// IndexByConnectionSecretOf assumes the passed object is a ProviderConfig. It
+// returns keys with "GroupKind + Name" for every managed resource whose
+// connection secret the provider config reads credentials from, so that the
+// provider configs reading a connection secret are found by its owner.
+func IndexByConnectionSecretOf(o client.Object) []string {
+	pc, ok := o.(*apisv1alpha1.ProviderConfig)
+	if !ok {
+		return nil // should never happen
+	}
+	creds := []kconfig.ProviderCredentials{pc.Spec.Credentials}
+	if pc.Spec.Identity != nil {
+		creds = append(creds, pc.Spec.Identity.ProviderCredentials)
+	}
+	keys := make([]string, 0, len(creds))
+	for _, c := range creds {
+		if c.Source != kconfig.CredentialsSourceConnectionSecret || c.ConnectionSecretOf == nil {
+			continue
+		}
+		keys = append(keys, connectionSecretOfKey(c.ConnectionSecretOf.APIVersion, c.ConnectionSecretOf.Kind, c.ConnectionSecretOf.Name))
+	}
+	return keys
+}
+
+func connectionSecretOfKey(apiVersion, kind, name string) string {
+	group, _ := parseAPIVersion(apiVersion)
+	return fmt.Sprintf("%s.%s/%s", kind, group, name)
+}
+
+// isControlled returns true if the supplied object has a controller, like the
+// connection secret of a managed resource has.
+func isControlled(o client.Object) bool {
+	return metav1.GetControllerOf(o) != nil
+}
+
+// objectsForConnectionSecret returns a function mapping a Secret to the
+// Objects of the provider configs that read their credentials from it, as the
+// connection secret of a managed resource, so that the Objects are reconciled
+// with the new credentials right away rather than at their next poll. The
+// provider configs are looked up by the controller of the Secret, i.e. the
+// managed resource owning it, so Secrets without one are mapped to nothing.
+func objectsForConnectionSecret(kube client.Reader, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, s client.Object) []reconcile.Request {
+		owner := metav1.GetControllerOf(s)
+		if owner == nil {
+			return nil
+		}
+		pcs := &apisv1alpha1.ProviderConfigList{}
+		if err := kube.List(ctx, pcs, client.MatchingFields{connectionSecretOfIndex: connectionSecretOfKey(owner.APIVersion, owner.Kind, owner.Name)}); err != nil {
+			log.Debug("Cannot list provider configs", "error", err)
+			return nil
+		}
+		secret := types.NamespacedName{Namespace: s.GetNamespace(), Name: s.GetName()}
+		names := make(map[string]bool)
+		for i := range pcs.Items {
+			if readsConnectionSecret(ctx, kube, pcs.Items[i].Spec, secret) {
+				names[pcs.Items[i].GetName()] = true
+			}
+		}
+		return objectsForProviderConfigs(ctx, kube, log, names)
+	}
+}
+
+// readsConnectionSecret returns true if the supplied provider config reads
+// credentials from the supplied connection secret.
+func readsConnectionSecret(ctx context.Context, kube client.Reader, pc kconfig.ProviderConfigSpec, secret types.NamespacedName) bool {
+	creds := []kconfig.ProviderCredentials{pc.Credentials}
+	if pc.Identity != nil {
+		creds = append(creds, pc.Identity.ProviderCredentials)
+	}
+	for _, c := range creds {
+		if c.Source != kconfig.CredentialsSourceConnectionSecret {
+			continue
+		}
+		ref, err := kubeclient.ConnectionSecretRef(ctx, kube, c.ConnectionSecretOf)
+		if err == nil && ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// objectsForProviderConfigs returns the requests to reconcile the Objects of
+// the supplied provider configs.
+func objectsForProviderConfigs(ctx context.Context, kube client.Reader, log logging.Logger, names map[string]bool) []reconcile.Request {
+	if len(names) == 0 {
+		return nil
+	}
+	objs := &v1alpha2.ObjectList{}
+	if err := kube.List(ctx, objs); err != nil {
+		log.Debug("Cannot list Objects", "error", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range objs.Items {
+		if ref := objs.Items[i].GetProviderConfigReference(); ref != nil && names[ref.Name] {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: objs.Items[i].GetName()}})
+		}
+	}
+	return reqs
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestObjectsForConnectionSecret(t *testing.T) {
+	chained := apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "chained"},
+		Spec: kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{
+			Source:             kconfig.CredentialsSourceConnectionSecret,
+			ConnectionSecretOf: &kconfig.ConnectionSecretSelector{APIVersion: "example.org/v1", Kind: "Cluster", Name: "target"},
+		}},
+	}
+	plain := apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "plain"},
+		Spec: kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+		}},
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*unstructured.Unstructured).Object["spec"] = map[string]any{
+				"writeConnectionSecretToRef": map[string]any{"namespace": "crossplane-system", "name": "target-conn"},
+			}
+			return nil
+		},
+		MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			switch l := list.(type) {
+			case *apisv1alpha1.ProviderConfigList:
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				if got := lo.FieldSelector.String(); got != connectionSecretOfIndex+"=Cluster.example.org/target" {
+					return nil
+				}
+				// The index may return provider configs reading another
+				// connection secret of the same managed resource.
+				l.Items = []apisv1alpha1.ProviderConfig{chained, plain}
+			case *v1alpha2.ObjectList:
+				for name, pc := range map[string]string{"chained-a": "chained", "plain-b": "plain", "chained-c": "chained"} {
+					obj := kubernetesObject(func(obj *v1alpha2.Object) {
+						obj.SetName(name)
+						obj.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc}
+					})
+					l.Items = append(l.Items, *obj)
+				}
+			}
+			return nil
+		},
+	}
+
+	owned := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "example.org/v1", Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+
+	cases := map[string]struct {
+		secret *corev1.Secret
+		want   []reconcile.Request
+	}{
+		"ConnectionSecret": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "target-conn", OwnerReferences: owned("Cluster", "target")}},
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "chained-a"}},
+				{NamespacedName: types.NamespacedName{Name: "chained-c"}},
+			},
+		},
+		"OtherSecretOfManagedResource": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "other", OwnerReferences: owned("Cluster", "target")}},
+		},
+		"OtherManagedResource": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "target-conn", OwnerReferences: owned("Cluster", "other")}},
+		},
+		"NoController": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "target-conn"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := objectsForConnectionSecret(kube, logging.NewNopLogger())(context.Background(), tc.secret)
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b reconcile.Request) bool { return a.Name < b.Name })); diff != "" {
+				t.Errorf("objectsForConnectionSecret(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIndexByConnectionSecretOf(t *testing.T) {
+	target := &kconfig.ConnectionSecretSelector{APIVersion: "example.org/v1", Kind: "Cluster", Name: "target"}
+	cases := map[string]struct {
+		pc   client.Object
+		want []string
+	}{
+		"NotAProviderConfig": {
+			pc: &corev1.Secret{},
+		},
+		"Secret": {
+			pc: &apisv1alpha1.ProviderConfig{Spec: kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+			}}},
+			want: []string{},
+		},
+		"CredentialsAndIdentity": {
+			pc: &apisv1alpha1.ProviderConfig{Spec: kconfig.ProviderConfigSpec{
+				Credentials: kconfig.ProviderCredentials{Source: kconfig.CredentialsSourceConnectionSecret, ConnectionSecretOf: target},
+				Identity: &kconfig.Identity{ProviderCredentials: kconfig.ProviderCredentials{
+					Source:             kconfig.CredentialsSourceConnectionSecret,
+					ConnectionSecretOf: &kconfig.ConnectionSecretSelector{APIVersion: "iam.example.org/v1", Kind: "Key", Name: "key"},
+				}},
+			}},
+			want: []string{"Cluster.example.org/target", "Key.iam.example.org/key"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IndexByConnectionSecretOf(tc.pc)); diff != "" {
+				t.Errorf("IndexByConnectionSecretOf(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// annotationKeyDebug elevates the log verbosity for a single Object when
+	// set to "true", without having to enable debug logging for the whole
+	// provider.
+	annotationKeyDebug = "kubernetes.crossplane.io/debug"
+)
+
+// debugEnabled returns true if debug logging is requested for the supplied
+// Object.
+func debugEnabled(obj *v1alpha2.Object) bool {
+	return obj.GetAnnotations()[annotationKeyDebug] == "true"
+}
+
+// loggerFor returns the logger to use while reconciling the supplied Object.
+// Objects annotated with kubernetes.crossplane.io/debug: "true" get a logger
+// that emits their debug messages at info level.
+func loggerFor(obj *v1alpha2.Object, l logging.Logger) logging.Logger {
+	l = l.WithValues("object", obj.GetName())
+	if debugEnabled(obj) {
+		return debugLogger{Logger: l}
+	}
+	return l
+}
+
+// debugLogger is a logging.Logger that logs debug messages at info level.
+type debugLogger struct {
+	logging.Logger
+}
+
+// Debug logs the supplied message at info level.
+func (l debugLogger) Debug(msg string, keysAndValues ...any) {
+	l.Logger.Info(msg, keysAndValues...)
+}
+
+// WithValues returns a debugLogger with the supplied structured data.
+func (l debugLogger) WithValues(keysAndValues ...any) logging.Logger {
+	return debugLogger{Logger: l.Logger.WithValues(keysAndValues...)}
+}
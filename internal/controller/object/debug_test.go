@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// recordingLogger records the level of every message it logs.
+type recordingLogger struct {
+	levels *[]string
+}
+
+func (l recordingLogger) Info(msg string, _ ...any) {
+	*l.levels = append(*l.levels, "info: "+msg)
+}
+
+func (l recordingLogger) Debug(msg string, _ ...any) {
+	*l.levels = append(*l.levels, "debug: "+msg)
+}
+
+func (l recordingLogger) WithValues(_ ...any) logging.Logger {
+	return l
+}
+
+func TestLoggerFor(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want []string
+	}{
+		"DebugNotRequested": {
+			obj:  kubernetesObject(),
+			want: []string{"debug: message"},
+		},
+		"DebugDisabled": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetAnnotations(map[string]string{annotationKeyDebug: "false"})
+			}),
+			want: []string{"debug: message"},
+		},
+		"DebugEnabled": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetAnnotations(map[string]string{annotationKeyDebug: "true"})
+			}),
+			want: []string{"info: message"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := []string{}
+			l := loggerFor(tc.obj, recordingLogger{levels: &got})
+			l.WithValues("key", "value").Debug("message")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("loggerFor(...).Debug(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const errAwaitObjectDefaults = "the object defaults of the provider config changed, waiting for them to take effect"
+
+// declaredPolicies are the management and deletion policies of the spec of an
+// Object, before the object defaults of its provider config were applied.
+type declaredPolicies struct {
+	management xpv1.ManagementPolicies
+	deletion   xpv1.DeletionPolicy
+}
+
+// An objectDefaultingClient applies the object defaults of their provider
+// configs to the management and deletion policies of the Objects it gets, so
+// that they are reconciled with the policies they inherit. The policies the
+// Objects declare are written back whenever they are updated, so that the
+// inherited ones are never persisted in their spec, e.g. fighting GitOps
+// tooling, and changes to the defaults take effect on the next reconcile.
+type objectDefaultingClient struct {
+	client.Client
+	managementPoliciesEnabled bool
+
+	mu       sync.Mutex
+	declared map[types.NamespacedName]declaredPolicies
+}
+
+func newObjectDefaultingClient(c client.Client, managementPoliciesEnabled bool) *objectDefaultingClient {
+	return &objectDefaultingClient{Client: c, managementPoliciesEnabled: managementPoliciesEnabled, declared: make(map[types.NamespacedName]declaredPolicies)}
+}
+
+// Get the supplied object, applying the object defaults of their provider
+// config to Objects.
+func (c *objectDefaultingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	o, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.declared, key)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	d := declaredPolicies{management: o.GetManagementPolicies(), deletion: o.GetDeletionPolicy()}
+	changed, err := applyObjectDefaults(ctx, c.Client, o, c.managementPoliciesEnabled)
+	if err != nil || !changed {
+		return err
+	}
+	c.mu.Lock()
+	c.declared[key] = d
+	c.mu.Unlock()
+	return nil
+}
+
+// Update the supplied object. Objects are updated with the management and
+// deletion policies they declare, rather than those they inherit.
+func (c *objectDefaultingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	o, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	c.mu.Lock()
+	d, ok := c.declared[client.ObjectKeyFromObject(o)]
+	c.mu.Unlock()
+	if !ok {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	management, deletion := o.GetManagementPolicies(), o.GetDeletionPolicy()
+	o.SetManagementPolicies(d.management)
+	o.SetDeletionPolicy(d.deletion)
+	err := c.Client.Update(ctx, o, opts...)
+	o.SetManagementPolicies(management)
+	o.SetDeletionPolicy(deletion)
+	return err
+}
+
+// applyObjectDefaults applies the object defaults of the provider config of
+// the supplied Object, if any, to its management and deletion policies. It
+// returns true if they changed. Objects whose provider config does not exist
+// keep their policies, since they cannot reach their target cluster anyway.
+func applyObjectDefaults(ctx context.Context, kube client.Reader, obj *v1alpha2.Object, managementPoliciesEnabled bool) (bool, error) {
+	ref := obj.GetProviderConfigReference()
+	if ref == nil || usesInlineKubeconfig(obj) {
+		return false, nil
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return false, errors.Wrap(resource.IgnoreNotFound(err), errGetProviderConfig)
+	}
+	defaults := pc.Spec.ObjectDefaults
+	if defaults == nil {
+		return false, nil
+	}
+	policiesChanged := managementPoliciesEnabled && setDefaultManagementPolicies(obj, defaults)
+	deletionChanged := setDefaultDeletionPolicy(obj, defaults)
+	return policiesChanged || deletionChanged, nil
+}
+
+// An objectDefaulter is an initializer that aborts the reconcile of an Object
+// whose policies the object defaults of its provider config would change,
+// e.g. because its provider config was only just selected. Since the policies
+// of a reconcile are resolved before the Object is initialized, no remote
+// object is touched with the previous ones, and the next reconcile gets the
+// Object with the policies it inherits from an objectDefaultingClient.
+type objectDefaulter struct {
+	kube                      client.Reader
+	managementPoliciesEnabled bool
+}
+
+// Initialize returns an error if the object defaults of the provider config of
+// the supplied Object change its management or deletion policies.
+func (d *objectDefaulter) Initialize(ctx context.Context, mg resource.Managed) error {
+	obj, ok := mg.(*v1alpha2.Object)
+	if !ok {
+		return errors.New(errNotKubernetesObject)
+	}
+	changed, err := applyObjectDefaults(ctx, d.kube, obj.DeepCopy(), d.managementPoliciesEnabled)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return errors.New(errAwaitObjectDefaults)
+	}
+	return nil
+}
+
+// setDefaultManagementPolicies sets the management policies of the supplied
+// Object to the defaults, if they are to be replaced. It returns true if they
+// changed.
+func setDefaultManagementPolicies(obj *v1alpha2.Object, defaults *kconfig.ObjectDefaults) bool {
+	want := defaults.ManagementPolicies
+	got := obj.GetManagementPolicies()
+	if len(want) == 0 || samePolicies(want, got) {
+		return false
+	}
+	isDefault := len(got) == 0 || samePolicies(got, xpv1.ManagementPolicies{xpv1.ManagementActionAll})
+	if !isDefault && !defaults.Enforce {
+		return false
+	}
+	obj.SetManagementPolicies(want)
+	return true
+}
+
+// setDefaultDeletionPolicy sets the deletion policy of the supplied Object to
+// the default, if it is to be replaced. It returns true if it changed.
+func setDefaultDeletionPolicy(obj *v1alpha2.Object, defaults *kconfig.ObjectDefaults) bool {
+	want := defaults.DeletionPolicy
+	got := obj.GetDeletionPolicy()
+	if want == "" || want == got {
+		return false
+	}
+	isDefault := got == "" || got == xpv1.DeletionDelete
+	if !isDefault && !defaults.Enforce {
+		return false
+	}
+	obj.SetDeletionPolicy(want)
+	return true
+}
+
+func samePolicies(a, b xpv1.ManagementPolicies) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestObjectDefaultingClient(t *testing.T) {
+	observe := xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+	all := xpv1.ManagementPolicies{xpv1.ManagementActionAll}
+
+	type want struct {
+		policies xpv1.ManagementPolicies
+		deletion xpv1.DeletionPolicy
+	}
+	cases := map[string]struct {
+		reason   string
+		defaults *kconfig.ObjectDefaults
+		obj      *v1alpha2.Object
+		want     want
+	}{
+		"NoDefaults": {
+			reason: "Objects should keep their policies if their provider config has no defaults.",
+			obj:    kubernetesObject(),
+			want: want{
+				policies: all,
+			},
+		},
+		"DefaultPolicies": {
+			reason:   "The default policies of Objects should be replaced.",
+			defaults: &kconfig.ObjectDefaults{ManagementPolicies: observe, DeletionPolicy: xpv1.DeletionOrphan},
+			obj:      kubernetesObject(func(obj *v1alpha2.Object) { obj.Spec.DeletionPolicy = xpv1.DeletionDelete }),
+			want: want{
+				policies: observe,
+				deletion: xpv1.DeletionOrphan,
+			},
+		},
+		"OwnPolicies": {
+			reason:   "The policies set by Objects should not be replaced unless enforced.",
+			defaults: &kconfig.ObjectDefaults{ManagementPolicies: observe},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate}
+			}),
+			want: want{
+				policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate},
+			},
+		},
+		"EnforcedPolicies": {
+			reason:   "The policies set by Objects should be replaced if enforced.",
+			defaults: &kconfig.ObjectDefaults{ManagementPolicies: observe, Enforce: true},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate}
+			}),
+			want: want{
+				policies: observe,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *v1alpha2.Object
+			c := newObjectDefaultingClient(&test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1alpha2.Object:
+						tc.obj.DeepCopyInto(o)
+					case *apisv1alpha1.ProviderConfig:
+						o.Spec.ObjectDefaults = tc.defaults
+					}
+					return nil
+				},
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					updated = obj.(*v1alpha2.Object).DeepCopy()
+					return nil
+				},
+			}, true)
+
+			got := &v1alpha2.Object{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(tc.obj), got); err != nil {
+				t.Fatalf("c.Get(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.policies, got.GetManagementPolicies()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want management policies, +got management policies:\n%s", tc.reason, diff)
+			}
+			if tc.want.deletion != "" && tc.want.deletion != got.GetDeletionPolicy() {
+				t.Errorf("\n%s\nc.Get(...): want deletion policy %q, got %q", tc.reason, tc.want.deletion, got.GetDeletionPolicy())
+			}
+
+			// Updated Objects keep the policies they declare.
+			if err := c.Update(context.Background(), got); err != nil {
+				t.Fatalf("c.Update(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.obj.GetManagementPolicies(), updated.GetManagementPolicies()); diff != "" {
+				t.Errorf("\n%s\nc.Update(...): -want declared management policies, +got management policies:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.obj.GetDeletionPolicy(), updated.GetDeletionPolicy()); diff != "" {
+				t.Errorf("\n%s\nc.Update(...): -want declared deletion policy, +got deletion policy:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.policies, got.GetManagementPolicies()); diff != "" {
+				t.Errorf("\n%s\nc.Update(...): -want inherited management policies, +got management policies:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObjectDefaulterInitialize(t *testing.T) {
+	observe := xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   error
+	}{
+		"DefaultsApplied": {
+			reason: "Objects reconciled with the policies they inherit should be initialized.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = observe
+			}),
+		},
+		"DefaultsNotApplied": {
+			reason: "The reconcile should be aborted if the policies the Object inherits were not applied, e.g. because its provider config was just selected.",
+			obj:    kubernetesObject(),
+			want:   errors.New(errAwaitObjectDefaults),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := &objectDefaulter{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*apisv1alpha1.ProviderConfig).Spec.ObjectDefaults = &kconfig.ObjectDefaults{ManagementPolicies: observe}
+						return nil
+					},
+				},
+				managementPoliciesEnabled: true,
+			}
+			err := d.Initialize(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nd.Initialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// localRefsIndex is an index of the resources on the control plane that
+	// are referenced by an Object.
+	localRefsIndex = "objectsLocalRefs"
+
+	// maxDependentNames is the maximum number of dependents of an Object
+	// that are named in its status.
+	maxDependentNames = 50
+
+	errListDependents = "cannot list the Objects referencing the Object"
+)
+
+var _ client.IndexerFunc = IndexByLocalReference
+
+// IndexByLocalReference assumes the passed object is an Object. It returns keys
+// with "GroupKind + NamespacedName" for every resource on the control plane
+// referenced by the Object, so that the Objects referencing a resource are
+// found regardless of the apiVersion they reference it with.
+func IndexByLocalReference(o client.Object) []string {
+	obj, ok := o.(*v1alpha2.Object)
+	if !ok {
+		return nil // should never happen
+	}
+	keys := make([]string, 0, len(obj.Spec.References))
+	for _, ref := range obj.Spec.References {
+		apiVersion, kind, namespace, name := getReferenceInfo(ref)
+		if kind == "" || name == "" {
+			continue
+		}
+		keys = append(keys, localRefKey(apiVersion, kind, namespace, name))
+	}
+	return keys
+}
+
+func localRefKey(apiVersion, kind, namespace, name string) string {
+	group, _ := parseAPIVersion(apiVersion)
+	return fmt.Sprintf("%s.%s/%s/%s", kind, group, namespace, name)
+}
+
+// setDependents records the Objects referencing the supplied Object in its
+// status, if the references of Objects are indexed.
+func (c *external) setDependents(ctx context.Context, obj *v1alpha2.Object) error {
+	if !c.localRefsIndexed {
+		return nil
+	}
+	l := &v1alpha2.ObjectList{}
+	key := localRefKey(v1alpha2.ObjectGroupVersionKind.GroupVersion().String(), v1alpha2.ObjectKind, "", obj.GetName())
+	if err := c.localClient.List(ctx, l, client.MatchingFields{localRefsIndex: key}); err != nil {
+		return errors.Wrap(err, errListDependents)
+	}
+	if len(l.Items) == 0 {
+		obj.Status.AtProvider.Dependents = nil
+		return nil
+	}
+	names := make([]string, 0, len(l.Items))
+	for _, d := range l.Items {
+		names = append(names, d.GetName())
+	}
+	sort.Strings(names)
+	d := &v1alpha2.Dependents{Count: int32(len(names)), Names: names}
+	if len(names) > maxDependentNames {
+		d.Names = names[:maxDependentNames]
+	}
+	obj.Status.AtProvider.Dependents = d
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestIndexByLocalReference(t *testing.T) {
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.References = []v1alpha2.Reference{
+			{DependsOn: &v1alpha2.DependsOn{APIVersion: "kubernetes.crossplane.io/v1alpha1", Kind: "Object", Name: "database"}},
+			{PatchesFrom: &v1alpha2.PatchesFrom{DependsOn: v1alpha2.DependsOn{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "settings"}}},
+			{},
+		}
+	})
+	want := []string{"Object.kubernetes.crossplane.io//database", "ConfigMap./default/settings"}
+	if diff := cmp.Diff(want, IndexByLocalReference(obj)); diff != "" {
+		t.Errorf("IndexByLocalReference(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSetDependents(t *testing.T) {
+	dependent := func(name string) v1alpha2.Object {
+		return v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	errBoom := errors.New("boom")
+
+	type want struct {
+		dependents *v1alpha2.Dependents
+		err        error
+	}
+	cases := map[string]struct {
+		reason  string
+		indexed bool
+		list    func(obj client.ObjectList, opts ...client.ListOption) error
+		want    want
+	}{
+		"NotIndexed": {
+			reason: "Dependents should not be recorded if the references of Objects are not indexed.",
+		},
+		"NoDependents": {
+			reason:  "No dependents should be recorded if no Object references the Object.",
+			indexed: true,
+			list: func(_ client.ObjectList, _ ...client.ListOption) error {
+				return nil
+			},
+		},
+		"Dependents": {
+			reason:  "The Objects referencing the Object should be recorded by name.",
+			indexed: true,
+			list: func(obj client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				if got := lo.FieldSelector.String(); got != localRefsIndex+"=Object.kubernetes.crossplane.io//"+testObjectName {
+					t.Errorf("Unexpected field selector %s", got)
+				}
+				obj.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{dependent("web"), dependent("api")}
+				return nil
+			},
+			want: want{dependents: &v1alpha2.Dependents{Count: 2, Names: []string{"api", "web"}}},
+		},
+		"ListError": {
+			reason:  "Errors listing the dependents should be returned.",
+			indexed: true,
+			list: func(_ client.ObjectList, _ ...client.ListOption) error {
+				return errBoom
+			},
+			want: want{err: errors.Wrap(errBoom, errListDependents)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{localRefsIndexed: tc.indexed}
+			if tc.list != nil {
+				e.localClient = &test.MockClient{MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+					return tc.list(obj, opts...)
+				}}
+			}
+			obj := kubernetesObject()
+			err := e.setDependents(context.Background(), obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.setDependents(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.dependents, obj.Status.AtProvider.Dependents); diff != "" {
+				t.Errorf("\n%s\ne.setDependents(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// maxFieldDiffs is the maximum number of fields at which the remote
+	// objects of an Object are reported to differ from their desired state.
+	maxFieldDiffs = 50
+
+	errMarshalFieldDiff = "cannot marshal the value of field %s"
+)
+
+// fieldDiffs appends the fields at which the supplied observed state of a
+// remote object of the supplied Object differs from the supplied desired state
+// to the supplied fields, up to the maximum number of field diffs, if
+// structured diffs are enabled. The paths of the fields are prefixed with the
+// supplied field path.
+func (c *external) fieldDiffs(diffs []v1alpha2.FieldDiff, obj *v1alpha2.Object, prefix string, last, desired *unstructured.Unstructured) ([]v1alpha2.FieldDiff, error) {
+	if !c.structuredDiffEnabled || len(diffs) >= maxFieldDiffs {
+		return diffs, nil
+	}
+	last, desired, err := c.comparableStates(obj, last, desired)
+	if err != nil {
+		return nil, err
+	}
+	var live, want map[string]any
+	if last != nil {
+		live = last.Object
+	}
+	if desired != nil {
+		want = desired.Object
+	}
+	// The fields are compared unredacted, so that fields with secret data
+	// are reported to differ, but with their values redacted.
+	redactedLive := fieldpath.Pave(c.redacted(&unstructured.Unstructured{Object: live}).Object)
+	redactedWant := fieldpath.Pave(c.redacted(&unstructured.Unstructured{Object: want}).Object)
+
+	walkDiff("", live, want, func(path string, l, d any) bool {
+		diff := v1alpha2.FieldDiff{Path: prefixedPath(prefix, path)}
+		if diff.Live, err = jsonValue(path, redactedValueAt(redactedLive, path, l)); err != nil {
+			return false
+		}
+		if diff.Desired, err = jsonValue(path, redactedValueAt(redactedWant, path, d)); err != nil {
+			return false
+		}
+		diffs = append(diffs, diff)
+		return len(diffs) < maxFieldDiffs
+	})
+	return diffs, err
+}
+
+// redactedValueAt returns the value of the supplied field of the supplied
+// redacted object, given its unredacted value. Fields whose data was redacted
+// away entirely, e.g. the data of Secrets, are reported redacted.
+func redactedValueAt(redacted *fieldpath.Paved, path string, v any) any {
+	if v == nil {
+		return nil
+	}
+	r, err := redacted.GetValue(path)
+	if err != nil {
+		return redactedValue
+	}
+	return r
+}
+
+// prefixedPath returns the supplied field path prefixed with the supplied one.
+func prefixedPath(prefix, path string) string {
+	if prefix == "" || strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+// jsonValue returns the JSON encoding of the supplied value of the supplied
+// field, or an empty string if it is not set.
+func jsonValue(path string, v any) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), errors.Wrapf(err, errMarshalFieldDiff, path)
+}
+
+// walkDiff calls the supplied function with the field path and the values of
+// every field at which the supplied values differ, in the order of their field
+// paths, until it returns false. Lists of different lengths differ as a whole.
+// It returns false if the walk was stopped.
+func walkDiff(path string, before, after any, fn func(path string, before, after any) bool) bool {
+	bm, bok := before.(map[string]any)
+	am, aok := after.(map[string]any)
+	if bok && aok {
+		keys := make([]string, 0, len(bm)+len(am))
+		for k := range bm {
+			keys = append(keys, k)
+		}
+		for k := range am {
+			if _, ok := bm[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !walkDiff(childPath(path, k), bm[k], am[k], fn) {
+				return false
+			}
+		}
+		return true
+	}
+	bs, bok := before.([]any)
+	as, aok := after.([]any)
+	if bok && aok && len(bs) == len(as) {
+		for i := range bs {
+			if !walkDiff(fmt.Sprintf("%s[%d]", path, i), bs[i], as[i], fn) {
+				return false
+			}
+		}
+		return true
+	}
+	if !reflect.DeepEqual(before, after) {
+		return fn(path, before, after)
+	}
+	return true
+}
+
+// childPath returns the field path of the supplied key of the object at the
+// supplied field path, in brackets unless it is a plain field name.
+func childPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]/ ") || key == "" {
+		return path + "[" + key + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestFieldDiffs(t *testing.T) {
+	deployment := func(replicas int64, image string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "app"},
+			"spec": map[string]any{
+				"replicas": replicas,
+				"template": map[string]any{"spec": map[string]any{"containers": []any{map[string]any{"name": "app", "image": image}}}},
+			},
+		}}
+	}
+	secret := func(password string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]any{"name": "creds"},
+			"data":       map[string]any{"password": password},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason   string
+		external *external
+		diffs    []v1alpha2.FieldDiff
+		prefix   string
+		last     *unstructured.Unstructured
+		desired  *unstructured.Unstructured
+		want     []v1alpha2.FieldDiff
+	}{
+		"Disabled": {
+			reason:   "No fields should be reported if structured diffs are not enabled.",
+			external: &external{},
+			last:     deployment(1, "app:v1"),
+			desired:  deployment(3, "app:v2"),
+		},
+		"Differ": {
+			reason:   "The fields that differ should be reported with their JSON encoded live and desired values.",
+			external: &external{structuredDiffEnabled: true},
+			last:     deployment(1, "app:v1"),
+			desired:  deployment(3, "app:v2"),
+			want: []v1alpha2.FieldDiff{
+				{Path: "spec.replicas", Live: "1", Desired: "3"},
+				{Path: "spec.template.spec.containers[0].image", Live: `"app:v1"`, Desired: `"app:v2"`},
+			},
+		},
+		"ListItem": {
+			reason:   "The fields of an item of a List should be appended to those of the previous items, prefixed with its index.",
+			external: &external{structuredDiffEnabled: true},
+			diffs:    []v1alpha2.FieldDiff{{Path: "items[0].spec.replicas", Live: "1", Desired: "3"}},
+			prefix:   "items[1]",
+			last:     deployment(1, "app:v1"),
+			desired:  deployment(1, "app:v2"),
+			want: []v1alpha2.FieldDiff{
+				{Path: "items[0].spec.replicas", Live: "1", Desired: "3"},
+				{Path: "items[1].spec.template.spec.containers[0].image", Live: `"app:v1"`, Desired: `"app:v2"`},
+			},
+		},
+		"Unset": {
+			reason:   "A field that is only desired should be reported without a live value.",
+			external: &external{structuredDiffEnabled: true},
+			last:     &unstructured.Unstructured{Object: map[string]any{"metadata": map[string]any{"name": "app"}}},
+			desired:  &unstructured.Unstructured{Object: map[string]any{"metadata": map[string]any{"name": "app", "labels": map[string]any{"app": "a"}}}},
+			want:     []v1alpha2.FieldDiff{{Path: "metadata.labels", Desired: `{"app":"a"}`}},
+		},
+		"Redacted": {
+			reason:   "Secret data should be reported redacted if secrets are sanitized.",
+			external: &external{structuredDiffEnabled: true, sanitizeSecrets: true},
+			last:     secret("b2xk"),
+			desired:  secret("bmV3"),
+			want:     []v1alpha2.FieldDiff{{Path: "data.password", Live: `"` + redactedValue + `"`, Desired: `"` + redactedValue + `"`}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.external.fieldDiffs(tc.diffs, kubernetesObject(), tc.prefix, tc.last, tc.desired)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.fieldDiffs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typeDrifted is the condition reporting whether the remote object of an
+	// Object was changed out of band since it was last in sync.
+	typeDrifted xpv1.ConditionType = "Drifted"
+
+	reasonDriftDetected  xpv1.ConditionReason = "DriftDetected"
+	reasonDriftCorrected xpv1.ConditionReason = "DriftCorrected"
+)
+
+// setDriftDetected reports that the remote object of the supplied Object is
+// no longer in sync with a desired state it was already in sync with.
+func setDriftDetected(obj *v1alpha2.Object) {
+	now := metav1.Now()
+	obj.Status.AtProvider.LastDriftDetectedTime = &now
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeDrifted,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             reasonDriftDetected,
+		Message:            "Remote object was changed out of band",
+	})
+}
+
+// setDriftCorrected reports that the drift previously detected on the remote
+// object of the supplied Object, if any, was corrected.
+func setDriftCorrected(obj *v1alpha2.Object) {
+	if obj.GetCondition(typeDrifted).Status != v1.ConditionTrue {
+		return
+	}
+	now := metav1.Now()
+	obj.Status.AtProvider.LastDriftCorrectedTime = &now
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeDrifted,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             reasonDriftCorrected,
+	})
+	recordDriftCorrection(obj)
+}
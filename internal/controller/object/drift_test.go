@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object/fake"
+)
+
+func TestObserveDrift(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want corev1.ConditionStatus
+	}{
+		"DesiredStateChanged": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.LastAppliedHash = "stale"
+			}),
+			want: corev1.ConditionUnknown,
+		},
+		"RemoteObjectChanged": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				hash, _ := (&external{}).appliedStateHash(obj)
+				obj.Status.AtProvider.LastAppliedHash = hash
+				obj.Status.AtProvider.Manifest.Raw = []byte(`{"metadata":{"resourceVersion":"1"}}`)
+			}),
+			want: corev1.ConditionTrue,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource(func(res *unstructured.Unstructured) {
+								res.SetLabels(map[string]string{"changed": "out-of-band"})
+								res.SetResourceVersion("2")
+							})
+							return nil
+						}),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(_ context.Context, _ *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(_ context.Context, _ *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			}
+			if _, err := e.Observe(context.Background(), tc.obj); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, tc.obj.GetCondition(typeDrifted).Status); diff != "" {
+				t.Errorf("e.Observe(...): -want drifted, +got drifted: %s", diff)
+			}
+			if got := tc.obj.Status.AtProvider.LastDriftDetectedTime != nil; got != (tc.want == corev1.ConditionTrue) {
+				t.Errorf("e.Observe(...): unexpected lastDriftDetectedTime %v", tc.obj.Status.AtProvider.LastDriftDetectedTime)
+			}
+		})
+	}
+}
+
+func TestSetDriftCorrected(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want corev1.ConditionStatus
+	}{
+		"NotDrifted": {
+			obj:  kubernetesObject(),
+			want: corev1.ConditionUnknown,
+		},
+		"Drifted": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetConditions(xpv1.Condition{Type: typeDrifted, Status: corev1.ConditionTrue})
+			}),
+			want: corev1.ConditionFalse,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setDriftCorrected(tc.obj)
+			if diff := cmp.Diff(tc.want, tc.obj.GetCondition(typeDrifted).Status); diff != "" {
+				t.Errorf("setDriftCorrected(...): -want, +got: %s", diff)
+			}
+			if got := tc.obj.Status.AtProvider.LastDriftCorrectedTime != nil; got != (tc.want == corev1.ConditionFalse) {
+				t.Errorf("setDriftCorrected(...): unexpected lastDriftCorrectedTime %v", tc.obj.Status.AtProvider.LastDriftCorrectedTime)
+			}
+		})
+	}
+}
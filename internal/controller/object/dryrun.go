@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	msgDryRun = "Dry run: the target cluster is not changed, see status.atProvider.plan"
+)
+
+// observeDryRun publishes what the supplied Object would change on the target
+// cluster to its plan. Since nothing is to be created, updated or deleted, the
+// remote objects are reported as existing and up-to-date, unless the Object is
+// deleted.
+func (c *external) observeDryRun(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(obj) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	objs, manifests := []*v1alpha2.Object{obj}, []*unstructured.Unstructured{manifest}
+	if manifest.IsList() {
+		var err error
+		if objs, manifests, err = children(obj, manifest); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	plan := &v1alpha2.Plan{Action: v1alpha2.PlanActionNone}
+	diffs := make([]string, 0, len(manifests))
+	for i, m := range manifests {
+		action, diff, err := c.planItem(ctx, objs[i], m)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if action == v1alpha2.PlanActionNone {
+			continue
+		}
+		if plan.Action != v1alpha2.PlanActionCreate {
+			plan.Action = action
+		}
+		diffs = append(diffs, fmt.Sprintf("%s %s:\n%s", m.GetKind(), resourceName(m), diff))
+	}
+	plan.Diff = strings.Join(diffs, "\n")
+
+	obj.Status.AtProvider.Plan = plan
+	obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+	obj.SetConditions(xpv1.Unavailable().WithMessage(msgDryRun))
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// planItem returns the action the supplied Object would take on the remote
+// object of the supplied manifest, and the diff between the remote object and
+// its desired state.
+func (c *external) planItem(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (v1alpha2.PlanAction, string, error) {
+	current := manifest.DeepCopy()
+	err := c.client.Get(ctx, types.NamespacedName{Namespace: current.GetNamespace(), Name: current.GetName()}, current)
+	if kerrors.IsNotFound(err) {
+		return v1alpha2.PlanActionCreate, cmp.Diff(nil, c.redacted(manifest).Object), nil
+	}
+	if err != nil {
+		return "", "", errors.Wrap(err, errGetObject)
+	}
+
+	observedState, err := c.syncer.GetObservedState(ctx, obj, current)
+	if err != nil {
+		return "", "", errors.Wrap(err, errGetObservedState)
+	}
+	desiredState, err := c.syncer.GetDesiredState(ctx, obj, manifest)
+	if err != nil {
+		return "", "", errors.Wrap(err, errGetDesiredState)
+	}
+	upToDate, err := c.isUpToDate(obj, observedState, desiredState)
+	if err != nil || upToDate {
+		return v1alpha2.PlanActionNone, "", err
+	}
+	observedState, desiredState, err = c.comparableStates(obj, observedState, desiredState)
+	if err != nil {
+		return "", "", err
+	}
+	var observed map[string]any
+	if observedState != nil {
+		observed = c.redacted(observedState).Object
+	}
+	return v1alpha2.PlanActionUpdate, cmp.Diff(observed, c.redacted(desiredState).Object), nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object/fake"
+)
+
+func TestObserveDryRun(t *testing.T) {
+	dryRun := func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.DryRun = true
+	}
+	type want struct {
+		obs    managed.ExternalObservation
+		action v1alpha2.PlanAction
+		diff   string
+		err    error
+	}
+	cases := map[string]struct {
+		get func(obj client.Object) error
+		obj *v1alpha2.Object
+		want
+	}{
+		"Deleted": {
+			obj: kubernetesObject(dryRun, func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"FailedToGet": {
+			get: func(_ client.Object) error {
+				return errBoom
+			},
+			obj: kubernetesObject(dryRun),
+			want: want{
+				err: errors.Wrap(errBoom, errGetObject),
+			},
+		},
+		"WouldCreate": {
+			get: func(_ client.Object) error {
+				return kerrors.NewNotFound(schema.GroupResource{}, "")
+			},
+			obj: kubernetesObject(dryRun),
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action: v1alpha2.PlanActionCreate,
+				diff:   "Namespace " + externalResourceName,
+			},
+		},
+		"WouldUpdate": {
+			get: func(obj client.Object) error {
+				*obj.(*unstructured.Unstructured) = *externalResource(func(res *unstructured.Unstructured) {
+					res.SetLabels(map[string]string{"changed": "out-of-band"})
+				})
+				return nil
+			},
+			obj: kubernetesObject(dryRun),
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action: v1alpha2.PlanActionUpdate,
+				diff:   "out-of-band",
+			},
+		},
+		"UpToDate": {
+			get: func(obj client.Object) error {
+				*obj.(*unstructured.Unstructured) = *externalResource()
+				return nil
+			},
+			obj: kubernetesObject(dryRun),
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action: v1alpha2.PlanActionNone,
+			},
+		},
+		"WouldCreateListItem": {
+			get: func(obj client.Object) error {
+				if obj.GetName() == "two" {
+					return kerrors.NewNotFound(schema.GroupResource{}, "")
+				}
+				obj.(*unstructured.Unstructured).SetLabels(map[string]string{"changed": "out-of-band"})
+				return nil
+			},
+			obj: kubernetesObject(dryRun, func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one"}},{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"two"}}]}`)
+			}),
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action: v1alpha2.PlanActionCreate,
+				diff:   "Namespace two",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+							return tc.get(obj)
+						},
+						MockCreate: func(_ context.Context, _ client.Object, _ ...client.CreateOption) error {
+							t.Errorf("A dry run should not create remote objects")
+							return nil
+						},
+						MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+							t.Errorf("A dry run should not update remote objects")
+							return nil
+						},
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(_ context.Context, _ *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(_ context.Context, _ *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			}
+			got, err := e.Observe(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got: %s", diff)
+			}
+			if tc.want.action == "" {
+				return
+			}
+			plan := tc.obj.Status.AtProvider.Plan
+			if plan == nil {
+				t.Fatalf("e.Observe(...): want plan, got none")
+			}
+			if diff := cmp.Diff(tc.want.action, plan.Action); diff != "" {
+				t.Errorf("e.Observe(...): -want action, +got action: %s", diff)
+			}
+			if !strings.Contains(plan.Diff, tc.want.diff) || (tc.want.diff == "") != (plan.Diff == "") {
+				t.Errorf("e.Observe(...): want diff containing %q, got %q", tc.want.diff, plan.Diff)
+			}
+			if diff := cmp.Diff(xpv1.Unavailable().WithMessage(msgDryRun), tc.obj.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want ready, +got ready: %s", diff)
+			}
+		})
+	}
+}
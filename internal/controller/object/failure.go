@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typeFailed is the condition reporting whether the manifest of an
+	// Object failed to be applied with a permanent error, i.e. one that
+	// applying the same manifest again would not fix, at least not right
+	// away.
+	typeFailed xpv1.ConditionType = "Failed"
+
+	reasonInvalidManifest xpv1.ConditionReason = "InvalidManifest"
+	reasonImmutableField  xpv1.ConditionReason = "ImmutableField"
+	reasonForbidden       xpv1.ConditionReason = "Forbidden"
+	reasonQuotaExceeded   xpv1.ConditionReason = "QuotaExceeded"
+	reasonWebhookDenied   xpv1.ConditionReason = "WebhookDenied"
+	reasonApplied         xpv1.ConditionReason = "Applied"
+
+	// failedRetryInterval is how long a desired state that failed to be
+	// applied with a permanent error is not applied again, unless it changes.
+	// Some permanent errors can be fixed out of band, e.g. by granting the
+	// missing permissions or removing an admission policy.
+	failedRetryInterval = 10 * time.Minute
+	// quotaRetryInterval is how long a desired state that failed to be applied
+	// due to an exhausted ResourceQuota is not applied again, unless it
+	// changes. Quota is usually freed by other workloads, so this is shorter
+	// than failedRetryInterval.
+	quotaRetryInterval = time.Minute
+
+	// quotaExceededMessage is how the ResourceQuota admission plugin words
+	// its denials, e.g. pods "a" is forbidden: exceeded quota: compute,
+	// requested: pods=1, used: pods=10, limited: pods=10.
+	quotaExceededMessage = "exceeded quota: "
+	// webhookDeniedMessage and webhookCallFailedMessage are how the API
+	// server words the denials of admission webhooks, e.g. admission webhook
+	// "validate.example.org" denied the request: replicas must be odd, and
+	// the failures to call them, e.g. failed calling webhook
+	// "validate.example.org": connect: connection refused.
+	webhookDeniedMessage     = "denied the request"
+	webhookCallFailedMessage = "failed calling webhook "
+)
+
+// permanentFailure returns the reason of the supplied apply error, and true,
+// if applying the same manifest again would fail the same way.
+func permanentFailure(err error) (xpv1.ConditionReason, bool) {
+	switch {
+	case isWebhookUnavailable(err):
+		// The webhook may become available again any time, so applying
+		// is retried with the backoff of the reconciler.
+		return "", false
+	case isWebhookDenied(err):
+		return reasonWebhookDenied, true
+	case isImmutableError(err):
+		return reasonImmutableField, true
+	case kerrors.IsInvalid(err), kerrors.IsBadRequest(err):
+		return reasonInvalidManifest, true
+	case isQuotaExceeded(err):
+		return reasonQuotaExceeded, true
+	case kerrors.IsForbidden(err):
+		// Denied by RBAC or an admission webhook.
+		return reasonForbidden, true
+	}
+	return "", false
+}
+
+// isQuotaExceeded returns true if the supplied error is the denial of a create
+// by the ResourceQuota admission plugin.
+func isQuotaExceeded(err error) bool {
+	return kerrors.IsForbidden(err) && strings.Contains(err.Error(), quotaExceededMessage)
+}
+
+// isWebhookDenied returns true if the supplied error is the explicit denial of
+// a request by an admission webhook.
+func isWebhookDenied(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "admission webhook ") && strings.Contains(msg, webhookDeniedMessage)
+}
+
+// isWebhookUnavailable returns true if the supplied error is the failure of the
+// API server to call an admission webhook, e.g. because it is not running.
+func isWebhookUnavailable(err error) bool {
+	return strings.Contains(err.Error(), webhookCallFailedMessage)
+}
+
+// failureMessage returns the message of the condition reporting the supplied
+// permanent error applying the supplied manifest. Denials are prefixed with
+// the resource that was denied, since they may concern one item of a List.
+func (c *external) failureMessage(manifest *unstructured.Unstructured, reason xpv1.ConditionReason, err error) string {
+	msg := c.redactedError(manifest, CleanErr(err)).Error()
+	if reason != reasonQuotaExceeded && reason != reasonForbidden && reason != reasonWebhookDenied {
+		return msg
+	}
+	if i := strings.Index(msg, quotaExceededMessage); reason == reasonQuotaExceeded && i >= 0 {
+		// Keep the quota name and its requested, used and limited amounts.
+		msg = msg[i:]
+	}
+	if i := strings.Index(msg, "admission webhook "); reason == reasonWebhookDenied && i >= 0 {
+		// Keep the webhook name and its rejection message.
+		msg = msg[i:]
+	}
+	return fmt.Sprintf("%s %s: %s", manifest.GetKind(), resourceName(manifest), msg)
+}
+
+// resourceName returns the namespaced name of the supplied manifest, or its
+// name if it is cluster scoped.
+func resourceName(manifest *unstructured.Unstructured) string {
+	if ns := manifest.GetNamespace(); ns != "" {
+		return ns + "/" + manifest.GetName()
+	}
+	return manifest.GetName()
+}
+
+// recordFailure records the supplied error applying the supplied manifest on
+// the supplied Object, if it is permanent, so that the same desired state is
+// not applied again right away.
+func (c *external) recordFailure(obj *v1alpha2.Object, manifest *unstructured.Unstructured, err error) {
+	reason, permanent := permanentFailure(err)
+	if !permanent {
+		return
+	}
+	hash, herr := desiredStateHash(obj)
+	if herr != nil {
+		return
+	}
+	now := metav1.Now()
+	obj.Status.AtProvider.LastFailedHash = hash
+	obj.Status.AtProvider.LastFailedTime = &now
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeFailed,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            c.failureMessage(manifest, reason, err),
+	})
+}
+
+// failedPermanently returns true if the current desired state of the supplied
+// Object recently failed to be applied with a permanent error. A desired state
+// explicitly denied by an admission webhook is terminal, i.e. it is not applied
+// again until it changes.
+func failedPermanently(obj *v1alpha2.Object) bool {
+	last := obj.Status.AtProvider.LastFailedTime
+	interval := failedRetryInterval
+	switch obj.GetCondition(typeFailed).Reason {
+	case reasonQuotaExceeded:
+		interval = quotaRetryInterval
+	case reasonWebhookDenied:
+		interval = math.MaxInt64
+	}
+	if obj.Status.AtProvider.LastFailedHash == "" || last == nil || time.Since(last.Time) >= interval {
+		return false
+	}
+	hash, err := desiredStateHash(obj)
+	return err == nil && hash == obj.Status.AtProvider.LastFailedHash
+}
+
+// notFoundObservation returns the observation of the supplied Object whose
+// remote object does not exist. It is reported to exist, so that it is not
+// created again, if creating it recently failed with a permanent error, or if
+// it was applied before and the apply schedule of the Object does not allow
+// applying it again at the supplied time, e.g. because it was deleted out of
+// band outside the maintenance windows.
+func notFoundObservation(obj *v1alpha2.Object, now time.Time) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(obj) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if failedPermanently(obj) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if obj.Status.AtProvider.LastAppliedTime != nil {
+		deferred, err := deferApply(obj, now)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if deferred {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
+	return managed.ExternalObservation{ResourceExists: false}, nil
+}
+
+// clearFailure reports that the manifest of the supplied Object, which may
+// have failed to be applied before, was applied. A readiness timeout is only
+// cleared once the remote object becomes ready, or the desired state changes.
+func clearFailure(obj *v1alpha2.Object) {
+	obj.Status.AtProvider.LastFailedHash = ""
+	obj.Status.AtProvider.LastFailedTime = nil
+	if obj.GetCondition(typeFailed).Status != v1.ConditionTrue || readinessTimedOut(obj, false) {
+		return
+	}
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeFailed,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonApplied,
+	})
+}
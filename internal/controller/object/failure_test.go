@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+var (
+	errQuota              = kerrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "name", errors.New("exceeded quota: count, requested: count/namespaces=1, used: count/namespaces=10, limited: count/namespaces=10"))
+	errWebhookDenied      = &kerrors.StatusError{ErrStatus: metav1.Status{Status: metav1.StatusFailure, Code: 403, Reason: metav1.StatusReasonForbidden, Message: `admission webhook "validate.example.org" denied the request: replicas must be odd`}}
+	errWebhookUnavailable = kerrors.NewInternalError(errors.New(`failed calling webhook "validate.example.org": failed to call webhook: Post "https://validate.default.svc:443/validate": dial tcp 10.0.0.1:443: connect: connection refused`))
+)
+
+func TestPermanentFailure(t *testing.T) {
+	gk := schema.GroupKind{Kind: "Namespace"}
+	cases := map[string]struct {
+		err  error
+		want xpv1.ConditionReason
+	}{
+		"Transient": {
+			err: kerrors.NewServerTimeout(schema.GroupResource{}, "create", 1),
+		},
+		"Conflict": {
+			err: kerrors.NewConflict(schema.GroupResource{}, "name", errBoom),
+		},
+		"Invalid": {
+			err:  kerrors.NewInvalid(gk, "name", field.ErrorList{field.Required(field.NewPath("spec"), "")}),
+			want: reasonInvalidManifest,
+		},
+		"Immutable": {
+			err:  kerrors.NewInvalid(gk, "name", field.ErrorList{field.Invalid(field.NewPath("spec"), "", "field is immutable")}),
+			want: reasonImmutableField,
+		},
+		"Forbidden": {
+			err:  kerrors.NewForbidden(schema.GroupResource{}, "name", errBoom),
+			want: reasonForbidden,
+		},
+		"QuotaExceeded": {
+			err:  errQuota,
+			want: reasonQuotaExceeded,
+		},
+		"WebhookDenied": {
+			err:  errWebhookDenied,
+			want: reasonWebhookDenied,
+		},
+		"WebhookUnavailable": {
+			err: errWebhookUnavailable,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, permanent := permanentFailure(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("permanentFailure(...): -want, +got: %s", diff)
+			}
+			if permanent != (tc.want != "") {
+				t.Errorf("permanentFailure(...): want permanent %t, got %t", tc.want != "", permanent)
+			}
+		})
+	}
+}
+
+func TestFailedPermanently(t *testing.T) {
+	forbidden := kerrors.NewForbidden(schema.GroupResource{}, "name", errBoom)
+	cases := map[string]struct {
+		obj    func() *v1alpha2.Object
+		want   bool
+		status corev1.ConditionStatus
+	}{
+		"NeverFailed": {
+			obj:    func() *v1alpha2.Object { return kubernetesObject() },
+			status: corev1.ConditionUnknown,
+		},
+		"Transient": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), errBoom)
+				return obj
+			},
+			status: corev1.ConditionUnknown,
+		},
+		"Failed": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), forbidden)
+				return obj
+			},
+			want:   true,
+			status: corev1.ConditionTrue,
+		},
+		"DesiredStateChanged": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), forbidden)
+				obj.Spec.ForProvider.ProtectTarget = true
+				return obj
+			},
+			status: corev1.ConditionTrue,
+		},
+		"RetryIntervalElapsed": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), forbidden)
+				obj.Status.AtProvider.LastFailedTime = &metav1.Time{Time: time.Now().Add(-failedRetryInterval)}
+				return obj
+			},
+			status: corev1.ConditionTrue,
+		},
+		"QuotaExceeded": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), errQuota)
+				return obj
+			},
+			want:   true,
+			status: corev1.ConditionTrue,
+		},
+		"QuotaRetryIntervalElapsed": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), errQuota)
+				obj.Status.AtProvider.LastFailedTime = &metav1.Time{Time: time.Now().Add(-quotaRetryInterval)}
+				return obj
+			},
+			status: corev1.ConditionTrue,
+		},
+		"WebhookDenied": {
+			obj: func() *v1alpha2.Object {
+				obj := kubernetesObject()
+				(&external{}).recordFailure(obj, externalResource(), errWebhookDenied)
+				obj.Status.AtProvider.LastFailedTime = &metav1.Time{Time: time.Now().Add(-failedRetryInterval)}
+				return obj
+			},
+			want:   true,
+			status: corev1.ConditionTrue,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := tc.obj()
+			if got := failedPermanently(obj); got != tc.want {
+				t.Errorf("failedPermanently(...): want %t, got %t", tc.want, got)
+			}
+			if diff := cmp.Diff(tc.status, obj.GetCondition(typeFailed).Status); diff != "" {
+				t.Errorf("recordFailure(...): -want status, +got status: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFailureMessage(t *testing.T) {
+	namespaced := externalResource(func(res *unstructured.Unstructured) {
+		res.SetKind("ConfigMap")
+		res.SetNamespace("default")
+	})
+
+	cases := map[string]struct {
+		reason   string
+		manifest *unstructured.Unstructured
+		err      error
+		want     string
+	}{
+		"QuotaExceeded": {
+			reason:   "The quota details should be reported after the denied resource.",
+			manifest: externalResource(),
+			err:      errQuota,
+			want:     fmt.Sprintf("Namespace %s: exceeded quota: count, requested: count/namespaces=1, used: count/namespaces=10, limited: count/namespaces=10", externalResourceName),
+		},
+		"Forbidden": {
+			reason:   "The denial should be reported after the namespaced name of the denied resource.",
+			manifest: namespaced,
+			err:      kerrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, externalResourceName, errBoom),
+			want:     fmt.Sprintf("ConfigMap default/%s: configmaps %q is forbidden: boom", externalResourceName, externalResourceName),
+		},
+		"WebhookDenied": {
+			reason:   "The rejection message of the webhook should be reported after the denied resource.",
+			manifest: namespaced,
+			err:      errWebhookDenied,
+			want:     fmt.Sprintf(`ConfigMap default/%s: admission webhook "validate.example.org" denied the request: replicas must be odd`, externalResourceName),
+		},
+		"Invalid": {
+			reason:   "Other errors should be reported as they are.",
+			manifest: externalResource(),
+			err:      kerrors.NewBadRequest("boom"),
+			want:     "boom",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reason, _ := permanentFailure(tc.err)
+			got := (&external{}).failureMessage(tc.manifest, reason, tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nfailureMessage(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNotFoundObservation(t *testing.T) {
+	failed := func(obj *v1alpha2.Object) {
+		(&external{}).recordFailure(obj, externalResource(), kerrors.NewForbidden(schema.GroupResource{}, "name", errBoom))
+	}
+	applied := func(obj *v1alpha2.Object) {
+		obj.Status.AtProvider.LastAppliedTime = ptr.To(metav1.Now())
+	}
+	// A maintenance window that never opens.
+	closed := func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.ApplySchedule = &v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{{Start: "00:00"}}}
+	}
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want managed.ExternalObservation
+	}{
+		"NotFailed": {
+			obj:  kubernetesObject(),
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+		"NeverApplied": {
+			obj:  kubernetesObject(closed),
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+		"OutsideMaintenanceWindow": {
+			obj:  kubernetesObject(applied, closed),
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"IntervalNotElapsed": {
+			obj: kubernetesObject(applied, func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ApplySchedule = &v1alpha2.ApplySchedule{Interval: &metav1.Duration{Duration: time.Hour}}
+			}),
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"AppliedAndDeleted": {
+			obj: kubernetesObject(applied, closed, func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+		"Failed": {
+			obj:  kubernetesObject(failed),
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"FailedAndDeleted": {
+			obj: kubernetesObject(failed, func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := notFoundObservation(tc.obj, time.Now())
+			if err != nil {
+				t.Fatalf("notFoundObservation(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("notFoundObservation(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestClearFailure(t *testing.T) {
+	obj := kubernetesObject()
+	(&external{}).recordFailure(obj, externalResource(), kerrors.NewForbidden(schema.GroupResource{}, "name", errBoom))
+	clearFailure(obj)
+	if diff := cmp.Diff(corev1.ConditionFalse, obj.GetCondition(typeFailed).Status); diff != "" {
+		t.Errorf("clearFailure(...): -want status, +got status: %s", diff)
+	}
+	if obj.Status.AtProvider.LastFailedHash != "" || obj.Status.AtProvider.LastFailedTime != nil {
+		t.Errorf("clearFailure(...): want no last failure, got %q at %v", obj.Status.AtProvider.LastFailedHash, obj.Status.AtProvider.LastFailedTime)
+	}
+}
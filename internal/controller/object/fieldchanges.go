@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetChangedField = "cannot get changed field %s"
+
+	reasonRemoteFieldChanged event.Reason = "RemoteFieldChanged"
+)
+
+// recordFieldChanges records an event on the supplied Object for every field
+// it asks to be notified of whose value changed between the remote object as
+// last observed and the supplied remote object, if the Object only observes
+// it. Nothing is recorded on the first observation of the remote object.
+func (c *external) recordFieldChanges(obj *v1alpha2.Object, current *unstructured.Unstructured) error {
+	paths := obj.Spec.ForProvider.NotifyFieldChanges
+	if len(paths) == 0 || !observeOnly(obj) {
+		return nil
+	}
+	raw, err := observedManifest(obj)
+	if err != nil || len(raw) == 0 {
+		return err
+	}
+	previous := map[string]any{}
+	if err := json.Unmarshal(raw, &previous); err != nil {
+		return errors.Wrap(err, errUnmarshalObserved)
+	}
+	before := fieldpath.Pave(previous)
+	after := fieldpath.Pave(c.redacted(current).UnstructuredContent())
+
+	for _, p := range paths {
+		b, err := fieldValue(before, p)
+		if err != nil {
+			return err
+		}
+		a, err := fieldValue(after, p)
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		c.recorder.Event(obj, event.Normal(reasonRemoteFieldChanged, fieldChangeMessage(p, b, a)))
+	}
+	return nil
+}
+
+// fieldValue returns the value of the supplied field of the supplied paved
+// remote object, or nil if it is not set.
+func fieldValue(p *fieldpath.Paved, path string) (any, error) {
+	v, err := p.GetValue(path)
+	if fieldpath.IsNotFound(err) {
+		return nil, nil
+	}
+	return v, errors.Wrapf(err, errGetChangedField, path)
+}
+
+// fieldChangeMessage describes the change of the value of the supplied field,
+// with the values JSON encoded and unset values described as such.
+func fieldChangeMessage(path string, before, after any) string {
+	return fmt.Sprintf("%s changed from %s to %s", path, describeValue(before), describeValue(after))
+}
+
+func describeValue(v any) string {
+	if v == nil {
+		return "<unset>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRecordFieldChanges(t *testing.T) {
+	observed := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"p"},"spec":{"nodeName":"a"},"status":{"phase":"Pending"}}`)
+	current := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]any{"name": "p"},
+			"spec":       map[string]any{"nodeName": "a"},
+			"status": map[string]any{
+				"phase":      "Running",
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+			},
+		}}
+	}
+	notifying := func(obj *v1alpha2.Object) {
+		obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+		obj.Spec.ForProvider.NotifyFieldChanges = []string{"status.phase", "spec.nodeName", "status.conditions"}
+		obj.Status.AtProvider.Manifest.Raw = observed
+	}
+
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   []string
+	}{
+		"NotNotifying": {
+			reason: "No events should be recorded if the Object does not ask for them.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+				obj.Status.AtProvider.Manifest.Raw = observed
+			}),
+		},
+		"Managed": {
+			reason: "No events should be recorded if the Object manages its remote object.",
+			obj: kubernetesObject(notifying, func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionAll})
+			}),
+		},
+		"FirstObservation": {
+			reason: "No events should be recorded the first time the remote object is observed.",
+			obj: kubernetesObject(notifying, func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.Manifest.Raw = nil
+			}),
+		},
+		"Changed": {
+			reason: "An event should be recorded for every field whose value changed.",
+			obj:    kubernetesObject(notifying),
+			want: []string{
+				`status.phase changed from "Pending" to "Running"`,
+				`status.conditions changed from <unset> to [{"status":"True","type":"Ready"}]`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			events := &recordedEvents{}
+			e := &external{recorder: events}
+			if err := e.recordFieldChanges(tc.obj, current()); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, ev := range *events {
+				if ev.Type != event.TypeNormal || ev.Reason != reasonRemoteFieldChanged {
+					t.Errorf("\n%s\ne.recordFieldChanges(...): unexpected event %v", tc.reason, ev)
+				}
+				got = append(got, ev.Message)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.recordFieldChanges(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
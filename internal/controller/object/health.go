@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// flapWindow is the window within which the flaps of an Object, i.e.
+	// the alternations of its reconciles between success and failure, are
+	// counted.
+	flapWindow = 30 * time.Minute
+	// flapThreshold is the number of flaps within the flap window from which
+	// an Object is flapping.
+	flapThreshold = 5
+)
+
+var (
+	flaps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "flaps_total",
+		Help:      "The number of times the reconciles of Objects alternated between success and failure, by managed GVK.",
+	}, gvkLabels)
+
+	quarantines = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "quarantined_reconciles_total",
+		Help:      "The number of reconciles of flapping Objects that were deferred, by managed GVK.",
+	}, gvkLabels)
+)
+
+// recordReconcileHealth records the outcome of the previous reconcile of the
+// supplied Object, as reported by its Synced condition, in its reconcile
+// health. It must be called once per reconcile, before the outcome of the
+// current reconcile is reported.
+func recordReconcileHealth(obj *v1alpha2.Object, now time.Time) {
+	synced := obj.GetCondition(xpv1.TypeSynced)
+	if synced.Status == v1.ConditionUnknown || synced.Reason == xpv1.ReasonReconcilePaused {
+		// The Object was not reconciled yet, or not since it was paused.
+		return
+	}
+	h := obj.Status.AtProvider.ReconcileHealth
+	if h == nil {
+		h = &v1alpha2.ReconcileHealth{}
+	}
+
+	failed := synced.Status == v1.ConditionFalse
+	if failed && conversionUnavailable(obj) {
+		// The outage of a conversion webhook is outside the control of
+		// the author of the manifest.
+		return
+	}
+	previouslyFailed := h.FailureStreak > 0
+	if failed {
+		h.FailureStreak++
+	} else {
+		h.FailureStreak = 0
+	}
+
+	if h.FlapWindowStart != nil && now.Sub(h.FlapWindowStart.Time) >= flapWindow {
+		h.Flaps, h.FlapWindowStart = 0, nil
+	}
+	if failed != previouslyFailed {
+		if h.FlapWindowStart == nil {
+			h.FlapWindowStart = &metav1.Time{Time: now}
+		}
+		h.Flaps++
+		flaps.With(labelsFor(managedGVK(obj))).Inc()
+	}
+	h.Flapping = h.Flaps >= flapThreshold
+
+	if *h == (v1alpha2.ReconcileHealth{}) {
+		h = nil
+	}
+	obj.Status.AtProvider.ReconcileHealth = h
+}
+
+// flapping returns true if the reconciles of the supplied Object are flapping.
+func flapping(obj *v1alpha2.Object) bool {
+	h := obj.Status.AtProvider.ReconcileHealth
+	return h != nil && h.Flapping
+}
+
+// A quarantineReconciler reconciles flapping Objects no more often than at
+// the quarantine interval, so that a few pathological Objects do not take a
+// disproportionate share of the reconcile workers.
+type quarantineReconciler struct {
+	inner    reconcile.Reconciler
+	kube     client.Reader
+	interval time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// reconciled is when flapping Objects were last reconciled.
+	reconciled map[types.NamespacedName]time.Time
+}
+
+func newQuarantineReconciler(inner reconcile.Reconciler, kube client.Reader, interval time.Duration) *quarantineReconciler {
+	return &quarantineReconciler{
+		inner:      inner,
+		kube:       kube,
+		interval:   interval,
+		now:        time.Now,
+		reconciled: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// Reconcile the supplied request with the inner reconciler, unless its Object
+// is flapping and was reconciled within the quarantine interval. Any error
+// getting the Object is left to the inner reconciler to deal with.
+func (r *quarantineReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if r.interval <= 0 {
+		return r.inner.Reconcile(ctx, req)
+	}
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, req.NamespacedName, obj); err != nil || !flapping(obj) {
+		r.forget(req.NamespacedName)
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	last, ok := r.reconciled[req.NamespacedName]
+	if ok && now.Before(last.Add(r.interval)) {
+		r.mu.Unlock()
+		quarantines.With(labelsFor(managedGVK(obj))).Inc()
+		return reconcile.Result{RequeueAfter: last.Add(r.interval).Sub(now)}, nil
+	}
+	r.reconciled[req.NamespacedName] = now
+	r.mu.Unlock()
+
+	res, err := r.inner.Reconcile(ctx, req)
+	if err == nil && (res.Requeue || res.RequeueAfter < r.interval) {
+		res = reconcile.Result{RequeueAfter: r.interval}
+	}
+	return res, err
+}
+
+func (r *quarantineReconciler) forget(name types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.reconciled, name)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRecordReconcileHealth(t *testing.T) {
+	now := time.Now()
+	start := &metav1.Time{Time: now.Add(-time.Minute)}
+	failed := xpv1.ReconcileError(errors.New("boom"))
+
+	cases := map[string]struct {
+		reason string
+		synced *xpv1.Condition
+		health *v1alpha2.ReconcileHealth
+		want   *v1alpha2.ReconcileHealth
+
+		conversionUnavailable bool
+	}{
+		"NotReconciled": {
+			reason: "Nothing should be recorded before the Object was reconciled.",
+		},
+		"Healthy": {
+			reason: "Nothing should be recorded while the reconciles succeed.",
+			synced: ptr.To(xpv1.ReconcileSuccess()),
+		},
+		"Failing": {
+			reason: "A failure should extend the failure streak.",
+			synced: &failed,
+			health: &v1alpha2.ReconcileHealth{FailureStreak: 2, Flaps: 1, FlapWindowStart: start},
+			want:   &v1alpha2.ReconcileHealth{FailureStreak: 3, Flaps: 1, FlapWindowStart: start},
+		},
+		"Recovered": {
+			reason: "A success after a failure should end the failure streak and count as a flap.",
+			synced: ptr.To(xpv1.ReconcileSuccess()),
+			health: &v1alpha2.ReconcileHealth{FailureStreak: 3, Flaps: 1, FlapWindowStart: start},
+			want:   &v1alpha2.ReconcileHealth{Flaps: 2, FlapWindowStart: start},
+		},
+		"Flapping": {
+			reason: "An Object with too many flaps within the window should be flapping.",
+			synced: &failed,
+			health: &v1alpha2.ReconcileHealth{Flaps: flapThreshold - 1, FlapWindowStart: start},
+			want:   &v1alpha2.ReconcileHealth{FailureStreak: 1, Flaps: flapThreshold, FlapWindowStart: start, Flapping: true},
+		},
+		"WindowElapsed": {
+			reason: "Flaps should be counted again once the flap window elapsed.",
+			synced: &failed,
+			health: &v1alpha2.ReconcileHealth{Flaps: flapThreshold, FlapWindowStart: &metav1.Time{Time: now.Add(-flapWindow)}, Flapping: true},
+			want:   &v1alpha2.ReconcileHealth{FailureStreak: 1, Flaps: 1, FlapWindowStart: &metav1.Time{Time: now}},
+		},
+		"ConversionUnavailable": {
+			reason:                "A failure due to an unavailable conversion webhook should not be counted.",
+			synced:                &failed,
+			health:                &v1alpha2.ReconcileHealth{FailureStreak: 2},
+			want:                  &v1alpha2.ReconcileHealth{FailureStreak: 2},
+			conversionUnavailable: true,
+		},
+		"Paused": {
+			reason: "Nothing should be recorded for a paused Object.",
+			synced: ptr.To(xpv1.ReconcilePaused()),
+			health: &v1alpha2.ReconcileHealth{FailureStreak: 2},
+			want:   &v1alpha2.ReconcileHealth{FailureStreak: 2},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				if tc.synced != nil {
+					obj.SetConditions(*tc.synced)
+				}
+				if tc.conversionUnavailable {
+					obj.SetConditions(xpv1.Condition{Type: typeConversionUnavailable, Status: "True", Reason: reasonConversionWebhookUnavailable})
+				}
+				obj.Status.AtProvider.ReconcileHealth = tc.health
+			})
+			recordReconcileHealth(obj, now)
+			if diff := cmp.Diff(tc.want, obj.Status.AtProvider.ReconcileHealth); diff != "" {
+				t.Errorf("\n%s\nrecordReconcileHealth(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQuarantineReconciler(t *testing.T) {
+	interval := 10 * time.Minute
+	now := time.Now()
+	flappingObject := func(f bool) client.Reader {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*v1alpha2.Object).Status.AtProvider.ReconcileHealth = &v1alpha2.ReconcileHealth{Flapping: f}
+			return nil
+		})}
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "flapper"}}
+
+	type want struct {
+		result     reconcile.Result
+		reconciled bool
+	}
+	cases := map[string]struct {
+		reason     string
+		kube       client.Reader
+		interval   time.Duration
+		reconciled map[types.NamespacedName]time.Time
+		want       want
+	}{
+		"Disabled": {
+			reason: "Objects should be reconciled right away if they are not quarantined.",
+			kube:   flappingObject(true),
+			want:   want{result: reconcile.Result{RequeueAfter: time.Second}, reconciled: true},
+		},
+		"NotFlapping": {
+			reason:     "Objects that are not flapping should be reconciled right away.",
+			kube:       flappingObject(false),
+			interval:   interval,
+			reconciled: map[types.NamespacedName]time.Time{req.NamespacedName: now},
+			want:       want{result: reconcile.Result{RequeueAfter: time.Second}, reconciled: true},
+		},
+		"FirstReconcile": {
+			reason:   "A flapping Object should be reconciled, and then requeued after the quarantine interval.",
+			kube:     flappingObject(true),
+			interval: interval,
+			want:     want{result: reconcile.Result{RequeueAfter: interval}, reconciled: true},
+		},
+		"Quarantined": {
+			reason:     "A flapping Object reconciled within the quarantine interval should be deferred until it elapses.",
+			kube:       flappingObject(true),
+			interval:   interval,
+			reconciled: map[types.NamespacedName]time.Time{req.NamespacedName: now.Add(-time.Minute)},
+			want:       want{result: reconcile.Result{RequeueAfter: interval - time.Minute}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reconciled := false
+			inner := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{RequeueAfter: time.Second}, nil
+			})
+			r := newQuarantineReconciler(inner, tc.kube, tc.interval)
+			r.now = func() time.Time { return now }
+			if tc.reconciled != nil {
+				r.reconciled = tc.reconciled
+			}
+			res, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.result, res); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reconciled, reconciled); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want reconciled, +got reconciled:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errParseHook             = "cannot parse hook manifest"
+	errHookName              = "hook manifest must have a name"
+	errGetHook               = "cannot get hook object"
+	errCreateHook            = "cannot create hook object"
+	errDeleteHook            = "cannot delete hook object"
+	errWaitForPostCreateHook = "waiting for the post-create hook to complete"
+	errPostCreateHookFailed  = "post-create hook failed"
+	errWaitForPreDeleteHook  = "waiting for the pre-delete hook to complete"
+	errPreDeleteHookFailed   = "pre-delete hook failed"
+	errHookTimedOut          = "timed out after %s"
+
+	msgPostCreateHookPaused   = "The post-create hook is run once the pause window of provider config %q ends"
+	msgPostCreateHookDeferred = "The post-create hook is run in the next maintenance window"
+)
+
+// observePostCreateHook runs the post-create hook of the supplied Object, if
+// it has one that did not succeed yet, and keeps the Object unavailable until
+// it does. Objects that only observe their remote objects have no hooks run,
+// and deleted Objects no post-create hooks. Like other changes, hooks are not
+// run during pause windows of the provider config or outside the maintenance
+// windows of the Object.
+func (c *external) observePostCreateHook(ctx context.Context, obj *v1alpha2.Object) error {
+	if obj.Spec.Hooks == nil || obj.Spec.Hooks.PostCreate == nil || observeOnly(obj) || meta.WasDeleted(obj) {
+		return nil
+	}
+	var last *v1alpha2.HookObservation
+	if obj.Status.AtProvider.Hooks != nil {
+		last = obj.Status.AtProvider.Hooks.PostCreate
+	}
+	if last != nil && last.Phase == v1alpha2.HookPhaseSucceeded {
+		return nil
+	}
+
+	now := time.Now()
+	paused, err := c.paused(now)
+	if err != nil {
+		return err
+	}
+	if paused {
+		obj.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgPostCreateHookPaused, obj.GetProviderConfigReference().Name)))
+		return nil
+	}
+	in, err := inApplyWindow(obj, now)
+	if err != nil {
+		return err
+	}
+	if !in {
+		obj.SetConditions(xpv1.Unavailable().WithMessage(msgPostCreateHookDeferred))
+		return nil
+	}
+
+	o, err := c.runHook(ctx, obj.Spec.Hooks.PostCreate, last)
+	if err != nil {
+		return err
+	}
+	if obj.Status.AtProvider.Hooks == nil {
+		obj.Status.AtProvider.Hooks = &v1alpha2.HooksObservation{}
+	}
+	obj.Status.AtProvider.Hooks.PostCreate = o
+
+	switch o.Phase {
+	case v1alpha2.HookPhaseSucceeded:
+	case v1alpha2.HookPhaseFailed:
+		obj.SetConditions(xpv1.Unavailable().WithMessage(errors.Wrap(errors.New(o.Message), errPostCreateHookFailed).Error()))
+	default:
+		obj.SetConditions(xpv1.Unavailable().WithMessage(errWaitForPostCreateHook))
+	}
+	return nil
+}
+
+// runPreDeleteHook runs the pre-delete hook of the supplied Object, if it has
+// one that did not succeed yet, and returns an error until the remote object
+// can be deleted.
+func (c *external) runPreDeleteHook(ctx context.Context, obj *v1alpha2.Object) error {
+	if obj.Spec.Hooks == nil || obj.Spec.Hooks.PreDelete == nil {
+		return nil
+	}
+	h := obj.Spec.Hooks.PreDelete
+	var last *v1alpha2.HookObservation
+	if obj.Status.AtProvider.Hooks != nil {
+		last = obj.Status.AtProvider.Hooks.PreDelete
+	}
+	if last != nil && (last.Phase == v1alpha2.HookPhaseSucceeded || last.Phase == v1alpha2.HookPhaseFailed && h.FailurePolicy == v1alpha2.HookFailurePolicyIgnore) {
+		return nil
+	}
+
+	o, err := c.runHook(ctx, &h.Hook, last)
+	if err != nil {
+		return err
+	}
+	if o.Phase == v1alpha2.HookPhaseRunning && h.Timeout != nil && time.Since(o.StartTime.Time) > h.Timeout.Duration {
+		o.Phase = v1alpha2.HookPhaseFailed
+		o.Message = fmt.Sprintf(errHookTimedOut, h.Timeout.Duration)
+	}
+	if obj.Status.AtProvider.Hooks == nil {
+		obj.Status.AtProvider.Hooks = &v1alpha2.HooksObservation{}
+	}
+	obj.Status.AtProvider.Hooks.PreDelete = o
+
+	switch o.Phase {
+	case v1alpha2.HookPhaseSucceeded:
+		return nil
+	case v1alpha2.HookPhaseFailed:
+		if h.FailurePolicy == v1alpha2.HookFailurePolicyIgnore {
+			c.logger.Debug("Ignoring failed pre-delete hook", "message", o.Message)
+			return nil
+		}
+		return errors.Wrap(errors.New(o.Message), errPreDeleteHookFailed)
+	default:
+		return errors.New(errWaitForPreDeleteHook)
+	}
+}
+
+// runHook creates the hook object of the supplied hook, unless it exists, and
+// returns its observed state. The start time of the last observed state is
+// kept while the hook object exists.
+func (c *external) runHook(ctx context.Context, h *v1alpha2.Hook, last *v1alpha2.HookObservation) (*v1alpha2.HookObservation, error) {
+	manifest, err := parseHook(h)
+	if err != nil {
+		return nil, err
+	}
+	current := manifest.DeepCopy()
+	err = c.client.Get(ctx, types.NamespacedName{Namespace: manifest.GetNamespace(), Name: manifest.GetName()}, current)
+	if kerrors.IsNotFound(err) {
+		if err := c.client.Create(ctx, manifest); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, errors.Wrap(CleanErr(err), errCreateHook)
+		}
+		return &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning, StartTime: ptr.To(metav1.Now())}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetHook)
+	}
+	o := hookObservation(current)
+	o.StartTime = ptr.To(metav1.Now())
+	if last != nil && last.StartTime != nil {
+		o.StartTime = last.StartTime
+	}
+	return o, nil
+}
+
+// deleteHooks deletes the hook objects of the supplied Object, along with the
+// objects they own, e.g. the Pods of a Job.
+func (c *external) deleteHooks(ctx context.Context, obj *v1alpha2.Object) error {
+	if obj.Spec.Hooks == nil {
+		return nil
+	}
+	hooks := make([]*v1alpha2.Hook, 0, 2)
+	if obj.Spec.Hooks.PostCreate != nil {
+		hooks = append(hooks, obj.Spec.Hooks.PostCreate)
+	}
+	if obj.Spec.Hooks.PreDelete != nil {
+		hooks = append(hooks, &obj.Spec.Hooks.PreDelete.Hook)
+	}
+	for _, h := range hooks {
+		manifest, err := parseHook(h)
+		if err != nil {
+			return err
+		}
+		if err := resource.IgnoreNotFound(c.client.Delete(ctx, manifest, client.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+			return errors.Wrap(err, errDeleteHook)
+		}
+	}
+	return nil
+}
+
+func parseHook(h *v1alpha2.Hook) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(h.Manifest.Raw, u); err != nil {
+		return nil, errors.Wrap(err, errParseHook)
+	}
+	if u.GetName() == "" {
+		return nil, errors.New(errHookName)
+	}
+	return u, nil
+}
+
+// hookObservation returns the observed state of the supplied hook object. A
+// Job completes once it has a Complete condition with status True, and a Pod
+// once it is in the Succeeded phase. Objects of any other kind complete once
+// they exist.
+func hookObservation(u *unstructured.Unstructured) *v1alpha2.HookObservation {
+	p := fieldpath.Pave(u.Object)
+	switch u.GroupVersionKind().GroupKind().String() {
+	case "Job.batch":
+		conditioned := xpv1.ConditionedStatus{}
+		_ = p.GetValueInto("status", &conditioned)
+		if failed := conditioned.GetCondition("Failed"); failed.Status == v1.ConditionTrue {
+			return &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: failed.Message}
+		}
+		if conditioned.GetCondition("Complete").Status != v1.ConditionTrue {
+			return &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning}
+		}
+	case "Pod":
+		phase, _ := p.GetString("status.phase")
+		switch v1.PodPhase(phase) {
+		case v1.PodSucceeded:
+		case v1.PodFailed:
+			msg, _ := p.GetString("status.message")
+			return &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: msg}
+		default:
+			return &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning}
+		}
+	}
+	return &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded}
+}
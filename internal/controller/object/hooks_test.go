@@ -0,0 +1,427 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const testHookManifest = `{"apiVersion":"batch/v1","kind":"Job","metadata":{"name":"smoke-test","namespace":"default"}}`
+
+func withPreDeleteHook(timeout time.Duration, policy v1alpha2.HookFailurePolicy) kubernetesObjectModifier {
+	return func(obj *v1alpha2.Object) {
+		obj.Spec.Hooks = &v1alpha2.Hooks{PreDelete: &v1alpha2.PreDeleteHook{
+			Hook:          v1alpha2.Hook{Manifest: runtime.RawExtension{Raw: []byte(testHookManifest)}},
+			Timeout:       &metav1.Duration{Duration: timeout},
+			FailurePolicy: policy,
+		}}
+	}
+}
+
+func withPreDeleteHookObservation(o *v1alpha2.HookObservation) kubernetesObjectModifier {
+	return func(obj *v1alpha2.Object) {
+		obj.Status.AtProvider.Hooks = &v1alpha2.HooksObservation{PreDelete: o}
+	}
+}
+
+func jobStatus(condition string) func(obj client.Object) error {
+	return func(obj client.Object) error {
+		obj.(*unstructured.Unstructured).Object["status"] = map[string]any{
+			"conditions": []any{map[string]any{"type": condition, "status": "True", "message": "backoff limit reached"}},
+		}
+		return nil
+	}
+}
+
+func withPostCreateHook(manifest string) kubernetesObjectModifier {
+	return func(obj *v1alpha2.Object) {
+		obj.Spec.Hooks = &v1alpha2.Hooks{PostCreate: &v1alpha2.Hook{Manifest: runtime.RawExtension{Raw: []byte(manifest)}}}
+	}
+}
+
+func TestObservePostCreateHook(t *testing.T) {
+	type want struct {
+		hooks *v1alpha2.HooksObservation
+		ready xpv1.Condition
+		err   error
+	}
+	noGet := func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+		t.Errorf("The post-create hook should not be run")
+		return nil
+	}
+	cases := map[string]struct {
+		client       client.Client
+		pauseWindows []kconfig.MaintenanceWindow
+		obj          *v1alpha2.Object
+		want
+	}{
+		"NoHook": {
+			obj: kubernetesObject(),
+			want: want{
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+			},
+		},
+		"Paused": {
+			client:       &test.MockClient{MockGet: noGet},
+			pauseWindows: []kconfig.MaintenanceWindow{{Start: "00:00", Duration: metav1.Duration{Duration: 24 * time.Hour}}},
+			obj:          kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				ready: xpv1.Unavailable().WithMessage(fmt.Sprintf(msgPostCreateHookPaused, providerName)),
+			},
+		},
+		"OutsideMaintenanceWindow": {
+			client: &test.MockClient{MockGet: noGet},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest), func(obj *v1alpha2.Object) {
+				// A maintenance window that never opens.
+				obj.Spec.ForProvider.ApplySchedule = &v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{{Start: "00:00"}}}
+			}),
+			want: want{
+				ready: xpv1.Unavailable().WithMessage(msgPostCreateHookDeferred),
+			},
+		},
+		"ObserveOnly": {
+			obj: kubernetesObject(withPostCreateHook(testHookManifest), func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+			}),
+			want: want{
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+			},
+		},
+		"AlreadySucceeded": {
+			client: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+					t.Errorf("A hook that succeeded should not be observed again")
+					return nil
+				},
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest), func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.Hooks = &v1alpha2.HooksObservation{PostCreate: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded}}
+			}),
+			want: want{
+				hooks: &v1alpha2.HooksObservation{PostCreate: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded}},
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+			},
+		},
+		"NoName": {
+			obj: kubernetesObject(withPostCreateHook(`{"apiVersion":"batch/v1","kind":"Job"}`)),
+			want: want{
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+				err:   errors.New(errHookName),
+			},
+		},
+		"FailedToGet": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+				err:   errors.Wrap(errBoom, errGetHook),
+			},
+		},
+		"FailedToCreate": {
+			client: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				MockCreate: test.NewMockCreateFn(errBoom),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+				err:   errors.Wrap(errBoom, errCreateHook),
+			},
+		},
+		"Created": {
+			client: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				hooks: &v1alpha2.HooksObservation{PostCreate: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning}},
+				ready: xpv1.Unavailable().WithMessage(errWaitForPostCreateHook),
+			},
+		},
+		"Running": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				hooks: &v1alpha2.HooksObservation{PostCreate: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning}},
+				ready: xpv1.Unavailable().WithMessage(errWaitForPostCreateHook),
+			},
+		},
+		"Failed": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, jobStatus("Failed")),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				hooks: &v1alpha2.HooksObservation{PostCreate: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: "backoff limit reached"}},
+				ready: xpv1.Unavailable().WithMessage(errPostCreateHookFailed + ": backoff limit reached"),
+			},
+		},
+		"Succeeded": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, jobStatus("Complete")),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: want{
+				hooks: &v1alpha2.HooksObservation{PostCreate: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded}},
+				ready: xpv1.Condition{Type: xpv1.TypeReady, Status: "Unknown"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: resource.ClientApplicator{Client: tc.client}, pauseWindows: tc.pauseWindows}
+			gotErr := e.observePostCreateHook(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.observePostCreateHook(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.hooks, tc.obj.Status.AtProvider.Hooks, cmpopts.IgnoreFields(v1alpha2.HookObservation{}, "StartTime")); diff != "" {
+				t.Errorf("e.observePostCreateHook(...): -want hooks, +got hooks: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ready, tc.obj.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("e.observePostCreateHook(...): -want ready, +got ready: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRunPreDeleteHook(t *testing.T) {
+	noGet := func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+		t.Errorf("The pre-delete hook should not be observed")
+		return nil
+	}
+	started := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	type want struct {
+		hook *v1alpha2.HookObservation
+		err  error
+	}
+	cases := map[string]struct {
+		client client.Client
+		obj    *v1alpha2.Object
+		want
+	}{
+		"NoHook": {
+			obj: kubernetesObject(),
+		},
+		"AlreadySucceeded": {
+			client: &test.MockClient{MockGet: noGet},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyAbort),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded},
+			},
+		},
+		"AlreadyFailedAndIgnored": {
+			client: &test.MockClient{MockGet: noGet},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyIgnore),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed},
+			},
+		},
+		"Created": {
+			client: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyAbort)),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning},
+				err:  errors.New(errWaitForPreDeleteHook),
+			},
+		},
+		"Running": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			obj: kubernetesObject(withPreDeleteHook(2*time.Hour, v1alpha2.HookFailurePolicyAbort),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning, StartTime: started})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning},
+				err:  errors.New(errWaitForPreDeleteHook),
+			},
+		},
+		"TimedOut": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyAbort),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning, StartTime: started})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: "timed out after 1m0s"},
+				err:  errors.Wrap(errors.New("timed out after 1m0s"), errPreDeleteHookFailed),
+			},
+		},
+		"TimedOutAndIgnored": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyIgnore),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning, StartTime: started})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: "timed out after 1m0s"},
+			},
+		},
+		"Failed": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, jobStatus("Failed")),
+			},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyAbort),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning, StartTime: started})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: "backoff limit reached"},
+				err:  errors.Wrap(errors.New("backoff limit reached"), errPreDeleteHookFailed),
+			},
+		},
+		"Succeeded": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, jobStatus("Complete")),
+			},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyAbort),
+				withPreDeleteHookObservation(&v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning, StartTime: started})),
+			want: want{
+				hook: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: resource.ClientApplicator{Client: tc.client}, logger: logging.NewNopLogger()}
+			gotErr := e.runPreDeleteHook(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.runPreDeleteHook(...): -want error, +got error: %s", diff)
+			}
+			var got *v1alpha2.HookObservation
+			if tc.obj.Status.AtProvider.Hooks != nil {
+				got = tc.obj.Status.AtProvider.Hooks.PreDelete
+			}
+			if diff := cmp.Diff(tc.want.hook, got, cmpopts.IgnoreFields(v1alpha2.HookObservation{}, "StartTime")); diff != "" {
+				t.Errorf("e.runPreDeleteHook(...): -want hook, +got hook: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHookObservation(t *testing.T) {
+	cases := map[string]struct {
+		hook string
+		want *v1alpha2.HookObservation
+	}{
+		"OtherKind": {
+			hook: `{"apiVersion":"v1","kind":"ConfigMap"}`,
+			want: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded},
+		},
+		"PodRunning": {
+			hook: `{"apiVersion":"v1","kind":"Pod","status":{"phase":"Running"}}`,
+			want: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning},
+		},
+		"PodSucceeded": {
+			hook: `{"apiVersion":"v1","kind":"Pod","status":{"phase":"Succeeded"}}`,
+			want: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded},
+		},
+		"PodFailed": {
+			hook: `{"apiVersion":"v1","kind":"Pod","status":{"phase":"Failed","message":"exit code 1"}}`,
+			want: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseFailed, Message: "exit code 1"},
+		},
+		"JobRunning": {
+			hook: `{"apiVersion":"batch/v1","kind":"Job","status":{"active":1}}`,
+			want: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseRunning},
+		},
+		"JobComplete": {
+			hook: `{"apiVersion":"batch/v1","kind":"Job","status":{"conditions":[{"type":"Complete","status":"True"}]}}`,
+			want: &v1alpha2.HookObservation{Phase: v1alpha2.HookPhaseSucceeded},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			if err := u.UnmarshalJSON([]byte(tc.hook)); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, hookObservation(u)); diff != "" {
+				t.Errorf("hookObservation(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteHooks(t *testing.T) {
+	cases := map[string]struct {
+		client client.Client
+		obj    *v1alpha2.Object
+		want   error
+	}{
+		"NoHooks": {
+			obj: kubernetesObject(),
+		},
+		"NotFound": {
+			client: &test.MockClient{
+				MockDelete: test.NewMockDeleteFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+			obj: kubernetesObject(withPostCreateHook(testHookManifest)),
+		},
+		"FailedToDelete": {
+			client: &test.MockClient{
+				MockDelete: test.NewMockDeleteFn(errBoom),
+			},
+			obj:  kubernetesObject(withPostCreateHook(testHookManifest)),
+			want: errors.Wrap(errBoom, errDeleteHook),
+		},
+		"Success": {
+			client: &test.MockClient{
+				MockDelete: test.NewMockDeleteFn(nil),
+			},
+			obj: kubernetesObject(withPreDeleteHook(time.Minute, v1alpha2.HookFailurePolicyAbort), func(obj *v1alpha2.Object) {
+				obj.Spec.Hooks.PostCreate = &v1alpha2.Hook{Manifest: runtime.RawExtension{Raw: []byte(testHookManifest)}}
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: resource.ClientApplicator{Client: tc.client}}
+			got := e.deleteHooks(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("e.deleteHooks(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
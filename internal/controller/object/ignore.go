@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	errIgnoreField = "cannot ignore field %q"
+)
+
+// ignorePresetFields are the field paths ignored by each IgnorePreset.
+var ignorePresetFields = map[v1alpha2.IgnorePreset][]string{
+	v1alpha2.IgnorePresetHPAManaged: {
+		"spec.replicas",
+	},
+	v1alpha2.IgnorePresetServiceAllocated: {
+		"spec.clusterIP",
+		"spec.clusterIPs",
+		"spec.ipFamilies",
+		"spec.ipFamilyPolicy",
+		"spec.healthCheckNodePort",
+		"spec.ports[*].nodePort",
+	},
+	v1alpha2.IgnorePresetWebhookInjected: {
+		"webhooks[*].clientConfig.caBundle",
+		"spec.conversion.webhook.clientConfig.caBundle",
+		"spec.caBundle",
+	},
+	v1alpha2.IgnorePresetIstioInjected: {
+		"metadata.annotations[sidecar.istio.io/status]",
+		"metadata.labels[security.istio.io/tlsMode]",
+		"metadata.labels[service.istio.io/canonical-name]",
+		"metadata.labels[service.istio.io/canonical-revision]",
+		"spec.template.metadata.annotations[sidecar.istio.io/status]",
+	},
+}
+
+// ignoredFields returns the field paths that should not be considered when
+// deciding whether the remote object of the supplied Object is up-to-date.
+func ignoredFields(obj *v1alpha2.Object) []string {
+	paths := make([]string, 0, len(obj.Spec.ForProvider.IgnoreFields))
+	for _, p := range obj.Spec.ForProvider.IgnorePresets {
+		paths = append(paths, ignorePresetFields[p]...)
+	}
+	return append(paths, obj.Spec.ForProvider.IgnoreFields...)
+}
+
+// defaultIgnoreFields returns the field paths ignored on the remote objects of
+// all Objects using a provider config, as configured for the provider and in
+// the supplied object defaults of the provider config.
+func defaultIgnoreFields(provider []string, d *kconfig.ObjectDefaults) []string {
+	if d == nil {
+		return provider
+	}
+	return append(slices.Clip(provider), d.IgnoreFields...)
+}
+
+// withoutFields returns a copy of the supplied object without the supplied
+// field paths, which may contain wildcards.
+func withoutFields(u *unstructured.Unstructured, paths []string) (*unstructured.Unstructured, error) {
+	if u == nil || len(paths) == 0 {
+		return u, nil
+	}
+	out := u.DeepCopy()
+	p := fieldpath.Pave(out.Object)
+	for _, path := range paths {
+		expanded, err := p.ExpandWildcards(path)
+		if fieldpath.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errIgnoreField, path)
+		}
+		// Delete in reverse order, so that deleting an array item does not
+		// shift the indexes of the remaining ones.
+		for i := len(expanded) - 1; i >= 0; i-- {
+			if err := p.DeleteField(expanded[i]); err != nil {
+				return nil, errors.Wrapf(err, errIgnoreField, path)
+			}
+		}
+	}
+	out.Object = p.UnstructuredContent()
+	return out, nil
+}
+
+// withoutLiveOnlyFields returns a copy of the supplied live object without the
+// fields that do not exist in the supplied desired object. Array items are only
+// compared one by one if both arrays have the same length, otherwise they are
+// kept as they are.
+func withoutLiveOnlyFields(live, desired *unstructured.Unstructured) *unstructured.Unstructured {
+	if live == nil || desired == nil {
+		return live
+	}
+	pruned, _ := prunedTo(live.DeepCopy().Object, desired.Object).(map[string]any)
+	return &unstructured.Unstructured{Object: pruned}
+}
+
+func prunedTo(live, desired any) any {
+	switch d := desired.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			return live
+		}
+		out := make(map[string]any, len(d))
+		for k, dv := range d {
+			if lv, ok := l[k]; ok {
+				out[k] = prunedTo(lv, dv)
+			}
+		}
+		return out
+	case []any:
+		l, ok := live.([]any)
+		if !ok || len(l) != len(d) {
+			return live
+		}
+		out := make([]any, len(l))
+		for i := range l {
+			out[i] = prunedTo(l[i], d[i])
+		}
+		return out
+	}
+	return live
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestIgnoredFields(t *testing.T) {
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.IgnorePresets = []v1alpha2.IgnorePreset{v1alpha2.IgnorePresetHPAManaged}
+		obj.Spec.ForProvider.IgnoreFields = []string{"metadata.labels"}
+	})
+	want := []string{"spec.replicas", "metadata.labels"}
+	if diff := cmp.Diff(want, ignoredFields(obj)); diff != "" {
+		t.Errorf("ignoredFields(...): -want, +got: %s", diff)
+	}
+}
+
+func TestDefaultIgnoreFields(t *testing.T) {
+	provider := []string{"metadata.annotations[kubectl.kubernetes.io/last-applied-configuration]"}
+	cases := map[string]struct {
+		reason   string
+		defaults *kconfig.ObjectDefaults
+		want     []string
+	}{
+		"ProviderOnly": {
+			reason: "The fields ignored for the provider should be ignored if the provider config has no object defaults.",
+			want:   provider,
+		},
+		"ProviderConfig": {
+			reason:   "The fields ignored by the provider config should be ignored in addition to those ignored for the provider.",
+			defaults: &kconfig.ObjectDefaults{IgnoreFields: []string{"spec.replicas"}},
+			want:     []string{"metadata.annotations[kubectl.kubernetes.io/last-applied-configuration]", "spec.replicas"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, defaultIgnoreFields(provider, tc.defaults)); diff != "" {
+				t.Errorf("\n%s\ndefaultIgnoreFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithoutFields(t *testing.T) {
+	type args struct {
+		u     string
+		paths []string
+	}
+	type want struct {
+		out string
+		err bool
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NoPaths": {
+			args: args{
+				u: `{"spec":{"replicas":3}}`,
+			},
+			want: want{
+				out: `{"spec":{"replicas":3}}`,
+			},
+		},
+		"MissingPath": {
+			args: args{
+				u:     `{"spec":{"replicas":3}}`,
+				paths: []string{"spec.clusterIP", "spec.ports[*].nodePort"},
+			},
+			want: want{
+				out: `{"spec":{"replicas":3}}`,
+			},
+		},
+		"Wildcards": {
+			args: args{
+				u:     `{"spec":{"clusterIP":"10.0.0.1","ports":[{"port":80,"nodePort":30080},{"port":443,"nodePort":30443}]}}`,
+				paths: ignorePresetFields[v1alpha2.IgnorePresetServiceAllocated],
+			},
+			want: want{
+				out: `{"spec":{"ports":[{"port":80},{"port":443}]}}`,
+			},
+		},
+		"ArrayItems": {
+			args: args{
+				u:     `{"spec":{"args":["a","b","c"]}}`,
+				paths: []string{"spec.args[*]"},
+			},
+			want: want{
+				out: `{"spec":{"args":[]}}`,
+			},
+		},
+		"InvalidPath": {
+			args: args{
+				u:     `{"spec":{"replicas":3}}`,
+				paths: []string{"spec[.replicas"},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(tc.args.u), &u.Object); err != nil {
+				t.Fatal(err)
+			}
+			got, err := withoutFields(u, tc.args.paths)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("withoutFields(...): -want error, +got error: %s: %v", diff, err)
+			}
+			if tc.want.err {
+				return
+			}
+			want := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(tc.want.out), &want.Object); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("withoutFields(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithoutLiveOnlyFields(t *testing.T) {
+	cases := map[string]struct {
+		live    string
+		desired string
+		want    string
+	}{
+		"LiveOnlyFieldsRemoved": {
+			live:    `{"metadata":{"name":"a","uid":"1","annotations":{"a":"b","c":"d"}},"spec":{"ports":[{"port":80,"nodePort":30080}]},"status":{"phase":"Active"}}`,
+			desired: `{"metadata":{"name":"a","annotations":{"a":"b"}},"spec":{"ports":[{"port":80}]}}`,
+			want:    `{"metadata":{"name":"a","annotations":{"a":"b"}},"spec":{"ports":[{"port":80}]}}`,
+		},
+		"DesiredOnlyFieldsKeptMissing": {
+			live:    `{"metadata":{"name":"a"}}`,
+			desired: `{"metadata":{"name":"a","labels":{"a":"b"}}}`,
+			want:    `{"metadata":{"name":"a"}}`,
+		},
+		"ArraysOfDifferentLengthKept": {
+			live:    `{"spec":{"ports":[{"port":80,"nodePort":30080},{"port":443}]}}`,
+			desired: `{"spec":{"ports":[{"port":80}]}}`,
+			want:    `{"spec":{"ports":[{"port":80,"nodePort":30080},{"port":443}]}}`,
+		},
+		"DifferentTypesKept": {
+			live:    `{"spec":{"value":"a"}}`,
+			desired: `{"spec":{"value":{"a":"b"}}}`,
+			want:    `{"spec":{"value":"a"}}`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			live, desired, want := &unstructured.Unstructured{}, &unstructured.Unstructured{}, &unstructured.Unstructured{}
+			for _, f := range []struct {
+				s string
+				u *unstructured.Unstructured
+			}{{tc.live, live}, {tc.desired, desired}, {tc.want, want}} {
+				if err := json.Unmarshal([]byte(f.s), &f.u.Object); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if diff := cmp.Diff(want, withoutLiveOnlyFields(live, desired)); diff != "" {
+				t.Errorf("withoutLiveOnlyFields(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -65,11 +65,10 @@ func IndexByProviderGVK(o client.Object) []string {
 		keys = append(keys, refKeyProviderGVK(providerConfig, refKind, group, version))
 	}
 
-	// Index the desired object.
-	// We don't expect errors here, as the parseManifest function is already called
-	// in the reconciler and the desired object already validated.
-	d, _ := parseManifest(obj)
-	keys = append(keys, refKeyProviderGVK(obj.Spec.ProviderConfigReference.Name, d.GetKind(), d.GroupVersionKind().Group, d.GroupVersionKind().Version)) // unification is done by the informer.
+	// Index the desired objects.
+	for _, d := range indexedManifests(obj) {
+		keys = append(keys, refKeyProviderGVK(targetName(obj), d.GetKind(), d.GroupVersionKind().Group, d.GroupVersionKind().Version)) // unification is done by the informer.
+	}
 
 	// unification is done by the informer.
 	return keys
@@ -97,11 +96,10 @@ func IndexByProviderNamespacedNameGVK(o client.Object) []string {
 		keys = append(keys, refKeyProviderNamespacedNameGVK(providerConfig, refNamespace, refName, refKind, refAPIVersion))
 	}
 
-	// Index the desired object.
-	// We don't expect errors here, as the parseManifest function is already called
-	// in the reconciler and the desired object already validated.
-	d, _ := parseManifest(obj)
-	keys = append(keys, refKeyProviderNamespacedNameGVK(obj.Spec.ProviderConfigReference.Name, d.GetNamespace(), d.GetName(), d.GetKind(), d.GetAPIVersion())) // unification is done by the informer.
+	// Index the desired objects.
+	for _, d := range indexedManifests(obj) {
+		keys = append(keys, refKeyProviderNamespacedNameGVK(targetName(obj), d.GetNamespace(), d.GetName(), d.GetKind(), d.GetAPIVersion())) // unification is done by the informer.
+	}
 
 	return keys
 }
@@ -123,9 +121,9 @@ func enqueueObjectsForReferences(ca cache.Cache, log logging.Logger) func(ctx co
 		}
 		// queue those Objects for reconciliation
 		for _, o := range objects.Items {
-			// We only enqueue the Object if it has the Watch flag set to true.
-			// Not every referencing Object watches the referenced resource.
-			if o.Spec.Watch {
+			// We only enqueue the Object if it watches its resources. Not
+			// every referencing Object watches the referenced resource.
+			if watched(&o) {
 				log.Info("Enqueueing Object because referenced resource changed", "name", o.GetName(), "referencedGVK", rGVK.String(), "referencedName", ev.Object.GetName(), "providerConfig", pc)
 				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: o.GetName()}})
 			}
@@ -69,6 +69,7 @@ type gvkWithConfig struct {
 
 type resourceCache struct {
 	cache    cache.Cache
+	informer cache.Informer
 	cancelFn context.CancelFunc
 }
 
@@ -199,6 +200,7 @@ func (i *resourceInformers) WatchResources(rc *rest.Config, providerConfig strin
 		}
 		i.resourceCaches[gvkWithConfig{providerConfig: providerConfig, gvk: gvk}] = resourceCache{
 			cache:    ca,
+			informer: inf,
 			cancelFn: cancelFn,
 		}
 		i.lock.Unlock()
@@ -212,6 +214,36 @@ func (i *resourceInformers) WatchResources(rc *rest.Config, providerConfig strin
 	}
 }
 
+// CachedReader returns a reader of the resources of the given GVK on the
+// cluster of the given providerConfig, served from the cache of their watch.
+// It returns false if the resources are not watched or the cache is not synced
+// yet, since the cache would report resources as not existing until then.
+func (i *resourceInformers) CachedReader(providerConfig string, gvk schema.GroupVersionKind) (client.Reader, bool) {
+	i.lock.RLock()
+	rc, found := i.resourceCaches[gvkWithConfig{providerConfig: providerConfig, gvk: gvk}]
+	i.lock.RUnlock()
+	if !found || !rc.informer.HasSynced() {
+		return nil, false
+	}
+	return rc.cache, true
+}
+
+// StopWatches stops the resource informers of the cluster of the given
+// providerConfig. They are started again against its current cluster by the
+// next reconciles of the Objects watching them.
+func (i *resourceInformers) StopWatches(providerConfig string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for gc, ca := range i.resourceCaches {
+		if gc.providerConfig != providerConfig {
+			continue
+		}
+		ca.cancelFn()
+		delete(i.resourceCaches, gc)
+		i.log.Info("Stopped resource watch", "provider config", gc.providerConfig, "gvk", gc.gvk)
+	}
+}
+
 // cleanupResourceInformers garbage collects resource informers that are
 // no longer referenced by any Object. Ideally, all resource informers should
 // stopped/cleaned up when the Object is deleted. However, in practice, this
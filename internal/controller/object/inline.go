@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+// inlineProviderConfig returns a provider config reading the credentials of
+// the target cluster of the supplied Object from its inline kubeconfig, or nil
+// if it uses a provider config. Objects reading the same kubeconfig share its
+// UID, and hence the schema caches of their target cluster.
+func inlineProviderConfig(obj *v1alpha2.Object) *apisv1alpha1.ProviderConfig {
+	ref := obj.Spec.ForProvider.KubeconfigSecretRef
+	if ref == nil {
+		return nil
+	}
+	return &apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: targetName(obj),
+			UID:  types.UID(targetName(obj)),
+		},
+		Spec: kconfig.ProviderConfigSpec{
+			Credentials: kconfig.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: ref.DeepCopy(),
+				},
+			},
+		},
+	}
+}
+
+// usesInlineKubeconfig returns true if the supplied Object targets the
+// cluster of its inline kubeconfig rather than that of its provider config.
+func usesInlineKubeconfig(obj *v1alpha2.Object) bool {
+	return obj.Spec.ForProvider.KubeconfigSecretRef != nil
+}
+
+// targetName returns the name the target cluster of the supplied Object is
+// known by to watches and their indexes, i.e. the name of its provider config,
+// or the Secret key of its inline kubeconfig. The latter contains slashes, so
+// it never collides with the name of a provider config.
+func targetName(obj *v1alpha2.Object) string {
+	if ref := obj.Spec.ForProvider.KubeconfigSecretRef; ref != nil {
+		return fmt.Sprintf("secret/%s/%s/%s", ref.Namespace, ref.Name, ref.Key)
+	}
+	if ref := obj.GetProviderConfigReference(); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestInlineProviderConfig(t *testing.T) {
+	ref := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "cluster", Namespace: "default"},
+		Key:             "kubeconfig",
+	}
+	inline := func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.KubeconfigSecretRef = ref
+	}
+
+	type want struct {
+		target string
+		spec   *kconfig.ProviderConfigSpec
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   want
+	}{
+		"ProviderConfig": {
+			reason: "An Object without an inline kubeconfig should target the cluster of its provider config.",
+			obj:    kubernetesObject(),
+			want:   want{target: providerName},
+		},
+		"InlineKubeconfig": {
+			reason: "An Object with an inline kubeconfig should target the cluster of the kubeconfig, under a name no provider config can have.",
+			obj:    kubernetesObject(inline),
+			want: want{
+				target: "secret/default/cluster/kubeconfig",
+				spec: &kconfig.ProviderConfigSpec{
+					Credentials: kconfig.ProviderCredentials{
+						Source:                    xpv1.CredentialsSourceSecret,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: ref},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want.target, targetName(tc.obj)); diff != "" {
+				t.Errorf("\n%s\ntargetName(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			var spec *kconfig.ProviderConfigSpec
+			if pc := inlineProviderConfig(tc.obj); pc != nil {
+				spec = &pc.Spec
+				if string(pc.GetUID()) != tc.want.target {
+					t.Errorf("\n%s\ninlineProviderConfig(...): want UID %q, got %q", tc.reason, tc.want.target, pc.GetUID())
+				}
+			}
+			if diff := cmp.Diff(tc.want.spec, spec); diff != "" {
+				t.Errorf("\n%s\ninlineProviderConfig(...): -want spec, +got spec:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// msgChildrenReady explains why an Object some of whose remote objects
+	// are not ready is ready nonetheless.
+	msgChildrenReady = "%d of %d remote objects are ready"
+	// msgChildStale explains why a remote object of an Object is not synced
+	// once it was removed from the List manifest of the Object.
+	msgChildStale = "removed from the manifest, to be pruned"
+
+	errPruneChild = "cannot prune %s %s that was removed from the manifest"
+)
+
+// observeList observes every item of the supplied List manifest. The remote
+// objects are considered to exist only if all of them exist, and to be
+// up-to-date only if all of them are up-to-date.
+func (c *external) observeList(ctx context.Context, obj *v1alpha2.Object, list *unstructured.Unstructured) (managed.ExternalObservation, error) {
+	objs, manifests, err := children(obj, list)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Skipping the comparison of unchanged objects relies on the resource
+	// version of a single remote object, so it is not done for lists.
+	obj.Status.AtProvider.LastAppliedHash = ""
+	obj.Status.AtProvider.ObservedResourceVersion = ""
+
+	obj.Status.AtProvider.Diff = nil
+
+	observed := make([]*unstructured.Unstructured, 0, len(manifests))
+	synced := make([]bool, 0, len(manifests))
+	var diffs []v1alpha2.FieldDiff
+	upToDate := true
+	for i, manifest := range manifests {
+		if c.shouldWatch(obj) {
+			c.kindObserver.WatchResources(c.rest, targetName(obj), manifest.GroupVersionKind())
+		}
+
+		current := manifest.DeepCopy()
+		err := c.remoteReader(obj, manifest.GroupVersionKind()).Get(ctx, types.NamespacedName{
+			Namespace: current.GetNamespace(),
+			Name:      current.GetName(),
+		}, current)
+		if kerrors.IsNotFound(err) {
+			obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+			return notFoundObservation(obj, time.Now())
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
+		}
+		if err := checkOwnership(obj, current); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if err := c.releaseRemote(ctx, obj, current); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		c.recordObservedVersion(current)
+
+		c.logger.Debug("Observed resource", "gvk", current.GroupVersionKind().String(), "namespace", current.GetNamespace(), "name", current.GetName(), "resourceVersion", current.GetResourceVersion())
+
+		observedState, err := c.syncer.GetObservedState(ctx, objs[i], current)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetObservedState)
+		}
+		desiredState, err := c.syncer.GetDesiredState(ctx, objs[i], manifest)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetDesiredState)
+		}
+		itemUpToDate, err := c.isUpToDate(objs[i], observedState, desiredState)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if !itemUpToDate {
+			if diffs, err = c.fieldDiffs(diffs, objs[i], fmt.Sprintf("items[%d]", i), observedState, desiredState); err != nil {
+				return managed.ExternalObservation{}, err
+			}
+		}
+		upToDate = upToDate && itemUpToDate
+		observed = append(observed, current)
+		synced = append(synced, itemUpToDate)
+	}
+	obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+	obj.Status.AtProvider.Diff = diffs
+	setOwned(obj)
+
+	// Remote objects removed from the manifest stay recorded until they are
+	// pruned, which the Object is updated for.
+	stale := c.staleChildren(obj, manifests)
+	if err := c.setAtProviderList(obj, objs, observed, synced); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	obj.Status.AtProvider.Children = append(obj.Status.AtProvider.Children, stale...)
+	return c.handleObservation(ctx, obj, upToDate && len(stale) == 0)
+}
+
+// syncList syncs every item of the supplied List manifest. If update is true,
+// an item that cannot be updated due to an immutable field change is deleted
+// to be recreated, if the update policy of the Object asks for it.
+func (c *external) syncList(ctx context.Context, obj *v1alpha2.Object, list *unstructured.Unstructured, update bool) error {
+	// The field conflicts of the items are collected as they are synced.
+	obj.Status.AtProvider.FieldConflicts = nil
+	objs, manifests, err := children(obj, list)
+	if err != nil {
+		return err
+	}
+
+	errSync := errCreateObject
+	if update {
+		errSync = errApplyObject
+	}
+
+	observed := make([]*unstructured.Unstructured, 0, len(manifests))
+	for i, manifest := range manifests {
+		sync := c.syncer.SyncResource
+		if update {
+			sync = c.updateResource
+		}
+		current, err := sync(ctx, objs[i], manifest)
+		for _, fc := range objs[i].Status.AtProvider.FieldConflicts {
+			fc.Field = fmt.Sprintf("items[%d]%s", i, fc.Field)
+			obj.Status.AtProvider.FieldConflicts = append(obj.Status.AtProvider.FieldConflicts, fc)
+		}
+		if err != nil && update && obj.Spec.ForProvider.UpdatePolicy == v1alpha2.UpdatePolicyRecreateOnImmutableError && isImmutableError(err) {
+			// The remaining items are synced once this one is recreated.
+			c.logger.Debug("Recreating resource due to an immutable field change", "error", CleanErr(err))
+			return errors.Wrap(resource.IgnoreNotFound(c.client.Delete(ctx, manifest, client.PropagationPolicy(metav1.DeletePropagationBackground))), errRecreateObject)
+		}
+		if err != nil {
+			c.recordFailure(obj, manifest, err)
+			return errors.Wrap(c.redactedError(manifest, CleanErr(err)), errSync)
+		}
+		c.logger.Debug("Synced resource", "resource", c.redacted(current))
+		observed = append(observed, current)
+	}
+	if err := c.pruneChildren(ctx, obj, c.staleChildren(obj, manifests)); err != nil {
+		return err
+	}
+	if update {
+		recordAdoption(obj)
+	}
+	setApplied(obj)
+	if err := recordRevision(obj, c.declaredManifest, c.sanitizeSecrets); err != nil {
+		return err
+	}
+	var before []byte
+	if update {
+		if before, err = observedManifest(obj); err != nil {
+			return err
+		}
+	}
+	if err := c.setAtProviderList(obj, objs, observed, nil); err != nil {
+		return err
+	}
+	return c.recordApplies(ctx, obj, before)
+}
+
+// staleChildren returns the remote objects recorded as children of the
+// supplied Object that are not among the supplied manifests, i.e. that were
+// removed from its List manifest since, marked as such. Children only tell
+// apart by their group, kind, namespace and name, so that those whose API
+// version was migrated are not stale. Objects that do not delete their remote
+// objects have no stale children.
+func (c *external) staleChildren(obj *v1alpha2.Object, manifests []*unstructured.Unstructured) []v1alpha2.ChildObservation {
+	if !c.deletesRemote(obj) {
+		return nil
+	}
+	id := func(apiVersion, kind, namespace, name string) string {
+		gv, _ := schema.ParseGroupVersion(apiVersion)
+		return gv.Group + "/" + kind + "/" + namespace + "/" + name
+	}
+	current := make(map[string]bool, len(manifests))
+	for _, m := range manifests {
+		current[id(m.GetAPIVersion(), m.GetKind(), m.GetNamespace(), m.GetName())] = true
+	}
+	var stale []v1alpha2.ChildObservation
+	for _, ch := range obj.Status.AtProvider.Children {
+		if current[id(ch.APIVersion, ch.Kind, ch.Namespace, ch.Name)] {
+			continue
+		}
+		stale = append(stale, v1alpha2.ChildObservation{
+			APIVersion: ch.APIVersion,
+			Kind:       ch.Kind,
+			Namespace:  ch.Namespace,
+			Name:       ch.Name,
+			Message:    msgChildStale,
+		})
+	}
+	return stale
+}
+
+// pruneChildren deletes the supplied stale children of the supplied Object
+// from the target cluster. Remote objects owned by other Objects are left
+// alone.
+func (c *external) pruneChildren(ctx context.Context, obj *v1alpha2.Object, stale []v1alpha2.ChildObservation) error {
+	for _, ch := range stale {
+		current := &unstructured.Unstructured{}
+		current.SetAPIVersion(ch.APIVersion)
+		current.SetKind(ch.Kind)
+		current.SetNamespace(ch.Namespace)
+		current.SetName(ch.Name)
+		err := c.client.Get(ctx, types.NamespacedName{Namespace: ch.Namespace, Name: ch.Name}, current)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, errGetObject)
+		}
+		if owner := current.GetLabels()[labelKeyObjectUID]; owner != "" && owner != string(obj.GetUID()) {
+			continue
+		}
+		if err := c.unprotectTarget(ctx, current); err != nil {
+			return err
+		}
+		if err := c.client.Delete(ctx, current); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, errPruneChild, ch.Kind, resourceName(current))
+		}
+		c.logger.Debug("Pruned resource removed from the manifest", "gvk", current.GroupVersionKind().String(), "namespace", ch.Namespace, "name", ch.Name)
+	}
+	return nil
+}
+
+// deleteList deletes every item of the supplied List manifest.
+func (c *external) deleteList(ctx context.Context, obj *v1alpha2.Object, list *unstructured.Unstructured) error {
+	_, manifests, err := children(obj, list)
+	if err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		if targetProtected(obj) {
+			if err := c.unprotectTarget(ctx, manifest); err != nil {
+				return err
+			}
+		}
+		if err := resource.IgnoreNotFound(c.client.Delete(ctx, manifest)); err != nil {
+			return errors.Wrap(err, errDeleteObject)
+		}
+	}
+	return nil
+}
+
+// setAtProviderList sets the observed state of the supplied Object to a List
+// of the supplied observed items, and records the state of each of them, i.e.
+// whether it is synced, as reported by the supplied flags or if there are
+// none, and whether it is ready. Unless the Object is ready on successful
+// create, it is ready according to the readiness of its items and its child
+// policy.
+func (c *external) setAtProviderList(obj *v1alpha2.Object, objs []*v1alpha2.Object, observed []*unstructured.Unstructured, synced []bool) error {
+	items := make([]any, 0, len(observed))
+	for _, o := range observed {
+		c.sanitizeObserved(o)
+		items = append(items, observedForStatus(obj, o).Object)
+	}
+	l := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}}
+	if err := c.setObservedManifest(obj, l); err != nil {
+		return err
+	}
+
+	children := make([]v1alpha2.ChildObservation, len(observed))
+	for i, o := range observed {
+		children[i] = v1alpha2.ChildObservation{
+			APIVersion: o.GetAPIVersion(),
+			Kind:       o.GetKind(),
+			Namespace:  o.GetNamespace(),
+			Name:       o.GetName(),
+			Synced:     synced == nil || synced[i],
+		}
+	}
+	obj.Status.AtProvider.Children = children
+
+	if p := obj.Spec.Readiness.Policy; p == v1alpha2.ReadinessPolicySuccessfulCreate || p == "" {
+		// will be handled by c.handleObservation method
+		for i := range children {
+			children[i].Ready = children[i].Synced
+		}
+		return nil
+	}
+	var notReady *xpv1.Condition
+	readyCount := 0
+	for i, o := range observed {
+		if err := c.updateConditionFromObserved(objs[i], o); err != nil {
+			return err
+		}
+		cond := objs[i].GetCondition(xpv1.TypeReady)
+		if cond.Status == v1.ConditionTrue {
+			children[i].Ready = true
+			readyCount++
+			continue
+		}
+		children[i].Message = cond.Message
+		if children[i].Message == "" {
+			children[i].Message = string(cond.Reason)
+		}
+		if notReady == nil {
+			notReady = &cond
+		}
+	}
+	switch {
+	case notReady == nil:
+		obj.SetConditions(xpv1.Available())
+	case obj.Spec.Readiness.ChildPolicy == v1alpha2.ChildReadinessPolicyAnyReady && readyCount > 0:
+		obj.SetConditions(xpv1.Available().WithMessage(fmt.Sprintf(msgChildrenReady, readyCount, len(children))))
+	default:
+		obj.SetConditions(*notReady)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestSetAtProviderList(t *testing.T) {
+	child := func(name, ready string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+			"status": map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": ready}},
+			},
+		}}
+	}
+	observation := func(name string, synced, ready bool, message string) v1alpha2.ChildObservation {
+		return v1alpha2.ChildObservation{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: name, Synced: synced, Ready: ready, Message: message}
+	}
+	deriving := func(p v1alpha2.ChildReadinessPolicy) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.Readiness.Policy = v1alpha2.ReadinessPolicyDeriveFromObject
+			obj.Spec.Readiness.ChildPolicy = p
+		}
+	}
+
+	type args struct {
+		obj      *v1alpha2.Object
+		observed []*unstructured.Unstructured
+		synced   []bool
+	}
+	type want struct {
+		children []v1alpha2.ChildObservation
+		ready    xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SuccessfulCreate": {
+			reason: "Children should be ready once synced if the Object is ready on successful create, which is left to be handled later.",
+			args: args{
+				obj:      kubernetesObject(),
+				observed: []*unstructured.Unstructured{child("a", "False"), child("b", "False")},
+				synced:   []bool{true, false},
+			},
+			want: want{
+				children: []v1alpha2.ChildObservation{observation("a", true, true, ""), observation("b", false, false, "")},
+				ready:    xpv1.Condition{Type: xpv1.TypeReady, Status: corev1.ConditionUnknown},
+			},
+		},
+		"AllReady": {
+			reason: "The Object should be ready if all of its children are ready.",
+			args: args{
+				obj:      kubernetesObject(deriving("")),
+				observed: []*unstructured.Unstructured{child("a", "True"), child("b", "True")},
+			},
+			want: want{
+				children: []v1alpha2.ChildObservation{observation("a", true, true, ""), observation("b", true, true, "")},
+				ready:    xpv1.Available(),
+			},
+		},
+		"NotAllReady": {
+			reason: "The Object should not be ready if one of its children is not ready with the AllReady child policy.",
+			args: args{
+				obj:      kubernetesObject(deriving(v1alpha2.ChildReadinessPolicyAllReady)),
+				observed: []*unstructured.Unstructured{child("a", "True"), child("b", "False")},
+			},
+			want: want{
+				children: []v1alpha2.ChildObservation{observation("a", true, true, ""), observation("b", true, false, string(xpv1.ReasonUnavailable))},
+				ready:    xpv1.Unavailable(),
+			},
+		},
+		"AnyReady": {
+			reason: "The Object should be ready if one of its children is ready with the AnyReady child policy.",
+			args: args{
+				obj:      kubernetesObject(deriving(v1alpha2.ChildReadinessPolicyAnyReady)),
+				observed: []*unstructured.Unstructured{child("a", "False"), child("b", "True")},
+			},
+			want: want{
+				children: []v1alpha2.ChildObservation{observation("a", true, false, string(xpv1.ReasonUnavailable)), observation("b", true, true, "")},
+				ready:    xpv1.Available().WithMessage("1 of 2 remote objects are ready"),
+			},
+		},
+		"NoneReady": {
+			reason: "The Object should not be ready if none of its children is ready, whatever its child policy.",
+			args: args{
+				obj:      kubernetesObject(deriving(v1alpha2.ChildReadinessPolicyAnyReady)),
+				observed: []*unstructured.Unstructured{child("a", "False"), child("b", "False")},
+			},
+			want: want{
+				children: []v1alpha2.ChildObservation{observation("a", true, false, string(xpv1.ReasonUnavailable)), observation("b", true, false, string(xpv1.ReasonUnavailable))},
+				ready:    xpv1.Unavailable(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := make([]*v1alpha2.Object, len(tc.args.observed))
+			for i := range objs {
+				objs[i] = tc.args.obj.DeepCopy()
+			}
+			c := &external{logger: logging.NewNopLogger()}
+			if err := c.setAtProviderList(tc.args.obj, objs, tc.args.observed, tc.args.synced); err != nil {
+				t.Fatalf("\n%s\nc.setAtProviderList(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.children, tc.args.obj.Status.AtProvider.Children); diff != "" {
+				t.Errorf("\n%s\nc.setAtProviderList(...): -want children, +got children:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ready, tc.args.obj.GetCondition(xpv1.TypeReady), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nc.setAtProviderList(...): -want ready condition, +got ready condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestStaleChildren(t *testing.T) {
+	child := func(apiVersion, name string) v1alpha2.ChildObservation {
+		return v1alpha2.ChildObservation{APIVersion: apiVersion, Kind: "Deployment", Namespace: "default", Name: name, Synced: true, Ready: true}
+	}
+	manifest := func(apiVersion, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind("Deployment")
+		u.SetNamespace("default")
+		u.SetName(name)
+		return u
+	}
+	withChildren := func(children ...v1alpha2.ChildObservation) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Status.AtProvider.Children = children
+		}
+	}
+
+	cases := map[string]struct {
+		reason    string
+		obj       *v1alpha2.Object
+		manifests []*unstructured.Unstructured
+		want      []v1alpha2.ChildObservation
+	}{
+		"Unchanged": {
+			reason:    "No child should be stale if every child is still in the manifest.",
+			obj:       kubernetesObject(withChildren(child("apps/v1", "a"), child("apps/v1", "b"))),
+			manifests: []*unstructured.Unstructured{manifest("apps/v1", "a"), manifest("apps/v1", "b")},
+		},
+		"Removed": {
+			reason:    "Children removed from the manifest should be stale.",
+			obj:       kubernetesObject(withChildren(child("apps/v1", "a"), child("apps/v1", "b"))),
+			manifests: []*unstructured.Unstructured{manifest("apps/v1", "a")},
+			want:      []v1alpha2.ChildObservation{{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "b", Message: msgChildStale}},
+		},
+		"VersionMigrated": {
+			reason:    "Children whose API version changed should not be stale.",
+			obj:       kubernetesObject(withChildren(child("apps/v1beta1", "a"))),
+			manifests: []*unstructured.Unstructured{manifest("apps/v1", "a")},
+		},
+		"Orphaned": {
+			reason: "Objects that orphan their remote objects should have no stale children.",
+			obj: kubernetesObject(withChildren(child("apps/v1", "a")), func(obj *v1alpha2.Object) {
+				obj.SetDeletionPolicy(xpv1.DeletionOrphan)
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &external{}
+			got := c.staleChildren(tc.obj, tc.manifests)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.staleChildren(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPruneChildren(t *testing.T) {
+	errBoom := errors.New("boom")
+	stale := func(name string) v1alpha2.ChildObservation {
+		return v1alpha2.ChildObservation{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name, Message: msgChildStale}
+	}
+
+	type want struct {
+		deleted []string
+		err     error
+	}
+	cases := map[string]struct {
+		reason string
+		labels map[string]string
+		getErr error
+		delErr error
+		want   want
+	}{
+		"Pruned": {
+			reason: "Stale children should be deleted from the target cluster.",
+			want:   want{deleted: []string{"default/a"}},
+		},
+		"Owned": {
+			reason: "Stale children owned by the Object should be deleted from the target cluster.",
+			labels: map[string]string{labelKeyObjectUID: "uid"},
+			want:   want{deleted: []string{"default/a"}},
+		},
+		"OwnedByOther": {
+			reason: "Stale children owned by other Objects should be left alone.",
+			labels: map[string]string{labelKeyObjectUID: "other"},
+		},
+		"Gone": {
+			reason: "Stale children that no longer exist should be skipped.",
+			getErr: kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "a"),
+		},
+		"DeleteError": {
+			reason: "Errors deleting stale children should be returned.",
+			delErr: errBoom,
+			want:   want{deleted: []string{"default/a"}, err: errors.Wrapf(errBoom, errPruneChild, "ConfigMap", "default/a")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			c := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{Client: &test.MockClient{
+					MockGet: test.NewMockGetFn(tc.getErr, func(obj client.Object) error {
+						obj.SetLabels(tc.labels)
+						return nil
+					}),
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						deleted = append(deleted, obj.GetNamespace()+"/"+obj.GetName())
+						return tc.delErr
+					},
+				}},
+			}
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetUID("uid")
+			})
+			err := c.pruneChildren(context.Background(), obj, []v1alpha2.ChildObservation{stale("a")})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.pruneChildren(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nc.pruneChildren(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
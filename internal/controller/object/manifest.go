@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errParseManifestYAML = "cannot parse manifestYAML"
+	errEmptyManifestYAML = "manifestYAML does not contain any object"
+	errEmptyList         = "list manifest does not contain any item"
+	errListItemName      = "list manifest items must have a name"
+	errMarshalManifest   = "cannot marshal manifest"
+)
+
+// manifestFromYAML converts the supplied YAML manifest to its JSON
+// representation. A manifest with multiple documents is converted to a v1 List
+// with a single item per document.
+func manifestFromYAML(s string) ([]byte, error) {
+	d := yaml.NewYAMLOrJSONDecoder(strings.NewReader(s), 4096)
+	var items []any
+	for {
+		doc := map[string]any{}
+		if err := d.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrap(err, errParseManifestYAML)
+		}
+		// Skip empty documents, e.g. a leading "---".
+		if len(doc) == 0 {
+			continue
+		}
+		items = append(items, doc)
+	}
+
+	switch len(items) {
+	case 0:
+		return nil, errors.New(errEmptyManifestYAML)
+	case 1:
+		b, err := json.Marshal(items[0])
+		return b, errors.Wrap(err, errParseManifestYAML)
+	}
+	b, err := json.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+	return b, errors.Wrap(err, errParseManifestYAML)
+}
+
+// normalizeManifest sets the JSON manifest of the supplied Object from its
+// YAML manifest, if any, so that the rest of the reconciliation, e.g. patching
+// from references, only deals with the JSON manifest.
+func normalizeManifest(obj *v1alpha2.Object) error {
+	if obj.Spec.ForProvider.ManifestYAML == "" {
+		return nil
+	}
+	raw, err := manifestFromYAML(obj.Spec.ForProvider.ManifestYAML)
+	if err != nil {
+		return err
+	}
+	obj.Spec.ForProvider.Manifest.Raw = raw
+	return nil
+}
+
+// children returns an Object for every item of the supplied List manifest of
+// the supplied Object, along with the parsed manifest of the item. The child
+// Objects are copies of the supplied Object with their manifest set to the
+// item, so that the items can be synced just like Objects with a single
+// manifest.
+func children(obj *v1alpha2.Object, list *unstructured.Unstructured) ([]*v1alpha2.Object, []*unstructured.Unstructured, error) {
+	l, err := list.ToList()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errUnmarshalTemplate)
+	}
+	if len(l.Items) == 0 {
+		return nil, nil, errors.New(errEmptyList)
+	}
+
+	objs := make([]*v1alpha2.Object, 0, len(l.Items))
+	manifests := make([]*unstructured.Unstructured, 0, len(l.Items))
+	for i := range l.Items {
+		item := &l.Items[i]
+		// Unlike a single manifest, items cannot default to the name of the
+		// Object, since they would all end up with the same name.
+		if item.GetName() == "" {
+			return nil, nil, errors.New(errListItemName)
+		}
+		raw, err := json.Marshal(item.Object)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errUnmarshalTemplate)
+		}
+		child := obj.DeepCopy()
+		child.Spec.ForProvider.Manifest.Raw = raw
+		child.Spec.ForProvider.ManifestYAML = ""
+		objs = append(objs, child)
+		manifests = append(manifests, item)
+	}
+	return objs, manifests, nil
+}
+
+// indexedManifests returns the manifests of the resources managed by the
+// supplied Object, or nil if they cannot be parsed.
+func indexedManifests(obj *v1alpha2.Object) []*unstructured.Unstructured {
+	if obj.Spec.ForProvider.ManifestYAML != "" || obj.Spec.ForProvider.TemplateRef != nil || len(obj.Spec.ForProvider.ManifestFrom) > 0 {
+		// The JSON manifest is only set from the YAML manifest, template or
+		// selected keys in memory while reconciling, and may be stale or
+		// missing in the cache.
+		obj = obj.DeepCopy()
+		obj.Spec.ForProvider.Manifest.Raw = nil
+	}
+	d, err := parseManifest(obj)
+	if err != nil {
+		return nil
+	}
+	if !d.IsList() {
+		return []*unstructured.Unstructured{d}
+	}
+	_, manifests, err := children(obj, d)
+	if err != nil {
+		return nil
+	}
+	return manifests
+}
+
+// listItems returns the items of the supplied List manifest.
+func listItems(list *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	l, err := list.ToList()
+	if err != nil {
+		return nil, errors.Wrap(err, errUnmarshalTemplate)
+	}
+	items := make([]*unstructured.Unstructured, 0, len(l.Items))
+	for i := range l.Items {
+		items = append(items, &l.Items[i])
+	}
+	return items, nil
+}
+
+// updateManifest calls the supplied function with the manifest of the supplied
+// Object, or with every item of its List manifest, and sets the manifest of the
+// Object to the result.
+func updateManifest(obj *v1alpha2.Object, fn func(*unstructured.Unstructured) error) error {
+	manifest, err := parseManifest(obj)
+	if err != nil {
+		return err
+	}
+	targets := []*unstructured.Unstructured{manifest}
+	if manifest.IsList() {
+		if targets, err = listItems(manifest); err != nil {
+			return err
+		}
+	}
+	for _, o := range targets {
+		if err := fn(o); err != nil {
+			return err
+		}
+	}
+	if manifest.IsList() {
+		items := make([]any, 0, len(targets))
+		for _, o := range targets {
+			items = append(items, o.Object)
+		}
+		manifest.Object["items"] = items
+	}
+
+	raw, err := json.Marshal(manifest.Object)
+	if err != nil {
+		return errors.Wrap(err, errMarshalManifest)
+	}
+	obj.Spec.ForProvider.Manifest.Raw = raw
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestManifestFromYAML(t *testing.T) {
+	type want struct {
+		out string
+		err error
+	}
+	cases := map[string]struct {
+		yaml string
+		want
+	}{
+		"Empty": {
+			yaml: "---\n# nothing here\n",
+			want: want{
+				err: errors.New(errEmptyManifestYAML),
+			},
+		},
+		"SingleDocument": {
+			yaml: "---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: one\n",
+			want: want{
+				out: `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one"}}`,
+			},
+		},
+		"MultipleDocuments": {
+			yaml: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: one\n---\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: two\n",
+			want: want{
+				out: `{"apiVersion":"v1","items":[{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one"}},{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"two"}}],"kind":"List"}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := manifestFromYAML(tc.yaml)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("manifestFromYAML(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, string(got)); diff != "" {
+				t.Errorf("manifestFromYAML(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestChildren(t *testing.T) {
+	type want struct {
+		manifests []string
+		err       error
+	}
+	cases := map[string]struct {
+		list string
+		want
+	}{
+		"EmptyList": {
+			list: `{"apiVersion":"v1","kind":"List","items":[]}`,
+			want: want{
+				err: errors.New(errEmptyList),
+			},
+		},
+		"ItemWithoutName": {
+			list: `{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Namespace"}]}`,
+			want: want{
+				err: errors.New(errListItemName),
+			},
+		},
+		"Success": {
+			list: `{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one"}},{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"two"}}]}`,
+			want: want{
+				manifests: []string{
+					`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one"}}`,
+					`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"two"}}`,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			list := &unstructured.Unstructured{}
+			if err := list.UnmarshalJSON([]byte(tc.list)); err != nil {
+				t.Fatal(err)
+			}
+			objs, _, gotErr := children(kubernetesObject(), list)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("children(...): -want error, +got error: %s", diff)
+			}
+			var got []string
+			for _, o := range objs {
+				got = append(got, string(o.Spec.ForProvider.Manifest.Raw))
+			}
+			if diff := cmp.Diff(tc.want.manifests, got); diff != "" {
+				t.Errorf("children(...): -want manifests, +got manifests: %s", diff)
+			}
+		})
+	}
+}
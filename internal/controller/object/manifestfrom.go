@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetManifestConfigMap = "cannot get config map %s/%s of the manifest"
+	errGetManifestSecret    = "cannot get secret %s/%s of the manifest"
+	errManifestKeyNotFound  = "key %q of %s/%s of the manifest not found"
+	errManifestSource       = "either configMapKeyRef or secretKeyRef must be set"
+)
+
+// loadManifestFrom sets the JSON manifest of the supplied Object from the keys
+// of the ConfigMaps and Secrets it selects, if any.
+func (c *external) loadManifestFrom(ctx context.Context, obj *v1alpha2.Object) error {
+	sources := obj.Spec.ForProvider.ManifestFrom
+	if len(sources) == 0 {
+		return nil
+	}
+
+	b := &bytes.Buffer{}
+	for _, src := range sources {
+		part, err := c.manifestPart(ctx, obj, src)
+		if kerrors.IsNotFound(err) && meta.WasDeleted(obj) && len(obj.Status.AtProvider.Manifest.Raw) > 0 {
+			// Deleting the remote objects only needs their names, which
+			// are recorded in the observed manifest.
+			obj.Spec.ForProvider.Manifest.Raw = bytes.Clone(obj.Status.AtProvider.Manifest.Raw)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		b.Write(part)
+	}
+
+	raw, err := manifestFromYAML(b.String())
+	if err != nil {
+		return err
+	}
+	obj.Spec.ForProvider.Manifest.Raw = raw
+	return nil
+}
+
+// stashManifestFrom moves the manifest the supplied Object loaded from the
+// keys it selects out of its spec, onto the external client, once an
+// operation on the Object is done. The manifest, e.g. of Secrets, would
+// otherwise be persisted along with the Object when the managed reconciler
+// updates it, e.g. to annotate it with the external create time.
+func (c *external) stashManifestFrom(obj *v1alpha2.Object) {
+	if len(obj.Spec.ForProvider.ManifestFrom) == 0 {
+		return
+	}
+	c.loadedManifest = obj.Spec.ForProvider.Manifest.Raw
+	obj.Spec.ForProvider.Manifest.Raw = nil
+}
+
+// restoreManifestFrom sets the manifest of the supplied Object to the one
+// stashed by the previous operation on it, e.g. the observation preceding a
+// create or update.
+func (c *external) restoreManifestFrom(obj *v1alpha2.Object) {
+	if len(obj.Spec.ForProvider.ManifestFrom) == 0 || c.loadedManifest == nil {
+		return
+	}
+	obj.Spec.ForProvider.Manifest.Raw = c.loadedManifest
+}
+
+// manifestPart returns the value of the key selected by the supplied source.
+// Errors getting the ConfigMap or Secret keep their API status, so that those
+// that do not exist can be told apart.
+func (c *external) manifestPart(ctx context.Context, obj *v1alpha2.Object, src v1alpha2.ManifestSource) ([]byte, error) {
+	switch {
+	case src.ConfigMapKeyRef != nil:
+		ref := src.ConfigMapKeyRef
+		cm := &v1.ConfigMap{}
+		if err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, errGetManifestConfigMap, ref.Namespace, ref.Name)
+		}
+		if v, ok := cm.Data[ref.Key]; ok {
+			return []byte(v), nil
+		}
+		if v, ok := cm.BinaryData[ref.Key]; ok {
+			return v, nil
+		}
+		return nil, errors.Errorf(errManifestKeyNotFound, ref.Key, ref.Namespace, ref.Name)
+	case src.SecretKeyRef != nil:
+		ref := src.SecretKeyRef
+		if err := c.checkSecretNamespace(obj, ref.Namespace, ref.Name); err != nil {
+			return nil, err
+		}
+		s := &v1.Secret{}
+		if err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errGetManifestSecret, ref.Namespace, ref.Name)
+		}
+		if v, ok := s.Data[ref.Key]; ok {
+			return v, nil
+		}
+		return nil, errors.Errorf(errManifestKeyNotFound, ref.Key, ref.Namespace, ref.Name)
+	}
+	return nil, errors.New(errManifestSource)
+}
+
+// observedForStatus returns the supplied observed remote object as it is to
+// be recorded in the status of the supplied Object. Objects whose manifest is
+// too large to be stored inline only record the compacted remote objects,
+// which hold everything the reconciliation reads back.
+func observedForStatus(obj *v1alpha2.Object, observed *unstructured.Unstructured) *unstructured.Unstructured {
+	if len(obj.Spec.ForProvider.ManifestFrom) == 0 {
+		return observed
+	}
+	return compactObserved(observed)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object/fake"
+)
+
+func TestLoadManifestFrom(t *testing.T) {
+	fromConfigMap := v1alpha2.ManifestSource{ConfigMapKeyRef: &v1alpha2.ManifestKeySelector{Namespace: "default", Name: "manifest", Key: "head"}}
+	fromSecret := v1alpha2.ManifestSource{SecretKeyRef: &v1alpha2.ManifestKeySelector{Namespace: "default", Name: "manifest", Key: "tail"}}
+	kube := func(err error) client.Client {
+		return &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				if err != nil {
+					return err
+				}
+				switch o := obj.(type) {
+				case *v1.ConfigMap:
+					o.Data = map[string]string{"head": "apiVersion: v1\nkind: ConfigMap\n"}
+				case *v1.Secret:
+					o.Data = map[string][]byte{"tail": []byte("metadata:\n  name: large\ndata:\n  key: value\n")}
+				}
+				return nil
+			},
+		}
+	}
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "manifest")
+
+	type want struct {
+		manifest string
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		client client.Client
+		obj    *v1alpha2.Object
+		want
+	}{
+		"NoManifestFrom": {
+			reason: "The manifest should be left as is if no keys are selected.",
+			obj:    kubernetesObject(),
+			want: want{
+				manifest: string(externalResourceRaw),
+			},
+		},
+		"Chunked": {
+			reason: "The values of the selected keys should be concatenated in order and parsed as YAML.",
+			client: kube(nil),
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{fromConfigMap, fromSecret}
+			}),
+			want: want{
+				manifest: `{"apiVersion":"v1","data":{"key":"value"},"kind":"ConfigMap","metadata":{"name":"large"}}`,
+			},
+		},
+		"KeyNotFound": {
+			reason: "An error should be returned if a selected key does not exist.",
+			client: kube(nil),
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{{ConfigMapKeyRef: &v1alpha2.ManifestKeySelector{Namespace: "default", Name: "manifest", Key: "missing"}}}
+			}),
+			want: want{
+				err: errors.Errorf(errManifestKeyNotFound, "missing", "default", "manifest"),
+			},
+		},
+		"FailedToGetConfigMap": {
+			reason: "An error should be returned if a selected ConfigMap cannot be read.",
+			client: kube(errBoom),
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{fromConfigMap}
+			}),
+			want: want{
+				err: errors.Wrapf(errBoom, errGetManifestConfigMap, "default", "manifest"),
+			},
+		},
+		"NoSource": {
+			reason: "An error should be returned if a source selects neither a ConfigMap nor a Secret.",
+			client: kube(nil),
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{{}}
+			}),
+			want: want{
+				err: errors.New(errManifestSource),
+			},
+		},
+		"GoneWhileDeleting": {
+			reason: "The observed manifest should be used to delete the remote object if the selected ConfigMap is gone.",
+			client: kube(notFound),
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+				obj.Spec.ForProvider.Manifest.Raw = nil
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{fromConfigMap}
+				obj.Status.AtProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"large"}}`)
+			}),
+			want: want{
+				manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"large"}}`,
+			},
+		},
+		"GoneWhileNotDeleting": {
+			reason: "An error should be returned if the selected ConfigMap is gone and the Object is not being deleted.",
+			client: kube(notFound),
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{fromConfigMap}
+			}),
+			want: want{
+				err: errors.Wrapf(notFound, errGetManifestConfigMap, "default", "manifest"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{localClient: tc.client}
+			gotErr := e.loadManifestFrom(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\ne.loadManifestFrom(...): -want error, +got error: %s", tc.reason, diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.manifest, string(tc.obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("\n%s\ne.loadManifestFrom(...): -want manifest, +got manifest: %s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManifestFromNotPersisted(t *testing.T) {
+	e := &external{
+		logger: logging.NewNopLogger(),
+		localClient: &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*v1.Secret).Data = map[string][]byte{"manifest": []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: s\ndata:\n  key: dmFsdWU=\n")}
+				return nil
+			}),
+		},
+		client: resource.ClientApplicator{Client: &test.MockClient{
+			MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "s")),
+		}},
+	}
+	var applied string
+	e.syncer = &fake.ResourceSyncer{
+		SyncResourceFn: func(_ context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+			applied = string(obj.Spec.ForProvider.Manifest.Raw)
+			return desired, nil
+		},
+	}
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Manifest.Raw = nil
+		obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{{SecretKeyRef: &v1alpha2.ManifestKeySelector{Namespace: "default", Name: "manifest", Key: "manifest"}}}
+	})
+
+	if _, err := e.Observe(context.Background(), obj); err != nil {
+		t.Fatalf("e.Observe(...): %s", err)
+	}
+	if obj.Spec.ForProvider.Manifest.Raw != nil {
+		t.Errorf("e.Observe(...): the loaded manifest should not be left in the spec: %s", obj.Spec.ForProvider.Manifest.Raw)
+	}
+	if _, err := e.Create(context.Background(), obj); err != nil {
+		t.Fatalf("e.Create(...): %s", err)
+	}
+	if diff := cmp.Diff(`{"apiVersion":"v1","data":{"key":"dmFsdWU="},"kind":"Secret","metadata":{"name":"s"}}`, applied); diff != "" {
+		t.Errorf("e.Create(...): -want applied manifest, +got applied manifest: %s", diff)
+	}
+	if obj.Spec.ForProvider.Manifest.Raw != nil {
+		t.Errorf("e.Create(...): the loaded manifest should not be left in the spec: %s", obj.Spec.ForProvider.Manifest.Raw)
+	}
+}
+
+func TestObservedForStatus(t *testing.T) {
+	observed := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "large"},
+			"data":       map[string]any{"key": "value"},
+		}}
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   *unstructured.Unstructured
+	}{
+		"Inline": {
+			reason: "The observed object should be recorded as is if the manifest is inline.",
+			obj:    kubernetesObject(),
+			want:   observed(),
+		},
+		"ManifestFrom": {
+			reason: "Only the identity and metadata of the observed object should be recorded if the manifest is selected from keys.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{{}}
+			}),
+			want: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "large"},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := observedForStatus(tc.obj, observed())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nobservedForStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const errReferenceNotMatched = "referenced resource %s %s does not match"
+
+// A fieldsNotMatchedError is returned when a referenced resource exists, but
+// its fields do not have the values the reference waits for yet. Like missing
+// referenced resources, it is skipped by Optional references.
+type fieldsNotMatchedError struct {
+	error
+}
+
+// referenceMatchFields returns the fields the resource referenced by the
+// supplied reference must match, if any.
+func referenceMatchFields(ref v1alpha2.Reference) []v1alpha2.MatchField {
+	switch {
+	case ref.PatchesFrom != nil:
+		return ref.PatchesFrom.MatchFields
+	case ref.PatchesFromConnectionSecret != nil:
+		return ref.PatchesFromConnectionSecret.MatchFields
+	case ref.DependsOn != nil:
+		return ref.DependsOn.MatchFields
+	}
+	return nil
+}
+
+// checkMatchFields returns a fieldsNotMatchedError describing the first of
+// the supplied fields the supplied referenced resource does not match, if any.
+func checkMatchFields(res *unstructured.Unstructured, fields []v1alpha2.MatchField) error {
+	p := fieldpath.Pave(res.Object)
+	for _, f := range fields {
+		if err := matchFieldValue(p, f.FieldPath, f.Value); err != nil {
+			return fieldsNotMatchedError{errors.Wrapf(err, errReferenceNotMatched, res.GetKind(), resourceName(res))}
+		}
+	}
+	return nil
+}
+
+// matchFieldValue returns an error describing the mismatch if the supplied
+// field path of the supplied object does not have the supplied value. Values
+// that are not strings are compared by their JSON representation.
+func matchFieldValue(p *fieldpath.Paved, fieldPath, want string) error {
+	v, err := p.GetValue(fieldPath)
+	if err != nil {
+		return errors.Wrapf(err, errMatchConditionField, fieldPath)
+	}
+	got, ok := v.(string)
+	if !ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return errors.Wrapf(err, errMatchConditionField, fieldPath)
+		}
+		got = string(b)
+	}
+	if got != want {
+		return errors.Errorf(errMatchCondition, fieldPath, got, want)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestCheckMatchFields(t *testing.T) {
+	namespace := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": "apps"},
+			"spec":       map[string]any{"finalizers": []any{"kubernetes"}},
+			"status":     map[string]any{"phase": "Terminating"},
+		}}
+	}
+
+	type want struct {
+		err     error
+		missing bool
+	}
+	cases := map[string]struct {
+		reason string
+		fields []v1alpha2.MatchField
+		want   want
+	}{
+		"NoFields": {
+			reason: "A referenced resource should match if there are no fields to match.",
+		},
+		"Matched": {
+			reason: "A referenced resource whose fields have the values should match, comparing values that are not strings by their JSON representation.",
+			fields: []v1alpha2.MatchField{
+				{FieldPath: "status.phase", Value: "Terminating"},
+				{FieldPath: "spec.finalizers", Value: `["kubernetes"]`},
+			},
+		},
+		"NotMatched": {
+			reason: "A referenced resource whose field has another value should not match.",
+			fields: []v1alpha2.MatchField{{FieldPath: "status.phase", Value: "Active"}},
+			want: want{
+				err:     fieldsNotMatchedError{errors.Wrapf(errors.Errorf(errMatchCondition, "status.phase", "Terminating", "Active"), errReferenceNotMatched, "Namespace", "apps")},
+				missing: true,
+			},
+		},
+		"FieldMissing": {
+			reason: "A referenced resource that lacks a field should not match.",
+			fields: []v1alpha2.MatchField{{FieldPath: "status.conditions", Value: "[]"}},
+			want: want{
+				err:     fieldsNotMatchedError{errors.Wrapf(errors.Wrapf(errors.New("status.conditions: no such field"), errMatchConditionField, "status.conditions"), errReferenceNotMatched, "Namespace", "apps")},
+				missing: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkMatchFields(namespace(), tc.fields)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncheckMatchFields(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.missing, missingReference(errors.Wrap(err, "cannot resolve"))); diff != "" {
+				t.Errorf("\n%s\nmissingReference(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReferenceMatchFields(t *testing.T) {
+	fields := []v1alpha2.MatchField{{FieldPath: "status.phase", Value: "Active"}}
+
+	cases := map[string]struct {
+		reason string
+		ref    v1alpha2.Reference
+		want   []v1alpha2.MatchField
+	}{
+		"DependsOn": {
+			reason: "The fields of a dependsOn reference should be matched.",
+			ref:    v1alpha2.Reference{DependsOn: &v1alpha2.DependsOn{Name: "apps", MatchFields: fields}},
+			want:   fields,
+		},
+		"PatchesFrom": {
+			reason: "The fields of a patchesFrom reference should be matched.",
+			ref:    v1alpha2.Reference{PatchesFrom: &v1alpha2.PatchesFrom{DependsOn: v1alpha2.DependsOn{Name: "apps", MatchFields: fields}}},
+			want:   fields,
+		},
+		"PatchesFromValue": {
+			reason: "A reference to a value has no fields to match.",
+			ref:    v1alpha2.Reference{PatchesFromValue: &v1alpha2.PatchesFromValue{Name: "v"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, referenceMatchFields(tc.ref)); diff != "" {
+				t.Errorf("\n%s\nreferenceMatchFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// observeMetadata observes only the metadata of the remote objects of the
+// supplied manifest, or of every item of it if it is a List. The remote
+// objects are considered up-to-date as long as they all exist.
+func (c *external) observeMetadata(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (managed.ExternalObservation, error) {
+	manifests := []*unstructured.Unstructured{manifest}
+	if manifest.IsList() {
+		var err error
+		if _, manifests, err = children(obj, manifest); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	// The resource version of the remote objects is observed, but not that
+	// of their whole payload, so the comparison is never skipped.
+	obj.Status.AtProvider.LastAppliedHash = ""
+	obj.Status.AtProvider.ObservedResourceVersion = ""
+
+	observed := make([]*metav1.PartialObjectMetadata, 0, len(manifests))
+	for _, m := range manifests {
+		current := &metav1.PartialObjectMetadata{}
+		current.SetGroupVersionKind(m.GroupVersionKind())
+		err := c.client.Get(ctx, types.NamespacedName{
+			Namespace: m.GetNamespace(),
+			Name:      m.GetName(),
+		}, current)
+		if kerrors.IsNotFound(err) {
+			obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
+		}
+		if c.sanitizeSecrets && len(redactSecretData(m.DeepCopy())) > 0 {
+			// The last applied configuration contains the secret data.
+			redactLastApplied(current)
+		}
+		observed = append(observed, current)
+	}
+	obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+
+	var status any = observed[0]
+	if manifest.IsList() {
+		status = map[string]any{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      observed,
+		}
+	}
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToMarshalExisting)
+	}
+	obj.Status.AtProvider.Manifest.Raw = raw
+
+	return c.handleObservation(ctx, obj, true)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestObserveMetadata(t *testing.T) {
+	metadataOnly := func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.ObservationMode = v1alpha2.ObservationModeMetadata
+	}
+	withResourceVersion := test.NewMockGetFn(nil, func(obj client.Object) error {
+		if _, ok := obj.(*metav1.PartialObjectMetadata); !ok {
+			t.Errorf("Only the metadata of the remote object should be read, got %T", obj)
+		}
+		obj.SetResourceVersion("1")
+		return nil
+	})
+
+	type want struct {
+		out      managed.ExternalObservation
+		manifest string
+		err      error
+	}
+	cases := map[string]struct {
+		client client.Client
+		obj    *v1alpha2.Object
+		want
+	}{
+		"FailedToGet": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			obj: kubernetesObject(metadataOnly),
+			want: want{
+				err: errors.Wrap(errBoom, errGetObject),
+			},
+		},
+		"DoesNotExist": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, externalResourceName)),
+			},
+			obj: kubernetesObject(metadataOnly),
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Exists": {
+			client: &test.MockClient{
+				MockGet: withResourceVersion,
+			},
+			obj: kubernetesObject(metadataOnly),
+			want: want{
+				out:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				manifest: `{"kind":"Namespace","apiVersion":"v1","metadata":{"resourceVersion":"1","creationTimestamp":null}}`,
+			},
+		},
+		"ListItemsExist": {
+			client: &test.MockClient{
+				MockGet: withResourceVersion,
+			},
+			obj: kubernetesObject(metadataOnly, func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"one","namespace":"default"}}]}`)
+			}),
+			want: want{
+				out:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				manifest: `{"apiVersion":"v1","items":[{"kind":"ConfigMap","apiVersion":"v1","metadata":{"resourceVersion":"1","creationTimestamp":null}}],"kind":"List"}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger:      logging.NewNopLogger(),
+				client:      resource.ClientApplicator{Client: tc.client},
+				localClient: tc.client,
+			}
+			got, gotErr := e.Observe(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, got); diff != "" {
+				t.Errorf("e.Observe(...): -want out, +got out: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.manifest, string(tc.obj.Status.AtProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("e.Observe(...): -want manifest, +got manifest: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	metricsNamespace = "provider_kubernetes"
+	metricsSubsystem = "object"
+)
+
+var gvkLabels = []string{"group", "version", "kind"}
+
+var (
+	driftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "drift_corrections_total",
+		Help:      "The number of times remote objects that drifted from their manifests were corrected, by managed GVK.",
+	}, gvkLabels)
+
+	adoptions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "adoptions_total",
+		Help:      "The number of remote objects that already existed when an Object first applied its manifest to them, by managed GVK.",
+	}, gvkLabels)
+
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "reconcile_duration_seconds",
+		Help:      "The duration of the reconciles of Objects, by managed GVK.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, gvkLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(driftCorrections, adoptions, reconcileDuration, providerConfigObjects, flaps, quarantines)
+}
+
+// managedGVK returns the GVK of the remote object managed by the supplied
+// Object, as declared by its manifest, or as last observed if the manifest
+// is rendered from a template. It returns an empty GVK if neither is known.
+func managedGVK(obj *v1alpha2.Object) schema.GroupVersionKind {
+	for _, raw := range [][]byte{obj.Spec.ForProvider.Manifest.Raw, obj.Status.AtProvider.Manifest.Raw} {
+		tm := struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}{}
+		if len(raw) == 0 || json.Unmarshal(raw, &tm) != nil || tm.Kind == "" {
+			continue
+		}
+		return schema.FromAPIVersionAndKind(tm.APIVersion, tm.Kind)
+	}
+	return schema.GroupVersionKind{}
+}
+
+func labelsFor(gvk schema.GroupVersionKind) prometheus.Labels {
+	return prometheus.Labels{"group": gvk.Group, "version": gvk.Version, "kind": gvk.Kind}
+}
+
+// recordDriftCorrection counts a correction of the drift of the remote object
+// of the supplied Object.
+func recordDriftCorrection(obj *v1alpha2.Object) {
+	driftCorrections.With(labelsFor(managedGVK(obj))).Inc()
+}
+
+// recordAdoption counts the adoption of the remote object of the supplied
+// Object if the Object is about to apply its manifest for the first time.
+// It must be called before the apply is recorded.
+func recordAdoption(obj *v1alpha2.Object) {
+	if obj.Status.AtProvider.LastAppliedTime != nil {
+		return
+	}
+	adoptions.With(labelsFor(managedGVK(obj))).Inc()
+}
+
+// A measuredReconciler records the duration of the reconciles of Objects by
+// the GVK of their remote objects.
+type measuredReconciler struct {
+	inner reconcile.Reconciler
+	kube  client.Reader
+	now   func() time.Time
+}
+
+func newMeasuredReconciler(inner reconcile.Reconciler, kube client.Reader) *measuredReconciler {
+	return &measuredReconciler{inner: inner, kube: kube, now: time.Now}
+}
+
+// Reconcile the supplied request with the inner reconciler and record how long
+// it took. Requests for Objects that no longer exist are not recorded.
+func (r *measuredReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, req.NamespacedName, obj); err != nil {
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	start := r.now()
+	res, err := r.inner.Reconcile(ctx, req)
+	reconcileDuration.With(labelsFor(managedGVK(obj))).Observe(r.now().Sub(start).Seconds())
+	return res, err
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestManagedGVK(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   schema.GroupVersionKind
+	}{
+		"FromManifest": {
+			reason: "The GVK should be read from the manifest.",
+			obj:    kubernetesObject(),
+			want:   schema.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+		},
+		"FromObserved": {
+			reason: "The GVK should be read from the observed manifest if the manifest is rendered from a template.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = nil
+				obj.Status.AtProvider.Manifest.Raw = []byte(`{"apiVersion":"apps/v1","kind":"Deployment"}`)
+			}),
+			want: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+		"Unknown": {
+			reason: "The GVK should be empty if it is not known.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest = runtime.RawExtension{}
+			}),
+			want: schema.GroupVersionKind{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := managedGVK(tc.obj)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nmanagedGVK(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordAdoption(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   float64
+	}{
+		"NeverApplied": {
+			reason: "An adoption should be counted if the Object never applied its manifest.",
+			obj:    kubernetesObject(),
+			want:   1,
+		},
+		"AlreadyApplied": {
+			reason: "No adoption should be counted if the Object applied its manifest before.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.LastAppliedTime = &metav1.Time{}
+			}),
+			want: 0,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := adoptions.With(labelsFor(managedGVK(tc.obj)))
+			before := testutil.ToFloat64(c)
+			recordAdoption(tc.obj)
+			if diff := cmp.Diff(tc.want, testutil.ToFloat64(c)-before); diff != "" {
+				t.Errorf("\n%s\nrecordAdoption(...): -want adoptions, +got adoptions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMeasuredReconciler(t *testing.T) {
+	manifests := map[string]string{
+		"exists": `{"apiVersion":"measured.example.org/v1","kind":"Exists"}`,
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			m, ok := manifests[key.Name]
+			if !ok {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			obj.(*v1alpha2.Object).Spec.ForProvider.Manifest.Raw = []byte(m)
+			return nil
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   int
+	}{
+		"Exists": {
+			reason: "The duration of the reconcile of an existing Object should be recorded by its managed GVK.",
+			name:   "exists",
+			want:   1,
+		},
+		"NotFound": {
+			reason: "The duration of the reconcile of an Object that no longer exists should not be recorded.",
+			name:   "gone",
+			want:   0,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reconciled := false
+			inner := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			})
+			r := newMeasuredReconciler(inner, kube)
+			now := time.Now()
+			r.now = func() time.Time {
+				now = now.Add(time.Second)
+				return now
+			}
+
+			before := testutil.CollectAndCount(reconcileDuration)
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.name}}); err != nil {
+				t.Fatalf("Reconcile(...): unexpected error: %v", err)
+			}
+			if !reconciled {
+				t.Errorf("\n%s\nReconcile(...): inner reconciler was not called", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want, testutil.CollectAndCount(reconcileDuration)-before); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want new series, +got new series:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
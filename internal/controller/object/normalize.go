@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"path"
+	"slices"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errStripItems = "cannot strip items of %q"
+
+	defaultStripField = "name"
+)
+
+// ignorePresetStripRules are the strip rules applied by each IgnorePreset, in
+// addition to its ignored fields.
+var ignorePresetStripRules = map[v1alpha2.IgnorePreset][]v1alpha2.StripRule{
+	v1alpha2.IgnorePresetIstioInjected: slices.Concat(
+		podSpecStripRules("containers", defaultStripField, "istio-proxy"),
+		podSpecStripRules("initContainers", defaultStripField, "istio-init", "istio-validation"),
+		podSpecStripRules("volumes", defaultStripField, "istio-envoy", "istio-data", "istio-podinfo", "istio-token", "istiod-ca-cert"),
+	),
+	v1alpha2.IgnorePresetDefaultTolerations: podSpecStripRules("tolerations", "key", "node.kubernetes.io/not-ready", "node.kubernetes.io/unreachable"),
+}
+
+// podSpecStripRules returns rules stripping the items of the supplied list of
+// the pod spec of pods and of pod templates.
+func podSpecStripRules(list, field string, values ...string) []v1alpha2.StripRule {
+	return []v1alpha2.StripRule{
+		{Path: "spec." + list, Field: field, Values: values},
+		{Path: "spec.template.spec." + list, Field: field, Values: values},
+	}
+}
+
+// stripRules returns the rules stripping list items that should not be
+// considered when deciding whether the remote object of the supplied Object is
+// up-to-date.
+func stripRules(obj *v1alpha2.Object) []v1alpha2.StripRule {
+	var rules []v1alpha2.StripRule
+	for _, p := range obj.Spec.ForProvider.IgnorePresets {
+		rules = append(rules, ignorePresetStripRules[p]...)
+	}
+	if n := obj.Spec.ForProvider.Normalization; n != nil {
+		rules = append(rules, n.StripRules...)
+	}
+	return rules
+}
+
+// withoutItems returns a copy of the supplied object without the list items
+// matching the supplied rules. Lists left empty are removed, so that they
+// compare equal to lists that were never there.
+func withoutItems(u *unstructured.Unstructured, rules []v1alpha2.StripRule) (*unstructured.Unstructured, error) {
+	if u == nil || len(rules) == 0 {
+		return u, nil
+	}
+	out := u.DeepCopy()
+	p := fieldpath.Pave(out.Object)
+	for _, r := range rules {
+		paths, err := p.ExpandWildcards(r.Path)
+		if fieldpath.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errStripItems, r.Path)
+		}
+		for _, fp := range paths {
+			v, err := p.GetValue(fp)
+			if fieldpath.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, errStripItems, r.Path)
+			}
+			items, ok := v.([]any)
+			if !ok {
+				continue
+			}
+			kept := make([]any, 0, len(items))
+			for _, item := range items {
+				if !matchesStripRule(item, r) {
+					kept = append(kept, item)
+				}
+			}
+			switch {
+			case len(kept) == len(items):
+				continue
+			case len(kept) == 0:
+				err = p.DeleteField(fp)
+			default:
+				err = p.SetValue(fp, kept)
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, errStripItems, r.Path)
+			}
+		}
+	}
+	out.Object = p.UnstructuredContent()
+	return out, nil
+}
+
+// matchesStripRule returns true if the field of the supplied list item matches
+// one of the values of the supplied rule.
+func matchesStripRule(item any, r v1alpha2.StripRule) bool {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return false
+	}
+	field := r.Field
+	if field == "" {
+		field = defaultStripField
+	}
+	v, ok := m[field].(string)
+	if !ok {
+		return false
+	}
+	for _, pattern := range r.Values {
+		if ok, err := path.Match(pattern, v); ok || (err != nil && pattern == v) {
+			return true
+		}
+	}
+	return false
+}
+
+// dryRunEquivalence returns true if the supplied Object asks to be compared
+// with a server-side dry-run of its patch.
+func dryRunEquivalence(obj *v1alpha2.Object) bool {
+	n := obj.Spec.ForProvider.Normalization
+	return n != nil && n.DryRunEquivalence
+}
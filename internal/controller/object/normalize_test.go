@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestWithoutItems(t *testing.T) {
+	deployment := func(containers, initContainers []any) *unstructured.Unstructured {
+		podSpec := map[string]any{"containers": containers}
+		if initContainers != nil {
+			podSpec["initContainers"] = initContainers
+		}
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec": map[string]any{
+				"template": map[string]any{"spec": podSpec},
+			},
+		}}
+	}
+	app := map[string]any{"name": "app", "image": "app:1"}
+	sidecar := map[string]any{"name": "istio-proxy", "image": "proxyv2"}
+	istioInit := map[string]any{"name": "istio-init", "image": "proxyv2"}
+
+	cases := map[string]struct {
+		u     *unstructured.Unstructured
+		rules []v1alpha2.StripRule
+		want  *unstructured.Unstructured
+	}{
+		"NoRules": {
+			u:    deployment([]any{app, sidecar}, nil),
+			want: deployment([]any{app, sidecar}, nil),
+		},
+		"IstioInjected": {
+			u:     deployment([]any{app, sidecar}, []any{istioInit}),
+			rules: ignorePresetStripRules[v1alpha2.IgnorePresetIstioInjected],
+			want:  deployment([]any{app}, nil),
+		},
+		"Pattern": {
+			u:     deployment([]any{app, sidecar}, nil),
+			rules: []v1alpha2.StripRule{{Path: "spec.template.spec.containers", Values: []string{"istio-*"}}},
+			want:  deployment([]any{app}, nil),
+		},
+		"Field": {
+			u:     deployment([]any{app, sidecar}, nil),
+			rules: []v1alpha2.StripRule{{Path: "spec.template.spec.containers", Field: "image", Values: []string{"app:*"}}},
+			want:  deployment([]any{sidecar}, nil),
+		},
+		"Wildcard": {
+			u: &unstructured.Unstructured{Object: map[string]any{
+				"items": []any{
+					map[string]any{"containers": []any{app, sidecar}},
+					map[string]any{"containers": []any{sidecar, app}},
+				},
+			}},
+			rules: []v1alpha2.StripRule{{Path: "items[*].containers", Values: []string{"istio-proxy"}}},
+			want: &unstructured.Unstructured{Object: map[string]any{
+				"items": []any{
+					map[string]any{"containers": []any{app}},
+					map[string]any{"containers": []any{app}},
+				},
+			}},
+		},
+		"MissingList": {
+			u:     deployment([]any{app}, nil),
+			rules: []v1alpha2.StripRule{{Path: "spec.template.spec.volumes", Values: []string{"istio-envoy"}}},
+			want:  deployment([]any{app}, nil),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := withoutItems(tc.u, tc.rules)
+			if err != nil {
+				t.Fatalf("withoutItems(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("withoutItems(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDateWithStripRules(t *testing.T) {
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.IgnorePresets = []v1alpha2.IgnorePreset{v1alpha2.IgnorePresetIstioInjected, v1alpha2.IgnorePresetDefaultTolerations}
+	})
+	desired := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"spec": map[string]any{
+			"containers": []any{map[string]any{"name": "app"}},
+		},
+	}}
+	live := desired.DeepCopy()
+	live.Object["spec"] = map[string]any{
+		"containers":     []any{map[string]any{"name": "app"}, map[string]any{"name": "istio-proxy"}},
+		"initContainers": []any{map[string]any{"name": "istio-init"}},
+		"tolerations": []any{
+			map[string]any{"key": "node.kubernetes.io/not-ready", "operator": "Exists", "effect": "NoExecute"},
+			map[string]any{"key": "node.kubernetes.io/unreachable", "operator": "Exists", "effect": "NoExecute"},
+		},
+	}
+
+	e := &external{}
+	upToDate, err := e.isUpToDate(obj, live, desired)
+	if err != nil {
+		t.Fatalf("e.isUpToDate(...): %s", err)
+	}
+	if !upToDate {
+		t.Errorf("e.isUpToDate(...): want up-to-date once injected mutations are stripped")
+	}
+}
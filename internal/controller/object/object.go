@@ -17,9 +17,13 @@ limitations under the License.
 package object
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"maps"
 	"math/rand"
 	"reflect"
 	"strings"
@@ -27,10 +31,13 @@ import (
 
 	"github.com/google/cel-go/cel"
 	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,11 +47,16 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -61,15 +73,18 @@ import (
 	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/features"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/shard"
 	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
 	"github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client/ssa/cache/extractor"
 	"github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client/ssa/cache/state"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
 )
 
 type key int
 
 const (
 	keyProviderConfigName key = iota
+	keyRequestObject
 )
 
 const (
@@ -78,7 +93,15 @@ const (
 	errGetObject         = "cannot get object"
 	errCreateObject      = "cannot create object"
 	errApplyObject       = "cannot apply object"
+	errDryRunObject      = "cannot dry run object"
 	errDeleteObject      = "cannot delete object"
+	errRecreateObject    = "cannot delete object to recreate it"
+	errCheckNamespaced   = "cannot determine whether object is namespaced"
+	errWaitForDependents = "cannot delete object before its %d dependent Objects are deleted"
+
+	// maxConcurrentReferenceLookups is the maximum number of resources
+	// referenced by an Object that are read concurrently.
+	maxConcurrentReferenceLookups = 8
 
 	errCreateDiscoveryClient      = "cannot create discovery client"
 	errCreateSSAExtractor         = "cannot create new unstructured server side apply extractor"
@@ -90,10 +113,14 @@ const (
 	errGetDesiredState         = "cannot get desired state"
 	errUnmarshalTemplate       = "cannot unmarshal template"
 	errFailedToMarshalExisting = "cannot marshal existing resource"
+	errHashDesiredState        = "cannot hash desired state"
 
 	errGetReferencedResource       = "cannot get referenced resource"
 	errPatchFromReferencedResource = "cannot patch from referenced resource"
 	errResolveResourceReferences   = "cannot resolve resource references"
+	errGetConnectionSecretRef      = "cannot get connection secret reference of referenced resource"
+	errGetConnectionSecret         = "cannot get connection secret of referenced resource"
+	errConnectionSecretKeyNotFound = "key %q not found in connection secret of referenced resource"
 
 	errAddFinalizer             = "cannot add finalizer to Object"
 	errRemoveFinalizer          = "cannot remove finalizer from Object"
@@ -105,7 +132,6 @@ const (
 	errGetConnectionDetails = "cannot get connection details"
 	errGetValueAtFieldPath  = "cannot get value at fieldPath"
 	errDecodeSecretData     = "cannot decode secret data"
-	errSanitizeSecretData   = "cannot sanitize secret data"
 
 	errCelQueryFailedToCompile           = "failed to compile query"
 	errCelQueryReturnTypeNotBool         = "celQuery does not return a bool type"
@@ -114,8 +140,28 @@ const (
 	errCelQueryCannotBeEmpty             = "cel query cannot be empty"
 	errCelQueryFailedToCreateEnvironment = "cel query failed to create environment"
 	errCelQueryJSON                      = "failed to marshal or unmarshal the obj for cel query"
+
+	errMatchCondition      = "%s is %q, want %q"
+	errMatchConditionField = "cannot get match condition field %q"
 )
 
+// restMappers are the RESTMappers of the provider configs, shared by the
+// clients of the Object controller and the orphan scanner.
+var restMappers = kubeclient.NewRESTMapperCache()
+
+// builderOptions returns the options of the builder of the clients of the
+// target clusters for the supplied manager and controller options.
+func builderOptions(mgr ctrl.Manager, o controller.Options) []kubeclient.BuilderOption {
+	opts := []kubeclient.BuilderOption{kubeclient.WithRESTMapperCache(restMappers)}
+	if o.Features.Enabled(features.EnableAlphaPortForwardTunnels) {
+		opts = append(opts, kubeclient.WithLocalConfig(mgr.GetConfig()))
+	}
+	if o.Features.Enabled(features.EnableAlphaProtobuf) {
+		opts = append(opts, kubeclient.WithProtobuf())
+	}
+	return opts
+}
+
 // KindObserver tracks kinds of referenced composed resources in order to start
 // watches for them for realtime events.
 type KindObserver interface {
@@ -124,6 +170,23 @@ type KindObserver interface {
 	WatchResources(rc *rest.Config, providerConfig string, gvks ...schema.GroupVersionKind)
 }
 
+// A CachedKindObserver is a KindObserver that can also serve the objects of
+// the watched kinds from its caches.
+type CachedKindObserver interface {
+	KindObserver
+	// CachedReader returns a reader of the objects of the given kind on the
+	// cluster of the given providerConfig, if they are watched and in sync.
+	CachedReader(providerConfig string, gvk schema.GroupVersionKind) (client.Reader, bool)
+}
+
+// A ManifestValidator validates manifests against the schemas published by
+// the target cluster.
+type ManifestValidator interface {
+	// Validate returns the violations of the schema of its kind by the
+	// given object, or an error if the schema cannot be fetched.
+	Validate(object *unstructured.Unstructured) (typed.ValidationErrors, error)
+}
+
 // ResourceSyncer contains the methods required to decide whether an object is
 // up-to-date or not, and to sync the object to the Kube API.
 type ResourceSyncer interface {
@@ -132,14 +195,16 @@ type ResourceSyncer interface {
 	// decide whether the object is up-to-date or not.
 	// Without server-side apply, the observed state is extracted from the last
 	// applied annotation, otherwise it is extracted from the current object
-	// using the server-side apply extractor.
+	// using the server-side apply extractor. With server-side dry-run, it is
+	// the current object itself.
 	GetObservedState(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	// GetDesiredState calculates the desired state of the object manifest that
 	// we would like to see at the Kube API so that we can compare it with the
 	// observed state to decide whether the object is up-to-date or not.
 	// Without server-side apply, the desired state is the object manifest
 	// itself, however, with server-side apply, the desired state is extracted
-	// with a dry-run apply of the object manifest. This is mostly a workaround
+	// with a dry-run apply of the object manifest. With server-side dry-run,
+	// it is the result of a dry-run patch of the object manifest. This is mostly a workaround
 	// for a limitation/bug in the server-side apply implementation due to poor
 	// handling of defaulting in certain cases.
 	// https://github.com/kubernetes/kubernetes/issues/115563
@@ -149,38 +214,96 @@ type ResourceSyncer interface {
 	SyncResource(ctx context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error)
 }
 
+// SetupOptions configure the controller of Object managed resources, beyond
+// the options it shares with the other controllers of the provider.
+type SetupOptions struct {
+	// SanitizeSecrets redacts the data of Secrets, and the values of
+	// secret-like keys of other kinds, from status, events and debug logs.
+	SanitizeSecrets bool
+	// PollJitterPercentage is the jitter of the poll interval, as a
+	// percentage of it.
+	PollJitterPercentage uint
+	// ReferenceCacheTTL is how long referenced resources are cached. They
+	// are not cached if zero.
+	ReferenceCacheTTL time.Duration
+	// MaxObservedManifestSize is the maximum size of the manifests of remote
+	// objects recorded in status.
+	MaxObservedManifestSize int64
+	// ConnectionWhenReady publishes connection details only once an Object
+	// is ready.
+	ConnectionWhenReady bool
+	// BackfillWindow is the window the reconciles of existing Objects are
+	// spread over after the provider starts. They are not spread if zero.
+	BackfillWindow time.Duration
+	// QuarantineInterval is how often flapping Objects are reconciled at
+	// most. They are not quarantined if zero.
+	QuarantineInterval time.Duration
+	// IgnoreFields are ignored on the remote objects of all Objects.
+	IgnoreFields []string
+	// Shard is the shard of the Objects reconciled by this replica.
+	Shard shard.Shard
+}
+
 // Setup adds a controller that reconciles Object managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJitterPercentage uint) error { // nolint:gocyclo // Too many branches due to alpha features, hopefully we can clean them up after we graduate them.
+func Setup(mgr ctrl.Manager, o controller.Options, so SetupOptions) error { // nolint:gocyclo // Too many branches due to alpha features, hopefully we can clean them up after we graduate them.
 	name := managed.ControllerName(v1alpha2.ObjectGroupKind)
 	l := o.Logger.WithValues("controller", name)
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if so.ConnectionWhenReady {
+		cps[0] = &readyConnectionPublisher{ConnectionPublisher: cps[0]}
+	}
+
+	// Objects are reconciled with the management and deletion policies they
+	// inherit from their provider config, but only ever written with those
+	// they declare.
+	managementPoliciesEnabled := o.Features.Enabled(feature.EnableBetaManagementPolicies)
+	kubeObjects := newObjectDefaultingClient(newStatusPatchingClient(mgr.GetClient()), managementPoliciesEnabled)
 
 	reconcilerOptions := []managed.ReconcilerOption{
-		managed.WithFinalizer(&objFinalizer{client: mgr.GetClient()}),
+		managed.WithFinalizer(&objFinalizer{client: kubeObjects, usagesEnabled: o.Features.Enabled(features.EnableAlphaUsages)}),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithPollIntervalHook(func(mg resource.Managed, pollInterval time.Duration) time.Duration {
 			if mg.GetCondition(xpv1.TypeReady).Status != v1.ConditionTrue {
 				// If the resource is not ready, we should poll more frequently not to delay time to readiness.
 				pollInterval = 30 * time.Second
 			}
-			pollJitter := time.Duration(float64(pollInterval) * (float64(pollJitterPercentage) / 100.0))
+			pollJitter := time.Duration(float64(pollInterval) * (float64(so.PollJitterPercentage) / 100.0))
 			// This is the same as runtime default poll interval with jitter, see:
 			// https://github.com/crossplane/crossplane-runtime/blob/7fcb8c5cad6fc4abb6649813b92ab92e1832d368/pkg/reconciler/managed/reconciler.go#L573
 			return pollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint G404 // No need for secure randomness
 		}),
 		managed.WithLogger(l),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithMetricRecorder(o.MetricOptions.MRMetrics),
+		managed.WithInitializers(
+			managed.NewNameAsExternalName(kubeObjects),
+			&providerConfigSelector{kube: kubeObjects},
+			&objectDefaulter{kube: mgr.GetClient(), managementPoliciesEnabled: managementPoliciesEnabled},
+		),
+	}
+
+	for _, f := range so.IgnoreFields {
+		if _, err := fieldpath.Parse(f); err != nil {
+			return errors.Wrapf(err, errIgnoreField, f)
+		}
 	}
 
 	conn := &connector{
 		logger:          o.Logger,
-		sanitizeSecrets: sanitizeSecrets,
+		sanitizeSecrets: so.SanitizeSecrets,
 		kube:            mgr.GetClient(),
 		usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-		clientBuilder:   kubeclient.NewIdentityAwareBuilder(mgr.GetClient()),
+		clientBuilder:   kubeclient.NewIdentityAwareBuilder(mgr.GetClient(), builderOptions(mgr, o)...),
+		restMappers:     restMappers,
+		breaker:         newCircuitBreaker(l, mgr.GetClient()),
+		referenceCache:  newReferenceCache(so.ReferenceCacheTTL),
+		recorder:        recorder,
+
+		maxObservedManifestSize: so.MaxObservedManifestSize,
+		ignoreFields:            so.IgnoreFields,
 	}
 
 	if o.Features.Enabled(features.EnableAlphaServerSideApply) {
@@ -189,11 +312,54 @@ func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJit
 		conn.parserCacheManager = extractor.NewGVKParserCacheManager()
 	}
 
+	if o.Features.Enabled(features.EnableAlphaServerSideDryRun) {
+		conn.dryRunEnabled = true
+	}
+
+	if o.Features.Enabled(features.EnableAlphaStructuredDiff) {
+		conn.structuredDiffEnabled = true
+	}
+
+	if o.Features.Enabled(features.EnableAlphaRequiredPermissions) {
+		conn.requiredPermissionsEnabled = true
+	}
+
+	if o.Features.Enabled(features.EnableAlphaSchemaValidation) {
+		conn.schemaValidationEnabled = true
+		if conn.parserCacheManager == nil {
+			conn.parserCacheManager = extractor.NewGVKParserCacheManager()
+		}
+	}
+
+	if o.Features.Enabled(features.EnableAlphaUsages) {
+		conn.usagesEnabled = true
+	}
+
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		conn.managementPoliciesEnabled = true
+	}
+
+	if o.Features.Enabled(features.EnableAlphaTenantCredentials) {
+		conn.tenantCredentialsEnforced = true
+	}
+
+	if err := mgr.GetCache().IndexField(context.Background(), &v1alpha2.Object{}, localRefsIndex, IndexByLocalReference); err != nil {
+		return errors.Wrap(err, "cannot add index for local object references")
+	}
+	conn.localRefsIndexed = true
+	if err := mgr.GetCache().IndexField(context.Background(), &apisv1alpha1.ProviderConfig{}, connectionSecretOfIndex, IndexByConnectionSecretOf); err != nil {
+		return errors.Wrap(err, "cannot add index for connection secrets of provider configs")
+	}
+
 	cb := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithEventFilter(resource.DesiredStateChanged()).
 		WithOptions(o.ForControllerRuntime()).
-		For(&v1alpha2.Object{})
+		For(&v1alpha2.Object{}, builder.WithPredicates(resource.DesiredStateChanged())).
+		// Secrets have no generation, so their changes are not filtered, but
+		// only connection secrets, which are controlled by their managed
+		// resources, are mapped to Objects.
+		Watches(&v1.Secret{}, handler.EnqueueRequestsFromMapFunc(objectsForConnectionSecret(mgr.GetClient(), l)), builder.WithPredicates(predicate.NewPredicateFuncs(isControlled))).
+		Watches(&apisv1alpha1.ProviderConfig{}, conn.providerConfigChanged(l))
 
 	if o.Features.Enabled(features.EnableAlphaWatches) {
 		ca := mgr.GetCache()
@@ -222,6 +388,10 @@ func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJit
 
 		cb = cb.WatchesRawSource(&i, handler.Funcs{
 			GenericFunc: func(ctx context.Context, ev runtimeevent.GenericEvent, q workqueue.RateLimitingInterface) {
+				if pc, _ := ctx.Value(keyProviderConfigName).(string); pc == "" {
+					// A referenced resource on the control plane changed.
+					conn.referenceCache.Invalidate(ev.Object)
+				}
 				enqueueObjectsForReferences(ca, l)(ctx, ev, q)
 			},
 		})
@@ -236,11 +406,26 @@ func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJit
 		mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha2.ObjectList{}, o.MetricOptions.PollStateMetricInterval)); err != nil {
 		return err
 	}
+	if err := mgr.Add(newSummaryRecorder(mgr.GetClient(), o.Logger, o.MetricOptions.PollStateMetricInterval)); err != nil {
+		return err
+	}
 
-	return cb.Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
+	// The reconcilers wrapping the managed reconciler get the Object of a
+	// request once between them.
+	kube := newRequestCachedReader(mgr.GetClient())
+	var r reconcile.Reconciler = managed.NewReconciler(newStatusPatchingManager(mgr, kubeObjects),
 		resource.ManagedKind(v1alpha2.ObjectGroupVersionKind),
 		reconcilerOptions...,
-	), o.GlobalRateLimiter))
+	)
+	r = newMeasuredReconciler(r, kube)
+	r = newQuarantineReconciler(r, kube, so.QuarantineInterval)
+	r = newPriorityReconciler(r, kube, so.Shard)
+	r = newBudgetedReconciler(r, kube)
+	r = newCreateThrottlingReconciler(r, kube)
+	r = newBackfillReconciler(r, kube, so.BackfillWindow)
+	r = shard.NewReconciler(r, kube, func() client.Object { return &v1alpha2.Object{} }, so.Shard)
+	r = newRequestCachingReconciler(r)
+	return cb.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
 type connector struct {
@@ -250,8 +435,26 @@ type connector struct {
 	sanitizeSecrets bool
 	kindObserver    KindObserver
 	ssaEnabled      bool
+	dryRunEnabled   bool
+	usagesEnabled   bool
+	breaker         *circuitBreaker
+	referenceCache  *referenceCache
+	recorder        event.Recorder
+	// tenantCredentialsEnforced restricts the Objects of claims to the
+	// provider configs whose credentials live in the namespace of the claim.
+	tenantCredentialsEnforced bool
+
+	managementPoliciesEnabled  bool
+	schemaValidationEnabled    bool
+	structuredDiffEnabled      bool
+	requiredPermissionsEnabled bool
+	localRefsIndexed           bool
+	maxObservedManifestSize    int64
+	// ignoreFields are ignored on the remote objects of all Objects.
+	ignoreFields []string
 
 	clientBuilder kubeclient.Builder
+	restMappers   *kubeclient.RESTMapperCache
 
 	stateCacheManager state.CacheManager
 
@@ -264,54 +467,124 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.New(errNotKubernetesObject)
 	}
 
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
+	// Objects with an inline kubeconfig use no provider config, and their
+	// target clusters come and go too often to be worth a circuit breaker,
+	// which reports on the provider config anyway.
+	pc := inlineProviderConfig(obj)
+	var breaker *circuitBreaker
+	if pc == nil {
+		if err := c.usage.Track(ctx, mg); err != nil {
+			return nil, errors.Wrap(err, errTrackPCUsage)
+		}
+
+		pc = &apisv1alpha1.ProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: obj.GetProviderConfigReference().Name}, pc); err != nil {
+			return nil, errors.Wrap(err, errGetProviderConfig)
+		}
+
+		if err := c.breaker.Allow(obj.GetProviderConfigReference().Name); err != nil {
+			setTargetReachability(obj, err)
+			return nil, err
+		}
+		breaker = c.breaker
 	}
 
-	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: obj.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetProviderConfig)
+	if c.tenantCredentialsEnforced {
+		if err := checkTenantCredentials(ctx, c.kube, obj, pc.GetName(), pc.Spec); err != nil {
+			return nil, err
+		}
 	}
 
-	k, rc, err := c.clientBuilder.KubeForProviderConfig(ctx, pc.Spec)
+	// The requests for the Object identify it in their user agent, e.g. in
+	// the audit logs of the target cluster.
+	k, rc, err := c.clientBuilder.KubeForProviderConfig(kubeclient.WithProviderConfigName(kubeclient.WithObjectName(ctx, obj.GetName()), pc.GetName()), pc.Spec)
 	if err != nil {
 		return nil, errors.Wrap(err, errBuildKubeForProviderConfig)
 	}
+	var requests *requestCounter
+	if recordRequestsEnabled(obj) {
+		requests = newRequestCounter()
+		k = newCountingClient(k, requests)
+	}
 
 	e := &external{
-		logger: c.logger,
+		logger: loggerFor(obj, c.logger),
 		client: resource.ClientApplicator{
 			Client:     k,
 			Applicator: resource.NewAPIPatchingApplicator(k),
 		},
-		rest:            rc,
-		localClient:     c.kube,
-		sanitizeSecrets: c.sanitizeSecrets,
+		rest:                rc,
+		localClient:         c.kube,
+		sanitizeSecrets:     c.sanitizeSecrets,
+		usagesEnabled:       c.usagesEnabled,
+		defaultNamespace:    pc.Spec.DefaultNamespace,
+		attribution:         pc.Spec.Attribution,
+		defaultIgnoreFields: defaultIgnoreFields(c.ignoreFields, pc.Spec.ObjectDefaults),
+		pauseWindows:        pc.Spec.PauseWindows,
+		secretPolicy:        pc.Spec.SecretPolicy,
+		breaker:             breaker,
+		recorder:            c.recorder,
+		requests:            requests,
+
+		managementPoliciesEnabled:  c.managementPoliciesEnabled,
+		structuredDiffEnabled:      c.structuredDiffEnabled,
+		requiredPermissionsEnabled: c.requiredPermissionsEnabled,
+		localRefsIndexed:           c.localRefsIndexed,
+		maxObservedManifestSize:    c.maxObservedManifestSize,
 
 		kindObserver: c.kindObserver,
 		syncer: &PatchingResourceSyncer{
 			client: resource.ClientApplicator{
 				Client:     k,
-				Applicator: resource.NewAPIPatchingApplicator(k),
+				Applicator: applicatorFor(obj, k),
 			},
 		},
 	}
 
-	if c.ssaEnabled {
-		dc, err := discovery.NewDiscoveryClientForConfig(rc)
-		if err != nil {
-			return nil, errors.Wrap(err, errCreateDiscoveryClient)
+	if c.dryRunEnabled || dryRunEquivalence(obj) {
+		e.preserveLiveOnlyFields = true
+		e.syncer = &DryRunResourceSyncer{
+			PatchingResourceSyncer: PatchingResourceSyncer{
+				client: resource.ClientApplicator{
+					Client:     k,
+					Applicator: applicatorFor(obj, k),
+				},
+			},
 		}
-		parserCache, err := c.parserCacheManager.LoadOrNewCacheForProviderConfig(pc)
-		if err != nil {
-			return nil, errors.Wrapf(err, errLoadSSAParserCacheTemplate, pc.GetName())
+	}
+
+	if obj.Spec.ForProvider.APIVersionPolicy == v1alpha2.APIVersionPolicyPreferredServed || obj.Spec.ForProvider.WaitForRemoteDependents {
+		if e.discovery, err = discovery.NewDiscoveryClientForConfig(rc); err != nil {
+			return nil, errors.Wrap(err, errCreateDiscoveryClient)
 		}
+	}
+
+	if !c.ssaEnabled && !c.schemaValidationEnabled {
+		return e, nil
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateDiscoveryClient)
+	}
+	parserCache, err := c.parserCacheManager.LoadOrNewCacheForProviderConfig(pc)
+	if err != nil {
+		return nil, errors.Wrapf(err, errLoadSSAParserCacheTemplate, pc.GetName())
+	}
+
+	if c.schemaValidationEnabled {
+		e.validator = extractor.NewCachingSchemaValidator(ctx, dc, parserCache)
+	}
+
+	if c.ssaEnabled {
 		applyExtractor, err := extractor.NewCachingUnstructuredExtractor(ctx, dc, parserCache)
 		if err != nil {
 			return nil, errors.Wrap(err, errCreateSSAExtractor)
 		}
+		e.preserveLiveOnlyFields = false
 		e.syncer = &SSAResourceSyncer{
 			client:    k,
+			local:     c.kube,
 			extractor: applyExtractor,
 			desiredStateCacheFn: func() state.Cache {
 				return c.stateCacheManager.LoadOrNewForManaged(mg)
@@ -325,6 +598,15 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	return e, nil
 }
 
+// applicatorFor returns the applicator that updates the remote object of the
+// supplied Object according to its update strategy.
+func applicatorFor(obj *v1alpha2.Object, k client.Client) resource.Applicator {
+	if obj.Spec.ForProvider.UpdateStrategy == v1alpha2.UpdateStrategyReplace {
+		return resource.NewAPIUpdatingApplicator(k)
+	}
+	return resource.NewAPIPatchingApplicator(k)
+}
+
 type external struct {
 	logger logging.Logger
 	client resource.ClientApplicator
@@ -335,53 +617,257 @@ type external struct {
 	kindObserver KindObserver
 
 	sanitizeSecrets bool
+	// preserveLiveOnlyFields ignores the fields that only exist on the
+	// observed state of the remote object when comparing it with its desired
+	// state, since the observed state is the whole remote object.
+	preserveLiveOnlyFields bool
+	// usagesEnabled reports whether the Object is protected from deletion by
+	// a Usage in its conditions.
+	usagesEnabled bool
+	// managementPoliciesEnabled reports whether the management and deletion
+	// policies of the Object contradict each other in its conditions.
+	managementPoliciesEnabled bool
+	// structuredDiffEnabled reports the fields at which the remote objects
+	// differ from their desired state in the status of the Object.
+	structuredDiffEnabled bool
+	// requiredPermissionsEnabled reports the permissions on the target
+	// cluster the Object requires in its status.
+	requiredPermissionsEnabled bool
+	// localRefsIndexed records the Objects referencing the Object in its
+	// status, using the index of the references of Objects.
+	localRefsIndexed bool
+	// defaultNamespace is the namespace of namespaced remote objects whose
+	// manifests do not specify one, as configured in the provider config.
+	defaultNamespace string
+	// attribution configures the labels and annotations identifying the
+	// Object on its remote objects, as configured in the provider config.
+	attribution *kconfig.Attribution
+	// defaultIgnoreFields are ignored in addition to the ignored fields of
+	// the Object, as configured for the provider and in the provider config.
+	defaultIgnoreFields []string
+	// pauseWindows are the pause windows of the provider config, during
+	// which the remote objects are not written to.
+	pauseWindows []kconfig.MaintenanceWindow
+	// secretPolicy restricts the namespaces of the Secrets the Object may
+	// read, as configured in the provider config.
+	secretPolicy *kconfig.SecretPolicy
+	// breaker fails reconciles fast while the target cluster is unreachable.
+	breaker *circuitBreaker
+	// validator validates manifests against the schemas published by the
+	// target cluster, if schema validation is enabled.
+	validator ManifestValidator
+	// loadedManifest is the manifest the Object loaded from the keys it
+	// selects, as resolved by the observation, if it selects any. It is not
+	// kept in the spec of the Object between operations.
+	loadedManifest []byte
+	// connectionSecretPatches are the fields of the manifest of the Object
+	// patched from connection secrets and secret values by the observation,
+	// and
+	// patchedManifest the manifest they are patched into. They are not kept
+	// in the spec of the Object between operations.
+	connectionSecretPatches []connectionSecretPatch
+	patchedManifest         []byte
+	// declaredManifest is the manifest of the Object before references were
+	// resolved, as recorded in its revision history once applied.
+	declaredManifest []byte
+	// observedVersions are the resourceVersions the remote objects were
+	// observed with, by their versionKey.
+	observedVersions map[string]string
+	// values are the values of spec.forProvider.valuesFrom by their names,
+	// and valueErrs why those that are unset could not be read.
+	values    map[string]any
+	valueErrs map[string]error
+	// referenceConnectionDetails are the connection details patched from
+	// referenced resources.
+	referenceConnectionDetails managed.ConnectionDetails
+	// referenceCache caches referenced resources shared by many Objects.
+	referenceCache *referenceCache
+	// maxObservedManifestSize is the size in bytes above which observed
+	// manifests are recorded compressed. Zero means no limit.
+	maxObservedManifestSize int64
+	// recorder records the applies to the remote objects of Objects that
+	// keep an apply history.
+	recorder event.Recorder
+	// discovery discovers the kinds served by the target cluster, if the
+	// Object migrates the apiVersions it does not serve or waits for the
+	// remote dependents of its remote object.
+	discovery discovery.DiscoveryInterface
+	// requests counts the requests made to the target cluster, if they are
+	// recorded in the status of the Object.
+	requests *requestCounter
 
 	// for cleaning-up the desired state cache of MR from
 	// state cache manager, when MR gets deleted
 	desiredStateCacheCleanupFn func()
 }
 
-func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo, mostly branches due to feature flags, hopefully will be refactored once they are promoted
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if obj, ok := mg.(*v1alpha2.Object); ok {
+		recordReconcileHealth(obj, time.Now())
+		defer c.stashManifestFrom(obj)
+		defer c.stashConnectionSecretPatches(obj)
+	}
+	o, err := c.observe(ctx, mg)
+	if obj, ok := mg.(*v1alpha2.Object); ok {
+		err = conversionAvailability(obj, err)
+		c.recordRequests(obj)
+	}
+	if obj, ok := mg.(*v1alpha2.Object); ok && c.breaker != nil {
+		c.breaker.Record(obj.GetProviderConfigReference().Name, c.rest, err)
+		if isUnreachable(err) {
+			setTargetReachability(obj, err)
+		} else {
+			setTargetReachability(obj, nil)
+		}
+	}
+	if obj, ok := mg.(*v1alpha2.Object); ok && err == nil {
+		return c.pauseWrites(obj, o, time.Now())
+	}
+	return o, err
+}
+
+func (c *external) observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo, mostly branches due to feature flags, hopefully will be refactored once they are promoted
 	obj, ok := mg.(*v1alpha2.Object)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotKubernetesObject)
 	}
 
-	c.logger.Debug("Observing", "resource", obj)
+	c.logger.Debug("Observing", "resource", c.redactedObject(obj))
+
+	if err := normalizeManifest(obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := c.resolveValues(ctx, obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := c.renderTemplateRef(ctx, obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := c.loadManifestFrom(ctx, obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := rollback(obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	c.declaredManifest = bytes.Clone(obj.Spec.ForProvider.Manifest.Raw)
+	if err := c.setDefaultNamespace(obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if c.usagesEnabled {
+		setInUseCondition(obj)
+	}
+	setPolicyConflictCondition(obj, c.managementPoliciesEnabled)
+	if err := c.setDependents(ctx, obj); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
 	if !meta.WasDeleted(obj) {
 		// If the object is not being deleted, we need to resolve references
 		if err := c.resolveReferencies(ctx, obj); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errResolveResourceReferences)
 		}
+		if err := c.resolveTargetOwnerReference(ctx, obj); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errResolveTargetOwnerReference)
+		}
+		if err := protectTarget(obj); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if err := c.setAttribution(obj); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if err := setOwnershipLabel(obj); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	if err := c.migrateAPIVersions(obj); err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
 	manifest, err := parseManifest(obj)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	if err := c.validateManifest(obj, manifest); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := c.setRequiredPermissions(obj, manifest); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := c.waitForCRD(ctx, obj, manifest); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if obj.Spec.ForProvider.Subresource != "" {
+		return c.observeSubresource(ctx, obj, manifest)
+	}
+
+	if obj.Spec.ForProvider.DryRun {
+		return c.observeDryRun(ctx, obj, manifest)
+	}
+	obj.Status.AtProvider.Plan = nil
+
+	if obj.Spec.ForProvider.ObservationMode == v1alpha2.ObservationModeMetadata {
+		return c.observeMetadata(ctx, obj, manifest)
+	}
+
+	if manifest.IsList() {
+		return c.observeList(ctx, obj, manifest)
+	}
 
 	if c.shouldWatch(obj) {
-		c.kindObserver.WatchResources(c.rest, obj.Spec.ProviderConfigReference.Name, manifest.GroupVersionKind())
+		c.kindObserver.WatchResources(c.rest, targetName(obj), manifest.GroupVersionKind())
 	}
 
 	current := manifest.DeepCopy()
-	err = c.client.Get(ctx, types.NamespacedName{
+	err = c.remoteReader(obj, manifest.GroupVersionKind()).Get(ctx, types.NamespacedName{
 		Namespace: current.GetNamespace(),
 		Name:      current.GetName(),
 	}, current)
 
 	if kerrors.IsNotFound(err) {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+		obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+		obj.Status.AtProvider.LastAppliedHash = ""
+		obj.Status.AtProvider.ObservedResourceVersion = ""
+		return notFoundObservation(obj, time.Now())
 	}
 
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
 	}
+	obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+	if err := checkOwnership(obj, current); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	setOwned(obj)
+	if err := c.releaseRemote(ctx, obj, current); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	c.recordObservedVersion(current)
+
+	c.logger.Debug("Observed resource", "gvk", current.GroupVersionKind().String(), "namespace", current.GetNamespace(), "name", current.GetName(), "resourceVersion", current.GetResourceVersion())
+
+	hash, err := c.appliedStateHash(obj)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	// Neither the desired state nor the remote object changed since we last
+	// confirmed they are in sync, so we can skip the comparison.
+	unchanged := obj.Status.AtProvider.LastAppliedHash == hash && obj.Status.AtProvider.ObservedResourceVersion == current.GetResourceVersion()
+	obj.Status.AtProvider.ObservedResourceVersion = current.GetResourceVersion()
 
+	if err = c.recordFieldChanges(obj, current); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	if err = c.setAtProvider(obj, current); err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	obj.Status.AtProvider.Diff = nil
+
+	if unchanged {
+		c.logger.Debug("Desired state and remote object are unchanged, skipping comparison", "hash", hash)
+		return c.handleObservation(ctx, obj, true)
+	}
 
 	// observedState contains the extracted state of the current object that
 	// should be compared with the desired state of the object to decide whether
@@ -399,7 +885,30 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetDesiredState)
 	}
 
-	return c.handleObservation(ctx, obj, observedState, desiredState)
+	upToDate, err := c.isUpToDate(obj, observedState, desiredState)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	switch {
+	case upToDate && observesOnly(obj):
+		// Nothing is applied to the remote objects of Objects that only
+		// observe them, so they are compared again once they may apply.
+		obj.Status.AtProvider.LastAppliedHash = ""
+	case upToDate:
+		obj.Status.AtProvider.LastAppliedHash = hash
+	default:
+		if obj.Status.AtProvider.Diff, err = c.fieldDiffs(nil, obj, "", observedState, desiredState); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if obj.Status.AtProvider.LastAppliedHash == hash {
+			// The desired state did not change since it was last in sync
+			// with the remote object, so the remote object drifted.
+			setDriftDetected(obj)
+		}
+		obj.Status.AtProvider.LastAppliedHash = ""
+	}
+
+	return c.handleObservation(ctx, obj, upToDate)
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -407,19 +916,40 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotKubernetesObject)
 	}
+	defer c.recordRequests(obj)
+	c.restoreManifestFrom(obj)
+	c.restoreConnectionSecretPatches(obj)
+	defer c.stashManifestFrom(obj)
+	defer c.stashConnectionSecretPatches(obj)
 
-	c.logger.Debug("Creating", "resource", obj)
+	c.logger.Debug("Creating", "resource", c.redactedObject(obj))
 
 	res, err := parseManifest(obj)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
+	if obj.Spec.ForProvider.Subresource != "" {
+		return managed.ExternalCreation{}, c.syncSubresource(ctx, obj, res)
+	}
+	if res.IsList() {
+		return managed.ExternalCreation{}, c.syncList(ctx, obj, res, false)
+	}
+
 	current, err := c.syncer.SyncResource(ctx, obj, res)
 	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(CleanErr(err), errCreateObject)
+		c.recordFailure(obj, res, err)
+		return managed.ExternalCreation{}, errors.Wrap(c.redactedError(res, CleanErr(err)), errCreateObject)
+	}
+	c.logger.Debug("Created resource", "resource", c.redacted(current))
+	setApplied(obj)
+	if err := recordRevision(obj, c.declaredManifest, c.sanitizeSecrets); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := c.setAtProvider(obj, current); err != nil {
+		return managed.ExternalCreation{}, err
 	}
-	return managed.ExternalCreation{}, c.setAtProvider(obj, current)
+	return managed.ExternalCreation{}, c.recordApplies(ctx, obj, nil)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -427,19 +957,51 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotKubernetesObject)
 	}
+	defer c.recordRequests(obj)
+	c.restoreManifestFrom(obj)
+	c.restoreConnectionSecretPatches(obj)
+	defer c.stashManifestFrom(obj)
+	defer c.stashConnectionSecretPatches(obj)
 
-	c.logger.Debug("Updating", "resource", obj)
+	c.logger.Debug("Updating", "resource", c.redactedObject(obj))
 
 	res, err := parseManifest(obj)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	current, err := c.syncer.SyncResource(ctx, obj, res)
+	if obj.Spec.ForProvider.Subresource != "" {
+		return managed.ExternalUpdate{}, c.syncSubresource(ctx, obj, res)
+	}
+	if res.IsList() {
+		return managed.ExternalUpdate{}, c.syncList(ctx, obj, res, true)
+	}
+
+	current, err := c.updateResource(ctx, obj, res)
+	if err != nil && obj.Spec.ForProvider.UpdatePolicy == v1alpha2.UpdatePolicyRecreateOnImmutableError && isImmutableError(err) {
+		// The remote object cannot be updated in place. Delete it, so that
+		// it is created again with the desired state on the next reconcile.
+		c.logger.Debug("Recreating resource due to an immutable field change", "error", CleanErr(err))
+		return managed.ExternalUpdate{}, errors.Wrap(resource.IgnoreNotFound(c.client.Delete(ctx, res, client.PropagationPolicy(metav1.DeletePropagationBackground))), errRecreateObject)
+	}
+	if err != nil {
+		c.recordFailure(obj, res, err)
+		return managed.ExternalUpdate{}, errors.Wrap(c.redactedError(res, CleanErr(err)), errApplyObject)
+	}
+	c.logger.Debug("Updated resource", "resource", c.redacted(current))
+	recordAdoption(obj)
+	setApplied(obj)
+	if err := recordRevision(obj, c.declaredManifest, c.sanitizeSecrets); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	before, err := observedManifest(obj)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(CleanErr(err), errApplyObject)
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.setAtProvider(obj, current); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
-	return managed.ExternalUpdate{}, c.setAtProvider(obj, current)
+	return managed.ExternalUpdate{}, c.recordApplies(ctx, obj, before)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -447,28 +1009,121 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotKubernetesObject)
 	}
+	defer c.recordRequests(obj)
+	c.restoreManifestFrom(obj)
+	c.restoreConnectionSecretPatches(obj)
+	defer c.stashManifestFrom(obj)
+	defer c.stashConnectionSecretPatches(obj)
 
-	c.logger.Debug("Deleting", "resource", obj)
+	c.logger.Debug("Deleting", "resource", c.redactedObject(obj))
+
+	// Objects depending on this one hold a reference finalizer on it until
+	// their own resources are deleted from the target cluster, so that the
+	// resources are torn down in the reverse order of their dependencies.
+	if n := dependents(obj); n > 0 {
+		return errors.Errorf(errWaitForDependents, n)
+	}
+	if err := c.pauseDeletion(obj, time.Now()); err != nil {
+		return err
+	}
 
 	res, err := parseManifest(obj)
 	if err != nil {
 		return err
 	}
+	if err := c.waitForRemoteDependents(ctx, obj, res); err != nil {
+		return err
+	}
+
+	if err := c.runPreDeleteHook(ctx, obj); err != nil {
+		return err
+	}
+	if err := c.deleteHooks(ctx, obj); err != nil {
+		return err
+	}
 
 	// SSA is enabled
 	if c.desiredStateCacheCleanupFn != nil {
 		c.desiredStateCacheCleanupFn()
 	}
+
+	if res.IsList() {
+		return c.deleteList(ctx, obj, res)
+	}
+	if targetProtected(obj) {
+		if err := c.unprotectTarget(ctx, res); err != nil {
+			return err
+		}
+	}
 	return errors.Wrap(resource.IgnoreNotFound(c.client.Delete(ctx, res)), errDeleteObject)
 }
 
+// setDefaultNamespace sets the namespace of the namespaced remote objects of the
+// supplied Object whose manifests do not specify one to the default namespace
+// of its provider config, if any.
+func (c *external) setDefaultNamespace(obj *v1alpha2.Object) error {
+	if c.defaultNamespace == "" {
+		return nil
+	}
+	return updateManifest(obj, func(o *unstructured.Unstructured) error {
+		if o.GetNamespace() != "" {
+			return nil
+		}
+		namespaced, err := c.client.IsObjectNamespaced(o)
+		if err != nil {
+			return errors.Wrap(err, errCheckNamespaced)
+		}
+		if namespaced {
+			o.SetNamespace(c.defaultNamespace)
+		}
+		return nil
+	})
+}
+
+// dependents returns the number of Objects that hold a reference finalizer on
+// the supplied Object, i.e. that depend on it and are not deleted yet.
+func dependents(obj *v1alpha2.Object) int {
+	n := 0
+	for _, f := range obj.GetFinalizers() {
+		if strings.HasPrefix(f, refFinalizerNamePrefix) {
+			n++
+		}
+	}
+	return n
+}
+
+// isImmutableError returns true if the supplied error is the API server
+// rejecting a change to an immutable field.
+func isImmutableError(err error) bool {
+	if !kerrors.IsInvalid(err) {
+		return false
+	}
+	var status kerrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, c := range status.Status().Details.Causes {
+			if strings.Contains(c.Message, "field is immutable") {
+				return true
+			}
+		}
+	}
+	return strings.Contains(err.Error(), "field is immutable")
+}
+
 func ssaFieldOwner(name string) string {
 	return fmt.Sprintf("provider-kubernetes/%s", name)
 }
 
 func parseManifest(obj *v1alpha2.Object) (*unstructured.Unstructured, error) {
+	raw := obj.Spec.ForProvider.Manifest.Raw
+	if len(raw) == 0 && obj.Spec.ForProvider.ManifestYAML != "" {
+		var err error
+		if raw, err = manifestFromYAML(obj.Spec.ForProvider.ManifestYAML); err != nil {
+			return nil, err
+		}
+	}
+
 	r := &unstructured.Unstructured{}
-	if err := json.Unmarshal(obj.Spec.ForProvider.Manifest.Raw, r); err != nil {
+	if err := json.Unmarshal(raw, r); err != nil {
 		return nil, errors.Wrap(err, errUnmarshalTemplate)
 	}
 
@@ -479,21 +1134,55 @@ func parseManifest(obj *v1alpha2.Object) (*unstructured.Unstructured, error) {
 	return r, nil
 }
 
-func (c *external) setAtProvider(obj *v1alpha2.Object, observed *unstructured.Unstructured) error {
-	var err error
+// desiredStateHash returns a hash of the desired state of the supplied
+// Object, i.e. its forProvider parameters after references were resolved.
+func desiredStateHash(obj *v1alpha2.Object) (string, error) {
+	b, err := json.Marshal(obj.Spec.ForProvider)
+	if err != nil {
+		return "", errors.Wrap(err, errHashDesiredState)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	if c.sanitizeSecrets {
-		if observed.GetKind() == "Secret" && observed.GetAPIVersion() == "v1" {
-			data := map[string][]byte{"redacted": []byte(nil)}
-			if err = fieldpath.Pave(observed.Object).SetValue("data", data); err != nil {
-				return errors.Wrap(err, errSanitizeSecretData)
-			}
-		}
+// appliedStateHash returns a hash of everything the remote object of the
+// supplied Object is compared with to decide whether it is up-to-date, i.e. its
+// desired state, its management policies and the fields ignored on it,
+// including those ignored on the remote objects of all Objects.
+func (c *external) appliedStateHash(obj *v1alpha2.Object) (string, error) {
+	b, err := json.Marshal(struct {
+		ForProvider        v1alpha2.ObjectParameters `json:"forProvider"`
+		ManagementPolicies xpv1.ManagementPolicies   `json:"managementPolicies"`
+		IgnoreFields       []string                  `json:"ignoreFields"`
+	}{
+		ForProvider:        obj.Spec.ForProvider,
+		ManagementPolicies: obj.GetManagementPolicies(),
+		IgnoreFields:       append(ignoredFields(obj), c.defaultIgnoreFields...),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errHashDesiredState)
 	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setApplied records a successful apply of the current generation of the
+// supplied Object.
+func setApplied(obj *v1alpha2.Object) {
+	obj.Status.AtProvider.LastAppliedTime = ptr.To(metav1.Now())
+	obj.Status.ObservedGeneration = obj.GetGeneration()
+	setDriftCorrected(obj)
+	setChangesApplied(obj)
+	clearFailure(obj)
+}
 
-	if obj.Status.AtProvider.Manifest.Raw, err = observed.MarshalJSON(); err != nil {
-		return errors.Wrap(err, errFailedToMarshalExisting)
+func (c *external) setAtProvider(obj *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	c.sanitizeObserved(observed)
+
+	if err := c.setObservedManifest(obj, observedForStatus(obj, observed)); err != nil {
+		return err
 	}
+	obj.Status.AtProvider.Children = nil
 
 	if err := c.updateConditionFromObserved(obj, observed); err != nil {
 		return err
@@ -501,6 +1190,15 @@ func (c *external) setAtProvider(obj *v1alpha2.Object, observed *unstructured.Un
 	return nil
 }
 
+// sanitizeObserved redacts the data of the observed object if it is a Secret,
+// or the values of its secret-like data keys otherwise, if secrets should be
+// sanitized.
+func (c *external) sanitizeObserved(observed *unstructured.Unstructured) {
+	if c.sanitizeSecrets {
+		redactSecretData(observed)
+	}
+}
+
 func (c *external) updateConditionFromObserved(obj *v1alpha2.Object, observed *unstructured.Unstructured) error {
 	var ready bool
 	var err error
@@ -512,6 +1210,8 @@ func (c *external) updateConditionFromObserved(obj *v1alpha2.Object, observed *u
 		ready = c.checkAllConditions(observed)
 	case v1alpha2.ReadinessPolicyDeriveFromCelQuery:
 		ready, err = c.checkDeriveFromCelQuery(obj, observed)
+	case v1alpha2.ReadinessPolicyMatchConditions:
+		ready, err = checkMatchConditions(obj, observed)
 	case v1alpha2.ReadinessPolicySuccessfulCreate, "":
 		// do nothing, will be handled by c.handleObservation method
 		// "" should never happen, but just in case we will treat it as SuccessfulCreate for backward compatibility
@@ -544,6 +1244,12 @@ func getReferenceInfo(ref v1alpha2.Reference) (string, string, string, string) {
 		kind = ref.PatchesFrom.Kind
 		namespace = ref.PatchesFrom.Namespace
 		name = ref.PatchesFrom.Name
+	} else if ref.PatchesFromConnectionSecret != nil {
+		// Reference information defined in PatchesFromConnectionSecret
+		apiVersion = ref.PatchesFromConnectionSecret.APIVersion
+		kind = ref.PatchesFromConnectionSecret.Kind
+		namespace = ref.PatchesFromConnectionSecret.Namespace
+		name = ref.PatchesFromConnectionSecret.Name
 	} else if ref.DependsOn != nil {
 		// Reference information defined in DependsOn
 		apiVersion = ref.DependsOn.APIVersion
@@ -585,6 +1291,27 @@ func (c *external) checkAllConditions(observed *unstructured.Unstructured) (allT
 	return allTrue
 }
 
+// checkMatchConditions returns true if all match conditions of the supplied
+// Object hold on the observed object, or an error describing the first one
+// that does not.
+func checkMatchConditions(obj *v1alpha2.Object, observed *unstructured.Unstructured) (bool, error) {
+	p := fieldpath.Pave(observed.Object)
+	for _, mc := range obj.Spec.Readiness.MatchConditions {
+		if mc.Type != "" {
+			conditioned := xpv1.ConditionedStatus{}
+			_ = p.GetValueInto("status", &conditioned)
+			if got := conditioned.GetCondition(xpv1.ConditionType(mc.Type)).Status; got != mc.Status {
+				return false, errors.Errorf(errMatchCondition, "condition "+mc.Type, got, mc.Status)
+			}
+			continue
+		}
+		if err := matchFieldValue(p, mc.FieldPath, mc.Value); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // checkDeriveFromCelQuery will look at the celQuery field and run it as a program, using the observed object as input to
 // evaluate if the object is ready or not
 func (c *external) checkDeriveFromCelQuery(obj *v1alpha2.Object, observed *unstructured.Unstructured) (ready bool, err error) {
@@ -657,41 +1384,54 @@ func (c *external) checkDeriveFromCelQuery(obj *v1alpha2.Object, observed *unstr
 // error and requeue to wait for resolving it next time.
 func (c *external) resolveReferencies(ctx context.Context, obj *v1alpha2.Object) error {
 	c.logger.Debug("Resolving referencies.")
+	c.connectionSecretPatches = nil
+
+	// Get the referenced resources concurrently, since every one of them
+	// costs a request.
+	refs := make([]*referencedResource, len(obj.Spec.References))
+	errs := make([]error, len(obj.Spec.References))
+	g := &errgroup.Group{}
+	g.SetLimit(maxConcurrentReferenceLookups)
+	for i, ref := range obj.Spec.References {
+		if ref.DependsOn == nil && ref.PatchesFrom == nil && ref.PatchesFromConnectionSecret == nil {
+			continue
+		}
+		g.Go(func() error {
+			refs[i], errs[i] = c.getReferencedResource(ctx, obj, ref)
+			return nil
+		})
+	}
+	_ = g.Wait()
 
-	// Loop through references to resolve each referenced resource
+	// Patch from the referenced resources in order, so that later references
+	// win when patching the same field.
+	labels, annotations := maps.Clone(obj.GetLabels()), maps.Clone(obj.GetAnnotations())
+	c.referenceConnectionDetails = nil
+	obj.Status.AtProvider.SkippedReferences = nil
 	gvks := make([]schema.GroupVersionKind, 0, len(obj.Spec.References))
-	for _, ref := range obj.Spec.References {
-		if ref.DependsOn == nil && ref.PatchesFrom == nil {
+	for i, ref := range obj.Spec.References {
+		if errs[i] != nil {
+			gvk, skipped := skipReference(obj, ref, errs[i])
+			if !skipped {
+				return errs[i]
+			}
+			gvks = append(gvks, gvk)
 			continue
 		}
-
-		refAPIVersion, refKind, refNamespace, refName := getReferenceInfo(ref)
-		res := &unstructured.Unstructured{}
-		res.SetAPIVersion(refAPIVersion)
-		res.SetKind(refKind)
-		// Try to get referenced resource
-		err := c.localClient.Get(ctx, client.ObjectKey{
-			Namespace: refNamespace,
-			Name:      refName,
-		}, res)
-
-		if err != nil {
-			return errors.Wrap(err, errGetReferencedResource)
+		if refs[i] == nil && ref.PatchesFromValue == nil {
+			continue
 		}
-
-		// Patch fields if any
-		if ref.PatchesFrom != nil && ref.PatchesFrom.FieldPath != nil {
-			if err := ref.ApplyFromFieldPathPatch(res, obj); err != nil {
-				return errors.Wrap(err, errPatchFromReferencedResource)
+		if refs[i] != nil {
+			gvks = append(gvks, refs[i].resource.GroupVersionKind())
+		}
+		if err := c.patchFromReference(obj, ref, refs[i]); err != nil {
+			if _, skipped := skipReference(obj, ref, err); !skipped {
+				return err
 			}
 		}
-
-		g, v := parseAPIVersion(refAPIVersion)
-		gvks = append(gvks, schema.GroupVersionKind{
-			Group:   g,
-			Version: v,
-			Kind:    refKind,
-		})
+	}
+	if err := c.patchObjectMetadata(ctx, obj, labels, annotations); err != nil {
+		return err
 	}
 
 	if c.shouldWatch(obj) {
@@ -704,27 +1444,201 @@ func (c *external) resolveReferencies(ctx context.Context, obj *v1alpha2.Object)
 	return nil
 }
 
-func (c *external) handleObservation(ctx context.Context, obj *v1alpha2.Object, last, desired *unstructured.Unstructured) (managed.ExternalObservation, error) {
-	isUpToDate := false
+// patchFromReference patches the supplied Object from the supplied resource
+// referenced by the supplied reference.
+func (c *external) patchFromReference(obj *v1alpha2.Object, ref v1alpha2.Reference, res *referencedResource) error {
+	if ref.PatchesFromValue != nil {
+		return c.patchFromValue(obj, ref)
+	}
+	if ref.PatchTo == v1alpha2.PatchToConnectionSecret {
+		return c.patchToConnectionSecret(ref, res)
+	}
+
+	// Patch fields if any
+	if ref.PatchesFrom != nil && ref.PatchesFrom.FieldPath != nil {
+		if err := ref.ApplyFromFieldPathPatch(res.resource, obj); err != nil {
+			return errors.Wrap(err, errPatchFromReferencedResource)
+		}
+	}
+
+	if ref.PatchesFromConnectionSecret != nil {
+		return c.patchFromConnectionSecret(obj, ref, res.connectionSecretValue)
+	}
+	return nil
+}
+
+// referencedResource is a resource referenced by an Object, along with the
+// value of the connection secret key it is patched from, if any.
+type referencedResource struct {
+	resource              *unstructured.Unstructured
+	connectionSecretValue string
+}
 
-	if !sets.New[xpv1.ManagementAction](obj.GetManagementPolicies()...).
-		HasAny(xpv1.ManagementActionUpdate, xpv1.ManagementActionCreate, xpv1.ManagementActionAll) {
+// getReferencedResource gets the resource referenced by the supplied
+// reference, and its connection secret value if the reference patches from it.
+func (c *external) getReferencedResource(ctx context.Context, obj *v1alpha2.Object, ref v1alpha2.Reference) (*referencedResource, error) {
+	refAPIVersion, refKind, refNamespace, refName := getReferenceInfo(ref)
+	if isSecret(refAPIVersion, refKind) {
+		if err := c.checkSecretNamespace(obj, refNamespace, refName); err != nil {
+			return nil, err
+		}
+	}
+	res := &unstructured.Unstructured{}
+	res.SetAPIVersion(refAPIVersion)
+	res.SetKind(refKind)
+	// Try to get referenced resource
+	if err := c.referenceCache.Get(ctx, c.localClient, client.ObjectKey{
+		Namespace: refNamespace,
+		Name:      refName,
+	}, res); err != nil {
+		return nil, errors.Wrap(err, errGetReferencedResource)
+	}
+	// The resource read may lack its type metadata, e.g. when read from a
+	// cache, so it is set again for the watch of its kind.
+	res.SetAPIVersion(refAPIVersion)
+	res.SetKind(refKind)
+	if err := checkMatchFields(res, referenceMatchFields(ref)); err != nil {
+		return nil, err
+	}
+
+	rr := &referencedResource{resource: res}
+	if ref.PatchesFromConnectionSecret != nil {
+		v, err := c.connectionSecretValue(ctx, obj, res, ref.PatchesFromConnectionSecret.Key)
+		if err != nil {
+			return nil, err
+		}
+		rr.connectionSecretValue = v
+	}
+	return rr, nil
+}
+
+// connectionSecretValue returns the value of the supplied key of the
+// connection secret of the supplied managed resource.
+func (c *external) connectionSecretValue(ctx context.Context, obj *v1alpha2.Object, mr *unstructured.Unstructured, key string) (string, error) {
+	ref := xpv1.SecretReference{}
+	if err := fieldpath.Pave(mr.Object).GetValueInto("spec.writeConnectionSecretToRef", &ref); err != nil {
+		return "", errors.Wrap(err, errGetConnectionSecretRef)
+	}
+	if err := c.checkSecretNamespace(obj, ref.Namespace, ref.Name); err != nil {
+		return "", err
+	}
+	s := &v1.Secret{}
+	if err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetConnectionSecret)
+	}
+	v, ok := s.Data[key]
+	if !ok {
+		return "", keyNotFoundError{errors.Errorf(errConnectionSecretKeyNotFound, key)}
+	}
+	return string(v), nil
+}
+
+// isUpToDate returns true if the observed state of the remote object matches
+// its desired state, apart from the ignored fields, or if the Object is not
+// allowed to change it anyway.
+func (c *external) isUpToDate(obj *v1alpha2.Object, last, desired *unstructured.Unstructured) (bool, error) {
+	if observesOnly(obj) {
 		// Treated as up-to-date as we don't update or create the resource
-		isUpToDate = true
+		return true, nil
+	}
+
+	last, desired, err := c.comparableStates(obj, last, desired)
+	if err != nil {
+		return false, err
 	}
+
 	if last != nil && equality.Semantic.DeepEqual(last, desired) {
 		// Mark as up-to-date since last is equal to desired
-		isUpToDate = true
+		return true, nil
+	}
+
+	if debugEnabled(obj) {
+		c.logger.Debug("Resource is not up to date", "diff", cmp.Diff(c.redacted(last), c.redacted(desired)))
+	}
+	return false, nil
+}
+
+// observesOnly returns true if the management policies of the supplied Object
+// allow it neither to create nor to update its remote object.
+func observesOnly(obj *v1alpha2.Object) bool {
+	return !sets.New[xpv1.ManagementAction](obj.GetManagementPolicies()...).
+		HasAny(xpv1.ManagementActionUpdate, xpv1.ManagementActionCreate, xpv1.ManagementActionAll)
+}
+
+// comparableStates returns the supplied observed and desired states of the
+// remote object of the supplied Object without the fields that are not
+// compared to decide whether it is up-to-date.
+func (c *external) comparableStates(obj *v1alpha2.Object, last, desired *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	var err error
+	ignored := append(ignoredFields(obj), c.defaultIgnoreFields...)
+	if last, err = withoutFields(last, ignored); err != nil {
+		return nil, nil, err
+	}
+	if desired, err = withoutFields(desired, ignored); err != nil {
+		return nil, nil, err
+	}
+	stripped := stripRules(obj)
+	if last, err = withoutItems(last, stripped); err != nil {
+		return nil, nil, err
+	}
+	if desired, err = withoutItems(desired, stripped); err != nil {
+		return nil, nil, err
+	}
+	if c.preserveLiveOnlyFields && !obj.Spec.ForProvider.CompareLiveOnlyFields {
+		last = withoutLiveOnlyFields(last, desired)
+	}
+	return last, desired, nil
+}
+
+func (c *external) handleObservation(ctx context.Context, obj *v1alpha2.Object, isUpToDate bool) (managed.ExternalObservation, error) {
+	ready := obj.GetCondition(xpv1.TypeReady).Status == v1.ConditionTrue
+	if p := obj.Spec.Readiness.Policy; p == v1alpha2.ReadinessPolicySuccessfulCreate || p == "" {
+		ready = ready || isUpToDate
+	}
+	checkReadinessTimeout(obj, ready, time.Now())
+
+	if !isUpToDate {
+		deferred := failedPermanently(obj) || readinessTimedOut(obj, true)
+		if !deferred {
+			var err error
+			if deferred, err = deferApply(obj, time.Now()); err != nil {
+				return managed.ExternalObservation{}, err
+			}
+		}
+		if deferred {
+			c.logger.Debug("Not up to date, but changes are deferred by the apply schedule, a permanent failure or a readiness timeout")
+			if err := c.publishObservedFields(ctx, obj); err != nil {
+				return managed.ExternalObservation{}, err
+			}
+			cd, err := c.connectionDetails(ctx, obj)
+			if err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errGetConnectionDetails)
+			}
+			return managed.ExternalObservation{
+				ResourceExists:    true,
+				ResourceUpToDate:  true,
+				ConnectionDetails: cd,
+			}, nil
+		}
 	}
 
 	if isUpToDate {
 		c.logger.Debug("Up to date!")
+		obj.Status.ObservedGeneration = obj.GetGeneration()
+		setChangesApplied(obj)
+		clearFailure(obj)
 
 		if p := obj.Spec.Readiness.Policy; p == v1alpha2.ReadinessPolicySuccessfulCreate || p == "" {
 			obj.Status.SetConditions(xpv1.Available())
 		}
+		if err := c.observePostCreateHook(ctx, obj); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if err := c.publishObservedFields(ctx, obj); err != nil {
+			return managed.ExternalObservation{}, err
+		}
 
-		cd, err := connectionDetails(ctx, c.client, obj.Spec.ConnectionDetails)
+		cd, err := c.connectionDetails(ctx, obj)
 		if err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errGetConnectionDetails)
 		}
@@ -745,6 +1659,9 @@ func (c *external) handleObservation(ctx context.Context, obj *v1alpha2.Object,
 type objFinalizer struct {
 	resource.Finalizer
 	client client.Client
+	// usagesEnabled protects the resources referenced by an Object from
+	// deletion with Usages.
+	usagesEnabled bool
 }
 
 type refFinalizerFn func(context.Context, *unstructured.Unstructured, string) error
@@ -752,7 +1669,7 @@ type refFinalizerFn func(context.Context, *unstructured.Unstructured, string) er
 func (f *objFinalizer) handleRefFinalizer(ctx context.Context, obj *v1alpha2.Object, finalizerFn refFinalizerFn, ignoreNotFound bool) error {
 	// Loop through references to resolve each referenced resource
 	for _, ref := range obj.Spec.References {
-		if ref.DependsOn == nil && ref.PatchesFrom == nil {
+		if ref.DependsOn == nil && ref.PatchesFrom == nil && ref.PatchesFromConnectionSecret == nil {
 			continue
 		}
 
@@ -790,10 +1707,28 @@ func (f *objFinalizer) AddFinalizer(ctx context.Context, res resource.Object) er
 		return errors.New(errNotKubernetesObject)
 	}
 
-	if meta.FinalizerExists(obj, objFinalizerName) {
+	if finalizerDisabled(obj) {
+		if !meta.FinalizerExists(obj, finalizerName(obj)) && !meta.FinalizerExists(obj, objFinalizerName) {
+			return nil
+		}
+		// The finalizer was disabled.
+		return f.RemoveFinalizer(ctx, obj)
+	}
+
+	if f.usagesEnabled {
+		if err := f.ensureUsages(ctx, obj); err != nil {
+			return errors.Wrap(err, errAddFinalizer)
+		}
+	}
+	name := finalizerName(obj)
+	if meta.FinalizerExists(obj, name) && (name == objFinalizerName || !meta.FinalizerExists(obj, objFinalizerName)) {
 		return nil
 	}
-	meta.AddFinalizer(obj, objFinalizerName)
+	meta.AddFinalizer(obj, name)
+	if name != objFinalizerName {
+		// The finalizer was renamed.
+		meta.RemoveFinalizer(obj, objFinalizerName)
+	}
 
 	err := f.client.Update(ctx, obj)
 	if err != nil {
@@ -835,15 +1770,37 @@ func (f *objFinalizer) RemoveFinalizer(ctx context.Context, res resource.Object)
 		return errors.Wrap(err, errRemoveFinalizer)
 	}
 
-	if !meta.FinalizerExists(obj, objFinalizerName) {
+	if f.usagesEnabled {
+		if err := f.deleteUsages(ctx, obj); err != nil {
+			return errors.Wrap(err, errRemoveFinalizer)
+		}
+	}
+
+	name := finalizerName(obj)
+	if !meta.FinalizerExists(obj, name) && !meta.FinalizerExists(obj, objFinalizerName) {
 		return nil
 	}
+	meta.RemoveFinalizer(obj, name)
 	meta.RemoveFinalizer(obj, objFinalizerName)
 
 	err = f.client.Update(ctx, obj)
 	return errors.Wrap(err, errRemoveFinalizer)
 }
 
+// finalizerName returns the name of the finalizer of the supplied Object.
+func finalizerName(obj *v1alpha2.Object) string {
+	if f := obj.Spec.Finalizer; f != nil && f.Name != "" {
+		return f.Name
+	}
+	return objFinalizerName
+}
+
+// finalizerDisabled returns true if the supplied Object should have no
+// finalizers.
+func finalizerDisabled(obj *v1alpha2.Object) bool {
+	return obj.Spec.Finalizer != nil && obj.Spec.Finalizer.Disabled
+}
+
 func connectionDetails(ctx context.Context, kube client.Client, connDetails []v1alpha2.ConnectionDetail) (managed.ConnectionDetails, error) {
 	mcd := managed.ConnectionDetails{}
 
@@ -853,6 +1810,15 @@ func connectionDetails(ctx context.Context, kube client.Client, connDetails []v1
 			return mcd, errors.Wrap(err, errGetObject)
 		}
 
+		if cd.Template != "" {
+			v, err := renderConnectionTemplate(cd.Template, ro.Object)
+			if err != nil {
+				return mcd, err
+			}
+			mcd[cd.ToConnectionSecretKey] = []byte(v)
+			continue
+		}
+
 		paved := fieldpath.Pave(ro.Object)
 		v, err := paved.GetValue(cd.FieldPath)
 		if err != nil {
@@ -875,8 +1841,37 @@ func connectionDetails(ctx context.Context, kube client.Client, connDetails []v1
 	return mcd, nil
 }
 
+// remoteReader returns the reader to get the remote objects of the given kind
+// of the supplied Object from. Observe-only Objects are served from the cache
+// of the watch on their kind once it is in sync, so that many of them sharing
+// a kind do not cause a request each on every poll.
+func (c *external) remoteReader(cr *v1alpha2.Object, gvk schema.GroupVersionKind) client.Reader {
+	if co, ok := c.kindObserver.(CachedKindObserver); ok && c.shouldWatch(cr) && observeOnly(cr) {
+		if r, ok := co.CachedReader(targetName(cr), gvk); ok {
+			return r
+		}
+	}
+	return c.client
+}
+
 func (c *external) shouldWatch(cr *v1alpha2.Object) bool {
-	return c.kindObserver != nil && cr.Spec.Watch
+	return c.kindObserver != nil && watched(cr)
+}
+
+// watched returns true if the supplied Object should be reconciled as soon as
+// the resources it references or manages change. Objects that only observe
+// their remote object are always watched, so that their status follows the
+// remote object without waiting for the poll interval, unless they observe
+// only its metadata, since watches cache whole objects.
+func watched(cr *v1alpha2.Object) bool {
+	return cr.Spec.Watch || (observeOnly(cr) && cr.Spec.ForProvider.ObservationMode != v1alpha2.ObservationModeMetadata)
+}
+
+// observeOnly returns true if the supplied Object only observes its remote
+// object.
+func observeOnly(cr *v1alpha2.Object) bool {
+	p := cr.GetManagementPolicies()
+	return len(p) == 1 && p[0] == xpv1.ManagementActionObserve
 }
 
 func unstructuredFromObjectRef(r v1.ObjectReference) unstructured.Unstructured {
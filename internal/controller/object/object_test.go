@@ -17,9 +17,12 @@ limitations under the License.
 package object
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,10 +56,11 @@ import (
 const (
 	providerName = "kubernetes-test"
 
-	testObjectName          = "test-object"
-	testNamespace           = "test-namespace"
-	testReferenceObjectName = "test-ref-object"
-	testSecretName          = "testcreds"
+	testObjectName           = "test-object"
+	testNamespace            = "test-namespace"
+	testReferenceObjectName  = "test-ref-object"
+	testSecretName           = "testcreds"
+	testConnectionSecretName = "test-connection-secret"
 
 	externalResourceName = "crossplane-system"
 
@@ -71,7 +76,10 @@ var (
 		}
 	}`, externalResourceName))
 
-	errBoom = errors.New("boom")
+	errBoom      = errors.New("boom")
+	errImmutable = kerrors.NewInvalid(schema.GroupKind{Kind: "Namespace"}, externalResourceName, field.ErrorList{
+		field.Invalid(field.NewPath("spec", "foo"), "bar", "field is immutable"),
+	})
 )
 
 type notKubernetesObject struct {
@@ -99,7 +107,9 @@ func kubernetesObject(om ...kubernetesObjectModifier) *v1alpha2.Object {
 				ManagementPolicies: xpv1.ManagementPolicies{xpv1.ManagementActionAll},
 			},
 			ForProvider: v1alpha2.ObjectParameters{
-				Manifest: runtime.RawExtension{Raw: externalResourceRaw},
+				// Patching the manifest may reuse its backing array, so it
+				// must not be shared between Objects.
+				Manifest: runtime.RawExtension{Raw: bytes.Clone(externalResourceRaw)},
 			},
 		},
 		Status: v1alpha2.ObjectStatus{},
@@ -150,6 +160,32 @@ func objectReferences() []v1alpha2.Reference {
 	return ref
 }
 
+func connectionSecretReferences() []v1alpha2.Reference {
+	return []v1alpha2.Reference{
+		{
+			PatchesFromConnectionSecret: &v1alpha2.PatchesFromConnectionSecret{
+				DependsOn: v1alpha2.DependsOn{
+					APIVersion: v1alpha2.SchemeGroupVersion.String(),
+					Kind:       v1alpha2.ObjectKind,
+					Name:       testReferenceObjectName,
+					Namespace:  testNamespace,
+				},
+				Key: "password",
+			},
+			ToFieldPath: ptr.To("metadata.annotations.password"),
+		},
+	}
+}
+
+func referenceObjectWithConnectionSecret() *unstructured.Unstructured {
+	return referenceObject(func(res *unstructured.Unstructured) {
+		_ = unstructured.SetNestedStringMap(res.Object, map[string]string{
+			"name":      testConnectionSecretName,
+			"namespace": testNamespace,
+		}, "spec", "writeConnectionSecretToRef")
+	})
+}
+
 func referenceObject(rm ...externalResourceModifier) *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -271,6 +307,25 @@ func TestConnect(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessInlineKubeconfig": {
+			args: args{
+				// Neither the provider config nor its usage should be
+				// touched by an Object with an inline kubeconfig.
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.KubeconfigSecretRef = &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "cluster", Namespace: testNamespace},
+						Key:             "kubeconfig",
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -405,6 +460,228 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"UpToDateIfOnlyIgnoredFieldsDiffer": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.IgnoreFields = []string{"metadata.labels"}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource(func(res *unstructured.Unstructured) {
+								res.SetLabels(map[string]string{"a-new-label": "foo"})
+							})
+							return nil
+						}),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				err: nil,
+			},
+		},
+		"UpToDateFromManifestYAML": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = nil
+					obj.Spec.ForProvider.ManifestYAML = fmt.Sprintf("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: %s\n", externalResourceName)
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource()
+							return nil
+						}),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				err: nil,
+			},
+		},
+		"ListItemDoesNotExist": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = nil
+					obj.Spec.ForProvider.ManifestYAML = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: one\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: two\n"
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+							if key.Name == "two" {
+								return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+							}
+							return nil
+						},
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ListUpToDate": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = nil
+					obj.Spec.ForProvider.ManifestYAML = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: one\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: two\n"
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				err: nil,
+			},
+		},
+		"ListItemRemoved": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = nil
+					obj.Spec.ForProvider.ManifestYAML = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: one\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: three\n"
+					obj.Status.AtProvider.Children = []v1alpha2.ChildObservation{
+						{APIVersion: "v1", Kind: "Namespace", Name: "one", Synced: true, Ready: true},
+						{APIVersion: "v1", Kind: "Namespace", Name: "two", Synced: true, Ready: true},
+					}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"UpToDateIfDesiredStateAndRemoteObjectUnchanged": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					hash, _ := (&external{}).appliedStateHash(obj)
+					obj.Status.AtProvider.LastAppliedHash = hash
+					obj.Status.AtProvider.ObservedResourceVersion = "1"
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource(func(res *unstructured.Unstructured) {
+								res.SetResourceVersion("1")
+							})
+							return nil
+						}),
+					},
+				},
+				// No syncer, comparison should be skipped.
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				err: nil,
+			},
+		},
+		"ComparedIfManagementPoliciesChanged": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					// The remote object was last observed only, e.g. before
+					// the management policies were defaulted.
+					obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+					hash, _ := (&external{}).appliedStateHash(obj)
+					obj.Status.AtProvider.LastAppliedHash = hash
+					obj.Status.AtProvider.ObservedResourceVersion = "1"
+					obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionAll}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource(func(res *unstructured.Unstructured) {
+								res.SetLabels(map[string]string{"changed": "out-of-band"})
+								res.SetResourceVersion("1")
+							})
+							return nil
+						}),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
 		"FailedToPatchFieldFromReferenceObject": {
 			args: args{
 				mg: kubernetesObject(func(obj *v1alpha2.Object) {
@@ -515,6 +792,74 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"FailedToGetKeyFromConnectionSecret": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.References = connectionSecretReferences()
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+							switch o := obj.(type) {
+							case *unstructured.Unstructured:
+								*o = *referenceObjectWithConnectionSecret()
+							case *corev1.Secret:
+								o.Data = map[string][]byte{}
+							}
+							return nil
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errConnectionSecretKeyNotFound, "password"), errResolveResourceReferences),
+			},
+		},
+		"PatchFromConnectionSecret": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.References = connectionSecretReferences()
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+							switch o := obj.(type) {
+							case *corev1.Secret:
+								if key.Name != testConnectionSecretName || key.Namespace != testNamespace {
+									return errBoom
+								}
+								o.Data = map[string][]byte{"password": []byte("s3cret")}
+							case *unstructured.Unstructured:
+								if key.Name == testReferenceObjectName {
+									*o = *referenceObjectWithConnectionSecret()
+									return nil
+								}
+								*o = *externalResource()
+							}
+							return nil
+						},
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						if got := manifest.GetAnnotations()["password"]; got != "s3cret" {
+							t.Errorf("manifest should be patched from the connection secret, got %q", got)
+						}
+						return manifest, nil
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
 		"ConnectionDetails": {
 			args: args{
 				mg: kubernetesObject(func(obj *v1alpha2.Object) {
@@ -660,6 +1005,100 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveLastAppliedHash(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   bool
+	}{
+		"ObserveOnly": {
+			reason: "No hash should be recorded for Objects that only observe their remote objects, as nothing was applied.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+			}),
+		},
+		"UpToDate": {
+			reason: "The hash of the applied state should be recorded for Objects whose remote objects are up-to-date.",
+			obj:    kubernetesObject(),
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource()
+							return nil
+						}),
+					},
+				},
+				syncer: &fake.ResourceSyncer{
+					GetObservedStateFn: func(_ context.Context, _ *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return current, nil
+					},
+					GetDesiredStateFn: func(_ context.Context, _ *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return manifest, nil
+					},
+				},
+			}
+			if _, err := e.Observe(context.Background(), tc.obj); err != nil {
+				t.Fatal(err)
+			}
+			want := ""
+			if tc.want {
+				want, _ = e.appliedStateHash(tc.obj)
+			}
+			if diff := cmp.Diff(want, tc.obj.Status.AtProvider.LastAppliedHash); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want lastAppliedHash, +got lastAppliedHash:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAppliedStateHash(t *testing.T) {
+	base := kubernetesObject()
+	cases := map[string]struct {
+		reason       string
+		obj          *v1alpha2.Object
+		ignoreFields []string
+		want         bool
+	}{
+		"Unchanged": {
+			reason: "The hash should not change if nothing the remote object is compared with changed.",
+			obj:    kubernetesObject(),
+			want:   true,
+		},
+		"ManagementPoliciesChanged": {
+			reason: "The hash should change with the management policies, e.g. once an Object may no longer only observe.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+			}),
+		},
+		"DefaultIgnoreFieldsChanged": {
+			reason: "The hash should change with the fields ignored on the remote objects of all Objects.",
+			obj:    kubernetesObject(),
+			ignoreFields: []string{
+				"metadata.labels",
+			},
+		},
+	}
+	want, _ := (&external{}).appliedStateHash(base)
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := (&external{defaultIgnoreFields: tc.ignoreFields}).appliedStateHash(tc.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got == want); diff != "" {
+				t.Errorf("\n%s\ne.appliedStateHash(...): -want unchanged, +got unchanged:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	type args struct {
 		mg     resource.Managed
@@ -758,6 +1197,7 @@ func TestCreate(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	type args struct {
+		client resource.ClientApplicator
 		mg     resource.Managed
 		syncer ResourceSyncer
 	}
@@ -800,6 +1240,66 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errApplyObject),
 			},
 		},
+		"FailedToApplyImmutableWithDefaultPolicy": {
+			args: args{
+				mg: kubernetesObject(),
+				syncer: &fake.ResourceSyncer{
+					SyncResourceFn: func(ctx context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return nil, errImmutable
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(CleanErr(errImmutable), errApplyObject),
+			},
+		},
+		"FailedToDeleteToRecreate": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.UpdatePolicy = v1alpha2.UpdatePolicyRecreateOnImmutableError
+				}),
+				syncer: &fake.ResourceSyncer{
+					SyncResourceFn: func(ctx context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return nil, errImmutable
+					},
+				},
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockDelete: test.NewMockDeleteFn(errBoom),
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errRecreateObject),
+			},
+		},
+		"RecreateOnImmutableError": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.UpdatePolicy = v1alpha2.UpdatePolicyRecreateOnImmutableError
+				}),
+				syncer: &fake.ResourceSyncer{
+					SyncResourceFn: func(ctx context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return nil, errImmutable
+					},
+				},
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockDelete: func(_ context.Context, obj client.Object, opts ...client.DeleteOption) error {
+							do := &client.DeleteOptions{}
+							do.ApplyOptions(opts)
+							if do.PropagationPolicy == nil || *do.PropagationPolicy != metav1.DeletePropagationBackground {
+								t.Errorf("Delete(...): expected background propagation")
+							}
+							return nil
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"SuccessDefaultsToObjectName": {
 			args: args{
 				mg: kubernetesObject(func(obj *v1alpha2.Object) {
@@ -838,6 +1338,7 @@ func TestUpdate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			e := &external{
 				logger: logging.NewNopLogger(),
+				client: tc.args.client,
 				syncer: tc.args.syncer,
 			}
 			got, gotErr := e.Update(context.Background(), tc.args.mg)
@@ -852,6 +1353,38 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestApplicatorFor(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want resource.Applicator
+	}{
+		"DefaultsToPatch": {
+			obj:  kubernetesObject(),
+			want: resource.NewAPIPatchingApplicator(nil),
+		},
+		"Patch": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.UpdateStrategy = v1alpha2.UpdateStrategyPatch
+			}),
+			want: resource.NewAPIPatchingApplicator(nil),
+		},
+		"Replace": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.UpdateStrategy = v1alpha2.UpdateStrategyReplace
+			}),
+			want: resource.NewAPIUpdatingApplicator(nil),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := applicatorFor(tc.obj, nil)
+			if reflect.TypeOf(got) != reflect.TypeOf(tc.want) {
+				t.Errorf("applicatorFor(...): want %T, got %T", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	type args struct {
 		client resource.ClientApplicator
@@ -882,6 +1415,24 @@ func TestDelete(t *testing.T) {
 				err: errors.Wrap(errors.Errorf(`Object 'Kind' is missing in '{"test": "not-a-valid-manifest"}'`), errUnmarshalTemplate),
 			},
 		},
+		"WaitForDependents": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetFinalizers([]string{objFinalizerName, refFinalizerNamePrefix + "some-uid", refFinalizerNamePrefix + "other-uid"})
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockDelete: func(_ context.Context, _ client.Object, _ ...client.DeleteOption) error {
+							t.Errorf("Object should not be deleted while it has dependents")
+							return nil
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errWaitForDependents, 2),
+			},
+		},
 		"FailedToDelete": {
 			args: args{
 				mg: kubernetesObject(),
@@ -951,7 +1502,8 @@ func TestAddFinalizer(t *testing.T) {
 		mg     resource.Managed
 	}
 	type want struct {
-		err error
+		err        error
+		finalizers []string
 	}
 	cases := map[string]struct {
 		args
@@ -975,7 +1527,8 @@ func TestAddFinalizer(t *testing.T) {
 				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errAddFinalizer),
+				err:        errors.Wrap(errBoom, errAddFinalizer),
+				finalizers: []string{objFinalizerName},
 			},
 		},
 		"ObjectFinalizerExists": {
@@ -985,7 +1538,8 @@ func TestAddFinalizer(t *testing.T) {
 				}),
 			},
 			want: want{
-				err: nil,
+				err:        nil,
+				finalizers: []string{objFinalizerName},
 			},
 		},
 		"NoReferenceObjectExists": {
@@ -1004,6 +1558,7 @@ func TestAddFinalizer(t *testing.T) {
 				err: errors.Wrap(
 					errors.Wrap(errBoom,
 						errGetReferencedResource), errAddFinalizer),
+				finalizers: []string{objFinalizerName},
 			},
 		},
 		"EmptyReference": {
@@ -1018,7 +1573,8 @@ func TestAddFinalizer(t *testing.T) {
 				},
 			},
 			want: want{
-				err: nil,
+				err:        nil,
+				finalizers: []string{objFinalizerName},
 			},
 		},
 		"FailedToAddReferenceFinalizer": {
@@ -1043,26 +1599,68 @@ func TestAddFinalizer(t *testing.T) {
 				},
 			},
 			want: want{
-				err: errors.Wrap(
-					errors.Wrap(errBoom,
-						errAddReferenceFinalizer), errAddFinalizer),
+				err: errors.Wrap(
+					errors.Wrap(errBoom,
+						errAddReferenceFinalizer), errAddFinalizer),
+				finalizers: []string{objFinalizerName},
+			},
+		},
+		"Success": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.References = objectReferences()
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet:    test.NewMockGetFn(nil),
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+				},
+			},
+			want: want{
+				err:        nil,
+				finalizers: []string{objFinalizerName},
+			},
+		},
+		"RenamedFinalizer": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.Finalizer = &v1alpha2.ObjectFinalizer{Name: "example.org/cleanup"}
+					obj.ObjectMeta.Finalizers = []string{objFinalizerName}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+				},
+			},
+			want: want{
+				finalizers: []string{"example.org/cleanup"},
 			},
 		},
-		"Success": {
+		"DisabledFinalizer": {
 			args: args{
 				mg: kubernetesObject(func(obj *v1alpha2.Object) {
-					obj.Spec.References = objectReferences()
+					obj.Spec.Finalizer = &v1alpha2.ObjectFinalizer{Disabled: true}
+					obj.ObjectMeta.Finalizers = []string{objFinalizerName}
 				}),
 				client: resource.ClientApplicator{
 					Client: &test.MockClient{
-						MockGet:    test.NewMockGetFn(nil),
 						MockUpdate: test.NewMockUpdateFn(nil),
 					},
 				},
 			},
 			want: want{
-				err: nil,
+				finalizers: []string{},
+			},
+		},
+		"Disabled": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.Finalizer = &v1alpha2.ObjectFinalizer{Disabled: true}
+				}),
 			},
+			want: want{},
 		},
 	}
 	for name, tc := range cases {
@@ -1074,6 +1672,11 @@ func TestAddFinalizer(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("f.AddFinalizer(...): -want error, +got error: %s", diff)
 			}
+			if obj, ok := tc.args.mg.(*v1alpha2.Object); ok {
+				if diff := cmp.Diff(tc.want.finalizers, obj.GetFinalizers(), cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("f.AddFinalizer(...): -want finalizers, +got finalizers: %s", diff)
+				}
+			}
 		})
 	}
 }
@@ -1217,6 +1820,22 @@ func TestRemoveFinalizer(t *testing.T) {
 				finalizers: []string{},
 			},
 		},
+		"RenamedFinalizer": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.Finalizer = &v1alpha2.ObjectFinalizer{Name: "example.org/cleanup"}
+					obj.ObjectMeta.Finalizers = []string{"example.org/cleanup", objFinalizerName, "other"}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+				},
+			},
+			want: want{
+				finalizers: []string{"other"},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1337,6 +1956,27 @@ func TestConnectionDetails(t *testing.T) {
 				},
 			},
 		},
+		"Success_Template": {
+			args: args{
+				kube: mockClient(
+					map[string]interface{}{
+						"db-user":     "YWRtaW4=",
+						"db-password": "MTIzNDU=",
+					},
+					nil,
+				),
+				connDetails: []v1alpha2.ConnectionDetail{{
+					ObjectReference:       connDetail.ObjectReference,
+					ToConnectionSecretKey: "url",
+					Template:              "postgres://{{ .data.db-user | b64dec }}:{{ .data.db-password | b64dec }}@db",
+				}},
+			},
+			want: want{
+				out: managed.ConnectionDetails{
+					"url": []byte("postgres://admin:12345@db"),
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1804,6 +2444,102 @@ func TestUpdateConditionFromObserved(t *testing.T) {
 				},
 			},
 		},
+		"AvailableIfMatchConditionsHold": {
+			args: args{
+				obj: &v1alpha2.Object{
+					Spec: v1alpha2.ObjectSpec{
+						Readiness: v1alpha2.Readiness{
+							Policy: v1alpha2.ReadinessPolicyMatchConditions,
+							MatchConditions: []v1alpha2.MatchCondition{
+								{FieldPath: "status.phase", Value: "Running"},
+								{FieldPath: "status.replicas", Value: "3"},
+								{Type: "Available", Status: corev1.ConditionTrue},
+							},
+						},
+					},
+				},
+				observed: &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"status": map[string]any{
+							"phase":    "Running",
+							"replicas": int64(3),
+							"conditions": []any{
+								map[string]any{"type": "Available", "status": "True"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				conditions: []xpv1.Condition{
+					{
+						Type:   xpv1.TypeReady,
+						Status: corev1.ConditionTrue,
+						Reason: xpv1.ReasonAvailable,
+					},
+				},
+			},
+		},
+		"UnavailableIfMatchConditionFieldDiffers": {
+			args: args{
+				obj: &v1alpha2.Object{
+					Spec: v1alpha2.ObjectSpec{
+						Readiness: v1alpha2.Readiness{
+							Policy: v1alpha2.ReadinessPolicyMatchConditions,
+							MatchConditions: []v1alpha2.MatchCondition{
+								{FieldPath: "status.phase", Value: "Running"},
+							},
+						},
+					},
+				},
+				observed: &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"status": map[string]any{
+							"phase": "Pending",
+						},
+					},
+				},
+			},
+			want: want{
+				conditions: []xpv1.Condition{
+					{
+						Type:    xpv1.TypeReady,
+						Status:  corev1.ConditionFalse,
+						Reason:  xpv1.ReasonUnavailable,
+						Message: fmt.Sprintf(errMatchCondition, "status.phase", "Pending", "Running"),
+					},
+				},
+			},
+		},
+		"UnavailableIfMatchConditionStatusDiffers": {
+			args: args{
+				obj: &v1alpha2.Object{
+					Spec: v1alpha2.ObjectSpec{
+						Readiness: v1alpha2.Readiness{
+							Policy: v1alpha2.ReadinessPolicyMatchConditions,
+							MatchConditions: []v1alpha2.MatchCondition{
+								{Type: "Available", Status: corev1.ConditionTrue},
+							},
+						},
+					},
+				},
+				observed: &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"status": map[string]any{},
+					},
+				},
+			},
+			want: want{
+				conditions: []xpv1.Condition{
+					{
+						Type:    xpv1.TypeReady,
+						Status:  corev1.ConditionFalse,
+						Reason:  xpv1.ReasonUnavailable,
+						Message: fmt.Sprintf(errMatchCondition, "condition Available", corev1.ConditionUnknown, corev1.ConditionTrue),
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1822,3 +2558,316 @@ func TestUpdateConditionFromObserved(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaultNamespace(t *testing.T) {
+	type args struct {
+		defaultNamespace string
+		client           resource.ClientApplicator
+		obj              *v1alpha2.Object
+	}
+	type want struct {
+		namespace string
+		err       error
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NoDefaultNamespace": {
+			args: args{
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`)
+				}),
+			},
+			want: want{},
+		},
+		"NamespaceInManifest": {
+			args: args{
+				defaultNamespace: "tenant",
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"other"}}`)
+				}),
+			},
+			want: want{
+				namespace: "other",
+			},
+		},
+		"FailedToCheckNamespaced": {
+			args: args{
+				defaultNamespace: "tenant",
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockIsObjectNamespaced: test.NewMockIsObjectNamespacedFn(errBoom, false),
+					},
+				},
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`)
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCheckNamespaced),
+			},
+		},
+		"ClusterScoped": {
+			args: args{
+				defaultNamespace: "tenant",
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockIsObjectNamespaced: test.NewMockIsObjectNamespacedFn(nil, false),
+					},
+				},
+				obj: kubernetesObject(),
+			},
+			want: want{},
+		},
+		"Namespaced": {
+			args: args{
+				defaultNamespace: "tenant",
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockIsObjectNamespaced: test.NewMockIsObjectNamespacedFn(nil, true),
+					},
+				},
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`)
+				}),
+			},
+			want: want{
+				namespace: "tenant",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.args.client, defaultNamespace: tc.args.defaultNamespace}
+			gotErr := e.setDefaultNamespace(tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.setDefaultNamespace(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			m, err := parseManifest(tc.args.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.namespace, m.GetNamespace()); diff != "" {
+				t.Errorf("e.setDefaultNamespace(...): -want namespace, +got namespace: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWatched(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want bool
+	}{
+		"NotWatched": {
+			obj: kubernetesObject(),
+		},
+		"Watch": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.Watch = true
+			}),
+			want: true,
+		},
+		"ObserveOnly": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+			}),
+			want: true,
+		},
+		"ObserveAndCreate": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate})
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := watched(tc.obj); got != tc.want {
+				t.Errorf("watched(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+type cachedKindObserver struct {
+	reader client.Reader
+	synced bool
+}
+
+func (o *cachedKindObserver) WatchResources(_ *rest.Config, _ string, _ ...schema.GroupVersionKind) {}
+
+func (o *cachedKindObserver) CachedReader(_ string, _ schema.GroupVersionKind) (client.Reader, bool) {
+	return o.reader, o.synced
+}
+
+func TestRemoteReader(t *testing.T) {
+	remote := &test.MockClient{}
+	cached := &test.MockClient{}
+	observeOnly := func(obj *v1alpha2.Object) {
+		obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+	}
+	cases := map[string]struct {
+		kindObserver KindObserver
+		obj          *v1alpha2.Object
+		want         *test.MockClient
+	}{
+		"NotWatched": {
+			obj:  kubernetesObject(observeOnly),
+			want: remote,
+		},
+		"NotObserveOnly": {
+			kindObserver: &cachedKindObserver{reader: cached, synced: true},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.Watch = true
+			}),
+			want: remote,
+		},
+		"CacheNotSynced": {
+			kindObserver: &cachedKindObserver{reader: cached},
+			obj:          kubernetesObject(observeOnly),
+			want:         remote,
+		},
+		"Cached": {
+			kindObserver: &cachedKindObserver{reader: cached, synced: true},
+			obj:          kubernetesObject(observeOnly),
+			want:         cached,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				client:       resource.ClientApplicator{Client: remote},
+				kindObserver: tc.kindObserver,
+			}
+			got := e.remoteReader(tc.obj, schema.GroupVersionKind{Version: "v1", Kind: "Namespace"})
+			if c, ok := got.(resource.ClientApplicator); ok {
+				got = c.Client
+			}
+			if got != client.Reader(tc.want) {
+				t.Errorf("e.remoteReader(...): want %p, got %p", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveReferencies(t *testing.T) {
+	refs := func(names ...string) []v1alpha2.Reference {
+		out := make([]v1alpha2.Reference, 0, len(names))
+		for _, n := range names {
+			out = append(out, v1alpha2.Reference{
+				PatchesFrom: &v1alpha2.PatchesFrom{
+					DependsOn: v1alpha2.DependsOn{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Name:       n,
+						Namespace:  testNamespace,
+					},
+					FieldPath: ptr.To("data.value"),
+				},
+				ToFieldPath: ptr.To("metadata.labels.value"),
+			})
+		}
+		return out
+	}
+	optional := func(refs []v1alpha2.Reference) []v1alpha2.Reference {
+		for i := range refs {
+			refs[i].Policy = v1alpha2.ReferencePolicyOptional
+		}
+		return refs
+	}
+	// ConfigMaps prefixed with absent do not exist, and those prefixed with
+	// empty lack the value.
+	getConfigMap := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if strings.HasPrefix(key.Name, "missing") {
+			return errors.New(key.Name)
+		}
+		if strings.HasPrefix(key.Name, "absent") {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+		}
+		if strings.HasPrefix(key.Name, "empty") {
+			return nil
+		}
+		obj.(*unstructured.Unstructured).Object["data"] = map[string]any{"value": key.Name}
+		return nil
+	}
+	skipped := func(name, message string) v1alpha2.SkippedReference {
+		return v1alpha2.SkippedReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: name, Message: message}
+	}
+
+	type want struct {
+		label   string
+		skipped []v1alpha2.SkippedReference
+		err     error
+	}
+	cases := map[string]struct {
+		refs []v1alpha2.Reference
+		want
+	}{
+		"FirstFailingReferenceInOrder": {
+			refs: refs("one", "missing-two", "missing-three"),
+			want: want{
+				err: errors.Wrap(errors.New("missing-two"), errGetReferencedResource),
+			},
+		},
+		"LaterReferencesWin": {
+			refs: refs("one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"),
+			want: want{
+				label: "ten",
+			},
+		},
+		"RequiredReferenceNotFound": {
+			refs: refs("absent-one"),
+			want: want{
+				err: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "absent-one"), errGetReferencedResource),
+			},
+		},
+		"OptionalReferencesSkipped": {
+			refs: optional(refs("one", "absent-two", "empty-three")),
+			want: want{
+				label: "one",
+				skipped: []v1alpha2.SkippedReference{
+					skipped("absent-two", errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "absent-two"), errGetReferencedResource).Error()),
+					skipped("empty-three", errPatchFromReferencedResource+": data: no such field"),
+				},
+			},
+		},
+		"OptionalReferenceFailing": {
+			refs: optional(refs("missing-one")),
+			want: want{
+				err: errors.Wrap(errors.New("missing-one"), errGetReferencedResource),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.References = tc.refs
+			})
+			e := &external{
+				logger:      logging.NewNopLogger(),
+				localClient: &test.MockClient{MockGet: getConfigMap},
+			}
+			gotErr := e.resolveReferencies(context.Background(), obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.resolveReferencies(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			m, err := parseManifest(obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.label, m.GetLabels()["value"]); diff != "" {
+				t.Errorf("e.resolveReferencies(...): -want label, +got label: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.skipped, obj.Status.AtProvider.SkippedReferences); diff != "" {
+				t.Errorf("e.resolveReferencies(...): -want skipped references, +got skipped references: %s", diff)
+			}
+		})
+	}
+}
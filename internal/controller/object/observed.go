@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errCompressObserved   = "cannot compress observed manifest"
+	errDecompressObserved = "cannot decompress observed manifest"
+)
+
+// setObservedManifest records the supplied observed remote object in the
+// status of the supplied Object. Remote objects larger than the maximum
+// observed manifest size are recorded compressed, next to their compacted
+// manifest, or only compacted if they are too large even when compressed, so
+// that huge remote objects, e.g. CRDs, do not keep the status of their Objects
+// from being persisted.
+func (c *external) setObservedManifest(obj *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	raw, err := observed.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, errFailedToMarshalExisting)
+	}
+	obj.Status.AtProvider.Manifest.Raw = raw
+	obj.Status.AtProvider.CompressedManifest = nil
+	obj.Status.AtProvider.ManifestTruncated = false
+	if c.maxObservedManifestSize <= 0 || int64(len(raw)) <= c.maxObservedManifestSize {
+		return nil
+	}
+
+	if obj.Status.AtProvider.Manifest.Raw, err = compactObserved(observed).MarshalJSON(); err != nil {
+		return errors.Wrap(err, errFailedToMarshalExisting)
+	}
+	compressed, err := compress(raw)
+	if err != nil {
+		return errors.Wrap(err, errCompressObserved)
+	}
+	// The compressed manifest is base64 encoded when the Object is stored.
+	if int64(base64.StdEncoding.EncodedLen(len(compressed))+len(obj.Status.AtProvider.Manifest.Raw)) > c.maxObservedManifestSize {
+		c.logger.Debug("Observed manifest is too large even when compressed, recording only its metadata", "size", len(raw), "compressedSize", len(compressed), "maxSize", c.maxObservedManifestSize)
+		obj.Status.AtProvider.ManifestTruncated = true
+		return nil
+	}
+	obj.Status.AtProvider.CompressedManifest = compressed
+	return nil
+}
+
+// observedManifest returns the JSON representation of the remote object of the
+// supplied Object as last observed, decompressing it if it was compressed.
+func observedManifest(obj *v1alpha2.Object) ([]byte, error) {
+	if obj.Status.AtProvider.CompressedManifest == nil {
+		return obj.Status.AtProvider.Manifest.Raw, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(obj.Status.AtProvider.CompressedManifest))
+	if err != nil {
+		return nil, errors.Wrap(err, errDecompressObserved)
+	}
+	raw, err := io.ReadAll(r)
+	return raw, errors.Wrap(err, errDecompressObserved)
+}
+
+// compactObserved returns the apiVersion, kind, metadata and status of the
+// supplied observed remote object, or of each of its items if it is a List.
+// The metadata omits the managed fields and the last applied configuration,
+// which are about as large as the remote object itself.
+func compactObserved(observed *unstructured.Unstructured) *unstructured.Unstructured {
+	compact := &unstructured.Unstructured{Object: map[string]any{}}
+	for _, k := range []string{"apiVersion", "kind", "metadata", "status"} {
+		if v, ok := observed.Object[k]; ok {
+			compact.Object[k] = runtime.DeepCopyJSONValue(v)
+		}
+	}
+	unstructured.RemoveNestedField(compact.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(compact.Object, "metadata", "annotations", v1.LastAppliedConfigAnnotation)
+
+	if items, ok := observed.Object["items"].([]any); ok && observed.IsList() {
+		compactItems := make([]any, 0, len(items))
+		for _, item := range items {
+			if m, ok := item.(map[string]any); ok {
+				compactItems = append(compactItems, compactObserved(&unstructured.Unstructured{Object: m}).Object)
+			}
+		}
+		compact.Object["items"] = compactItems
+	}
+	return compact
+}
+
+// compress the supplied data with gzip.
+func compress(data []byte) ([]byte, error) {
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestSetObservedManifest(t *testing.T) {
+	compressible := strings.Repeat("a", 4096)
+	random := make([]byte, 4096)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+	observed := func(data string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "large"},
+			"data":       map[string]any{"key": data},
+		}}
+	}
+	compact := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"large"}}`
+
+	type want struct {
+		manifest   string
+		compressed bool
+		truncated  bool
+	}
+	cases := map[string]struct {
+		reason   string
+		maxSize  int64
+		observed *unstructured.Unstructured
+		want     want
+	}{
+		"NoLimit": {
+			reason:   "The observed manifest should be recorded in full if there is no size limit.",
+			observed: observed(compressible),
+			want: want{
+				manifest: `{"apiVersion":"v1","data":{"key":"` + compressible + `"},"kind":"ConfigMap","metadata":{"name":"large"}}`,
+			},
+		},
+		"UnderLimit": {
+			reason:   "The observed manifest should be recorded in full if it is not larger than the size limit.",
+			maxSize:  1024,
+			observed: observed("value"),
+			want: want{
+				manifest: `{"apiVersion":"v1","data":{"key":"value"},"kind":"ConfigMap","metadata":{"name":"large"}}`,
+			},
+		},
+		"Compressed": {
+			reason:   "The observed manifest should be recorded compressed, next to its compacted manifest, if it is larger than the size limit.",
+			maxSize:  1024,
+			observed: observed(compressible),
+			want: want{
+				manifest:   compact,
+				compressed: true,
+			},
+		},
+		"Truncated": {
+			reason:   "Only the compacted manifest should be recorded if the observed manifest is too large even when compressed.",
+			maxSize:  1024,
+			observed: observed(hex.EncodeToString(random)),
+			want: want{
+				manifest:  compact,
+				truncated: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject()
+			full, err := tc.observed.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &external{logger: logging.NewNopLogger(), maxObservedManifestSize: tc.maxSize}
+			if err := c.setObservedManifest(obj, tc.observed); err != nil {
+				t.Fatalf("\n%s\nsetObservedManifest(...): unexpected error: %v", tc.reason, err)
+			}
+			// Unstructured objects are marshalled with a trailing newline.
+			if diff := cmp.Diff(tc.want.manifest+"\n", string(obj.Status.AtProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("\n%s\nsetObservedManifest(...): -want manifest, +got manifest:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.truncated, obj.Status.AtProvider.ManifestTruncated); diff != "" {
+				t.Errorf("\n%s\nsetObservedManifest(...): -want truncated, +got truncated:\n%s", tc.reason, diff)
+			}
+			if got := obj.Status.AtProvider.CompressedManifest != nil; got != tc.want.compressed {
+				t.Fatalf("\n%s\nsetObservedManifest(...): want compressed %t, got %t", tc.reason, tc.want.compressed, got)
+			}
+			if !tc.want.compressed {
+				return
+			}
+			r, err := gzip.NewReader(bytes.NewReader(obj.Status.AtProvider.CompressedManifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			decompressed, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(full), string(decompressed)); diff != "" {
+				t.Errorf("\n%s\nsetObservedManifest(...): -want decompressed manifest, +got decompressed manifest:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompactObserved(t *testing.T) {
+	item := func() map[string]any {
+		return map[string]any{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]any{
+				"name":          "huge",
+				"annotations":   map[string]any{v1.LastAppliedConfigAnnotation: "{}", "keep": "me"},
+				"managedFields": []any{map[string]any{"manager": "provider-kubernetes"}},
+			},
+			"spec":   map[string]any{"versions": []any{}},
+			"status": map[string]any{"acceptedNames": map[string]any{"kind": "Huge"}},
+		}
+	}
+	want := map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]any{
+			"name":        "huge",
+			"annotations": map[string]any{"keep": "me"},
+		},
+		"status": map[string]any{"acceptedNames": map[string]any{"kind": "Huge"}},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		observed *unstructured.Unstructured
+		want     *unstructured.Unstructured
+	}{
+		"Object": {
+			reason:   "The spec, managed fields and last applied configuration should be omitted.",
+			observed: &unstructured.Unstructured{Object: item()},
+			want:     &unstructured.Unstructured{Object: want},
+		},
+		"List": {
+			reason: "Every item of a List should be compacted.",
+			observed: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "List",
+				"items":      []any{item()},
+			}},
+			want: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "List",
+				"items":      []any{want},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			before := tc.observed.DeepCopy()
+			got := compactObserved(tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ncompactObserved(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(before, tc.observed); diff != "" {
+				t.Errorf("\n%s\ncompactObserved(...): observed object was modified: -before, +after:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+)
+
+const (
+	orphanScannerName = "orphan-scanner"
+
+	reasonOrphanFound   event.Reason = "OrphanedRemoteObject"
+	reasonOrphanDeleted event.Reason = "DeletedOrphanedRemoteObject"
+
+	errListProviderConfigs = "cannot list provider configs"
+	errListObjects         = "cannot list Objects"
+	errDiscoverKinds       = "cannot discover the kinds of the target cluster"
+	errDeleteOrphan        = "cannot delete orphaned remote object"
+	errReleaseRemote       = "cannot release the remote object of the orphaned Object"
+)
+
+// An orphanScanner periodically scans the target clusters of the provider
+// configs with attribution for remote objects labeled with the UID of an
+// Object that no longer exists, e.g. because deleting the remote object
+// failed. It reports them as events of the provider config and, if asked to,
+// deletes them. Only the orphans of provider configs with an attribution
+// identity are deleted, outside of their pause windows, as the remote objects
+// of other providers sharing the target cluster cannot be told apart
+// otherwise.
+type orphanScanner struct {
+	kube          client.Client
+	objects       client.Reader
+	clientBuilder kubeclient.Builder
+	recorder      event.Recorder
+	logger        logging.Logger
+	interval      time.Duration
+	cleanup       bool
+	discoverKinds func(rc *rest.Config) ([]schema.GroupVersionKind, error)
+	now           func() time.Time
+}
+
+// SetupOrphanScanner adds a scanner for remote objects left behind by deleted
+// Objects to the supplied manager. With cleanup, the orphaned remote objects
+// of provider configs with an attribution identity are deleted rather than
+// only reported.
+func SetupOrphanScanner(mgr ctrl.Manager, o controller.Options, interval time.Duration, cleanup bool) error {
+	return mgr.Add(&orphanScanner{
+		kube:          mgr.GetClient(),
+		objects:       mgr.GetAPIReader(),
+		clientBuilder: kubeclient.NewIdentityAwareBuilder(mgr.GetClient(), builderOptions(mgr, o)...),
+		recorder:      event.NewAPIRecorder(mgr.GetEventRecorderFor(orphanScannerName)),
+		logger:        o.Logger.WithValues("controller", orphanScannerName),
+		interval:      interval,
+		cleanup:       cleanup,
+		discoverKinds: listableKinds,
+		now:           time.Now,
+	})
+}
+
+// NeedLeaderElection returns true, so that orphans are only deleted by the
+// leader.
+func (s *orphanScanner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start scans the target clusters at the interval of the scanner until the
+// supplied context is done.
+func (s *orphanScanner) Start(ctx context.Context) error {
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := s.scan(ctx); err != nil {
+				s.logger.Info("Cannot scan target clusters for orphaned remote objects", "error", err)
+			}
+		}
+	}
+}
+
+func (s *orphanScanner) scan(ctx context.Context) error {
+	pcs := &apisv1alpha1.ProviderConfigList{}
+	if err := s.kube.List(ctx, pcs); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+	for i := range pcs.Items {
+		pc := &pcs.Items[i]
+		if pc.Spec.Attribution == nil {
+			// Remote objects cannot be traced back to their Objects.
+			continue
+		}
+		if err := s.scanProviderConfig(ctx, pc); err != nil {
+			s.logger.Info("Cannot scan target cluster for orphaned remote objects", "providerConfig", pc.GetName(), "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *orphanScanner) scanProviderConfig(ctx context.Context, pc *apisv1alpha1.ProviderConfig) error {
+	k, rc, err := s.clientBuilder.KubeForProviderConfig(kubeclient.WithProviderConfigName(ctx, pc.GetName()), pc.Spec)
+	if err != nil {
+		return errors.Wrap(err, errBuildKubeForProviderConfig)
+	}
+	gvks, err := s.discoverKinds(rc)
+	if err != nil {
+		return errors.Wrap(err, errDiscoverKinds)
+	}
+
+	sel := labels.NewSelector()
+	r, _ := labels.NewRequirement(labelKeyObjectUID, selection.Exists, nil)
+	sel = sel.Add(*r)
+	if id := pc.Spec.Attribution.Identity; id != "" {
+		// Remote objects of other providers sharing the target cluster are
+		// not orphans of this one.
+		r, _ := labels.NewRequirement(labelKeyProviderIdentity, selection.Equals, []string{id})
+		sel = sel.Add(*r)
+	}
+
+	var remote []metav1.PartialObjectMetadata
+	for _, gvk := range gvks {
+		l := &metav1.PartialObjectMetadataList{}
+		l.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := k.List(ctx, l, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			s.logger.Debug("Cannot list remote objects", "providerConfig", pc.GetName(), "gvk", gvk.String(), "error", err)
+			continue
+		}
+		for i := range l.Items {
+			l.Items[i].SetGroupVersionKind(gvk)
+			remote = append(remote, l.Items[i])
+		}
+	}
+	if len(remote) == 0 {
+		return nil
+	}
+
+	// The Objects are listed after the remote objects, so that the Object of
+	// a remote object created in the meantime is listed too. They are listed
+	// from the API server rather than the cache, which may only hold the
+	// Objects this provider reconciles.
+	objs := &metav1.PartialObjectMetadataList{}
+	objs.SetGroupVersionKind(v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.ObjectKind + "List"))
+	if err := s.objects.List(ctx, objs); err != nil {
+		return errors.Wrap(err, errListObjects)
+	}
+	live := make(map[types.UID]bool, len(objs.Items))
+	for _, o := range objs.Items {
+		live[o.GetUID()] = true
+	}
+
+	for i := range remote {
+		o := &remote[i]
+		if live[types.UID(o.GetLabels()[labelKeyObjectUID])] {
+			continue
+		}
+		s.report(ctx, pc, k, o)
+	}
+	return nil
+}
+
+func (s *orphanScanner) report(ctx context.Context, pc *apisv1alpha1.ProviderConfig, k client.Client, o *metav1.PartialObjectMetadata) {
+	desc := fmt.Sprintf("%s %s", o.GroupVersionKind().Kind, o.GetName())
+	if ns := o.GetNamespace(); ns != "" {
+		desc = fmt.Sprintf("%s %s/%s", o.GroupVersionKind().Kind, ns, o.GetName())
+	}
+	if name := o.GetAnnotations()[annotationKeyObjectName]; name != "" {
+		desc += fmt.Sprintf(" of deleted Object %s", name)
+	}
+	s.logger.Info("Found orphaned remote object", "providerConfig", pc.GetName(), "gvk", o.GroupVersionKind().String(), "namespace", o.GetNamespace(), "name", o.GetName(), "objectUID", o.GetLabels()[labelKeyObjectUID])
+	if !s.cleanup {
+		s.recorder.Event(pc, event.Warning(reasonOrphanFound, errors.Errorf("found orphaned remote object %s", desc)))
+		return
+	}
+	if pc.Spec.Attribution.Identity == "" {
+		s.recorder.Event(pc, event.Warning(reasonOrphanFound, errors.Errorf("found orphaned remote object %s, not deleting it as the provider config has no attribution identity", desc)))
+		return
+	}
+	paused, err := inPauseWindow(pc.Spec.PauseWindows, s.now())
+	if err != nil || paused {
+		s.recorder.Event(pc, event.Warning(reasonOrphanFound, errors.Errorf("found orphaned remote object %s, not deleting it during a pause window of the provider config", desc)))
+		return
+	}
+	if err := s.deleteOrphan(ctx, k, o); err != nil {
+		s.recorder.Event(pc, event.Warning(reasonOrphanFound, errors.Wrapf(err, "found orphaned remote object %s", desc)))
+		return
+	}
+	s.recorder.Event(pc, event.Normal(reasonOrphanDeleted, fmt.Sprintf("Deleted orphaned remote object %s", desc)))
+}
+
+func (s *orphanScanner) deleteOrphan(ctx context.Context, k client.Client, o *metav1.PartialObjectMetadata) error {
+	if meta.FinalizerExists(o, targetFinalizerName) {
+		// The Object protecting the remote object is gone.
+		p := client.MergeFrom(o.DeepCopy())
+		meta.RemoveFinalizer(o, targetFinalizerName)
+		if err := k.Patch(ctx, o, p); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errRemoveTargetFinalizer)
+		}
+	}
+	return errors.Wrap(resource.IgnoreNotFound(k.Delete(ctx, o)), errDeleteOrphan)
+}
+
+// releaseRemote strips the supplied remote object of the supplied Object of
+// the label tracing it back to the Object, and of the target finalizer
+// protecting it, if the Object is being deleted but its remote object is not,
+// e.g. due to the Orphan deletion policy. The remote object would otherwise
+// be taken for an orphan, and deleted by the orphan scanner. Like deleting it,
+// releasing the remote object waits for the pause windows of the provider
+// config to end.
+func (c *external) releaseRemote(ctx context.Context, obj *v1alpha2.Object, current *unstructured.Unstructured) error {
+	if !meta.WasDeleted(obj) || c.deletesRemote(obj) {
+		return nil
+	}
+	_, labeled := current.GetLabels()[labelKeyObjectUID]
+	if !labeled && !meta.FinalizerExists(current, targetFinalizerName) {
+		return nil
+	}
+	if err := c.pauseDeletion(obj, time.Now()); err != nil {
+		return err
+	}
+	p := client.MergeFrom(current.DeepCopy())
+	meta.RemoveLabels(current, labelKeyObjectUID, labelKeyProviderIdentity)
+	meta.RemoveFinalizer(current, targetFinalizerName)
+	return errors.Wrap(resource.IgnoreNotFound(c.client.Patch(ctx, current, p)), errReleaseRemote)
+}
+
+// deletesRemote returns true if the remote object of the supplied Object is
+// deleted along with it, i.e. unless its deletion or management policies
+// orphan it.
+func (c *external) deletesRemote(obj *v1alpha2.Object) bool {
+	if obj.GetDeletionPolicy() == xpv1.DeletionOrphan {
+		return false
+	}
+	if !c.managementPoliciesEnabled {
+		return true
+	}
+	p := sets.New[xpv1.ManagementAction](obj.GetManagementPolicies()...)
+	return p.HasAny(xpv1.ManagementActionAll, xpv1.ManagementActionDelete)
+}
+
+// listableKinds returns the preferred version of every kind of the target
+// cluster that can be listed and deleted.
+func listableKinds(rc *rest.Config) ([]schema.GroupVersionKind, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := dc.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		// Kinds of groups that failed to be discovered, e.g. due to an
+		// unavailable aggregated API server, are skipped.
+		return nil, err
+	}
+	lists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}}, lists)
+
+	var gvks []schema.GroupVersionKind
+	for _, l := range lists {
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range l.APIResources {
+			if strings.Contains(r.Name, "/") {
+				// Subresources are not objects of their own.
+				continue
+			}
+			gvks = append(gvks, gv.WithKind(r.Kind))
+		}
+	}
+	return gvks, nil
+}
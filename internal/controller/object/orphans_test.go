@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+type recordedEvents []event.Event
+
+func (r *recordedEvents) Event(_ runtime.Object, e event.Event) { *r = append(*r, e) }
+
+func (r *recordedEvents) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestScanProviderConfig(t *testing.T) {
+	remote := func(name, uid string) metav1.PartialObjectMetadata {
+		return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{labelKeyObjectUID: uid},
+			Annotations: map[string]string{annotationKeyObjectName: "object-" + name},
+		}}
+	}
+
+	identified := &kconfig.Attribution{Identity: "control-plane"}
+	identitySelector := labelKeyObjectUID + "," + labelKeyProviderIdentity + "=control-plane"
+
+	type want struct {
+		selector string
+		deleted  []string
+		events   []event.Event
+	}
+	cases := map[string]struct {
+		reason       string
+		cleanup      bool
+		attribution  *kconfig.Attribution
+		pauseWindows []kconfig.MaintenanceWindow
+		want
+	}{
+		"Report": {
+			reason:      "Remote objects of deleted Objects should be reported.",
+			attribution: identified,
+			want: want{
+				selector: identitySelector,
+				events: []event.Event{
+					event.Warning(reasonOrphanFound, errors.New("found orphaned remote object Namespace orphan of deleted Object object-orphan")),
+				},
+			},
+		},
+		"Cleanup": {
+			reason:      "Remote objects of deleted Objects should be deleted if asked to.",
+			cleanup:     true,
+			attribution: identified,
+			want: want{
+				selector: identitySelector,
+				deleted:  []string{"orphan"},
+				events: []event.Event{
+					event.Normal(reasonOrphanDeleted, "Deleted orphaned remote object Namespace orphan of deleted Object object-orphan"),
+				},
+			},
+		},
+		"CleanupWithoutIdentity": {
+			reason:      "Remote objects of deleted Objects should only be reported if the provider config has no attribution identity, as they may be those of other providers.",
+			cleanup:     true,
+			attribution: &kconfig.Attribution{},
+			want: want{
+				selector: labelKeyObjectUID,
+				events: []event.Event{
+					event.Warning(reasonOrphanFound, errors.New("found orphaned remote object Namespace orphan of deleted Object object-orphan, not deleting it as the provider config has no attribution identity")),
+				},
+			},
+		},
+		"CleanupDuringPauseWindow": {
+			reason:       "Remote objects of deleted Objects should only be reported during a pause window of the provider config.",
+			cleanup:      true,
+			attribution:  identified,
+			pauseWindows: []kconfig.MaintenanceWindow{{Start: "00:00", Duration: metav1.Duration{Duration: 24 * time.Hour}}},
+			want: want{
+				selector: identitySelector,
+				events: []event.Event{
+					event.Warning(reasonOrphanFound, errors.New("found orphaned remote object Namespace orphan of deleted Object object-orphan, not deleting it during a pause window of the provider config")),
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			var selector string
+			k := &test.MockClient{
+				MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					lo := &client.ListOptions{}
+					lo.ApplyOptions(opts)
+					selector = lo.LabelSelector.String()
+					l := list.(*metav1.PartialObjectMetadataList)
+					l.Items = []metav1.PartialObjectMetadata{remote("owned", "live-uid"), remote("orphan", "deleted-uid")}
+					return nil
+				},
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					deleted = append(deleted, obj.GetName())
+					return nil
+				},
+			}
+			local := &test.MockClient{
+				MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					l := list.(*metav1.PartialObjectMetadataList)
+					l.Items = []metav1.PartialObjectMetadata{{ObjectMeta: metav1.ObjectMeta{Name: "object-owned", UID: "live-uid"}}}
+					return nil
+				},
+			}
+			events := &recordedEvents{}
+			s := &orphanScanner{
+				objects: local,
+				clientBuilder: kubeclient.BuilderFn(func(_ context.Context, _ kconfig.ProviderConfigSpec) (client.Client, *rest.Config, error) {
+					return k, &rest.Config{}, nil
+				}),
+				recorder: events,
+				logger:   logging.NewNopLogger(),
+				cleanup:  tc.cleanup,
+				discoverKinds: func(_ *rest.Config) ([]schema.GroupVersionKind, error) {
+					return []schema.GroupVersionKind{{Version: "v1", Kind: "Namespace"}}, nil
+				},
+				now: time.Now,
+			}
+			pc := &apisv1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: providerName},
+				Spec:       kconfig.ProviderConfigSpec{Attribution: tc.attribution, PauseWindows: tc.pauseWindows},
+			}
+
+			if err := s.scanProviderConfig(context.Background(), pc); err != nil {
+				t.Fatalf("\n%s\ns.scanProviderConfig(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.selector, selector); diff != "" {
+				t.Errorf("\n%s\ns.scanProviderConfig(...): -want selector, +got selector:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ns.scanProviderConfig(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, []event.Event(*events)); diff != "" {
+				t.Errorf("\n%s\ns.scanProviderConfig(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReleaseRemote(t *testing.T) {
+	deleted := func(obj *v1alpha2.Object) {
+		obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	}
+	orphaned := func(obj *v1alpha2.Object) {
+		obj.SetDeletionPolicy(xpv1.DeletionOrphan)
+	}
+	labeled := func() *unstructured.Unstructured {
+		u := externalResource()
+		u.SetLabels(map[string]string{labelKeyObjectUID: "uid", labelKeyProviderIdentity: "control-plane", "app": "a"})
+		u.SetFinalizers([]string{targetFinalizerName})
+		return u
+	}
+	released := func() *unstructured.Unstructured {
+		u := externalResource()
+		u.SetLabels(map[string]string{"app": "a"})
+		u.SetFinalizers([]string{})
+		return u
+	}
+
+	type want struct {
+		current *unstructured.Unstructured
+		err     error
+	}
+	cases := map[string]struct {
+		reason                    string
+		obj                       *v1alpha2.Object
+		managementPoliciesEnabled bool
+		pauseWindows              []kconfig.MaintenanceWindow
+		want
+	}{
+		"NotDeleted": {
+			reason: "The remote objects of Objects that are not being deleted should be left untouched.",
+			obj:    kubernetesObject(orphaned),
+			want:   want{current: labeled()},
+		},
+		"Deleted": {
+			reason: "The remote objects deleted along with their Objects should be left untouched.",
+			obj:    kubernetesObject(deleted),
+			want:   want{current: labeled()},
+		},
+		"Orphaned": {
+			reason: "The remote objects of Objects deleted with the Orphan deletion policy should be released.",
+			obj:    kubernetesObject(deleted, orphaned),
+			want:   want{current: released()},
+		},
+		"OrphanedByManagementPolicies": {
+			reason: "The remote objects of Objects whose management policies do not allow deleting them should be released.",
+			obj: kubernetesObject(deleted, func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+			}),
+			managementPoliciesEnabled: true,
+			want:                      want{current: released()},
+		},
+		"PauseWindow": {
+			reason:       "The remote objects of orphaned Objects should only be released once the pause windows of the provider config end.",
+			obj:          kubernetesObject(deleted, orphaned),
+			pauseWindows: []kconfig.MaintenanceWindow{{Start: "00:00", Duration: metav1.Duration{Duration: 24 * time.Hour}}},
+			want: want{
+				current: labeled(),
+				err:     errors.Errorf(errPauseWindow, providerName),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				client: resource.ClientApplicator{Client: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(nil),
+				}},
+				managementPoliciesEnabled: tc.managementPoliciesEnabled,
+				pauseWindows:              tc.pauseWindows,
+			}
+			current := labeled()
+			err := e.releaseRemote(context.Background(), tc.obj, current)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.releaseRemote(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.current, current); diff != "" {
+				t.Errorf("\n%s\ne.releaseRemote(...): -want remote object, +got remote object:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetTargetOwner              = "cannot get target owner Object"
+	errTargetOwnerProviderConfig   = "target owner Object must use the same provider config"
+	errTargetOwnerNotCreated       = "remote object of target owner Object is not created yet"
+	errTargetOwnerNamespace        = "namespaced target owner must be in the same namespace as the owned object"
+	errUnmarshalTargetOwner        = "cannot unmarshal remote object of target owner Object"
+	errResolveTargetOwnerReference = "cannot resolve target owner reference"
+)
+
+// resolveTargetOwnerReference adds an owner reference to the remote object of
+// the target owner Object of the supplied Object, if any, to its manifest.
+func (c *external) resolveTargetOwnerReference(ctx context.Context, obj *v1alpha2.Object) error {
+	ref := obj.Spec.ForProvider.TargetOwnerRef
+	if ref == nil {
+		return nil
+	}
+
+	owner := &v1alpha2.Object{}
+	if err := c.localClient.Get(ctx, types.NamespacedName{Name: ref.Name}, owner); err != nil {
+		return errors.Wrap(err, errGetTargetOwner)
+	}
+	if targetName(owner) != targetName(obj) {
+		return errors.New(errTargetOwnerProviderConfig)
+	}
+	if len(owner.Status.AtProvider.Manifest.Raw) == 0 {
+		return errors.New(errTargetOwnerNotCreated)
+	}
+	remote := &unstructured.Unstructured{}
+	if err := json.Unmarshal(owner.Status.AtProvider.Manifest.Raw, &remote.Object); err != nil {
+		return errors.Wrap(err, errUnmarshalTargetOwner)
+	}
+	if remote.GetUID() == "" || remote.IsList() {
+		return errors.New(errTargetOwnerNotCreated)
+	}
+
+	or := metav1.OwnerReference{
+		APIVersion:         remote.GetAPIVersion(),
+		Kind:               remote.GetKind(),
+		Name:               remote.GetName(),
+		UID:                remote.GetUID(),
+		Controller:         ref.Controller,
+		BlockOwnerDeletion: ref.BlockOwnerDeletion,
+	}
+
+	return updateManifest(obj, func(o *unstructured.Unstructured) error {
+		if remote.GetNamespace() != "" && remote.GetNamespace() != o.GetNamespace() {
+			return errors.New(errTargetOwnerNamespace)
+		}
+		meta.AddOwnerReference(o, or)
+		return nil
+	})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	testOwnerObjectName = "test-owner-object"
+)
+
+func ownerObject(om ...kubernetesObjectModifier) func(client.Object) error {
+	return func(obj client.Object) error {
+		o := kubernetesObject(func(o *v1alpha2.Object) {
+			o.SetName(testOwnerObjectName)
+			o.Status.AtProvider.Manifest.Raw = []byte(`{"apiVersion":"example.org/v1","kind":"Parent","metadata":{"name":"parent","uid":"parent-uid"}}`)
+		})
+		for _, m := range om {
+			m(o)
+		}
+		*obj.(*v1alpha2.Object) = *o
+		return nil
+	}
+}
+
+func TestResolveTargetOwnerReference(t *testing.T) {
+	type want struct {
+		ownerRefs []metav1.OwnerReference
+		err       error
+	}
+	cases := map[string]struct {
+		client client.Client
+		obj    *v1alpha2.Object
+		want
+	}{
+		"NoTargetOwner": {
+			obj:  kubernetesObject(),
+			want: want{},
+		},
+		"FailedToGetTargetOwner": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TargetOwnerRef = &v1alpha2.TargetOwnerReference{Name: testOwnerObjectName}
+			}),
+			want: want{
+				err: errors.Wrap(errBoom, errGetTargetOwner),
+			},
+		},
+		"DifferentProviderConfig": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, ownerObject(func(o *v1alpha2.Object) {
+					o.SetProviderConfigReference(&xpv1.Reference{Name: "other"})
+				})),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TargetOwnerRef = &v1alpha2.TargetOwnerReference{Name: testOwnerObjectName}
+			}),
+			want: want{
+				err: errors.New(errTargetOwnerProviderConfig),
+			},
+		},
+		"TargetOwnerNotCreated": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, ownerObject(func(o *v1alpha2.Object) {
+					o.Status.AtProvider.Manifest.Raw = nil
+				})),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TargetOwnerRef = &v1alpha2.TargetOwnerReference{Name: testOwnerObjectName}
+			}),
+			want: want{
+				err: errors.New(errTargetOwnerNotCreated),
+			},
+		},
+		"NamespacedTargetOwnerInOtherNamespace": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, ownerObject(func(o *v1alpha2.Object) {
+					o.Status.AtProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"parent","namespace":"other","uid":"parent-uid"}}`)
+				})),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TargetOwnerRef = &v1alpha2.TargetOwnerReference{Name: testOwnerObjectName}
+			}),
+			want: want{
+				err: errors.New(errTargetOwnerNamespace),
+			},
+		},
+		"Success": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, ownerObject()),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TargetOwnerRef = &v1alpha2.TargetOwnerReference{Name: testOwnerObjectName, Controller: ptr.To(true)}
+			}),
+			want: want{
+				ownerRefs: []metav1.OwnerReference{{
+					APIVersion: "example.org/v1",
+					Kind:       "Parent",
+					Name:       "parent",
+					UID:        "parent-uid",
+					Controller: ptr.To(true),
+				}},
+			},
+		},
+		"SuccessForListItems": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, ownerObject()),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one"}}]}`)
+				obj.Spec.ForProvider.TargetOwnerRef = &v1alpha2.TargetOwnerReference{Name: testOwnerObjectName}
+			}),
+			want: want{
+				ownerRefs: []metav1.OwnerReference{{
+					APIVersion: "example.org/v1",
+					Kind:       "Parent",
+					Name:       "parent",
+					UID:        "parent-uid",
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{localClient: tc.client}
+			gotErr := e.resolveTargetOwnerReference(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.resolveTargetOwnerReference(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			m, err := parseManifest(tc.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.IsList() {
+				_, items, err := children(tc.obj, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				m = items[0]
+			}
+			if diff := cmp.Diff(tc.want.ownerRefs, m.GetOwnerReferences()); diff != "" {
+				t.Errorf("e.resolveTargetOwnerReference(...): -want owner references, +got owner references: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typeOwnershipConflict is the condition reporting whether a remote
+	// object of an Object is not updated because it belongs to someone else.
+	typeOwnershipConflict xpv1.ConditionType = "OwnershipConflict"
+
+	reasonNotOwned xpv1.ConditionReason = "NotOwned"
+	reasonOwned    xpv1.ConditionReason = "Owned"
+
+	errNotOwned     = "%s %s is not labeled as owned by this Object; set spec.forProvider.ownershipPolicy to Adopt to take it over"
+	errOwnedByOther = "%s %s is owned by another Object with UID %s"
+)
+
+// setOwnershipLabel labels the manifest of the supplied Object, or every item
+// of its List manifest, as owned by the Object, if its ownership policy asks
+// for it.
+func setOwnershipLabel(obj *v1alpha2.Object) error {
+	if obj.Spec.ForProvider.OwnershipPolicy == "" || observeOnly(obj) || obj.GetUID() == "" {
+		return nil
+	}
+	return updateManifest(obj, func(o *unstructured.Unstructured) error {
+		meta.AddLabels(o, map[string]string{labelKeyObjectUID: string(obj.GetUID())})
+		return nil
+	})
+}
+
+// checkOwnership returns an error, and sets the OwnershipConflict condition of
+// the supplied Object, if its ownership policy does not allow it to update the
+// supplied remote object. Objects being deleted, or only observing their
+// remote objects, are not checked. See setOwned.
+func checkOwnership(obj *v1alpha2.Object, current *unstructured.Unstructured) error {
+	p := obj.Spec.ForProvider.OwnershipPolicy
+	if p == "" || meta.WasDeleted(obj) || observeOnly(obj) {
+		return nil
+	}
+	var err error
+	switch owner, labeled := current.GetLabels()[labelKeyObjectUID]; {
+	case owner == string(obj.GetUID()):
+	case !labeled || owner == "":
+		if p != v1alpha2.OwnershipPolicyAdopt {
+			err = errors.Errorf(errNotOwned, current.GetKind(), resourceName(current))
+		}
+	default:
+		err = errors.Errorf(errOwnedByOther, current.GetKind(), resourceName(current), owner)
+	}
+	if err != nil {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typeOwnershipConflict,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonNotOwned,
+			Message:            err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+// setOwned reports that the ownership conflict of the supplied Object, if any,
+// was resolved.
+func setOwned(obj *v1alpha2.Object) {
+	if obj.GetCondition(typeOwnershipConflict).Status != v1.ConditionTrue {
+		return
+	}
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeOwnershipConflict,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonOwned,
+	})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func withOwnershipPolicy(p v1alpha2.OwnershipPolicy) kubernetesObjectModifier {
+	return func(obj *v1alpha2.Object) {
+		obj.SetUID(types.UID("object-uid"))
+		obj.Spec.ForProvider.OwnershipPolicy = p
+	}
+}
+
+func TestSetOwnershipLabel(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   map[string]string
+	}{
+		"NoPolicy": {
+			reason: "The manifest should not be labeled if the Object has no ownership policy.",
+			obj:    kubernetesObject(),
+		},
+		"Enforce": {
+			reason: "The manifest should be labeled as owned by the Object if it enforces its ownership.",
+			obj:    kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyEnforce)),
+			want:   map[string]string{labelKeyObjectUID: "object-uid"},
+		},
+		"ObserveOnly": {
+			reason: "The manifest of an Object only observing its remote object should not be labeled.",
+			obj: kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyEnforce), func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := setOwnershipLabel(tc.obj); err != nil {
+				t.Fatal(err)
+			}
+			m, err := parseManifest(tc.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, m.GetLabels()); diff != "" {
+				t.Errorf("\n%s\nsetOwnershipLabel(...): -want labels, +got labels:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCheckOwnership(t *testing.T) {
+	remote := func(labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName("settings")
+		u.SetLabels(labels)
+		return u
+	}
+
+	type want struct {
+		err    error
+		status corev1.ConditionStatus
+	}
+	cases := map[string]struct {
+		reason  string
+		obj     *v1alpha2.Object
+		current *unstructured.Unstructured
+		want    want
+	}{
+		"NoPolicy": {
+			reason:  "Remote objects should not be checked if the Object has no ownership policy.",
+			obj:     kubernetesObject(),
+			current: remote(map[string]string{labelKeyObjectUID: "other-uid"}),
+		},
+		"Owned": {
+			reason:  "A remote object labeled as owned by the Object should be updated.",
+			obj:     kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyEnforce)),
+			current: remote(map[string]string{labelKeyObjectUID: "object-uid"}),
+		},
+		"NotLabeled": {
+			reason:  "A remote object not labeled as owned by any Object should not be updated if ownership is enforced.",
+			obj:     kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyEnforce)),
+			current: remote(nil),
+			want: want{
+				err:    errors.Errorf(errNotOwned, "ConfigMap", "default/settings"),
+				status: corev1.ConditionTrue,
+			},
+		},
+		"Adopt": {
+			reason:  "A remote object not labeled as owned by any Object should be adopted if the Object asks for it.",
+			obj:     kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyAdopt)),
+			current: remote(nil),
+		},
+		"OwnedByOther": {
+			reason:  "A remote object owned by another Object should not be updated, even if adoption is enabled.",
+			obj:     kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyAdopt)),
+			current: remote(map[string]string{labelKeyObjectUID: "other-uid"}),
+			want: want{
+				err:    errors.Errorf(errOwnedByOther, "ConfigMap", "default/settings", "other-uid"),
+				status: corev1.ConditionTrue,
+			},
+		},
+		"Deleted": {
+			reason: "The remote objects of Objects being deleted should not be checked.",
+			obj: kubernetesObject(withOwnershipPolicy(v1alpha2.OwnershipPolicyEnforce), func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			current: remote(nil),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkOwnership(tc.obj, tc.current)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncheckOwnership(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, tc.obj.GetCondition(typeOwnershipConflict).Status); tc.want.status != "" && diff != "" {
+				t.Errorf("\n%s\ncheckOwnership(...): -want condition status, +got condition status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetOwned(t *testing.T) {
+	obj := kubernetesObject()
+	setOwned(obj)
+	if c := obj.GetCondition(typeOwnershipConflict); c.Status != corev1.ConditionUnknown {
+		t.Errorf("setOwned(...): no condition should be set without a conflict, got %v", c)
+	}
+	obj.SetConditions(xpv1.Condition{Type: typeOwnershipConflict, Status: corev1.ConditionTrue, Reason: reasonNotOwned})
+	setOwned(obj)
+	if c := obj.GetCondition(typeOwnershipConflict); c.Status != corev1.ConditionFalse || c.Reason != reasonOwned {
+		t.Errorf("setOwned(...): a resolved conflict should be reported, got %v", c)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	reasonPauseWindow xpv1.ConditionReason = "PauseWindow"
+
+	msgPauseWindow = "Changes are applied once the pause window of provider config %q ends"
+	errPauseWindow = "cannot delete the remote object during a pause window of provider config %q"
+)
+
+// pauseWrites returns the supplied observation as if the remote object of the
+// supplied Object existed and was up-to-date, and sets the ChangesPending
+// condition of the Object, if it needs to be created or updated during a pause
+// window of its provider config. The observations of Objects being deleted are
+// returned as is, see pauseDeletion.
+func (c *external) pauseWrites(obj *v1alpha2.Object, o managed.ExternalObservation, now time.Time) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(obj) || (o.ResourceExists && o.ResourceUpToDate) {
+		return o, nil
+	}
+	paused, err := c.paused(now)
+	if err != nil || !paused {
+		return o, err
+	}
+	c.logger.Debug("Not applying changes during a pause window of the provider config")
+	setChangesPending(obj, reasonPauseWindow, fmt.Sprintf(msgPauseWindow, obj.GetProviderConfigReference().Name))
+	o.ResourceExists = true
+	o.ResourceUpToDate = true
+	return o, nil
+}
+
+// pauseDeletion returns an error, and sets the ChangesPending condition of the
+// supplied Object, if its remote object would be deleted during a pause window
+// of its provider config, so that the deletion is retried until it ends.
+func (c *external) pauseDeletion(obj *v1alpha2.Object, now time.Time) error {
+	paused, err := c.paused(now)
+	if err != nil || !paused {
+		return err
+	}
+	setChangesPending(obj, reasonPauseWindow, fmt.Sprintf(msgPauseWindow, obj.GetProviderConfigReference().Name))
+	return errors.Errorf(errPauseWindow, obj.GetProviderConfigReference().Name)
+}
+
+// paused returns true if the supplied time is within one of the pause windows
+// of the provider config.
+func (c *external) paused(now time.Time) (bool, error) {
+	return inPauseWindow(c.pauseWindows, now)
+}
+
+// inPauseWindow returns true if the supplied time is within one of the
+// supplied pause windows of a provider config.
+func inPauseWindow(windows []kconfig.MaintenanceWindow, now time.Time) (bool, error) {
+	for _, w := range windows {
+		in, err := inWindow(maintenanceWindow(w), now)
+		if err != nil || in {
+			return in, err
+		}
+	}
+	return false, nil
+}
+
+// maintenanceWindow returns the supplied pause window of a provider config as
+// a maintenance window of an Object.
+func maintenanceWindow(w kconfig.MaintenanceWindow) v1alpha2.MaintenanceWindow {
+	days := make([]v1alpha2.Weekday, len(w.Days))
+	for i, d := range w.Days {
+		days[i] = v1alpha2.Weekday(d)
+	}
+	return v1alpha2.MaintenanceWindow{Days: days, Start: w.Start, Duration: w.Duration, TimeZone: w.TimeZone}
+}
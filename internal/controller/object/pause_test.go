@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestPauseWrites(t *testing.T) {
+	// A Friday.
+	now := time.Date(2024, time.May, 17, 23, 0, 0, 0, time.UTC)
+	freeze := []kconfig.MaintenanceWindow{{Days: []kconfig.Weekday{"Fri"}, Start: "18:00", Duration: metav1.Duration{Duration: 60 * time.Hour}}}
+	deleted := func(obj *v1alpha2.Object) {
+		obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+	}
+
+	type want struct {
+		o      managed.ExternalObservation
+		reason xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason  string
+		windows []kconfig.MaintenanceWindow
+		obj     *v1alpha2.Object
+		o       managed.ExternalObservation
+		want    want
+	}{
+		"NoPauseWindows": {
+			reason: "Changes should be applied if the provider config has no pause windows.",
+			obj:    kubernetesObject(),
+			o:      managed.ExternalObservation{ResourceExists: true},
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"OutsidePauseWindow": {
+			reason:  "Changes should be applied outside the pause windows of the provider config.",
+			windows: []kconfig.MaintenanceWindow{{Days: []kconfig.Weekday{"Mon"}, Start: "18:00", Duration: metav1.Duration{Duration: time.Hour}}},
+			obj:     kubernetesObject(),
+			o:       managed.ExternalObservation{},
+			want:    want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason:  "No changes should be reported pending if the remote object is up-to-date.",
+			windows: freeze,
+			obj:     kubernetesObject(),
+			o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want:    want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"UpdatePaused": {
+			reason:  "An out of date remote object should not be updated during a pause window.",
+			windows: freeze,
+			obj:     kubernetesObject(),
+			o:       managed.ExternalObservation{ResourceExists: true},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				reason: reasonPauseWindow,
+			},
+		},
+		"CreatePaused": {
+			reason:  "A missing remote object should not be created during a pause window.",
+			windows: freeze,
+			obj:     kubernetesObject(),
+			o:       managed.ExternalObservation{},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				reason: reasonPauseWindow,
+			},
+		},
+		"Deleted": {
+			reason:  "The observations of Objects being deleted should be returned as is.",
+			windows: freeze,
+			obj:     kubernetesObject(deleted),
+			o:       managed.ExternalObservation{ResourceExists: true},
+			want:    want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), pauseWindows: tc.windows}
+			got, err := e.pauseWrites(tc.obj, tc.o, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.pauseWrites(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			c := tc.obj.GetCondition(typeChangesPending)
+			if tc.want.reason == "" {
+				if c.Status == corev1.ConditionTrue {
+					t.Errorf("\n%s\ne.pauseWrites(...): unexpected ChangesPending condition %v", tc.reason, c)
+				}
+				return
+			}
+			if c.Status != corev1.ConditionTrue || c.Reason != tc.want.reason {
+				t.Errorf("\n%s\ne.pauseWrites(...): want ChangesPending condition with reason %s, got %v", tc.reason, tc.want.reason, c)
+			}
+		})
+	}
+}
+
+func TestPauseDeletion(t *testing.T) {
+	// A Saturday.
+	now := time.Date(2024, time.May, 18, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		reason  string
+		windows []kconfig.MaintenanceWindow
+		want    error
+	}{
+		"OutsidePauseWindow": {
+			reason:  "The remote object should be deleted outside the pause windows of the provider config.",
+			windows: []kconfig.MaintenanceWindow{{Days: []kconfig.Weekday{"Mon"}, Start: "00:00", Duration: metav1.Duration{Duration: time.Hour}}},
+		},
+		"Paused": {
+			reason:  "The remote object should not be deleted during a pause window of the provider config.",
+			windows: []kconfig.MaintenanceWindow{{Days: []kconfig.Weekday{"Sat", "Sun"}, Start: "00:00", Duration: metav1.Duration{Duration: 24 * time.Hour}}},
+			want:    errors.Errorf(errPauseWindow, providerName),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), pauseWindows: tc.windows}
+			err := e.pauseDeletion(kubernetesObject(), now)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.pauseDeletion(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	errNoProviderConfigMatches = "no provider config matches the provider config selector"
+	errSelectProviderConfig    = "cannot update the reference to the selected provider config"
+)
+
+// A providerConfigSelector is an initializer that resolves the provider config
+// selector of an Object to the provider config it refers to.
+type providerConfigSelector struct {
+	kube client.Client
+}
+
+// Initialize refers the supplied Object to a provider config matching its
+// provider config selector, if any. The provider config it refers to is kept
+// as long as it matches, so that Objects do not move between target clusters
+// when another provider config starts matching.
+func (s *providerConfigSelector) Initialize(ctx context.Context, mg resource.Managed) error {
+	obj, ok := mg.(*v1alpha2.Object)
+	if !ok {
+		return errors.New(errNotKubernetesObject)
+	}
+	sel := obj.Spec.ProviderConfigSelector
+	if sel == nil || meta.WasDeleted(obj) || usesInlineKubeconfig(obj) {
+		return nil
+	}
+
+	pcs := &apisv1alpha1.ProviderConfigList{}
+	if err := s.kube.List(ctx, pcs, client.MatchingLabels(sel.MatchLabels)); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+	names := make([]string, 0, len(pcs.Items))
+	for i := range pcs.Items {
+		names = append(names, pcs.Items[i].GetName())
+	}
+	if len(names) == 0 {
+		return errors.New(errNoProviderConfigMatches)
+	}
+	sort.Strings(names)
+
+	ref := obj.GetProviderConfigReference()
+	for _, n := range names {
+		if ref != nil && ref.Name == n {
+			return nil
+		}
+	}
+	obj.SetProviderConfigReference(&xpv1.Reference{Name: names[0]})
+	return errors.Wrap(s.kube.Update(ctx, obj), errSelectProviderConfig)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestProviderConfigSelectorInitialize(t *testing.T) {
+	selecting := func(obj *v1alpha2.Object) {
+		obj.Spec.ProviderConfigSelector = &v1alpha2.ProviderConfigSelector{MatchLabels: map[string]string{"env": "staging"}}
+	}
+
+	type want struct {
+		ref     string
+		updated bool
+		err     error
+	}
+	cases := map[string]struct {
+		reason  string
+		matches []string
+		listErr error
+		obj     *v1alpha2.Object
+		want    want
+	}{
+		"NoSelector": {
+			reason: "Objects without a provider config selector should keep their provider config.",
+			obj:    kubernetesObject(),
+			want:   want{ref: providerName},
+		},
+		"Deleted": {
+			reason: "Deleted Objects should keep their provider config, so that their remote objects are deleted from the same cluster.",
+			obj: kubernetesObject(selecting, func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			want: want{ref: providerName},
+		},
+		"ListError": {
+			reason:  "Errors listing provider configs should be returned.",
+			listErr: errBoom,
+			obj:     kubernetesObject(selecting),
+			want:    want{ref: providerName, err: errors.Wrap(errBoom, errListProviderConfigs)},
+		},
+		"NoMatch": {
+			reason: "An error should be returned if no provider config matches.",
+			obj:    kubernetesObject(selecting),
+			want:   want{ref: providerName, err: errors.New(errNoProviderConfigMatches)},
+		},
+		"StillMatching": {
+			reason:  "The provider config of the Object should be kept while it matches.",
+			matches: []string{"a", providerName},
+			obj:     kubernetesObject(selecting),
+			want:    want{ref: providerName},
+		},
+		"Selected": {
+			reason:  "The first matching provider config by name should be selected if the current one does not match.",
+			matches: []string{"b", "a"},
+			obj:     kubernetesObject(selecting),
+			want:    want{ref: "a", updated: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			s := &providerConfigSelector{
+				kube: &test.MockClient{
+					MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						for _, n := range tc.matches {
+							pcs := list.(*apisv1alpha1.ProviderConfigList)
+							pcs.Items = append(pcs.Items, apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: n}})
+						}
+						return tc.listErr
+					},
+					MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+						updated = true
+						return nil
+					},
+				},
+			}
+			err := s.Initialize(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Initialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(&xpv1.Reference{Name: tc.want.ref}, tc.obj.GetProviderConfigReference()); diff != "" {
+				t.Errorf("\n%s\ns.Initialize(...): -want provider config reference, +got provider config reference:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("\n%s\ns.Initialize(...): -want updated, +got updated:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// RBAC verbs.
+const (
+	verbGet    = "get"
+	verbList   = "list"
+	verbWatch  = "watch"
+	verbCreate = "create"
+	verbUpdate = "update"
+	verbPatch  = "patch"
+	verbDelete = "delete"
+)
+
+// setRequiredPermissions records the permissions on the target cluster the
+// supplied Object requires to reconcile the remote objects of the supplied
+// manifest in its status, if they are reported. Kinds the target cluster
+// does not serve are left out, since the Object fails to observe them anyway.
+func (c *external) setRequiredPermissions(obj *v1alpha2.Object, manifest *unstructured.Unstructured) error {
+	if !c.requiredPermissionsEnabled {
+		return nil
+	}
+	manifests := []*unstructured.Unstructured{manifest}
+	if manifest.IsList() {
+		_, items, err := children(obj, manifest)
+		if err != nil {
+			return err
+		}
+		manifests = items
+	}
+	_, ssa := c.syncer.(*SSAResourceSyncer)
+	obj.Status.AtProvider.RequiredPermissions = requiredPermissions(obj, manifests, c.client.RESTMapper(), c.shouldWatch(obj), ssa)
+	return nil
+}
+
+// requiredPermissions returns the permissions the supplied Object requires to
+// reconcile the supplied remote objects, whose resources are mapped with the
+// supplied RESTMapper, according to its management and deletion policies.
+func requiredPermissions(obj *v1alpha2.Object, manifests []*unstructured.Unstructured, mapper meta.RESTMapper, watched, ssa bool) []v1alpha2.PermissionRule {
+	if mapper == nil {
+		return nil
+	}
+	policies := sets.New[xpv1.ManagementAction](obj.GetManagementPolicies()...)
+	allowed := func(a xpv1.ManagementAction) bool {
+		return policies.HasAny(a, xpv1.ManagementActionAll)
+	}
+	sub := string(obj.Spec.ForProvider.Subresource)
+
+	rules := map[permissionKey]sets.Set[string]{}
+	require := func(group, resource, namespace string, verbs ...string) {
+		k := permissionKey{group: group, resource: resource, namespace: namespace}
+		if rules[k] == nil {
+			rules[k] = sets.New[string]()
+		}
+		rules[k].Insert(verbs...)
+	}
+
+	for _, m := range manifests {
+		gvk := m.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			continue
+		}
+		group, resource := mapping.Resource.Group, mapping.Resource.Resource
+		ns := ""
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			ns = m.GetNamespace()
+		}
+
+		require(group, resource, ns, verbGet)
+		if watched {
+			// Kinds are watched in all namespaces.
+			require(group, resource, "", verbList, verbWatch)
+		}
+		if sub != "" {
+			// The subresource is patched, but the remote object is
+			// neither created nor deleted.
+			require(group, resource+"/"+sub, ns, verbGet)
+			if allowed(xpv1.ManagementActionCreate) || allowed(xpv1.ManagementActionUpdate) {
+				require(group, resource+"/"+sub, ns, verbPatch)
+			}
+			continue
+		}
+		if allowed(xpv1.ManagementActionCreate) {
+			require(group, resource, ns, verbCreate)
+		}
+		if allowed(xpv1.ManagementActionUpdate) {
+			require(group, resource, ns, updateVerb(obj, ssa))
+		}
+		if allowed(xpv1.ManagementActionDelete) && obj.GetDeletionPolicy() != xpv1.DeletionOrphan {
+			require(group, resource, ns, verbDelete)
+		}
+	}
+
+	out := make([]v1alpha2.PermissionRule, 0, len(rules))
+	for k, verbs := range rules {
+		out = append(out, v1alpha2.PermissionRule{APIGroup: k.group, Resource: k.resource, Namespace: k.namespace, Verbs: sets.List(verbs)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.APIGroup != b.APIGroup {
+			return a.APIGroup < b.APIGroup
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.Namespace < b.Namespace
+	})
+	return out
+}
+
+// updateVerb returns the verb the remote objects of the supplied Object are
+// updated with.
+func updateVerb(obj *v1alpha2.Object, ssa bool) string {
+	if !ssa && obj.Spec.ForProvider.UpdateStrategy == v1alpha2.UpdateStrategyReplace {
+		return verbUpdate
+	}
+	return verbPatch
+}
+
+// A permissionKey identifies the permission rules whose verbs are merged.
+type permissionKey struct {
+	group     string
+	resource  string
+	namespace string
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRequiredPermissions(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("default")
+	deployment.SetName("cool")
+	namespace := &unstructured.Unstructured{}
+	namespace.SetAPIVersion("v1")
+	namespace.SetKind("Namespace")
+	namespace.SetName("cool")
+	unknown := &unstructured.Unstructured{}
+	unknown.SetAPIVersion("example.org/v1")
+	unknown.SetKind("Cool")
+
+	cases := map[string]struct {
+		reason    string
+		obj       *v1alpha2.Object
+		manifests []*unstructured.Unstructured
+		watched   bool
+		ssa       bool
+		want      []v1alpha2.PermissionRule
+	}{
+		"FullyManaged": {
+			reason:    "A fully managed Object should require every write on its namespaced remote object in its namespace.",
+			obj:       kubernetesObject(),
+			manifests: []*unstructured.Unstructured{deployment},
+			want: []v1alpha2.PermissionRule{
+				{APIGroup: "apps", Resource: "deployments", Namespace: "default", Verbs: []string{"create", "delete", "get", "patch"}},
+			},
+		},
+		"ObserveOnlyWatched": {
+			reason: "A watched observe only Object should require reading its remote object, and watching its kind in all namespaces.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+			}),
+			manifests: []*unstructured.Unstructured{deployment},
+			watched:   true,
+			want: []v1alpha2.PermissionRule{
+				{APIGroup: "apps", Resource: "deployments", Verbs: []string{"list", "watch"}},
+				{APIGroup: "apps", Resource: "deployments", Namespace: "default", Verbs: []string{"get"}},
+			},
+		},
+		"ReplacedOrphaned": {
+			reason: "An Object replacing its remote object should require updating it, and not deleting it if it is orphaned.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.UpdateStrategy = v1alpha2.UpdateStrategyReplace
+				obj.SetDeletionPolicy(xpv1.DeletionOrphan)
+			}),
+			manifests: []*unstructured.Unstructured{namespace},
+			want: []v1alpha2.PermissionRule{
+				{Resource: "namespaces", Verbs: []string{"create", "get", "update"}},
+			},
+		},
+		"ServerSideApply": {
+			reason: "An Object applying its remote object server-side should require patching it regardless of its update strategy.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.UpdateStrategy = v1alpha2.UpdateStrategyReplace
+			}),
+			manifests: []*unstructured.Unstructured{namespace},
+			ssa:       true,
+			want: []v1alpha2.PermissionRule{
+				{Resource: "namespaces", Verbs: []string{"create", "delete", "get", "patch"}},
+			},
+		},
+		"Subresource": {
+			reason: "An Object applying to a subresource should require reading the remote object and patching the subresource.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Subresource = v1alpha2.SubresourceScale
+			}),
+			manifests: []*unstructured.Unstructured{deployment},
+			want: []v1alpha2.PermissionRule{
+				{APIGroup: "apps", Resource: "deployments", Namespace: "default", Verbs: []string{"get"}},
+				{APIGroup: "apps", Resource: "deployments/scale", Namespace: "default", Verbs: []string{"get", "patch"}},
+			},
+		},
+		"UnknownKind": {
+			reason:    "Kinds the target cluster does not serve should be left out.",
+			obj:       kubernetesObject(),
+			manifests: []*unstructured.Unstructured{unknown, namespace},
+			want: []v1alpha2.PermissionRule{
+				{Resource: "namespaces", Verbs: []string{"create", "delete", "get", "patch"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := requiredPermissions(tc.obj, tc.manifests, mapper, tc.watched, tc.ssa)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nrequiredPermissions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
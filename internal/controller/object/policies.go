@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typePolicyConflict is the condition reporting whether the management
+	// and deletion policies of an Object contradict each other. The
+	// contradictions that are certainly mistakes are rejected on admission,
+	// while those that are also the defaults are only reported.
+	typePolicyConflict xpv1.ConditionType = "PolicyConflict"
+
+	reasonDeletionPolicyIgnored xpv1.ConditionReason = "DeletionPolicyIgnored"
+	reasonNoPolicyConflict      xpv1.ConditionReason = "NoPolicyConflict"
+
+	msgDeletionPolicyIgnored = "deletionPolicy Delete is ignored since managementPolicies do not include Delete, so the remote object is orphaned when the Object is deleted: set deletionPolicy to Orphan, or add Delete to managementPolicies"
+)
+
+// setPolicyConflictCondition reports whether the management and deletion
+// policies of the supplied Object contradict each other, if its management
+// policies are honored. The condition is only reported as false if it was
+// previously reported as true.
+func setPolicyConflictCondition(obj *v1alpha2.Object, managementPoliciesEnabled bool) {
+	if managementPoliciesEnabled && deletionPolicyIgnored(obj) {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typePolicyConflict,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonDeletionPolicyIgnored,
+			Message:            msgDeletionPolicyIgnored,
+		})
+		return
+	}
+	if obj.GetCondition(typePolicyConflict).Status == v1.ConditionTrue {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typePolicyConflict,
+			Status:             v1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonNoPolicyConflict,
+		})
+	}
+}
+
+// deletionPolicyIgnored returns true if the supplied Object asks for its
+// remote object to be deleted, but its management policies do not allow it.
+// Paused Objects, i.e. with no management policies, are not considered.
+func deletionPolicyIgnored(obj *v1alpha2.Object) bool {
+	policies := obj.GetManagementPolicies()
+	if obj.GetDeletionPolicy() != xpv1.DeletionDelete || len(policies) == 0 {
+		return false
+	}
+	for _, p := range policies {
+		if p == xpv1.ManagementActionAll || p == xpv1.ManagementActionDelete {
+			return false
+		}
+	}
+	return true
+}
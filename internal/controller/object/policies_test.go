@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestSetPolicyConflictCondition(t *testing.T) {
+	observe := func(obj *v1alpha2.Object) {
+		obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+		obj.Spec.DeletionPolicy = xpv1.DeletionDelete
+	}
+	conflicting := func(obj *v1alpha2.Object) {
+		obj.SetConditions(xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionTrue, Reason: reasonDeletionPolicyIgnored})
+	}
+
+	cases := map[string]struct {
+		reason  string
+		enabled bool
+		obj     *v1alpha2.Object
+		want    xpv1.Condition
+	}{
+		"Default": {
+			reason:  "No conflict should be reported for the default policies.",
+			enabled: true,
+			obj:     kubernetesObject(func(obj *v1alpha2.Object) { obj.Spec.DeletionPolicy = xpv1.DeletionDelete }),
+			want:    xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionUnknown},
+		},
+		"DeletionPolicyIgnored": {
+			reason:  "A conflict should be reported if the deletion policy asks for a deletion the management policies do not allow.",
+			enabled: true,
+			obj:     kubernetesObject(observe),
+			want:    xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionTrue, Reason: reasonDeletionPolicyIgnored, Message: msgDeletionPolicyIgnored},
+		},
+		"Orphan": {
+			reason:  "No conflict should be reported for an Object that orphans its remote object anyway.",
+			enabled: true,
+			obj: kubernetesObject(observe, func(obj *v1alpha2.Object) {
+				obj.Spec.DeletionPolicy = xpv1.DeletionOrphan
+			}),
+			want: xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionUnknown},
+		},
+		"Paused": {
+			reason:  "No conflict should be reported for a paused Object.",
+			enabled: true,
+			obj: kubernetesObject(observe, func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{}
+			}),
+			want: xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionUnknown},
+		},
+		"ManagementPoliciesDisabled": {
+			reason: "No conflict should be reported if the management policies are not honored.",
+			obj:    kubernetesObject(observe),
+			want:   xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionUnknown},
+		},
+		"Resolved": {
+			reason:  "A conflict previously reported should be reported as resolved.",
+			enabled: true,
+			obj:     kubernetesObject(conflicting),
+			want:    xpv1.Condition{Type: typePolicyConflict, Status: v1.ConditionFalse, Reason: reasonNoPolicyConflict},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setPolicyConflictCondition(tc.obj, tc.enabled)
+			got := tc.obj.GetCondition(typePolicyConflict)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nsetPolicyConflictCondition(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/shard"
+)
+
+const (
+	// priorityRequeueAfter is the delay after which an Object held back for
+	// Objects with a higher priority is reconciled again.
+	priorityRequeueAfter = 2 * time.Second
+	// maxPriorityDeferral is how long an Object is held back at most, so that
+	// Objects with a higher priority that are slow to reconcile do not starve
+	// it.
+	maxPriorityDeferral = 30 * time.Second
+	// priorityCountInterval is how often the Objects are counted by priority.
+	priorityCountInterval = 5 * time.Second
+)
+
+// A priorityReconciler holds back the reconciles of Objects while Objects of
+// its shard with a higher priority are still to be reconciled since the
+// provider started. Objects are held back only until every Object of the shard
+// was reconciled once, successfully or not, i.e. while the provider works
+// through its startup backlog. Held back Objects are requeued rather than
+// waited for, so that their reconcile workers are free for the Objects with a
+// higher priority.
+type priorityReconciler struct {
+	inner reconcile.Reconciler
+	kube  client.Reader
+	shard shard.Shard
+	now   func() time.Time
+
+	mu sync.Mutex
+	// settled is true once every Object of the shard was reconciled, after
+	// which no Object is held back anymore.
+	settled bool
+	// counts are the numbers of Objects of the shard by priority, as of
+	// countedAt.
+	counts    map[int32]int
+	countedAt time.Time
+	// reconciled are the priorities of the Objects reconciled, and
+	// reconciledCounts their numbers by priority.
+	reconciled       map[types.NamespacedName]int32
+	reconciledCounts map[int32]int
+	// deferredSince is when Objects were first held back.
+	deferredSince map[types.NamespacedName]time.Time
+}
+
+func newPriorityReconciler(inner reconcile.Reconciler, kube client.Reader, s shard.Shard) *priorityReconciler {
+	return &priorityReconciler{
+		inner:            inner,
+		kube:             kube,
+		shard:            s,
+		now:              time.Now,
+		reconciled:       make(map[types.NamespacedName]int32),
+		reconciledCounts: make(map[int32]int),
+		deferredSince:    make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// Reconcile the supplied request with the inner reconciler, unless Objects
+// with a higher priority than its Object are still to be reconciled.
+func (r *priorityReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if r.isSettled() {
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, req.NamespacedName, obj); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.forget(req.NamespacedName)
+		}
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	p := obj.Spec.Priority
+	if r.deferred(ctx, req.NamespacedName, p) {
+		return reconcile.Result{RequeueAfter: priorityRequeueAfter + time.Duration(rand.Int63n(int64(priorityRequeueAfter)))}, nil //nolint:gosec // No need for secure randomness
+	}
+
+	res, err := r.inner.Reconcile(ctx, req)
+	// Failed Objects are done as well, so that Objects with a higher priority
+	// that keep failing do not hold back the others.
+	r.record(req.NamespacedName, p)
+	return res, err
+}
+
+// isSettled returns true if every Object of the shard was reconciled since
+// the provider started.
+func (r *priorityReconciler) isSettled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.settled
+}
+
+// deferred returns true if the Object of the supplied name and priority is to
+// be held back.
+func (r *priorityReconciler) deferred(ctx context.Context, name types.NamespacedName, p int32) bool {
+	r.count(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending := 0
+	for q, n := range r.counts {
+		if q > p && n > r.reconciledCounts[q] {
+			pending += n - r.reconciledCounts[q]
+		}
+	}
+	if pending == 0 {
+		delete(r.deferredSince, name)
+		return false
+	}
+
+	now := r.now()
+	since, ok := r.deferredSince[name]
+	if !ok {
+		r.deferredSince[name] = now
+		return true
+	}
+	if now.Sub(since) >= maxPriorityDeferral {
+		delete(r.deferredSince, name)
+		return false
+	}
+	return true
+}
+
+// count the Objects of the shard by priority, unless they were counted
+// recently.
+func (r *priorityReconciler) count(ctx context.Context) {
+	r.mu.Lock()
+	stale := r.now().Sub(r.countedAt) >= priorityCountInterval
+	r.mu.Unlock()
+	if !stale {
+		return
+	}
+
+	l := &v1alpha2.ObjectList{}
+	if err := r.kube.List(ctx, l, client.UnsafeDisableDeepCopy); err != nil {
+		return
+	}
+	counts := make(map[int32]int)
+	for i := range l.Items {
+		if r.shard.Owns(&l.Items[i]) {
+			counts[l.Items[i].Spec.Priority]++
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts = counts
+	r.countedAt = r.now()
+}
+
+// record that the Object of the supplied name and priority was reconciled,
+// and whether every Object of the shard is reconciled now.
+func (r *priorityReconciler) record(name types.NamespacedName, p int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.settled {
+		return
+	}
+	r.unrecord(name)
+	r.reconciled[name] = p
+	r.reconciledCounts[p]++
+
+	if r.counts == nil {
+		return
+	}
+	for q, n := range r.counts {
+		if n > r.reconciledCounts[q] {
+			return
+		}
+	}
+	r.settled = true
+	r.counts, r.reconciled, r.reconciledCounts, r.deferredSince = nil, nil, nil, nil
+}
+
+// forget the Object of the supplied name, which was deleted.
+func (r *priorityReconciler) forget(name types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.settled {
+		r.unrecord(name)
+	}
+}
+
+// unrecord the Object of the supplied name. The mutex must be held.
+func (r *priorityReconciler) unrecord(name types.NamespacedName) {
+	q, ok := r.reconciled[name]
+	if !ok {
+		return
+	}
+	if r.reconciledCounts[q]--; r.reconciledCounts[q] <= 0 {
+		delete(r.reconciledCounts, q)
+	}
+	delete(r.reconciled, name)
+}
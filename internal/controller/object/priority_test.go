@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/shard"
+)
+
+func TestPriorityReconciler(t *testing.T) {
+	// objects are the Objects by name. The remote one belongs to another
+	// shard.
+	objects := map[string]v1alpha2.Object{
+		"high":   {ObjectMeta: metav1.ObjectMeta{Name: "high", Labels: map[string]string{shard.LabelKey: "0"}}, Spec: v1alpha2.ObjectSpec{Priority: 10}},
+		"low":    {ObjectMeta: metav1.ObjectMeta{Name: "low", Labels: map[string]string{shard.LabelKey: "0"}}},
+		"remote": {ObjectMeta: metav1.ObjectMeta{Name: "remote", Labels: map[string]string{shard.LabelKey: "1"}}, Spec: v1alpha2.ObjectSpec{Priority: 20}},
+	}
+	req := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}
+	}
+
+	type step struct {
+		name       string
+		advance    time.Duration
+		failing    bool
+		reconciled bool
+		// created is an Object of the shard with the highest priority that
+		// is created before the step.
+		created string
+	}
+	cases := map[string][]step{
+		"HigherPriorityFirst": {
+			{name: "low", reconciled: false},
+			{name: "high", reconciled: true},
+			{name: "low", reconciled: true},
+		},
+		"HigherPriorityFailing": {
+			{name: "high", failing: true, reconciled: true},
+			{name: "low", reconciled: true},
+		},
+		"HigherPrioritySlow": {
+			{name: "low", reconciled: false},
+			{name: "low", advance: maxPriorityDeferral, reconciled: true},
+		},
+		"Settled": {
+			{name: "high", reconciled: true},
+			{name: "low", reconciled: true},
+			{name: "low", advance: priorityCountInterval, created: "new", reconciled: true},
+		},
+		"NotFound": {
+			{name: "deleted", reconciled: true},
+		},
+	}
+	for name, steps := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := make(map[string]v1alpha2.Object, len(objects))
+			for n, o := range objects {
+				objs[n] = o
+			}
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					o, ok := objs[key.Name]
+					if !ok {
+						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+					o.DeepCopyInto(obj.(*v1alpha2.Object))
+					return nil
+				},
+				MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					l := list.(*v1alpha2.ObjectList)
+					for _, o := range objs {
+						l.Items = append(l.Items, o)
+					}
+					return nil
+				},
+			}
+
+			now := time.Now()
+			var failing, reconciled bool
+			r := newPriorityReconciler(reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{Requeue: failing}, nil
+			}), kube, shard.Shard{Index: 0, Count: 2})
+			r.now = func() time.Time { return now }
+
+			for i, s := range steps {
+				now = now.Add(s.advance)
+				if s.created != "" {
+					objs[s.created] = v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: s.created, Labels: map[string]string{shard.LabelKey: "0"}}, Spec: v1alpha2.ObjectSpec{Priority: 30}}
+				}
+				failing, reconciled = s.failing, false
+				got, err := r.Reconcile(context.Background(), req(s.name))
+				if err != nil {
+					t.Fatalf("step %d: r.Reconcile(...): %v", i, err)
+				}
+				if reconciled != s.reconciled {
+					t.Errorf("step %d: r.Reconcile(%q): want reconciled %t, got %t", i, s.name, s.reconciled, reconciled)
+				}
+				if !s.reconciled && got.RequeueAfter < priorityRequeueAfter {
+					t.Errorf("step %d: r.Reconcile(%q): want requeue after at least %s, got %s", i, s.name, priorityRequeueAfter, got.RequeueAfter)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	targetFinalizerName = "kubernetes.crossplane.io/protected-by-object"
+
+	errRemoveTargetFinalizer = "cannot remove finalizer from remote object"
+)
+
+// protectTarget adds the target finalizer to the manifest of the supplied
+// Object, or to every item of its List manifest, if the Object asks for its
+// remote objects to be protected.
+func protectTarget(obj *v1alpha2.Object) error {
+	if !obj.Spec.ForProvider.ProtectTarget {
+		return nil
+	}
+
+	return updateManifest(obj, func(o *unstructured.Unstructured) error {
+		meta.AddFinalizer(o, targetFinalizerName)
+		return nil
+	})
+}
+
+// targetProtected returns true if the remote objects of the supplied Object
+// may carry the target finalizer, either because the Object asks for it or
+// because it was observed on them while protection was still enabled.
+func targetProtected(obj *v1alpha2.Object) bool {
+	return obj.Spec.ForProvider.ProtectTarget || bytes.Contains(obj.Status.AtProvider.Manifest.Raw, []byte(targetFinalizerName))
+}
+
+// unprotectTarget removes the target finalizer from the remote object of the
+// supplied manifest, if it exists, so that it can be deleted.
+func (c *external) unprotectTarget(ctx context.Context, manifest *unstructured.Unstructured) error {
+	current := manifest.DeepCopy()
+	err := c.client.Get(ctx, types.NamespacedName{
+		Namespace: current.GetNamespace(),
+		Name:      current.GetName(),
+	}, current)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetObject)
+	}
+	if !meta.FinalizerExists(current, targetFinalizerName) {
+		return nil
+	}
+	meta.RemoveFinalizer(current, targetFinalizerName)
+	return errors.Wrap(resource.IgnoreNotFound(c.client.Update(ctx, current)), errRemoveTargetFinalizer)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestProtectTarget(t *testing.T) {
+	type want struct {
+		finalizers []string
+		err        error
+	}
+	cases := map[string]struct {
+		obj *v1alpha2.Object
+		want
+	}{
+		"NotProtected": {
+			obj:  kubernetesObject(),
+			want: want{},
+		},
+		"Success": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ProtectTarget = true
+			}),
+			want: want{
+				finalizers: []string{targetFinalizerName},
+			},
+		},
+		"SuccessForListItems": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"one","finalizers":["other"]}}]}`)
+				obj.Spec.ForProvider.ProtectTarget = true
+			}),
+			want: want{
+				finalizers: []string{"other", targetFinalizerName},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := protectTarget(tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("protectTarget(...): -want error, +got error: %s", diff)
+			}
+			m, err := parseManifest(tc.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.IsList() {
+				_, items, err := children(tc.obj, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				m = items[0]
+			}
+			if diff := cmp.Diff(tc.want.finalizers, m.GetFinalizers()); diff != "" {
+				t.Errorf("protectTarget(...): -want finalizers, +got finalizers: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnprotectTarget(t *testing.T) {
+	protected := func(obj client.Object) error {
+		obj.SetFinalizers([]string{"other", targetFinalizerName})
+		return nil
+	}
+	cases := map[string]struct {
+		client client.Client
+		want   error
+	}{
+		"NotFound": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+		},
+		"FailedToGet": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: errors.Wrap(errBoom, errGetObject),
+		},
+		"NoTargetFinalizer": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+				MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+					t.Errorf("Remote object without the target finalizer should not be updated")
+					return nil
+				},
+			},
+		},
+		"FailedToUpdate": {
+			client: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil, protected),
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			want: errors.Wrap(errBoom, errRemoveTargetFinalizer),
+		},
+		"Success": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, protected),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					if diff := cmp.Diff([]string{"other"}, obj.GetFinalizers()); diff != "" {
+						t.Errorf("-want finalizers, +got finalizers: %s", diff)
+					}
+					return nil
+				}),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: resource.ClientApplicator{Client: tc.client}}
+			m, err := parseManifest(kubernetesObject())
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := e.unprotectTarget(context.Background(), m)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("e.unprotectTarget(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTargetProtected(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want bool
+	}{
+		"NotProtected": {
+			obj: kubernetesObject(),
+		},
+		"Protected": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ProtectTarget = true
+			}),
+			want: true,
+		},
+		"ObservedProtected": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				u := &unstructured.Unstructured{}
+				u.SetFinalizers([]string{targetFinalizerName})
+				obj.Status.AtProvider.Manifest.Raw, _ = u.MarshalJSON()
+			}),
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := targetProtected(tc.obj); got != tc.want {
+				t.Errorf("targetProtected(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
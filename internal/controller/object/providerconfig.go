@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+// A StoppableKindObserver is a KindObserver that can also stop the watches it
+// started on the cluster of a provider config.
+type StoppableKindObserver interface {
+	KindObserver
+	// StopWatches stops all watches on the cluster of the given
+	// providerConfig.
+	StopWatches(providerConfig string)
+}
+
+// providerConfigChanged returns an event handler that, once the spec of a
+// provider config changed, drops everything cached for its target cluster and
+// enqueues its Objects, so that they are reconciled against the new target
+// cluster right away rather than at their next poll. Everything cached for the
+// target cluster of a deleted provider config is dropped as well. Its Objects are enqueued
+// as well once its target cluster is reachable again, so that they recover
+// right away rather than once their backoff, which grows long during an
+// outage, expires.
+func (c *connector) providerConfigChanged(log logging.Logger) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(ctx context.Context, ev runtimeevent.UpdateEvent, q workqueue.RateLimitingInterface) {
+			pc, ok := ev.ObjectNew.(*apisv1alpha1.ProviderConfig)
+			if !ok {
+				return
+			}
+			switch {
+			case ev.ObjectOld.GetGeneration() != pc.GetGeneration():
+				c.invalidate(pc)
+				c.enqueueObjects(ctx, log, q, pc, "Enqueueing Objects because their provider config changed")
+			case becameReachable(ev.ObjectOld, pc):
+				// The target cluster may have been found reachable by
+				// another replica, e.g. of another shard.
+				c.breaker.Reset(pc.GetName())
+				c.enqueueObjects(ctx, log, q, pc, "Enqueueing Objects because their target cluster is reachable again")
+			}
+		},
+		DeleteFunc: func(_ context.Context, ev runtimeevent.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			if pc, ok := ev.Object.(*apisv1alpha1.ProviderConfig); ok {
+				c.invalidate(pc)
+			}
+		},
+	}
+}
+
+// enqueueObjects enqueues the Objects of the supplied provider config.
+func (c *connector) enqueueObjects(ctx context.Context, log logging.Logger, q workqueue.RateLimitingInterface, pc *apisv1alpha1.ProviderConfig, msg string) {
+	reqs := objectsForProviderConfigs(ctx, c.kube, log, map[string]bool{pc.GetName(): true})
+	log.Debug(msg, "providerConfig", pc.GetName(), "count", len(reqs))
+	for _, r := range reqs {
+		q.Add(r)
+	}
+}
+
+// becameReachable returns true if the target cluster of the supplied provider
+// config was reported unreachable before, but no longer is.
+func becameReachable(old client.Object, pc *apisv1alpha1.ProviderConfig) bool {
+	o, ok := old.(*apisv1alpha1.ProviderConfig)
+	if !ok {
+		return false
+	}
+	return o.GetCondition(typeTargetUnreachable).Status == corev1.ConditionTrue &&
+		pc.GetCondition(typeTargetUnreachable).Status != corev1.ConditionTrue
+}
+
+// invalidate drops everything cached for the target cluster of the supplied
+// provider config.
+func (c *connector) invalidate(pc *apisv1alpha1.ProviderConfig) {
+	c.breaker.Reset(pc.GetName())
+	if c.parserCacheManager != nil {
+		c.parserCacheManager.RemoveCache(pc)
+	}
+	if c.restMappers != nil {
+		c.restMappers.Forget(pc.GetName())
+	}
+	if s, ok := c.kindObserver.(StoppableKindObserver); ok {
+		s.StopWatches(pc.GetName())
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+)
+
+func TestProviderConfigChanged(t *testing.T) {
+	errUnreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	type want struct {
+		reqs    []reconcile.Request
+		allow   error
+		watches []gvkWithConfig
+	}
+	unreachable := targetReachabilityCondition(errUnreachable)
+	reachable := targetReachabilityCondition(nil)
+
+	cases := map[string]struct {
+		oldGeneration int64
+		oldConditions []xpv1.Condition
+		newConditions []xpv1.Condition
+		want          want
+	}{
+		"SpecChanged": {
+			oldGeneration: 1,
+			want: want{
+				reqs: []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Name: "changed-a"}},
+					{NamespacedName: types.NamespacedName{Name: "changed-c"}},
+				},
+				watches: []gvkWithConfig{{providerConfig: "other", gvk: gvk}},
+			},
+		},
+		"TargetReachableAgain": {
+			oldGeneration: 2,
+			oldConditions: []xpv1.Condition{unreachable},
+			newConditions: []xpv1.Condition{reachable},
+			want: want{
+				reqs: []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Name: "changed-a"}},
+					{NamespacedName: types.NamespacedName{Name: "changed-c"}},
+				},
+				watches: []gvkWithConfig{
+					{providerConfig: "changed", gvk: gvk},
+					{providerConfig: "other", gvk: gvk},
+				},
+			},
+		},
+		"TargetStillUnreachable": {
+			oldGeneration: 2,
+			oldConditions: []xpv1.Condition{unreachable},
+			newConditions: []xpv1.Condition{unreachable},
+			want: want{
+				allow: errors.Wrap(errUnreachable, errTargetUnreachable),
+				watches: []gvkWithConfig{
+					{providerConfig: "changed", gvk: gvk},
+					{providerConfig: "other", gvk: gvk},
+				},
+			},
+		},
+		"StatusChanged": {
+			oldGeneration: 2,
+			want: want{
+				allow: errors.Wrap(errUnreachable, errTargetUnreachable),
+				watches: []gvkWithConfig{
+					{providerConfig: "changed", gvk: gvk},
+					{providerConfig: "other", gvk: gvk},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := newCircuitBreaker(logging.NewNopLogger(), nil)
+			b.probe = func(_ context.Context, _ *rest.Config) error { return errUnreachable }
+			for i := 0; i < breakerThreshold; i++ {
+				b.Record("changed", &rest.Config{}, errUnreachable)
+			}
+			i := &resourceInformers{
+				log: logging.NewNopLogger(),
+				resourceCaches: map[gvkWithConfig]resourceCache{
+					{providerConfig: "changed", gvk: gvk}: {cancelFn: func() {}},
+					{providerConfig: "other", gvk: gvk}:   {cancelFn: func() {}},
+				},
+			}
+			c := &connector{
+				kube: &test.MockClient{
+					MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						for name, pc := range map[string]string{"changed-a": "changed", "other-b": "other", "changed-c": "changed"} {
+							obj := kubernetesObject(func(obj *v1alpha2.Object) {
+								obj.SetName(name)
+								obj.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc}
+							})
+							list.(*v1alpha2.ObjectList).Items = append(list.(*v1alpha2.ObjectList).Items, *obj)
+						}
+						return nil
+					},
+				},
+				breaker:      b,
+				kindObserver: i,
+			}
+
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+			old := &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "changed", Generation: tc.oldGeneration}}
+			old.SetConditions(tc.oldConditions...)
+			pc := &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "changed", Generation: 2}}
+			pc.SetConditions(tc.newConditions...)
+			c.providerConfigChanged(logging.NewNopLogger()).Update(context.Background(), runtimeevent.UpdateEvent{
+				ObjectOld: old,
+				ObjectNew: pc,
+			}, q)
+
+			var reqs []reconcile.Request
+			for q.Len() > 0 {
+				r, _ := q.Get()
+				reqs = append(reqs, r.(reconcile.Request))
+				q.Done(r)
+			}
+			sortReqs := cmpopts.SortSlices(func(a, b reconcile.Request) bool { return a.Name < b.Name })
+			if diff := cmp.Diff(tc.want.reqs, reqs, sortReqs); diff != "" {
+				t.Errorf("providerConfigChanged(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.allow, b.Allow("changed"), test.EquateErrors()); diff != "" {
+				t.Errorf("b.Allow(...): -want error, +got error: %s", diff)
+			}
+			watches := make([]gvkWithConfig, 0, len(i.resourceCaches))
+			for gc := range i.resourceCaches {
+				watches = append(watches, gc)
+			}
+			sortWatches := cmpopts.SortSlices(func(a, b gvkWithConfig) bool { return a.providerConfig < b.providerConfig })
+			if diff := cmp.Diff(tc.want.watches, watches, sortWatches, cmp.AllowUnexported(gvkWithConfig{})); diff != "" {
+				t.Errorf("providerConfigChanged(...): -want watches, +got watches: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigDeleted(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	rc := &rest.Config{Host: "https://target.example.org"}
+
+	mappers := kubeclient.NewRESTMapperCache()
+	cached, err := mappers.MapperFor("deleted", rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := &resourceInformers{
+		log: logging.NewNopLogger(),
+		resourceCaches: map[gvkWithConfig]resourceCache{
+			{providerConfig: "deleted", gvk: gvk}: {cancelFn: func() {}},
+			{providerConfig: "other", gvk: gvk}:   {cancelFn: func() {}},
+		},
+	}
+	c := &connector{
+		breaker:      newCircuitBreaker(logging.NewNopLogger(), nil),
+		kindObserver: i,
+		restMappers:  mappers,
+	}
+
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	c.providerConfigChanged(logging.NewNopLogger()).Delete(context.Background(), runtimeevent.DeleteEvent{
+		Object: &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "deleted"}},
+	}, q)
+
+	watches := make([]gvkWithConfig, 0, len(i.resourceCaches))
+	for gc := range i.resourceCaches {
+		watches = append(watches, gc)
+	}
+	if diff := cmp.Diff([]gvkWithConfig{{providerConfig: "other", gvk: gvk}}, watches, cmp.AllowUnexported(gvkWithConfig{})); diff != "" {
+		t.Errorf("providerConfigChanged(...): -want watches, +got watches: %s", diff)
+	}
+	if m, _ := mappers.MapperFor("deleted", rc); m == cached {
+		t.Errorf("providerConfigChanged(...): want the RESTMapper of the deleted provider config dropped, got it still cached")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errUnmarshalObserved     = "cannot unmarshal observed manifest"
+	errGetObservedField      = "cannot get observed field %s"
+	errMarshalObservedField  = "cannot marshal observed field %s"
+	errPublishObservedFields = "cannot publish observed fields to config map %s/%s"
+)
+
+// publishObservedFields publishes the selected fields of the observed remote
+// object of the supplied Object to the ConfigMap it asks for, if any. The
+// ConfigMap is controlled by the Object, so that it is garbage collected along
+// with it, and a ConfigMap controlled by something else is not overwritten.
+func (c *external) publishObservedFields(ctx context.Context, obj *v1alpha2.Object) error {
+	to := obj.Spec.ObservedFieldsTo
+	if to == nil || meta.WasDeleted(obj) {
+		return nil
+	}
+
+	data, err := observedFields(obj, to.Fields)
+	if err != nil {
+		return err
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            to.Name,
+			Namespace:       to.Namespace,
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(obj, v1alpha2.ObjectGroupVersionKind))},
+		},
+		Data: data,
+	}
+	err = resource.NewAPIUpdatingApplicator(c.localClient).Apply(ctx, cm, resource.MustBeControllableBy(obj.GetUID()))
+	return errors.Wrapf(err, errPublishObservedFields, to.Namespace, to.Name)
+}
+
+// observedFields returns the values of the supplied fields of the observed
+// remote object of the supplied Object by their keys. Fields that are not set
+// are omitted.
+func observedFields(obj *v1alpha2.Object, fields []v1alpha2.ObservedField) (map[string]string, error) {
+	raw, err := observedManifest(obj)
+	if err != nil || len(raw) == 0 {
+		return nil, err
+	}
+	observed := map[string]any{}
+	if err := json.Unmarshal(raw, &observed); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalObserved)
+	}
+	paved := fieldpath.Pave(observed)
+
+	data := make(map[string]string, len(fields))
+	for _, f := range fields {
+		v, err := paved.GetValue(f.FieldPath)
+		if fieldpath.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errGetObservedField, f.FieldPath)
+		}
+		if s, ok := v.(string); ok {
+			data[f.Key] = s
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, errMarshalObservedField, f.FieldPath)
+		}
+		data[f.Key] = string(b)
+	}
+	return data, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestPublishObservedFields(t *testing.T) {
+	observed := []byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"lb"},"spec":{"ports":[{"port":80}]},"status":{"loadBalancer":{"ingress":[{"ip":"10.0.0.1"}]}}}`)
+	compressed, err := compress(observed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publishing := func(obj *v1alpha2.Object) {
+		obj.SetUID(types.UID("object-uid"))
+		obj.Spec.ObservedFieldsTo = &v1alpha2.ObservedFieldsConfigMap{
+			Name:      "lb",
+			Namespace: "default",
+			Fields: []v1alpha2.ObservedField{
+				{FieldPath: "status.loadBalancer.ingress[0].ip", Key: "ip"},
+				{FieldPath: "spec.ports", Key: "ports"},
+				{FieldPath: "status.loadBalancer.ingress[0].hostname", Key: "hostname"},
+			},
+		}
+		obj.Status.AtProvider.Manifest.Raw = observed
+	}
+	published := func(obj *v1alpha2.Object) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "lb",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(obj, v1alpha2.ObjectGroupVersionKind))},
+			},
+			Data: map[string]string{
+				"ip":    "10.0.0.1",
+				"ports": `[{"port":80}]`,
+			},
+		}
+	}
+
+	type want struct {
+		created *v1.ConfigMap
+		err     error
+	}
+	cases := map[string]struct {
+		reason string
+		kube   func(created **v1.ConfigMap) client.Client
+		obj    *v1alpha2.Object
+		want   func(obj *v1alpha2.Object) want
+	}{
+		"NotPublishing": {
+			reason: "Nothing should be published if the Object does not ask for it.",
+			obj:    kubernetesObject(),
+			want:   func(_ *v1alpha2.Object) want { return want{} },
+		},
+		"Deleted": {
+			reason: "Nothing should be published if the Object is being deleted.",
+			obj: kubernetesObject(publishing, func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			want: func(_ *v1alpha2.Object) want { return want{} },
+		},
+		"Published": {
+			reason: "The fields that are set should be published to a ConfigMap controlled by the Object.",
+			kube: func(created **v1.ConfigMap) client.Client {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "lb")),
+					MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						*created = obj.(*v1.ConfigMap)
+						return nil
+					},
+				}
+			},
+			obj: kubernetesObject(publishing),
+			want: func(obj *v1alpha2.Object) want {
+				return want{created: published(obj)}
+			},
+		},
+		"PublishedFromCompressed": {
+			reason: "The fields should be published from the compressed observed manifest if there is one.",
+			kube: func(created **v1.ConfigMap) client.Client {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "lb")),
+					MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						*created = obj.(*v1.ConfigMap)
+						return nil
+					},
+				}
+			},
+			obj: kubernetesObject(publishing, func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.Manifest.Raw = []byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"lb"}}`)
+				obj.Status.AtProvider.CompressedManifest = compressed
+			}),
+			want: func(obj *v1alpha2.Object) want {
+				return want{created: published(obj)}
+			},
+		},
+		"ControlledBySomethingElse": {
+			reason: "A ConfigMap controlled by something else should not be overwritten.",
+			kube: func(_ **v1.ConfigMap) client.Client {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetOwnerReferences([]metav1.OwnerReference{{UID: types.UID("other"), Controller: ptr.To(true)}})
+						return nil
+					}),
+				}
+			},
+			obj: kubernetesObject(publishing),
+			want: func(_ *v1alpha2.Object) want {
+				return want{err: errors.Wrapf(errors.Errorf("existing object is not controlled by UID %q", "object-uid"), errPublishObservedFields, "default", "lb")}
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created *v1.ConfigMap
+			e := &external{}
+			if tc.kube != nil {
+				e.localClient = tc.kube(&created)
+			}
+			want := tc.want(tc.obj)
+			err := e.publishObservedFields(context.Background(), tc.obj)
+			if diff := cmp.Diff(want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.publishObservedFields(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(want.created, created); diff != "" {
+				t.Errorf("\n%s\ne.publishObservedFields(...): -want config map, +got config map:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
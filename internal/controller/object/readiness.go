@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	reasonReadinessTimeout xpv1.ConditionReason = "ReadinessTimeout"
+	reasonBecameReady      xpv1.ConditionReason = "BecameReady"
+)
+
+// checkReadinessTimeout reports the supplied Object Failed if its remote
+// object, which is ready if so, did not become ready within the readiness
+// timeout of the Object since its desired state last changed, and clears that
+// failure once it becomes ready.
+func checkReadinessTimeout(obj *v1alpha2.Object, ready bool, now time.Time) {
+	timeout := obj.Spec.Readiness.Timeout
+	if timeout == nil || ready || meta.WasDeleted(obj) {
+		resetReadinessTimeout(obj, reasonBecameReady)
+		return
+	}
+	hash, err := desiredStateHash(obj)
+	if err != nil {
+		return
+	}
+	start := obj.Status.AtProvider.ReadinessStartTime
+	if start == nil || hash != obj.Status.AtProvider.ReadinessHash {
+		// The desired state changed, so the remote object is rolling out
+		// again.
+		resetReadinessTimeout(obj, reasonApplied)
+		obj.Status.AtProvider.ReadinessHash = hash
+		obj.Status.AtProvider.ReadinessStartTime = &metav1.Time{Time: now}
+		return
+	}
+	if now.Sub(start.Time) < timeout.Duration || readinessTimedOut(obj, false) {
+		return
+	}
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeFailed,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Time{Time: now},
+		Reason:             reasonReadinessTimeout,
+		Message:            fmt.Sprintf("remote object did not become ready within %s", timeout.Duration),
+	})
+}
+
+// resetReadinessTimeout forgets when the remote object of the supplied Object
+// started to become ready, clearing its readiness timeout failure, if any,
+// with the supplied reason.
+func resetReadinessTimeout(obj *v1alpha2.Object, reason xpv1.ConditionReason) {
+	obj.Status.AtProvider.ReadinessHash = ""
+	obj.Status.AtProvider.ReadinessStartTime = nil
+	if !readinessTimedOut(obj, false) {
+		return
+	}
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeFailed,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+	})
+}
+
+// readinessTimedOut returns true if the remote object of the supplied Object
+// did not become ready within its readiness timeout, and, if terminal, the
+// Object stops applying its desired state on timeout.
+func readinessTimedOut(obj *v1alpha2.Object, terminal bool) bool {
+	c := obj.GetCondition(typeFailed)
+	if c.Status != v1.ConditionTrue || c.Reason != reasonReadinessTimeout {
+		return false
+	}
+	return !terminal || obj.Spec.Readiness.StopOnTimeout
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestCheckReadinessTimeout(t *testing.T) {
+	now := time.Now()
+	withTimeout := func(obj *v1alpha2.Object) {
+		obj.Spec.Readiness.Timeout = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+	hash, err := desiredStateHash(kubernetesObject())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rollingOutSince := func(d time.Duration) func(obj *v1alpha2.Object) {
+		return func(obj *v1alpha2.Object) {
+			obj.Status.AtProvider.ReadinessHash = hash
+			obj.Status.AtProvider.ReadinessStartTime = &metav1.Time{Time: now.Add(-d)}
+		}
+	}
+	timedOut := func(obj *v1alpha2.Object) {
+		obj.SetConditions(xpv1.Condition{Type: typeFailed, Status: v1.ConditionTrue, Reason: reasonReadinessTimeout, Message: "remote object did not become ready within 5m0s"})
+	}
+
+	type want struct {
+		hash   string
+		start  *metav1.Time
+		failed xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		ready  bool
+		want   want
+	}{
+		"NoTimeout": {
+			reason: "Nothing should be tracked without a readiness timeout.",
+			obj:    kubernetesObject(),
+			want:   want{failed: xpv1.Condition{Type: typeFailed, Status: v1.ConditionUnknown}},
+		},
+		"StartRollingOut": {
+			reason: "The start of the rollout of a desired state should be recorded.",
+			obj:    kubernetesObject(withTimeout),
+			want: want{
+				hash:   hash,
+				start:  &metav1.Time{Time: now},
+				failed: xpv1.Condition{Type: typeFailed, Status: v1.ConditionUnknown},
+			},
+		},
+		"StillRollingOut": {
+			reason: "An Object rolling out within the timeout should not fail.",
+			obj:    kubernetesObject(withTimeout, rollingOutSince(time.Minute)),
+			want: want{
+				hash:   hash,
+				start:  &metav1.Time{Time: now.Add(-time.Minute)},
+				failed: xpv1.Condition{Type: typeFailed, Status: v1.ConditionUnknown},
+			},
+		},
+		"TimedOut": {
+			reason: "An Object that did not become ready within the timeout should fail.",
+			obj:    kubernetesObject(withTimeout, rollingOutSince(10*time.Minute)),
+			want: want{
+				hash:   hash,
+				start:  &metav1.Time{Time: now.Add(-10 * time.Minute)},
+				failed: xpv1.Condition{Type: typeFailed, Status: v1.ConditionTrue, Reason: reasonReadinessTimeout, Message: "remote object did not become ready within 5m0s"},
+			},
+		},
+		"DesiredStateChanged": {
+			reason: "A change of the desired state should restart the rollout and clear the timeout.",
+			obj: kubernetesObject(withTimeout, rollingOutSince(10*time.Minute), timedOut, func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.ReadinessHash = "previous"
+			}),
+			want: want{
+				hash:   hash,
+				start:  &metav1.Time{Time: now},
+				failed: xpv1.Condition{Type: typeFailed, Status: v1.ConditionFalse, Reason: reasonApplied},
+			},
+		},
+		"BecameReady": {
+			reason: "A remote object that became ready should clear the timeout.",
+			obj:    kubernetesObject(withTimeout, rollingOutSince(10*time.Minute), timedOut),
+			ready:  true,
+			want:   want{failed: xpv1.Condition{Type: typeFailed, Status: v1.ConditionFalse, Reason: reasonBecameReady}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			checkReadinessTimeout(tc.obj, tc.ready, now)
+			if diff := cmp.Diff(tc.want.hash, tc.obj.Status.AtProvider.ReadinessHash); diff != "" {
+				t.Errorf("\n%s\ncheckReadinessTimeout(...): -want hash, +got hash:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.start, tc.obj.Status.AtProvider.ReadinessStartTime); diff != "" {
+				t.Errorf("\n%s\ncheckReadinessTimeout(...): -want start time, +got start time:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.failed, tc.obj.GetCondition(typeFailed), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\ncheckReadinessTimeout(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadinessTimedOut(t *testing.T) {
+	timedOut := func(obj *v1alpha2.Object) {
+		obj.SetConditions(xpv1.Condition{Type: typeFailed, Status: v1.ConditionTrue, Reason: reasonReadinessTimeout})
+	}
+	cases := map[string]struct {
+		reason   string
+		obj      *v1alpha2.Object
+		terminal bool
+		want     bool
+	}{
+		"NotTimedOut": {
+			reason: "An Object without a readiness timeout failure should not be timed out.",
+			obj:    kubernetesObject(),
+		},
+		"TimedOut": {
+			reason: "An Object with a readiness timeout failure should be timed out.",
+			obj:    kubernetesObject(timedOut),
+			want:   true,
+		},
+		"NotTerminal": {
+			reason:   "A timeout should not be terminal unless the Object stops on timeout.",
+			obj:      kubernetesObject(timedOut),
+			terminal: true,
+		},
+		"Terminal": {
+			reason: "A timeout should be terminal if the Object stops on timeout.",
+			obj: kubernetesObject(timedOut, func(obj *v1alpha2.Object) {
+				obj.Spec.Readiness.StopOnTimeout = true
+			}),
+			terminal: true,
+			want:     true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, readinessTimedOut(tc.obj, tc.terminal)); diff != "" {
+				t.Errorf("\n%s\nreadinessTimedOut(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	redactedValue = "redacted"
+
+	// minRedactedLength is the length below which values are not redacted
+	// from error messages, since they would match unrelated text.
+	minRedactedLength = 4
+)
+
+// secretLikeKey matches the data keys of non-Secret kinds, e.g. ConfigMaps,
+// whose values are redacted.
+var secretLikeKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|api[-_.]?key|private[-_.]?key|credential)`)
+
+// dataFields are the fields of a non-Secret kind that are searched for
+// secret-like keys.
+var dataFields = []string{"data", "stringData", "binaryData"}
+
+// redactSecretData redacts the data of the supplied object, if it is a Secret,
+// or the values of its secret-like data keys otherwise. Since the last applied
+// configuration contains the same data, it is redacted too. It returns the
+// redacted values.
+func redactSecretData(u *unstructured.Unstructured) []string {
+	var values []string
+	if u.GetKind() == "Secret" && u.GetAPIVersion() == "v1" {
+		data, _, _ := unstructured.NestedMap(u.Object, "data")
+		for _, v := range data {
+			values = append(values, dataValues(v, true)...)
+		}
+		stringData, _, _ := unstructured.NestedMap(u.Object, "stringData")
+		for _, v := range stringData {
+			values = append(values, dataValues(v, false)...)
+		}
+		u.Object["data"] = map[string]any{redactedValue: nil}
+		delete(u.Object, "stringData")
+		redactLastApplied(u)
+		return values
+	}
+
+	for _, f := range dataFields {
+		data, ok := u.Object[f].(map[string]any)
+		if !ok {
+			continue
+		}
+		for k, v := range data {
+			if !secretLikeKey.MatchString(k) {
+				continue
+			}
+			values = append(values, dataValues(v, f == "binaryData")...)
+			data[k] = redactedValue
+		}
+	}
+	if len(values) > 0 {
+		redactLastApplied(u)
+	}
+	return values
+}
+
+// dataValues returns the supplied data value, along with its decoded value if
+// it is base64 encoded.
+func dataValues(v any, encoded bool) []string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	values := []string{s}
+	if d, err := base64.StdEncoding.DecodeString(s); encoded && err == nil && len(d) > 0 {
+		values = append(values, string(d))
+	}
+	return values
+}
+
+func redactLastApplied(u metav1.Object) {
+	a := u.GetAnnotations()
+	if _, ok := a[v1.LastAppliedConfigAnnotation]; !ok {
+		return
+	}
+	a[v1.LastAppliedConfigAnnotation] = redactedValue
+	u.SetAnnotations(a)
+}
+
+// redacted returns a copy of the supplied object with its secret data
+// redacted, e.g. to be logged, if secrets should be sanitized.
+func (c *external) redacted(u *unstructured.Unstructured) *unstructured.Unstructured {
+	if !c.sanitizeSecrets || u == nil {
+		return u
+	}
+	u = u.DeepCopy()
+	redactSecretData(u)
+	return u
+}
+
+// redactedObject returns a copy of the supplied Object with the secret data of
+// its manifest redacted, e.g. to be logged, if secrets should be sanitized.
+// The observed manifest is already redacted when it is set.
+func (c *external) redactedObject(obj *v1alpha2.Object) *v1alpha2.Object {
+	if !c.sanitizeSecrets {
+		return obj
+	}
+	obj = obj.DeepCopy()
+	if err := updateManifest(obj, func(u *unstructured.Unstructured) error {
+		redactSecretData(u)
+		return nil
+	}); err != nil {
+		obj.Spec.ForProvider.Manifest.Raw = nil
+	}
+	obj.Spec.ForProvider.ManifestYAML = ""
+	return obj
+}
+
+// redactedError returns the supplied error with the secret data of the
+// supplied manifest removed from its message, e.g. because it is reported
+// in an event, if secrets should be sanitized.
+func (c *external) redactedError(manifest *unstructured.Unstructured, err error) error {
+	if !c.sanitizeSecrets || err == nil {
+		return err
+	}
+	values := redactSecretData(manifest.DeepCopy())
+	msg := err.Error()
+	for _, v := range values {
+		if len(v) >= minRedactedLength {
+			msg = strings.ReplaceAll(msg, v, redactedValue)
+		}
+	}
+	if msg == err.Error() {
+		return err
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestRedactSecretData(t *testing.T) {
+	type want struct {
+		u      *unstructured.Unstructured
+		values []string
+	}
+	cases := map[string]struct {
+		reason string
+		u      *unstructured.Unstructured
+		want   want
+	}{
+		"Secret": {
+			reason: "The whole data of a Secret, including its last applied configuration, should be redacted.",
+			u: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]any{
+					"name":        "test",
+					"annotations": map[string]any{v1.LastAppliedConfigAnnotation: `{"data":{"user":"YWRtaW4="}}`},
+				},
+				"data":       map[string]any{"user": "YWRtaW4="},
+				"stringData": map[string]any{"password": "hunter2"},
+			}},
+			want: want{
+				u: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"metadata": map[string]any{
+						"name":        "test",
+						"annotations": map[string]any{v1.LastAppliedConfigAnnotation: redactedValue},
+					},
+					"data": map[string]any{redactedValue: nil},
+				}},
+				values: []string{"YWRtaW4=", "admin", "hunter2"},
+			},
+		},
+		"SecretLikeKeys": {
+			reason: "Only the values of secret-like keys of other kinds should be redacted.",
+			u: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "test"},
+				"data": map[string]any{
+					"db_password": "hunter2",
+					"API-Key":     "abcd1234",
+					"host":        "db.example.org",
+				},
+			}},
+			want: want{
+				u: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata":   map[string]any{"name": "test"},
+					"data": map[string]any{
+						"db_password": redactedValue,
+						"API-Key":     redactedValue,
+						"host":        "db.example.org",
+					},
+				}},
+				values: []string{"abcd1234", "hunter2"},
+			},
+		},
+		"NothingSecret": {
+			reason: "Objects without secret data should not be changed.",
+			u: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name":        "test",
+					"annotations": map[string]any{v1.LastAppliedConfigAnnotation: `{"data":{"host":"db.example.org"}}`},
+				},
+				"data": map[string]any{"host": "db.example.org"},
+			}},
+			want: want{
+				u: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]any{
+						"name":        "test",
+						"annotations": map[string]any{v1.LastAppliedConfigAnnotation: `{"data":{"host":"db.example.org"}}`},
+					},
+					"data": map[string]any{"host": "db.example.org"},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			values := redactSecretData(tc.u)
+			if diff := cmp.Diff(tc.want.u, tc.u); diff != "" {
+				t.Errorf("\n%s\nredactSecretData(...): -want object, +got object:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.values, values, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nredactSecretData(...): -want values, +got values:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRedactedError(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "test"},
+		"data":       map[string]any{"password": "aHVudGVyMg=="},
+	}}
+
+	cases := map[string]struct {
+		reason          string
+		sanitizeSecrets bool
+		err             error
+		want            error
+	}{
+		"NotSanitized": {
+			reason: "Errors should not be changed if secrets are not sanitized.",
+			err:    errors.New(`invalid value "hunter2"`),
+			want:   errors.New(`invalid value "hunter2"`),
+		},
+		"Sanitized": {
+			reason:          "Secret data should be removed from errors, encoded or not.",
+			sanitizeSecrets: true,
+			err:             errors.New(`invalid value "hunter2", was "aHVudGVyMg=="`),
+			want:            errors.New(`invalid value "redacted", was "redacted"`),
+		},
+		"NoSecretData": {
+			reason:          "Errors without secret data should be returned as they are.",
+			sanitizeSecrets: true,
+			err:             errBoom,
+			want:            errBoom,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{sanitizeSecrets: tc.sanitizeSecrets}
+			got := e.redactedError(secret, tc.err)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.redactedError(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if secret.Object["data"].(map[string]any)["password"] != "aHVudGVyMg==" {
+				t.Errorf("\n%s\ne.redactedError(...): the supplied manifest should not be changed", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// A referenceCache caches the resources referenced by Objects for a short
+// time, so that a resource referenced by many Objects, e.g. a shared ConfigMap,
+// is read once rather than once for every one of them.
+type referenceCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[referenceCacheKey]referenceCacheEntry
+	lastSweep time.Time
+}
+
+type referenceCacheKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+type referenceCacheEntry struct {
+	resource *unstructured.Unstructured
+	expires  time.Time
+}
+
+// newReferenceCache returns a referenceCache keeping resources for the
+// supplied duration, or nil if it is not positive, which disables caching.
+func newReferenceCache(ttl time.Duration) *referenceCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &referenceCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[referenceCacheKey]referenceCacheEntry),
+	}
+}
+
+// Get gets the supplied resource from the cache, or reads it with the supplied
+// reader and caches it if it is not cached or expired.
+func (rc *referenceCache) Get(ctx context.Context, r client.Reader, key client.ObjectKey, res *unstructured.Unstructured) error {
+	if rc == nil {
+		return r.Get(ctx, key, res)
+	}
+	k := referenceCacheKey{gvk: res.GroupVersionKind(), namespace: key.Namespace, name: key.Name}
+
+	rc.mu.Lock()
+	e, ok := rc.entries[k]
+	rc.mu.Unlock()
+	if ok && rc.now().Before(e.expires) {
+		e.resource.DeepCopyInto(res)
+		return nil
+	}
+
+	if err := r.Get(ctx, key, res); err != nil {
+		return err
+	}
+
+	now := rc.now()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[k] = referenceCacheEntry{resource: res.DeepCopy(), expires: now.Add(rc.ttl)}
+	if now.Sub(rc.lastSweep) > rc.ttl {
+		for k, e := range rc.entries {
+			if !now.Before(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+		rc.lastSweep = now
+	}
+	return nil
+}
+
+// Invalidate removes the supplied resource from the cache, e.g. because it is
+// known to have changed.
+func (rc *referenceCache) Invalidate(obj client.Object) {
+	if rc == nil {
+		return
+	}
+	k := referenceCacheKey{gvk: obj.GetObjectKind().GroupVersionKind(), namespace: obj.GetNamespace(), name: obj.GetName()}
+	rc.mu.Lock()
+	delete(rc.entries, k)
+	rc.mu.Unlock()
+}
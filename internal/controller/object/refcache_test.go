@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestReferenceCache(t *testing.T) {
+	gets := 0
+	r := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			gets++
+			u := obj.(*unstructured.Unstructured)
+			u.SetName(key.Name)
+			u.SetNamespace(key.Namespace)
+			u.SetResourceVersion("1")
+			return nil
+		},
+	}
+	now := time.Now()
+	rc := newReferenceCache(time.Minute)
+	rc.now = func() time.Time { return now }
+
+	key := client.ObjectKey{Namespace: testNamespace, Name: "cluster-info"}
+	get := func() *unstructured.Unstructured {
+		t.Helper()
+		res := &unstructured.Unstructured{}
+		res.SetAPIVersion("v1")
+		res.SetKind("ConfigMap")
+		if err := rc.Get(context.Background(), r, key, res); err != nil {
+			t.Fatalf("rc.Get(...): %s", err)
+		}
+		return res
+	}
+
+	first := get()
+	second := get()
+	if gets != 1 {
+		t.Errorf("rc.Get(...): want 1 read before expiry, got %d", gets)
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("rc.Get(...): -want cached resource, +got: %s", diff)
+	}
+
+	// Cached resources must not be shared with callers.
+	second.SetLabels(map[string]string{"mutated": "true"})
+	if get().GetLabels() != nil {
+		t.Error("rc.Get(...): cached resource was mutated by a caller")
+	}
+
+	now = now.Add(time.Minute)
+	get()
+	if gets != 2 {
+		t.Errorf("rc.Get(...): want 2 reads after expiry, got %d", gets)
+	}
+
+	rc.Invalidate(first)
+	get()
+	if gets != 3 {
+		t.Errorf("rc.Get(...): want 3 reads after invalidation, got %d", gets)
+	}
+
+	var disabled *referenceCache
+	res := &unstructured.Unstructured{}
+	if err := disabled.Get(context.Background(), r, key, res); err != nil {
+		t.Fatalf("disabled.Get(...): %s", err)
+	}
+	if gets != 4 {
+		t.Errorf("disabled.Get(...): want every call to be read, got %d reads", gets)
+	}
+}
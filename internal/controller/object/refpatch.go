@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errPatchToConnectionSecret = "cannot patch from referenced resource to connection secret key %s"
+	errMarshalMetadataPatch    = "cannot marshal the labels and annotations patched from referenced resources"
+	errPatchObjectMetadata     = "cannot patch the labels and annotations patched from referenced resources to the Object"
+)
+
+// A connectionSecretPatch is a field of the manifest of an Object patched
+// from a connection secret or another secret value, along with the value it
+// replaced, if any.
+type connectionSecretPatch struct {
+	path     string
+	previous any
+	replaced bool
+}
+
+// patchFromConnectionSecret patches the supplied value of a connection secret
+// to the supplied Object as the supplied reference asks for. Values patched to
+// the manifest are recorded, so that they can be taken out of the spec again
+// once an operation on the Object is done.
+func (c *external) patchFromConnectionSecret(obj *v1alpha2.Object, ref v1alpha2.Reference, value string) error {
+	c.recordConnectionSecretPatch(obj, ref)
+	return errors.Wrap(ref.ApplyConnectionSecretPatch(value, obj), errPatchFromReferencedResource)
+}
+
+// recordConnectionSecretPatch records the field of the manifest of the
+// supplied Object the supplied reference is about to patch a secret value to,
+// along with the value it replaces, if any.
+func (c *external) recordConnectionSecretPatch(obj *v1alpha2.Object, ref v1alpha2.Reference) {
+	if ref.PatchTo == v1alpha2.PatchToMetadata || ref.ToFieldPath == nil {
+		return
+	}
+	p := connectionSecretPatch{path: *ref.ToFieldPath}
+	m := map[string]any{}
+	if err := json.Unmarshal(obj.Spec.ForProvider.Manifest.Raw, &m); err == nil {
+		v, err := fieldpath.Pave(m).GetValue(p.path)
+		p.previous, p.replaced = v, err == nil
+	}
+	c.connectionSecretPatches = append(c.connectionSecretPatches, p)
+}
+
+// stashConnectionSecretPatches moves the values the supplied Object patched
+// from connection secrets out of the manifest in its spec, once an operation
+// on the Object is done, so that the desired state holds them only while it
+// is applied. They would otherwise be persisted along with the Object when
+// the managed reconciler updates it, e.g. to annotate it with the external
+// create time. The whole manifest as declared, i.e. without any values
+// patched from referenced resources, is left in the spec if the values cannot
+// be taken out one by one.
+func (c *external) stashConnectionSecretPatches(obj *v1alpha2.Object) {
+	if len(c.connectionSecretPatches) == 0 {
+		return
+	}
+	c.patchedManifest = obj.Spec.ForProvider.Manifest.Raw
+	obj.Spec.ForProvider.Manifest.Raw = c.declaredManifest
+
+	m := map[string]any{}
+	if err := json.Unmarshal(c.patchedManifest, &m); err != nil {
+		return
+	}
+	p := fieldpath.Pave(m)
+	for i := len(c.connectionSecretPatches) - 1; i >= 0; i-- {
+		cp := c.connectionSecretPatches[i]
+		err := p.DeleteField(cp.path)
+		if cp.replaced {
+			err = p.SetValue(cp.path, cp.previous)
+		}
+		if err != nil {
+			return
+		}
+	}
+	if raw, err := json.Marshal(m); err == nil {
+		obj.Spec.ForProvider.Manifest.Raw = raw
+	}
+}
+
+// restoreConnectionSecretPatches sets the manifest of the supplied Object to
+// the one the previous operation on it, e.g. the observation preceding a
+// create or update, patched the values of connection secrets into.
+func (c *external) restoreConnectionSecretPatches(obj *v1alpha2.Object) {
+	if len(c.connectionSecretPatches) == 0 || c.patchedManifest == nil {
+		return
+	}
+	obj.Spec.ForProvider.Manifest.Raw = c.patchedManifest
+}
+
+// patchToConnectionSecret records the value the supplied reference patches
+// from the supplied referenced resource as a connection detail.
+func (c *external) patchToConnectionSecret(ref v1alpha2.Reference, res *referencedResource) error {
+	var value any = res.connectionSecretValue
+	if ref.PatchesFrom != nil && ref.PatchesFrom.FieldPath != nil {
+		v, err := ref.FieldPathValue(res.resource)
+		if err != nil {
+			return errors.Wrap(err, errPatchFromReferencedResource)
+		}
+		value = v
+	}
+	return c.setReferenceConnectionDetail(ref, value)
+}
+
+// setReferenceConnectionDetail records the supplied value as the connection
+// detail the supplied reference patches to.
+func (c *external) setReferenceConnectionDetail(ref v1alpha2.Reference, value any) error {
+	if ref.ToFieldPath == nil {
+		return errors.Wrap(errors.New("toFieldPath is required to patch to a connection secret"), errPatchFromReferencedResource)
+	}
+
+	key := *ref.ToFieldPath
+	if c.referenceConnectionDetails == nil {
+		c.referenceConnectionDetails = managed.ConnectionDetails{}
+	}
+	if s, ok := value.(string); ok {
+		c.referenceConnectionDetails[key] = []byte(s)
+		return nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, errPatchToConnectionSecret, key)
+	}
+	c.referenceConnectionDetails[key] = b
+	return nil
+}
+
+// patchObjectMetadata persists the labels and annotations of the supplied
+// Object that were patched from referenced resources, i.e. that differ from
+// the supplied ones, since the Object is not updated otherwise. Only they are
+// patched, so that the manifest patched from referenced resources is not
+// persisted.
+func (c *external) patchObjectMetadata(ctx context.Context, obj *v1alpha2.Object, labels, annotations map[string]string) error {
+	changed := func(before, after map[string]string) map[string]string {
+		var m map[string]string
+		for k, v := range after {
+			if old, ok := before[k]; ok && old == v {
+				continue
+			}
+			if m == nil {
+				m = make(map[string]string)
+			}
+			m[k] = v
+		}
+		return m
+	}
+	md := map[string]map[string]string{}
+	if l := changed(labels, obj.GetLabels()); l != nil {
+		md["labels"] = l
+	}
+	if a := changed(annotations, obj.GetAnnotations()); a != nil {
+		md["annotations"] = a
+	}
+	if len(md) == 0 {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": md})
+	if err != nil {
+		return errors.Wrap(err, errMarshalMetadataPatch)
+	}
+	patched := &v1alpha2.Object{}
+	patched.SetName(obj.GetName())
+	if err := c.localClient.Patch(ctx, patched, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return errors.Wrap(err, errPatchObjectMetadata)
+	}
+	obj.SetResourceVersion(patched.GetResourceVersion())
+	return nil
+}
+
+// connectionDetails returns the connection details of the supplied Object,
+// i.e. those it asks for, and those patched from referenced resources.
+func (c *external) connectionDetails(ctx context.Context, obj *v1alpha2.Object) (managed.ConnectionDetails, error) {
+	cd, err := connectionDetails(ctx, c.client, obj.Spec.ConnectionDetails)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.referenceConnectionDetails) == 0 {
+		return cd, nil
+	}
+	if cd == nil {
+		cd = managed.ConnectionDetails{}
+	}
+	maps.Copy(cd, c.referenceConnectionDetails)
+	return cd, nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestPatchToConnectionSecret(t *testing.T) {
+	res := &referencedResource{
+		resource: &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"endpoint": "db.example.org", "port": int64(5432)},
+		}},
+		connectionSecretValue: "s3cr3t",
+	}
+
+	type want struct {
+		cd  managed.ConnectionDetails
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		ref    v1alpha2.Reference
+		want   want
+	}{
+		"FromFieldPath": {
+			reason: "A string field of the referenced resource should be patched to the connection secret as is.",
+			ref: v1alpha2.Reference{
+				PatchesFrom: &v1alpha2.PatchesFrom{FieldPath: ptr.To("spec.endpoint")},
+				ToFieldPath: ptr.To("endpoint"),
+			},
+			want: want{cd: managed.ConnectionDetails{"endpoint": []byte("db.example.org")}},
+		},
+		"FromNonStringFieldPath": {
+			reason: "Other fields of the referenced resource should be patched to the connection secret as JSON.",
+			ref: v1alpha2.Reference{
+				PatchesFrom: &v1alpha2.PatchesFrom{FieldPath: ptr.To("spec.port")},
+			},
+			want: want{cd: managed.ConnectionDetails{"spec.port": []byte("5432")}},
+		},
+		"FromConnectionSecret": {
+			reason: "A connection secret value of the referenced resource should be patched to the connection secret.",
+			ref: v1alpha2.Reference{
+				PatchesFromConnectionSecret: &v1alpha2.PatchesFromConnectionSecret{Key: "password"},
+				ToFieldPath:                 ptr.To("password"),
+			},
+			want: want{cd: managed.ConnectionDetails{"password": []byte("s3cr3t")}},
+		},
+		"NoToFieldPath": {
+			reason: "A connection secret value should not be patched to the connection secret without a key.",
+			ref: v1alpha2.Reference{
+				PatchesFromConnectionSecret: &v1alpha2.PatchesFromConnectionSecret{Key: "password"},
+			},
+			want: want{err: errors.Wrap(errors.New("toFieldPath is required to patch to a connection secret"), errPatchFromReferencedResource)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.ref.PatchTo = v1alpha2.PatchToConnectionSecret
+			c := &external{}
+			err := c.patchToConnectionSecret(tc.ref, res)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.patchToConnectionSecret(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cd, c.referenceConnectionDetails); diff != "" {
+				t.Errorf("\n%s\nc.patchToConnectionSecret(...): -want connection details, +got connection details:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectionSecretPatchesNotPersisted(t *testing.T) {
+	declared := `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"s"},"stringData":{"user":"admin"}}`
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Manifest.Raw = []byte(declared)
+	})
+	c := &external{declaredManifest: []byte(declared)}
+	for _, path := range []string{"stringData.password", "stringData.user"} {
+		ref := v1alpha2.Reference{
+			PatchesFromConnectionSecret: &v1alpha2.PatchesFromConnectionSecret{Key: "password"},
+			ToFieldPath:                 ptr.To(path),
+		}
+		if err := c.patchFromConnectionSecret(obj, ref, "s3cr3t"); err != nil {
+			t.Fatalf("c.patchFromConnectionSecret(...): %s", err)
+		}
+	}
+	// Fields changed after references were resolved, e.g. by the attribution
+	// of the remote object, are kept in the spec.
+	if err := updateManifest(obj, func(u *unstructured.Unstructured) error {
+		u.SetLabels(map[string]string{"owner": "provider-kubernetes"})
+		return nil
+	}); err != nil {
+		t.Fatalf("updateManifest(...): %s", err)
+	}
+	patched := string(obj.Spec.ForProvider.Manifest.Raw)
+
+	c.stashConnectionSecretPatches(obj)
+	want := `{"apiVersion":"v1","kind":"Secret","metadata":{"labels":{"owner":"provider-kubernetes"},"name":"s"},"stringData":{"user":"admin"}}`
+	if diff := cmp.Diff(want, string(obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+		t.Errorf("c.stashConnectionSecretPatches(...): the values patched from connection secrets should not be left in the spec: -want, +got:\n%s", diff)
+	}
+
+	c.restoreConnectionSecretPatches(obj)
+	if diff := cmp.Diff(patched, string(obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+		t.Errorf("c.restoreConnectionSecretPatches(...): -want patched manifest, +got manifest:\n%s", diff)
+	}
+}
+
+func TestPatchObjectMetadata(t *testing.T) {
+	type want struct {
+		patch string
+		err   error
+	}
+	cases := map[string]struct {
+		reason      string
+		labels      map[string]string
+		annotations map[string]string
+		patchErr    error
+		obj         *v1alpha2.Object
+		want        want
+	}{
+		"Unchanged": {
+			reason: "Nothing should be patched if no label or annotation was patched from referenced resources.",
+			labels: map[string]string{"env": "staging"},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetLabels(map[string]string{"env": "staging"})
+			}),
+		},
+		"Changed": {
+			reason:      "Only the labels and annotations patched from referenced resources should be patched.",
+			labels:      map[string]string{"env": "staging", "team": "a"},
+			annotations: map[string]string{"note": "kept"},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetLabels(map[string]string{"env": "prod", "team": "a"})
+				obj.SetAnnotations(map[string]string{"note": "kept", "example.org/region": "eu"})
+			}),
+			want: want{patch: `{"metadata":{"annotations":{"example.org/region":"eu"},"labels":{"env":"prod"}}}`},
+		},
+		"PatchError": {
+			reason:   "Errors patching the Object should be returned.",
+			patchErr: errBoom,
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetLabels(map[string]string{"env": "prod"})
+			}),
+			want: want{
+				patch: `{"metadata":{"labels":{"env":"prod"}}}`,
+				err:   errors.Wrap(errBoom, errPatchObjectMetadata),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			c := &external{localClient: &test.MockClient{
+				MockPatch: func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+					b, _ := patch.Data(obj)
+					got = string(b)
+					return tc.patchErr
+				},
+			}}
+			err := c.patchObjectMetadata(context.Background(), tc.obj, tc.labels, tc.annotations)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.patchObjectMetadata(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patch, got); diff != "" {
+				t.Errorf("\n%s\nc.patchObjectMetadata(...): -want patch, +got patch:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyFromFieldPathPatchToMetadata(t *testing.T) {
+	from := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"labels": map[string]any{"env": "staging"}},
+	}}
+
+	cases := map[string]struct {
+		reason string
+		to     string
+		want   map[string]string
+		err    bool
+	}{
+		"Label": {
+			reason: "A value should be patched to a label of the Object.",
+			to:     "labels[example.org/env]",
+			want:   map[string]string{"example.org/env": "staging"},
+		},
+		"NotLabelOrAnnotation": {
+			reason: "Values should only be patched to labels and annotations of the Object.",
+			to:     "name",
+			err:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref := v1alpha2.Reference{
+				PatchesFrom: &v1alpha2.PatchesFrom{FieldPath: ptr.To("metadata.labels.env")},
+				ToFieldPath: ptr.To(tc.to),
+				PatchTo:     v1alpha2.PatchToMetadata,
+			}
+			obj := kubernetesObject()
+			err := ref.ApplyFromFieldPathPatch(from, obj)
+			if diff := cmp.Diff(tc.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nref.ApplyFromFieldPathPatch(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, obj.GetLabels()); diff != "" {
+				t.Errorf("\n%s\nref.ApplyFromFieldPathPatch(...): -want labels, +got labels:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
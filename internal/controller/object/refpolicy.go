@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// A keyNotFoundError is returned when the key a reference patches from does
+// not exist, e.g. in the connection secret of the referenced resource.
+type keyNotFoundError struct {
+	error
+}
+
+// IsNotFound returns true, so that fieldpath.IsNotFound is true for a
+// keyNotFoundError too.
+func (keyNotFoundError) IsNotFound() bool {
+	return true
+}
+
+// missingReference returns true if the supplied error of resolving a
+// reference means that its resource, its field or its connection secret key
+// does not exist (yet), or that its resource does not match its fields (yet).
+func missingReference(err error) bool {
+	return kerrors.IsNotFound(err) || fieldpath.IsNotFound(err) || errors.As(err, &fieldsNotMatchedError{})
+}
+
+// skipReference records that the supplied reference of the supplied Object
+// was skipped for the supplied error in its status, if the reference is
+// Optional and the error means it is missing. It returns the kind of the
+// referenced resource, so that it is still watched for, and true if the
+// reference was skipped. References patching from a value are recorded by
+// the name of the value.
+func skipReference(obj *v1alpha2.Object, ref v1alpha2.Reference, err error) (schema.GroupVersionKind, bool) {
+	if ref.Policy != v1alpha2.ReferencePolicyOptional || !missingReference(err) {
+		return schema.GroupVersionKind{}, false
+	}
+	apiVersion, kind, namespace, name := getReferenceInfo(ref)
+	if ref.PatchesFromValue != nil {
+		name = ref.PatchesFromValue.Name
+	}
+	obj.Status.AtProvider.SkippedReferences = append(obj.Status.AtProvider.SkippedReferences, v1alpha2.SkippedReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+		Message:    err.Error(),
+	})
+	return schema.FromAPIVersionAndKind(apiVersion, kind), true
+}
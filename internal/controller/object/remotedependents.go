@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// annotationKeyForceDelete deletes the remote object of an Object even if
+	// it waits for the remote dependents of its remote object.
+	annotationKeyForceDelete = "kubernetes.crossplane.io/force-delete"
+
+	// typeDependentsRemain is the condition reporting that the deletion of
+	// the remote object of an Object is held until its remote dependents are
+	// deleted.
+	typeDependentsRemain xpv1.ConditionType = "DependentsRemain"
+
+	reasonDependentsRemain xpv1.ConditionReason = "DependentsRemain"
+
+	errDiscoverNamespacedKinds = "cannot discover the namespaced kinds of the target cluster"
+	errListRemoteDependents    = "cannot list the %s remote dependents"
+	errRemoteDependentsRemain  = "cannot delete the remote object while its dependents remain: %s"
+)
+
+var (
+	namespaceKind = schema.GroupKind{Kind: "Namespace"}
+	crdKind       = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+	// ignoredDependentKinds are not waited for, since they do not keep a
+	// Namespace from being deleted.
+	ignoredDependentKinds = map[schema.GroupKind]bool{
+		{Kind: "Event"}:                         true,
+		{Group: "events.k8s.io", Kind: "Event"}: true,
+	}
+
+	// defaultNamespaceContents are the resources the control plane of the
+	// target cluster creates in every Namespace.
+	defaultNamespaceContents = map[schema.GroupKind]string{
+		{Kind: "ServiceAccount"}: "default",
+		{Kind: "ConfigMap"}:      "kube-root-ca.crt",
+	}
+)
+
+// waitForRemoteDependents returns an error, and reports the remaining remote
+// dependents in the conditions of the supplied Object, if the supplied remote
+// object, or any item of it if it is a List, is a Namespace or
+// CustomResourceDefinition whose dependents remain, and the Object waits for
+// them and is not forced to delete it anyway.
+func (c *external) waitForRemoteDependents(ctx context.Context, obj *v1alpha2.Object, res *unstructured.Unstructured) error {
+	if !obj.Spec.ForProvider.WaitForRemoteDependents || obj.GetAnnotations()[annotationKeyForceDelete] == "true" {
+		return nil
+	}
+	manifests := []*unstructured.Unstructured{res}
+	if res.IsList() {
+		var err error
+		if _, manifests, err = children(obj, res); err != nil {
+			return err
+		}
+	}
+
+	var remain []string
+	for _, m := range manifests {
+		var kinds []string
+		var err error
+		switch m.GroupVersionKind().GroupKind() {
+		case namespaceKind:
+			kinds, err = c.namespaceDependents(ctx, m.GetName())
+		case crdKind:
+			kinds, err = c.crdDependents(ctx, m)
+		}
+		if err != nil {
+			return err
+		}
+		if len(kinds) > 0 {
+			remain = append(remain, fmt.Sprintf("%s %s still has %s", m.GetKind(), m.GetName(), strings.Join(kinds, ", ")))
+		}
+	}
+	if len(remain) == 0 {
+		return nil
+	}
+
+	msg := strings.Join(remain, "; ")
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeDependentsRemain,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonDependentsRemain,
+		Message:            msg,
+	})
+	return errors.Errorf(errRemoteDependentsRemain, msg)
+}
+
+// namespaceDependents returns the kinds of the resources other than its
+// defaults that remain in the supplied Namespace.
+func (c *external) namespaceDependents(ctx context.Context, namespace string) ([]string, error) {
+	lists, err := discovery.ServerPreferredNamespacedResources(c.discovery)
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		// Kinds of groups that failed to be discovered, e.g. due to an
+		// unavailable aggregated API server, are skipped.
+		return nil, errors.Wrap(err, errDiscoverNamespacedKinds)
+	}
+	lists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, lists)
+
+	var kinds []string
+	for _, l := range lists {
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range l.APIResources {
+			gk := gv.WithKind(r.Kind).GroupKind()
+			if strings.Contains(r.Name, "/") || ignoredDependentKinds[gk] {
+				continue
+			}
+			// There is at most one default resource of a kind, so listing
+			// two is enough to find any other.
+			remaining, err := c.remaining(ctx, gv.WithKind(r.Kind), client.InNamespace(namespace), client.Limit(2))
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range remaining {
+				if item.GetName() != defaultNamespaceContents[gk] {
+					kinds = append(kinds, gk.String())
+					break
+				}
+			}
+		}
+	}
+	// Discovery does not return kinds in a stable order.
+	sort.Strings(kinds)
+	return kinds, nil
+}
+
+// crdDependents returns the kind of the supplied CustomResourceDefinition if
+// custom resources of it remain.
+func (c *external) crdDependents(ctx context.Context, crd *unstructured.Unstructured) ([]string, error) {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, _ := v.(map[string]any)
+		name, _ := m["name"].(string)
+		if served, _ := m["served"].(bool); !served || name == "" {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: group, Version: name, Kind: kind}
+		remaining, err := c.remaining(ctx, gvk, client.Limit(1))
+		if err != nil {
+			return nil, err
+		}
+		if len(remaining) > 0 {
+			return []string{gvk.GroupKind().String()}, nil
+		}
+		// Custom resources of any served version are listed with the
+		// others.
+		return nil, nil
+	}
+	return nil, nil
+}
+
+// remaining lists the resources of the supplied kind. A kind that is not
+// served by the target cluster has none.
+func (c *external) remaining(ctx context.Context, gvk schema.GroupVersionKind, opts ...client.ListOption) ([]unstructured.Unstructured, error) {
+	l := &unstructured.UnstructuredList{}
+	l.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	err := c.client.List(ctx, l, opts...)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	return l.Items, errors.Wrapf(err, errListRemoteDependents, gvk.Kind)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	ktesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestWaitForRemoteDependents(t *testing.T) {
+	listable := []string{"list"}
+	dc := &fakediscovery.FakeDiscovery{Fake: &ktesting.Fake{Resources: []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: listable},
+			{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true, Verbs: listable},
+			{Name: "events", Kind: "Event", Namespaced: true, Verbs: listable},
+			{Name: "namespaces", Kind: "Namespace", Verbs: listable},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: listable},
+			{Name: "deployments/status", Kind: "Deployment", Namespaced: true, Verbs: listable},
+		}},
+	}}}
+	// listing lists the supplied names for every kind.
+	listing := func(names map[string][]string) client.Client {
+		return &test.MockClient{MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			l := list.(*unstructured.UnstructuredList)
+			for _, n := range names[l.GetKind()] {
+				item := unstructured.Unstructured{}
+				item.SetName(n)
+				l.Items = append(l.Items, item)
+			}
+			return nil
+		}}
+	}
+	waiting := func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.WaitForRemoteDependents = true
+	}
+	ns := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]any{"name": "team"}}}
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "widgets.example.org"},
+		"spec": map[string]any{
+			"group":    "example.org",
+			"names":    map[string]any{"kind": "Widget"},
+			"versions": []any{map[string]any{"name": "v1alpha1", "served": false}, map[string]any{"name": "v1", "served": true}},
+		},
+	}}
+
+	type want struct {
+		err       bool
+		condition corev1.ConditionStatus
+		message   string
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		obj    *v1alpha2.Object
+		res    *unstructured.Unstructured
+		want   want
+	}{
+		"NotWaiting": {
+			reason: "Deletion should not be held if the Object does not wait for remote dependents.",
+			kube:   listing(map[string][]string{"DeploymentList": {"app"}}),
+			obj:    kubernetesObject(),
+			res:    ns,
+			want:   want{condition: corev1.ConditionUnknown},
+		},
+		"Forced": {
+			reason: "Deletion should not be held if the Object is forced to delete its remote object.",
+			kube:   listing(map[string][]string{"DeploymentList": {"app"}}),
+			obj: kubernetesObject(waiting, func(obj *v1alpha2.Object) {
+				obj.SetAnnotations(map[string]string{annotationKeyForceDelete: "true"})
+			}),
+			res:  ns,
+			want: want{condition: corev1.ConditionUnknown},
+		},
+		"OnlyDefaults": {
+			reason: "Deletion of a Namespace should not be held by its default resources and events.",
+			kube:   listing(map[string][]string{"ServiceAccountList": {"default"}, "ConfigMapList": {"kube-root-ca.crt"}, "EventList": {"e"}}),
+			obj:    kubernetesObject(waiting),
+			res:    ns,
+			want:   want{condition: corev1.ConditionUnknown},
+		},
+		"NamespaceDependentsRemain": {
+			reason: "Deletion of a Namespace should be held while other resources remain in it.",
+			kube:   listing(map[string][]string{"ServiceAccountList": {"default", "app"}, "DeploymentList": {"app"}}),
+			obj:    kubernetesObject(waiting),
+			res:    ns,
+			want: want{
+				err:       true,
+				condition: corev1.ConditionTrue,
+				message:   "Namespace team still has Deployment.apps, ServiceAccount",
+			},
+		},
+		"CustomResourcesRemain": {
+			reason: "Deletion of a CustomResourceDefinition should be held while custom resources of it remain.",
+			kube:   listing(map[string][]string{"WidgetList": {"w"}}),
+			obj:    kubernetesObject(waiting),
+			res:    crd,
+			want: want{
+				err:       true,
+				condition: corev1.ConditionTrue,
+				message:   "CustomResourceDefinition widgets.example.org still has Widget.example.org",
+			},
+		},
+		"NoCustomResources": {
+			reason: "Deletion of a CustomResourceDefinition without custom resources should not be held.",
+			kube:   listing(nil),
+			obj:    kubernetesObject(waiting),
+			res:    crd,
+			want:   want{condition: corev1.ConditionUnknown},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: resource.ClientApplicator{Client: tc.kube}, discovery: dc}
+			err := e.waitForRemoteDependents(context.Background(), tc.obj, tc.res)
+			c := tc.obj.GetCondition(typeDependentsRemain)
+			got := want{err: err != nil, condition: c.Status, message: c.Message}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.waitForRemoteDependents(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
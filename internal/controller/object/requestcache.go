@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// A requestObject is the Object of a request, got once for all the
+// reconcilers wrapping the managed reconciler.
+type requestObject struct {
+	key client.ObjectKey
+	got bool
+	obj *v1alpha2.Object
+	err error
+}
+
+// A requestCachingReconciler caches the Object of each request in its
+// context, so that the readers returned by newRequestCachedReader get it once
+// per request rather than once for every reconciler wrapping the managed
+// reconciler.
+type requestCachingReconciler struct {
+	inner reconcile.Reconciler
+}
+
+func newRequestCachingReconciler(inner reconcile.Reconciler) *requestCachingReconciler {
+	return &requestCachingReconciler{inner: inner}
+}
+
+// Reconcile the supplied request with the inner reconciler, caching its
+// Object for the duration of the reconcile.
+func (r *requestCachingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return r.inner.Reconcile(context.WithValue(ctx, keyRequestObject, &requestObject{key: req.NamespacedName}), req)
+}
+
+// A requestCachedReader gets the Object of a request, as cached by a
+// requestCachingReconciler, with the reader it wraps only once. Any other
+// object is read with the wrapped reader.
+type requestCachedReader struct {
+	client.Reader
+}
+
+func newRequestCachedReader(r client.Reader) *requestCachedReader {
+	return &requestCachedReader{Reader: r}
+}
+
+// Get the object with the supplied key. The Object of the request is copied
+// from the cache, so that callers cannot change it for each other.
+func (r *requestCachedReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	o, ok := obj.(*v1alpha2.Object)
+	ro, cached := ctx.Value(keyRequestObject).(*requestObject)
+	if !ok || !cached || ro.key != key || len(opts) > 0 {
+		return r.Reader.Get(ctx, key, obj, opts...)
+	}
+	if !ro.got {
+		ro.obj = &v1alpha2.Object{}
+		ro.err = r.Reader.Get(ctx, key, ro.obj)
+		ro.got = true
+	}
+	if ro.err != nil {
+		return ro.err
+	}
+	ro.obj.DeepCopyInto(o)
+	return nil
+}
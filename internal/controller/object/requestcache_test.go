@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestRequestCachedReader(t *testing.T) {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "cool"}}
+	type want struct {
+		gets map[string]int
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		getErr error
+		// get is run by the inner reconciler.
+		get func(ctx context.Context, r client.Reader) error
+		want
+	}{
+		"ObjectOfRequest": {
+			reason: "The Object of the request should be got once, however often it is read.",
+			get: func(ctx context.Context, r client.Reader) error {
+				for i := 0; i < 3; i++ {
+					obj := &v1alpha2.Object{}
+					if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+						return err
+					}
+					if obj.Spec.Priority != 1 {
+						t.Errorf("r.Get(...): want priority 1, got %d", obj.Spec.Priority)
+					}
+					// Changes of a copy should not be seen by other readers.
+					obj.Spec.Priority = 2
+				}
+				return nil
+			},
+			want: want{gets: map[string]int{"cool": 1}},
+		},
+		"ErrorOfRequest": {
+			reason: "An error getting the Object of the request should be cached too.",
+			getErr: errBoom,
+			get: func(ctx context.Context, r client.Reader) error {
+				_ = r.Get(ctx, req.NamespacedName, &v1alpha2.Object{})
+				return r.Get(ctx, req.NamespacedName, &v1alpha2.Object{})
+			},
+			want: want{gets: map[string]int{"cool": 1}, err: errBoom},
+		},
+		"OtherObjects": {
+			reason: "Other Objects and other kinds should be read every time.",
+			get: func(ctx context.Context, r client.Reader) error {
+				for i := 0; i < 2; i++ {
+					if err := r.Get(ctx, types.NamespacedName{Name: "other"}, &v1alpha2.Object{}); err != nil {
+						return err
+					}
+					if err := r.Get(ctx, req.NamespacedName, &apisv1alpha1.ProviderConfig{}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: want{gets: map[string]int{"other": 2, "cool": 2}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gets := make(map[string]int)
+			r := newRequestCachedReader(&test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					gets[key.Name]++
+					if o, ok := obj.(*v1alpha2.Object); ok {
+						o.Spec.Priority = 1
+					}
+					return tc.getErr
+				},
+			})
+			var err error
+			inner := reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				err = tc.get(ctx, r)
+				return reconcile.Result{}, nil
+			})
+			if _, rerr := newRequestCachingReconciler(inner).Reconcile(context.Background(), req); rerr != nil {
+				t.Fatalf("Reconcile(...): %v", rerr)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Get(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.gets, gets); diff != "" {
+				t.Errorf("\n%s\nr.Get(...): -want gets, +got gets:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
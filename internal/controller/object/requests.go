@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// annotationKeyRecordRequests records the requests each reconcile of an
+	// Object makes to the target cluster in its status when set to "true", to
+	// tell which Objects are expensive to reconcile.
+	annotationKeyRecordRequests = "kubernetes.crossplane.io/record-requests"
+)
+
+// verbDeleteCollection is the verb of requests deleting all objects of a kind.
+const verbDeleteCollection = "deletecollection"
+
+// recordRequestsEnabled returns true if the requests of the reconciles of the
+// supplied Object are to be recorded.
+func recordRequestsEnabled(obj *v1alpha2.Object) bool {
+	return obj.GetAnnotations()[annotationKeyRecordRequests] == "true"
+}
+
+type requestKey struct {
+	verb        string
+	subresource string
+}
+
+// A requestCounter counts requests by verb and subresource.
+type requestCounter struct {
+	mu     sync.Mutex
+	counts map[requestKey]int32
+}
+
+func newRequestCounter() *requestCounter {
+	return &requestCounter{counts: make(map[requestKey]int32)}
+}
+
+func (c *requestCounter) count(verb, subresource string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[requestKey{verb: verb, subresource: subresource}]++
+}
+
+// Counts returns the requests counted so far, sorted by verb and subresource.
+func (c *requestCounter) Counts() []v1alpha2.RequestCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make([]v1alpha2.RequestCount, 0, len(c.counts))
+	for k, n := range c.counts {
+		counts = append(counts, v1alpha2.RequestCount{Verb: k.verb, Subresource: k.subresource, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Verb != counts[j].Verb {
+			return counts[i].Verb < counts[j].Verb
+		}
+		return counts[i].Subresource < counts[j].Subresource
+	})
+	return counts
+}
+
+// recordRequests records the requests the current reconcile of the supplied
+// Object made to the target cluster so far in its status, or clears them if
+// they are not recorded. It is called at the end of every operation of the
+// reconcile, so that the status written at its end covers all of them.
+func (c *external) recordRequests(obj *v1alpha2.Object) {
+	if c.requests == nil {
+		obj.Status.AtProvider.LastReconcileRequests = nil
+		return
+	}
+	obj.Status.AtProvider.LastReconcileRequests = c.requests.Counts()
+	c.logger.Debug("Made requests to the target cluster", "requests", obj.Status.AtProvider.LastReconcileRequests)
+}
+
+// A countingClient is a client that counts the requests it makes, e.g. to
+// the target cluster of an Object.
+type countingClient struct {
+	client.Client
+
+	counter *requestCounter
+}
+
+func newCountingClient(c client.Client, counter *requestCounter) *countingClient {
+	return &countingClient{Client: c, counter: counter}
+}
+
+// Get the supplied object, counting the request.
+func (c *countingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.counter.count(verbGet, "")
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// List the supplied objects, counting the request.
+func (c *countingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.counter.count(verbList, "")
+	return c.Client.List(ctx, list, opts...)
+}
+
+// Create the supplied object, counting the request.
+func (c *countingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.counter.count(verbCreate, "")
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Update the supplied object, counting the request.
+func (c *countingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.counter.count(verbUpdate, "")
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch the supplied object, counting the request.
+func (c *countingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.counter.count(verbPatch, "")
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete the supplied object, counting the request.
+func (c *countingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.counter.count(verbDelete, "")
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// DeleteAllOf the supplied kind of objects, counting the request.
+func (c *countingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.counter.count(verbDeleteCollection, "")
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status returns a writer of the status subresource that counts its requests.
+func (c *countingClient) Status() client.SubResourceWriter {
+	return &countingSubResourceClient{SubResourceWriter: c.Client.Status(), counter: c.counter, subresource: "status"}
+}
+
+// SubResource returns a client of the supplied subresource that counts its
+// requests.
+func (c *countingClient) SubResource(subresource string) client.SubResourceClient {
+	inner := c.Client.SubResource(subresource)
+	return &countingSubResourceClient{SubResourceWriter: inner, reader: inner, counter: c.counter, subresource: subresource}
+}
+
+type countingSubResourceClient struct {
+	client.SubResourceWriter
+
+	reader      client.SubResourceReader
+	counter     *requestCounter
+	subresource string
+}
+
+// Get the subresource of the supplied object, counting the request.
+func (c *countingSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	c.counter.count(verbGet, c.subresource)
+	return c.reader.Get(ctx, obj, subResource, opts...)
+}
+
+// Create the subresource of the supplied object, counting the request.
+func (c *countingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	c.counter.count(verbCreate, c.subresource)
+	return c.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+// Update the subresource of the supplied object, counting the request.
+func (c *countingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	c.counter.count(verbUpdate, c.subresource)
+	return c.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+// Patch the subresource of the supplied object, counting the request.
+func (c *countingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	c.counter.count(verbPatch, c.subresource)
+	return c.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
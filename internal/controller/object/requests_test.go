@@ -0,0 +1,90 @@
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestCountingClient(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet:         test.NewMockGetFn(nil),
+		MockList:        test.NewMockListFn(nil),
+		MockPatch:       test.NewMockPatchFn(nil),
+		MockDelete:      test.NewMockDeleteFn(nil),
+		MockStatusPatch: test.NewMockSubResourcePatchFn(nil),
+	}
+	counter := newRequestCounter()
+	c := newCountingClient(kube, counter)
+
+	ctx := context.Background()
+	cm := &corev1.ConfigMap{}
+	_ = c.Get(ctx, client.ObjectKey{Name: "cm"}, cm)
+	_ = c.Get(ctx, client.ObjectKey{Name: "cm"}, cm)
+	_ = c.List(ctx, &corev1.ConfigMapList{})
+	_ = c.Patch(ctx, cm, client.Merge)
+	_ = c.Status().Patch(ctx, cm, client.Merge)
+	_ = c.Delete(ctx, cm)
+
+	want := []v1alpha2.RequestCount{
+		{Verb: verbDelete, Count: 1},
+		{Verb: verbGet, Count: 2},
+		{Verb: verbList, Count: 1},
+		{Verb: verbPatch, Count: 1},
+		{Verb: verbPatch, Subresource: "status", Count: 1},
+	}
+	if diff := cmp.Diff(want, counter.Counts()); diff != "" {
+		t.Errorf("counter.Counts(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRecordRequests(t *testing.T) {
+	counted := func() *requestCounter {
+		c := newRequestCounter()
+		c.count(verbGet, "")
+		return c
+	}
+
+	cases := map[string]struct {
+		reason   string
+		requests *requestCounter
+		obj      *v1alpha2.Object
+		want     []v1alpha2.RequestCount
+	}{
+		"NotRecorded": {
+			reason: "The requests recorded before should be cleared if the requests are no longer recorded.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.LastReconcileRequests = []v1alpha2.RequestCount{{Verb: verbGet, Count: 1}}
+			}),
+		},
+		"Recorded": {
+			reason:   "The requests counted so far should be recorded.",
+			requests: counted(),
+			obj:      kubernetesObject(),
+			want:     []v1alpha2.RequestCount{{Verb: verbGet, Count: 1}},
+		},
+		"NoRequests": {
+			reason:   "No requests should be recorded if none were made.",
+			requests: newRequestCounter(),
+			obj:      kubernetesObject(),
+			want:     []v1alpha2.RequestCount{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), requests: tc.requests}
+			e.recordRequests(tc.obj)
+			if diff := cmp.Diff(tc.want, tc.obj.Status.AtProvider.LastReconcileRequests); diff != "" {
+				t.Errorf("\n%s\ne.recordRequests(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// annotationKeyRollbackToRevision pins an Object to the manifest of a
+	// revision in its history, instead of the manifest in its spec, for as
+	// long as it is set.
+	annotationKeyRollbackToRevision = "kubernetes.crossplane.io/rollback-to-revision"
+
+	errParseRollbackRevision = "cannot parse the revision to roll back to"
+	errRevisionNotFound      = "revision %d is not in the revision history"
+	errRevisionNoManifest    = "revision %d cannot be rolled back to, as only the hash of its manifest was recorded"
+	errRevisionRedacted      = "revision %d cannot be rolled back to, as the secret data of its manifest was redacted"
+	errHashManifest          = "cannot hash manifest"
+)
+
+// rollback replaces the manifest of the supplied Object with the manifest of
+// the revision it is annotated to roll back to, if any.
+func rollback(obj *v1alpha2.Object) error {
+	v, ok := obj.GetAnnotations()[annotationKeyRollbackToRevision]
+	if !ok {
+		return nil
+	}
+	rev, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errParseRollbackRevision)
+	}
+	for _, r := range obj.Status.AtProvider.Revisions {
+		if r.Revision != rev {
+			continue
+		}
+		switch {
+		case r.Redacted:
+			return errors.Errorf(errRevisionRedacted, rev)
+		case len(r.Manifest.Raw) == 0:
+			return errors.Errorf(errRevisionNoManifest, rev)
+		}
+		obj.Spec.ForProvider.Manifest.Raw = bytes.Clone(r.Manifest.Raw)
+		return nil
+	}
+	if meta.WasDeleted(obj) {
+		// Deleting the remote objects only needs their names, which the
+		// manifest in the spec most likely still has.
+		return nil
+	}
+	return errors.Errorf(errRevisionNotFound, rev)
+}
+
+// recordRevision records the supplied manifest, as declared by the supplied
+// Object, as the most recently applied revision of the Object. A manifest that
+// is applied again keeps its revision number, so that an Object keeps rolling
+// back to the same revision. Only the last revisions up to the revision
+// history limit of the Object are kept. Only the hash of manifests loaded or
+// rendered from other resources is recorded, and the secret data of other
+// manifests is redacted if secrets are sanitized, so that the status of the
+// Object does not hold secrets.
+func recordRevision(obj *v1alpha2.Object, manifest []byte, sanitize bool) error {
+	limit := 0
+	if l := obj.Spec.ForProvider.RevisionHistoryLimit; l != nil {
+		limit = int(*l)
+	}
+	if limit == 0 {
+		obj.Status.AtProvider.Revisions = nil
+		return nil
+	}
+	if len(manifest) == 0 {
+		return nil
+	}
+	hash, err := manifestHash(manifest)
+	if err != nil {
+		return err
+	}
+
+	revs := make([]v1alpha2.ManifestRevision, 0, len(obj.Status.AtProvider.Revisions)+1)
+	var applied *v1alpha2.ManifestRevision
+	next := int64(1)
+	for _, r := range obj.Status.AtProvider.Revisions {
+		if r.Revision >= next {
+			next = r.Revision + 1
+		}
+		if r.Hash == hash {
+			applied = r.DeepCopy()
+			continue
+		}
+		revs = append(revs, r)
+	}
+	if applied == nil {
+		applied = &v1alpha2.ManifestRevision{Revision: next, Hash: hash}
+		if len(obj.Spec.ForProvider.ManifestFrom) == 0 && obj.Spec.ForProvider.TemplateRef == nil {
+			raw, redacted, err := revisionManifest(manifest, sanitize)
+			if err != nil {
+				return err
+			}
+			applied.Manifest = runtime.RawExtension{Raw: raw}
+			applied.Redacted = redacted
+		}
+	}
+	applied.AppliedTime = metav1.Now()
+	revs = append(revs, *applied)
+	if len(revs) > limit {
+		revs = revs[len(revs)-limit:]
+	}
+	obj.Status.AtProvider.Revisions = revs
+	return nil
+}
+
+// revisionManifest returns the supplied manifest as it is recorded in a
+// revision, with the secret data of the manifest, or of every item of its List
+// manifest, redacted if asked to. It also returns whether anything was
+// redacted.
+func revisionManifest(manifest []byte, sanitize bool) ([]byte, bool, error) {
+	if !sanitize {
+		return manifest, false, nil
+	}
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(manifest, &u.Object); err != nil {
+		return nil, false, errors.Wrap(err, errUnmarshalTemplate)
+	}
+	items := []*unstructured.Unstructured{u}
+	if u.IsList() {
+		var err error
+		if items, err = listItems(u); err != nil {
+			return nil, false, err
+		}
+	}
+	redacted := false
+	for _, item := range items {
+		if len(redactSecretData(item)) > 0 {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return manifest, false, nil
+	}
+	if u.IsList() {
+		l := make([]any, 0, len(items))
+		for _, item := range items {
+			l = append(l, item.Object)
+		}
+		u.Object["items"] = l
+	}
+	raw, err := json.Marshal(u.Object)
+	return raw, true, errors.Wrap(err, errMarshalManifest)
+}
+
+// manifestHash returns a hash of the supplied manifest that does not depend on
+// the order of its fields.
+func manifestHash(manifest []byte) (string, error) {
+	var m any
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return "", errors.Wrap(err, errHashManifest)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", errors.Wrap(err, errHashManifest)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	manifestV1 = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"},"data":{"version":"1"}}`
+	manifestV2 = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"},"data":{"version":"2"}}`
+	manifestV3 = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"},"data":{"version":"3"}}`
+)
+
+func revision(t *testing.T, rev int64, manifest string) v1alpha2.ManifestRevision {
+	t.Helper()
+	hash, err := manifestHash([]byte(manifest))
+	if err != nil {
+		t.Fatalf("manifestHash(...): %s", err)
+	}
+	return v1alpha2.ManifestRevision{Revision: rev, Hash: hash, Manifest: runtime.RawExtension{Raw: []byte(manifest)}}
+}
+
+func TestRollback(t *testing.T) {
+	type want struct {
+		manifest string
+		err      error
+	}
+	cases := map[string]struct {
+		revision string
+		deleted  bool
+		want     want
+	}{
+		"NoRollback": {
+			want: want{manifest: manifestV2},
+		},
+		"RollbackToRevision": {
+			revision: "1",
+			want:     want{manifest: manifestV1},
+		},
+		"InvalidRevision": {
+			revision: "latest",
+			want: want{
+				manifest: manifestV2,
+				err:      errors.Wrap(errors.New(`strconv.ParseInt: parsing "latest": invalid syntax`), errParseRollbackRevision),
+			},
+		},
+		"RevisionNotFound": {
+			revision: "3",
+			want: want{
+				manifest: manifestV2,
+				err:      errors.Errorf(errRevisionNotFound, 3),
+			},
+		},
+		"RevisionWithoutManifest": {
+			revision: "10",
+			want: want{
+				manifest: manifestV2,
+				err:      errors.Errorf(errRevisionNoManifest, 10),
+			},
+		},
+		"RedactedRevision": {
+			revision: "11",
+			want: want{
+				manifest: manifestV2,
+				err:      errors.Errorf(errRevisionRedacted, 11),
+			},
+		},
+		"RevisionNotFoundWhileDeleting": {
+			revision: "3",
+			deleted:  true,
+			want:     want{manifest: manifestV2},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.Manifest.Raw = []byte(manifestV2)
+				obj.Status.AtProvider.Revisions = []v1alpha2.ManifestRevision{revision(t, 1, manifestV1), revision(t, 2, manifestV2), {Revision: 10, Hash: "10"}, {Revision: 11, Hash: "11", Manifest: runtime.RawExtension{Raw: []byte(manifestV1)}, Redacted: true}}
+				if tc.revision != "" {
+					obj.SetAnnotations(map[string]string{annotationKeyRollbackToRevision: tc.revision})
+				}
+				if tc.deleted {
+					obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+				}
+			})
+			err := rollback(obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("rollback(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.manifest, string(obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("rollback(...): -want manifest, +got manifest: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordRevision(t *testing.T) {
+	cases := map[string]struct {
+		limit    *int32
+		history  []string
+		manifest string
+		want     []v1alpha2.ManifestRevision
+	}{
+		"Disabled": {
+			history:  []string{manifestV1},
+			manifest: manifestV2,
+		},
+		"FirstRevision": {
+			limit:    ptr.To[int32](2),
+			manifest: manifestV1,
+			want:     []v1alpha2.ManifestRevision{revision(t, 1, manifestV1)},
+		},
+		"NewRevision": {
+			limit:    ptr.To[int32](3),
+			history:  []string{manifestV1},
+			manifest: manifestV2,
+			want:     []v1alpha2.ManifestRevision{revision(t, 1, manifestV1), revision(t, 2, manifestV2)},
+		},
+		"ReappliedRevisionKeepsItsNumber": {
+			limit:    ptr.To[int32](3),
+			history:  []string{manifestV1, manifestV2},
+			manifest: `{"kind":"ConfigMap","apiVersion":"v1","data":{"version":"1"},"metadata":{"name":"cm"}}`,
+			want:     []v1alpha2.ManifestRevision{revision(t, 2, manifestV2), revision(t, 1, manifestV1)},
+		},
+		"OldestRevisionsDropped": {
+			limit:    ptr.To[int32](2),
+			history:  []string{manifestV1, manifestV2},
+			manifest: manifestV3,
+			want:     []v1alpha2.ManifestRevision{revision(t, 2, manifestV2), revision(t, 3, manifestV3)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.RevisionHistoryLimit = tc.limit
+				for i, m := range tc.history {
+					obj.Status.AtProvider.Revisions = append(obj.Status.AtProvider.Revisions, revision(t, int64(i+1), m))
+				}
+			})
+			if err := recordRevision(obj, []byte(tc.manifest), false); err != nil {
+				t.Fatalf("recordRevision(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, obj.Status.AtProvider.Revisions,
+				cmpopts.IgnoreFields(v1alpha2.ManifestRevision{}, "AppliedTime", "Manifest")); diff != "" {
+				t.Errorf("recordRevision(...): -want revisions, +got revisions: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordRevisionManifest(t *testing.T) {
+	secret := `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"s"},"data":{"password":"c2VjcmV0"}}`
+	type want struct {
+		manifest string
+		redacted bool
+	}
+	cases := map[string]struct {
+		reason   string
+		obj      *v1alpha2.Object
+		manifest string
+		sanitize bool
+		want     want
+	}{
+		"Declared": {
+			reason:   "The manifests declared in the spec of Objects should be recorded as is.",
+			obj:      kubernetesObject(),
+			manifest: secret,
+			want:     want{manifest: secret},
+		},
+		"Sanitized": {
+			reason:   "The secret data of the manifests of Objects should be redacted if secrets are sanitized.",
+			obj:      kubernetesObject(),
+			manifest: secret,
+			sanitize: true,
+			want: want{
+				manifest: `{"apiVersion":"v1","data":{"redacted":null},"kind":"Secret","metadata":{"name":"s"}}`,
+				redacted: true,
+			},
+		},
+		"SanitizedWithoutSecrets": {
+			reason:   "Manifests without secret data should be recorded as is if secrets are sanitized.",
+			obj:      kubernetesObject(),
+			manifest: manifestV1,
+			sanitize: true,
+			want:     want{manifest: manifestV1},
+		},
+		"ManifestFrom": {
+			reason: "Only the hash of manifests loaded from other resources should be recorded.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ManifestFrom = []v1alpha2.ManifestSource{{SecretKeyRef: &v1alpha2.ManifestKeySelector{Namespace: "default", Name: "manifest", Key: "manifest"}}}
+			}),
+			manifest: secret,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.obj.Spec.ForProvider.RevisionHistoryLimit = ptr.To[int32](1)
+			if err := recordRevision(tc.obj, []byte(tc.manifest), tc.sanitize); err != nil {
+				t.Fatalf("\n%s\nrecordRevision(...): %s", tc.reason, err)
+			}
+			got := tc.obj.Status.AtProvider.Revisions[0]
+			if diff := cmp.Diff(tc.want.manifest, string(got.Manifest.Raw)); diff != "" {
+				t.Errorf("\n%s\nrecordRevision(...): -want manifest, +got manifest: %s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.redacted, got.Redacted); diff != "" {
+				t.Errorf("\n%s\nrecordRevision(...): -want redacted, +got redacted: %s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typeChangesPending is the condition reporting whether the remote
+	// object of an Object is out of date, but changes are not applied yet
+	// due to its apply schedule.
+	typeChangesPending xpv1.ConditionType = "ChangesPending"
+
+	reasonApplyIntervalNotElapsed  xpv1.ConditionReason = "ApplyIntervalNotElapsed"
+	reasonOutsideMaintenanceWindow xpv1.ConditionReason = "OutsideMaintenanceWindow"
+	reasonChangesApplied           xpv1.ConditionReason = "ChangesApplied"
+
+	errTimeZone        = "cannot load time zone of maintenance window"
+	errWindowStart     = "cannot parse start of maintenance window"
+	msgApplyInterval   = "Changes are applied no sooner than %s"
+	msgOutsideWindow   = "Changes are applied in the next maintenance window"
+	maintenanceDayFmt  = "Mon"
+	maintenanceTimeFmt = "15:04"
+)
+
+// deferApply returns true, and sets the ChangesPending condition of the
+// supplied Object, if the apply schedule of the Object does not allow applying
+// changes at the supplied time.
+func deferApply(obj *v1alpha2.Object, now time.Time) (bool, error) {
+	s := obj.Spec.ForProvider.ApplySchedule
+	if s == nil {
+		return false, nil
+	}
+
+	if last := obj.Status.AtProvider.LastAppliedTime; s.Interval != nil && last != nil {
+		if next := last.Add(s.Interval.Duration); now.Before(next) {
+			setChangesPending(obj, reasonApplyIntervalNotElapsed, fmt.Sprintf(msgApplyInterval, next.UTC().Format(time.RFC3339)))
+			return true, nil
+		}
+	}
+
+	in, err := inApplyWindow(obj, now)
+	if err != nil || in {
+		return false, err
+	}
+	setChangesPending(obj, reasonOutsideMaintenanceWindow, msgOutsideWindow)
+	return true, nil
+}
+
+// inApplyWindow returns true if the supplied time is within one of the
+// maintenance windows of the supplied Object, or if it has none.
+func inApplyWindow(obj *v1alpha2.Object, now time.Time) (bool, error) {
+	s := obj.Spec.ForProvider.ApplySchedule
+	if s == nil || len(s.Windows) == 0 {
+		return true, nil
+	}
+	for _, w := range s.Windows {
+		in, err := inWindow(w, now)
+		if err != nil || in {
+			return in, err
+		}
+	}
+	return false, nil
+}
+
+// inWindow returns true if the supplied time is within an occurrence of the
+// supplied maintenance window.
+func inWindow(w v1alpha2.MaintenanceWindow, now time.Time) (bool, error) {
+	tz := w.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return false, errors.Wrap(err, errTimeZone)
+	}
+	start, err := time.Parse(maintenanceTimeFmt, w.Start)
+	if err != nil {
+		return false, errors.Wrap(err, errWindowStart)
+	}
+
+	now = now.In(loc)
+	// Occurrences starting on earlier days may still be ongoing if the
+	// window is longer than a day.
+	for d := 0; d <= int(w.Duration.Hours()/24)+1; d++ {
+		day := now.AddDate(0, 0, -d)
+		begin := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+		if now.Before(begin) || !now.Before(begin.Add(w.Duration.Duration)) {
+			continue
+		}
+		if len(w.Days) == 0 {
+			return true, nil
+		}
+		for _, wd := range w.Days {
+			if string(wd) == begin.Format(maintenanceDayFmt) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// setChangesPending reports that the remote object of the supplied Object is
+// out of date, but changes are not applied yet.
+func setChangesPending(obj *v1alpha2.Object, reason xpv1.ConditionReason, msg string) {
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeChangesPending,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	})
+}
+
+// setChangesApplied reports that the pending changes of the supplied Object,
+// if any, are no longer pending.
+func setChangesApplied(obj *v1alpha2.Object) {
+	if obj.GetCondition(typeChangesPending).Status != v1.ConditionTrue {
+		return
+	}
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeChangesPending,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonChangesApplied,
+	})
+}
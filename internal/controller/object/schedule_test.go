@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestDeferApply(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC)
+	withSchedule := func(s *v1alpha2.ApplySchedule) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.ApplySchedule = s
+		}
+	}
+	type want struct {
+		deferred bool
+		reason   xpv1.ConditionReason
+		err      bool
+	}
+	cases := map[string]struct {
+		obj *v1alpha2.Object
+		want
+	}{
+		"NoSchedule": {
+			obj:  kubernetesObject(),
+			want: want{},
+		},
+		"IntervalNotElapsed": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Interval: &metav1.Duration{Duration: time.Hour}}), func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.LastAppliedTime = &metav1.Time{Time: now.Add(-30 * time.Minute)}
+			}),
+			want: want{deferred: true, reason: reasonApplyIntervalNotElapsed},
+		},
+		"IntervalElapsed": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Interval: &metav1.Duration{Duration: time.Hour}}), func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.LastAppliedTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+			}),
+			want: want{},
+		},
+		"NeverApplied": {
+			obj:  kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Interval: &metav1.Duration{Duration: time.Hour}})),
+			want: want{},
+		},
+		"InWindow": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{
+				{Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				{Start: "11:00", Duration: metav1.Duration{Duration: 2 * time.Hour}, Days: []v1alpha2.Weekday{"Wed"}},
+			}})),
+			want: want{},
+		},
+		"OutsideWindow": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{
+				{Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				{Start: "11:00", Duration: metav1.Duration{Duration: 2 * time.Hour}, Days: []v1alpha2.Weekday{"Sat", "Sun"}},
+			}})),
+			want: want{deferred: true, reason: reasonOutsideMaintenanceWindow},
+		},
+		"InWindowStartedOnEarlierDay": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{
+				{Start: "20:00", Duration: metav1.Duration{Duration: 48 * time.Hour}, Days: []v1alpha2.Weekday{"Mon"}},
+			}})),
+			want: want{},
+		},
+		"InWindowInTimeZone": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{
+				// 14:00 in Helsinki is 11:00 UTC in summer.
+				{Start: "14:00", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: "Europe/Helsinki"},
+			}})),
+			want: want{},
+		},
+		"InvalidTimeZone": {
+			obj: kubernetesObject(withSchedule(&v1alpha2.ApplySchedule{Windows: []v1alpha2.MaintenanceWindow{
+				{Start: "14:00", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Nowhere/Special"},
+			}})),
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := deferApply(tc.obj, now)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("deferApply(...): want error %t, got %v", tc.want.err, err)
+			}
+			if got != tc.want.deferred {
+				t.Errorf("deferApply(...): want %t, got %t", tc.want.deferred, got)
+			}
+			if diff := cmp.Diff(tc.want.reason, tc.obj.GetCondition(typeChangesPending).Reason); diff != "" {
+				t.Errorf("deferApply(...): -want reason, +got reason: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetChangesApplied(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want corev1.ConditionStatus
+	}{
+		"NotPending": {
+			obj:  kubernetesObject(),
+			want: corev1.ConditionUnknown,
+		},
+		"Pending": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				setChangesPending(obj, reasonOutsideMaintenanceWindow, msgOutsideWindow)
+			}),
+			want: corev1.ConditionFalse,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setChangesApplied(tc.obj)
+			if diff := cmp.Diff(tc.want, tc.obj.GetCondition(typeChangesPending).Status); diff != "" {
+				t.Errorf("setChangesApplied(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
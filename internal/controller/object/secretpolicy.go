@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const errSecretNamespaceNotAllowed = "cannot read secret %s/%s: the provider config does not allow reading secrets from its namespace"
+
+// labelKeyClaimNamespace is the label Crossplane sets on composed resources to
+// the namespace of their claim.
+const labelKeyClaimNamespace = "crossplane.io/claim-namespace"
+
+// checkSecretNamespace returns an error if the secret policy of the provider
+// config does not allow the supplied Object to read the Secret of the
+// supplied namespace and name.
+func (c *external) checkSecretNamespace(obj *v1alpha2.Object, namespace, name string) error {
+	p := c.secretPolicy
+	if p == nil || slices.Contains(p.AllowedNamespaces, namespace) {
+		return nil
+	}
+	if p.AllowClaimNamespace && namespace != "" && obj.GetLabels()[labelKeyClaimNamespace] == namespace {
+		return nil
+	}
+	return errors.Errorf(errSecretNamespaceNotAllowed, namespace, name)
+}
+
+// isSecret returns true if the supplied apiVersion and kind are those of a
+// Secret.
+func isSecret(apiVersion, kind string) bool {
+	return apiVersion == "v1" && kind == "Secret"
+}
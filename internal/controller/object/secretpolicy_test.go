@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestCheckSecretNamespace(t *testing.T) {
+	claimed := func(obj *v1alpha2.Object) {
+		obj.SetLabels(map[string]string{labelKeyClaimNamespace: "tenant"})
+	}
+	cases := map[string]struct {
+		reason    string
+		policy    *kconfig.SecretPolicy
+		obj       *v1alpha2.Object
+		namespace string
+		want      error
+	}{
+		"NoPolicy": {
+			reason:    "Secrets should be read from any namespace if the provider config has no secret policy.",
+			obj:       kubernetesObject(),
+			namespace: "crossplane-system",
+		},
+		"AllowedNamespace": {
+			reason:    "Secrets should be read from the allowed namespaces.",
+			policy:    &kconfig.SecretPolicy{AllowedNamespaces: []string{"shared"}},
+			obj:       kubernetesObject(),
+			namespace: "shared",
+		},
+		"ClaimNamespace": {
+			reason:    "Secrets should be read from the namespace of the claim of the Object if it is allowed.",
+			policy:    &kconfig.SecretPolicy{AllowClaimNamespace: true},
+			obj:       kubernetesObject(claimed),
+			namespace: "tenant",
+		},
+		"OtherNamespace": {
+			reason:    "Secrets should not be read from other namespaces.",
+			policy:    &kconfig.SecretPolicy{AllowedNamespaces: []string{"shared"}, AllowClaimNamespace: true},
+			obj:       kubernetesObject(claimed),
+			namespace: "crossplane-system",
+			want:      errors.Errorf(errSecretNamespaceNotAllowed, "crossplane-system", "s"),
+		},
+		"ClaimNamespaceNotAllowed": {
+			reason:    "Secrets should not be read from the namespace of the claim of the Object unless it is allowed.",
+			policy:    &kconfig.SecretPolicy{},
+			obj:       kubernetesObject(claimed),
+			namespace: "tenant",
+			want:      errors.Errorf(errSecretNamespaceNotAllowed, "tenant", "s"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{secretPolicy: tc.policy}
+			err := e.checkSecretNamespace(tc.obj, tc.namespace, "s")
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.checkSecretNamespace(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetReferencedSecretNotAllowed(t *testing.T) {
+	e := &external{
+		secretPolicy: &kconfig.SecretPolicy{AllowedNamespaces: []string{"shared"}},
+		localClient: &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+				t.Errorf("e.getReferencedResource(...): Secrets in namespaces that are not allowed should not be read")
+				return nil
+			},
+		},
+	}
+	ref := v1alpha2.Reference{PatchesFrom: &v1alpha2.PatchesFrom{
+		DependsOn: v1alpha2.DependsOn{APIVersion: "v1", Kind: "Secret", Namespace: "crossplane-system", Name: "admin"},
+	}}
+	_, err := e.getReferencedResource(context.Background(), kubernetesObject(), ref)
+	if diff := cmp.Diff(errors.Errorf(errSecretNamespaceNotAllowed, "crossplane-system", "admin"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.getReferencedResource(...): -want error, +got error:\n%s", diff)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// A statusPatchingManager is a manager whose client writes the status of
+// Objects with patches rather than updates.
+type statusPatchingManager struct {
+	manager.Manager
+
+	client client.Client
+}
+
+// newStatusPatchingManager returns the supplied manager with the supplied
+// client, which writes the status of Objects through a statusPatchingClient.
+func newStatusPatchingManager(mgr manager.Manager, c client.Client) *statusPatchingManager {
+	return &statusPatchingManager{Manager: mgr, client: c}
+}
+
+// GetClient returns the status patching client of the manager.
+func (m *statusPatchingManager) GetClient() client.Client {
+	return m.client
+}
+
+// A statusPatchingClient writes the status of Objects with JSON merge patches
+// of the changes since their status was last read, rather than with updates,
+// so that writing it does not conflict with the changes external tooling makes
+// to Objects concurrently, e.g. annotating them.
+type statusPatchingClient struct {
+	client.Client
+
+	mu   sync.Mutex
+	read map[types.NamespacedName]*v1alpha2.ObjectStatus
+}
+
+func newStatusPatchingClient(c client.Client) *statusPatchingClient {
+	return &statusPatchingClient{Client: c, read: make(map[types.NamespacedName]*v1alpha2.ObjectStatus)}
+}
+
+// Get the supplied object, recording the status of Objects as read.
+func (c *statusPatchingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	o, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		delete(c.read, key)
+		return err
+	}
+	c.read[key] = o.Status.DeepCopy()
+	return nil
+}
+
+// Status returns a writer of the status subresource that patches the status of
+// Objects.
+func (c *statusPatchingClient) Status() client.SubResourceWriter {
+	return &statusPatcher{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+// take returns and forgets the status of the Object of the supplied key as
+// last read, if any.
+func (c *statusPatchingClient) take(key types.NamespacedName) *v1alpha2.ObjectStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.read[key]
+	delete(c.read, key)
+	return s
+}
+
+type statusPatcher struct {
+	client.SubResourceWriter
+
+	client *statusPatchingClient
+}
+
+// Update the status of the supplied object. The status of Objects whose status
+// was read is patched with the changes since it was read instead, without the
+// resource version as a precondition. The status as read is forgotten, so
+// that it is not held on to once written, and further updates before the
+// Object is read again are updates.
+func (w *statusPatcher) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	o, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	}
+	read := w.client.take(client.ObjectKeyFromObject(o))
+	if read == nil {
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	}
+	// Only the status is diffed, so that the patch neither carries the resource
+	// version nor changes made to the rest of the Object since it was read.
+	orig := o.DeepCopy()
+	orig.Status = *read
+	return w.SubResourceWriter.Patch(ctx, o, client.MergeFrom(orig))
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestStatusPatchingClient(t *testing.T) {
+	var patches []string
+	var updates int
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			o := obj.(*v1alpha2.Object)
+			o.SetName(testObjectName)
+			o.SetResourceVersion("1")
+			o.SetConditions(xpv1.Available())
+			return nil
+		}),
+		MockStatusPatch: func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+			b, err := patch.Data(obj)
+			if err != nil {
+				return err
+			}
+			patches = append(patches, string(b))
+			return nil
+		},
+		MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+			updates++
+			return nil
+		},
+	}
+	c := newStatusPatchingClient(kube)
+
+	// Updates of Objects that were not read are updates.
+	if err := c.Status().Update(context.Background(), kubernetesObject()); err != nil {
+		t.Fatal(err)
+	}
+
+	obj := &v1alpha2.Object{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: testObjectName}, obj); err != nil {
+		t.Fatal(err)
+	}
+	obj.SetAnnotations(map[string]string{"external": "tooling"})
+	obj.Status.AtProvider.Manifest.Raw = []byte(`{"kind":"Namespace"}`)
+	if err := c.Status().Update(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+	// Further updates before the Object is read again are updates.
+	if err := c.Status().Update(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+	// Other kinds are updated as is.
+	if err := c.Status().Update(context.Background(), &v1.ConfigMap{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{`{"status":{"atProvider":{"manifest":{"kind":"Namespace"}}}}`}
+	if diff := cmp.Diff(want, patches); diff != "" {
+		t.Errorf("Status().Update(...): -want patches, +got patches:\n%s", diff)
+	}
+	if diff := cmp.Diff(3, updates); diff != "" {
+		t.Errorf("Status().Update(...): -want updates, +got updates:\n%s", diff)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errSubresourceList     = "cannot apply a List manifest to a subresource"
+	errSubresourceNotFound = "cannot apply to the %s subresource of a remote object that does not exist"
+	errGetSubresource      = "cannot get the %s subresource of the remote object"
+	errApplySubresource    = "cannot apply to the %s subresource of the remote object"
+	errMarshalSubresource  = "cannot marshal the manifest of the subresource"
+)
+
+// observeSubresource observes the subresource of the remote object that the
+// supplied Object applies its manifest to. The subresource is up-to-date if it
+// contains every field of the manifest outside its metadata.
+func (c *external) observeSubresource(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (managed.ExternalObservation, error) {
+	sub := obj.Spec.ForProvider.Subresource
+	if manifest.IsList() {
+		return managed.ExternalObservation{}, errors.New(errSubresourceList)
+	}
+
+	if meta.WasDeleted(obj) {
+		// The remote object is not owned by the Object, so there is nothing
+		// to delete.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	current := manifest.DeepCopy()
+	err := c.remoteReader(obj, manifest.GroupVersionKind()).Get(ctx, types.NamespacedName{
+		Namespace: current.GetNamespace(),
+		Name:      current.GetName(),
+	}, current)
+	obj.Status.AtProvider.LastObservedTime = ptr.To(metav1.Now())
+	if kerrors.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
+	}
+	observed := current
+	if sub == v1alpha2.SubresourceScale {
+		observed = &unstructured.Unstructured{}
+		if err := c.client.SubResource(string(sub)).Get(ctx, current, observed); err != nil {
+			return managed.ExternalObservation{}, errors.Wrapf(err, errGetSubresource, sub)
+		}
+	}
+	if err := c.setAtProvider(obj, observed); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	upToDate := contains(observed.Object, subresourceFields(manifest))
+	if obj.Spec.ForProvider.DryRun {
+		plan := &v1alpha2.Plan{Action: v1alpha2.PlanActionNone}
+		if !upToDate {
+			plan.Action = v1alpha2.PlanActionUpdate
+		}
+		obj.Status.AtProvider.Plan = plan
+		obj.SetConditions(xpv1.Unavailable().WithMessage(msgDryRun))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	obj.Status.AtProvider.Plan = nil
+	return c.handleObservation(ctx, obj, upToDate)
+}
+
+// syncSubresource applies the fields of the supplied manifest outside its
+// metadata to the subresource of the remote object, with a JSON merge patch.
+func (c *external) syncSubresource(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) error {
+	sub := obj.Spec.ForProvider.Subresource
+	if manifest.IsList() {
+		return errors.New(errSubresourceList)
+	}
+	patch, err := json.Marshal(subresourceFields(manifest))
+	if err != nil {
+		return errors.Wrap(err, errMarshalSubresource)
+	}
+	target := manifest.DeepCopy()
+	err = c.client.SubResource(string(sub)).Patch(ctx, target, client.RawPatch(types.MergePatchType, patch))
+	if kerrors.IsNotFound(err) {
+		return errors.Errorf(errSubresourceNotFound, sub)
+	}
+	if err != nil {
+		c.recordFailure(obj, manifest, err)
+		return errors.Wrapf(c.redactedError(manifest, CleanErr(err)), errApplySubresource, sub)
+	}
+	c.logger.Debug("Applied to subresource", "subresource", sub, "resource", c.redacted(manifest))
+	setApplied(obj)
+	return recordRevision(obj, c.declaredManifest, c.sanitizeSecrets)
+}
+
+// subresourceFields returns the fields of the supplied manifest outside its
+// metadata, i.e. those to apply to a subresource.
+func subresourceFields(manifest *unstructured.Unstructured) map[string]any {
+	fields := make(map[string]any, len(manifest.Object))
+	for k, v := range manifest.Object {
+		switch k {
+		case "apiVersion", "kind", "metadata":
+			continue
+		}
+		fields[k] = v
+	}
+	return fields
+}
+
+// contains returns true if the supplied observed value contains the supplied
+// desired value, i.e. if every field of desired maps has the same value in
+// the observed maps. Lists must have the same length, and their items must
+// contain the desired items.
+func contains(observed, desired any) bool {
+	switch d := desired.(type) {
+	case map[string]any:
+		o, ok := observed.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range d {
+			if !contains(o[k], v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		o, ok := observed.([]any)
+		if !ok || len(o) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !contains(o[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(observed, desired)
+}
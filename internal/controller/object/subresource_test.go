@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	widgetStatusManifest = `{"apiVersion":"example.org/v1","kind":"Widget","metadata":{"name":"widget","namespace":"default"},"status":{"state":"Ready","endpoints":["a"]}}`
+	widgetScaleManifest  = `{"apiVersion":"example.org/v1","kind":"Widget","metadata":{"name":"widget","namespace":"default"},"spec":{"replicas":3}}`
+)
+
+func subresourceObject(sub v1alpha2.Subresource, manifest string, mods ...kubernetesObjectModifier) *v1alpha2.Object {
+	return kubernetesObject(append([]kubernetesObjectModifier{func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Subresource = sub
+		obj.Spec.ForProvider.Manifest.Raw = []byte(manifest)
+	}}, mods...)...)
+}
+
+func widget(fields map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]any{"name": "widget", "namespace": "default", "resourceVersion": "1"},
+	}}
+	for k, v := range fields {
+		u.Object[k] = v
+	}
+	return u
+}
+
+func TestObserveSubresource(t *testing.T) {
+	type want struct {
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		obj     *v1alpha2.Object
+		current *unstructured.Unstructured
+		scale   *unstructured.Unstructured
+		getErr  error
+		want    want
+	}{
+		"NotFound": {
+			obj:    subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			getErr: kerrors.NewNotFound(schema.GroupResource{}, "widget"),
+			want:   want{obs: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"FailedToGet": {
+			obj:    subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			getErr: errBoom,
+			want:   want{err: errors.Wrap(errBoom, errGetObject)},
+		},
+		"Deleted": {
+			obj: subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest, func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+			getErr: errBoom,
+			want:   want{obs: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"List": {
+			obj:  subresourceObject(v1alpha2.SubresourceStatus, `{"apiVersion":"v1","kind":"List","items":[]}`),
+			want: want{err: errors.New(errSubresourceList)},
+		},
+		"StatusUpToDate": {
+			obj: subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			current: widget(map[string]any{
+				"spec":   map[string]any{"size": "large"},
+				"status": map[string]any{"state": "Ready", "endpoints": []any{"a"}, "observedGeneration": int64(1)},
+			}),
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}}},
+		},
+		"StatusNotUpToDate": {
+			obj: subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			current: widget(map[string]any{
+				"status": map[string]any{"state": "Ready", "endpoints": []any{"a", "b"}},
+			}),
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"ScaleUpToDate": {
+			obj:     subresourceObject(v1alpha2.SubresourceScale, widgetScaleManifest),
+			current: widget(map[string]any{"spec": map[string]any{"replicas": int64(1)}}),
+			scale: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "autoscaling/v1",
+				"kind":       "Scale",
+				"metadata":   map[string]any{"name": "widget", "namespace": "default"},
+				"spec":       map[string]any{"replicas": int64(3)},
+			}},
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+							if tc.getErr != nil {
+								return tc.getErr
+							}
+							*obj.(*unstructured.Unstructured) = *tc.current.DeepCopy()
+							return nil
+						},
+						MockSubResourceGet: func(_ context.Context, _, sub client.Object, _ ...client.SubResourceGetOption) error {
+							*sub.(*unstructured.Unstructured) = *tc.scale.DeepCopy()
+							return nil
+						},
+					},
+				},
+			}
+			got, err := e.Observe(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncSubresource(t *testing.T) {
+	cases := map[string]struct {
+		obj       *v1alpha2.Object
+		patchErr  error
+		wantPatch string
+		wantErr   error
+	}{
+		"Status": {
+			obj:       subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			wantPatch: `{"status":{"endpoints":["a"],"state":"Ready"}}`,
+		},
+		"Scale": {
+			obj:       subresourceObject(v1alpha2.SubresourceScale, widgetScaleManifest),
+			wantPatch: `{"spec":{"replicas":3}}`,
+		},
+		"NotFound": {
+			obj:       subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			patchErr:  kerrors.NewNotFound(schema.GroupResource{}, "widget"),
+			wantPatch: `{"status":{"endpoints":["a"],"state":"Ready"}}`,
+			wantErr:   errors.Errorf(errSubresourceNotFound, v1alpha2.SubresourceStatus),
+		},
+		"Failed": {
+			obj:       subresourceObject(v1alpha2.SubresourceStatus, widgetStatusManifest),
+			patchErr:  errBoom,
+			wantPatch: `{"status":{"endpoints":["a"],"state":"Ready"}}`,
+			wantErr:   errors.Wrapf(errBoom, errApplySubresource, v1alpha2.SubresourceStatus),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotPatch string
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockSubResourcePatch: func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+							b, _ := patch.Data(obj)
+							gotPatch = string(b)
+							return tc.patchErr
+						},
+					},
+				},
+			}
+			_, err := e.Update(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Update(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPatch, gotPatch); diff != "" {
+				t.Errorf("e.Update(...): -want patch, +got patch: %s", diff)
+			}
+			if tc.wantErr == nil && tc.obj.Status.AtProvider.LastAppliedTime == nil {
+				t.Errorf("e.Update(...): want the apply to be recorded")
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	cases := map[string]struct {
+		observed any
+		desired  any
+		want     bool
+	}{
+		"ExtraObservedFields": {
+			observed: map[string]any{"a": int64(1), "b": map[string]any{"c": "d", "e": "f"}},
+			desired:  map[string]any{"b": map[string]any{"c": "d"}},
+			want:     true,
+		},
+		"DifferentValue": {
+			observed: map[string]any{"a": int64(1)},
+			desired:  map[string]any{"a": int64(2)},
+		},
+		"MissingField": {
+			observed: map[string]any{},
+			desired:  map[string]any{"a": nil, "b": "c"},
+		},
+		"ListLength": {
+			observed: []any{"a", "b"},
+			desired:  []any{"a"},
+		},
+		"ListItems": {
+			observed: []any{map[string]any{"name": "a", "port": int64(80)}},
+			desired:  []any{map[string]any{"name": "a"}},
+			want:     true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := contains(tc.observed, tc.desired); got != tc.want {
+				t.Errorf("contains(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
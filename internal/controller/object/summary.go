@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errListSummarized = "cannot list Objects to summarize"
+
+	stateReady   = "Ready"
+	stateSynced  = "Synced"
+	stateDrifted = "Drifted"
+	stateFailed  = "Failed"
+	// stateFlapping counts the Objects whose reconciles keep alternating
+	// between success and failure.
+	stateFlapping = "Flapping"
+)
+
+var providerConfigObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsSubsystem,
+	Name:      "provider_config_objects",
+	Help:      "The number of Objects that are Ready, Synced, Drifted, Failed to sync or Flapping, by the ProviderConfig they use.",
+}, []string{"provider_config", "state"})
+
+// A providerConfigState is a state Objects using a ProviderConfig are counted
+// in.
+type providerConfigState struct {
+	providerConfig string
+	state          string
+}
+
+// summarize counts the supplied Objects by the ProviderConfig they use and by
+// each of the states they are in. Objects whose reconciles are paused are not
+// counted as failed.
+func summarize(objs []v1alpha2.Object) map[providerConfigState]float64 {
+	counts := map[providerConfigState]float64{}
+	for i := range objs {
+		obj := &objs[i]
+		pc := ""
+		if ref := obj.GetProviderConfigReference(); ref != nil {
+			pc = ref.Name
+		}
+		if obj.GetCondition(xpv1.TypeReady).Status == v1.ConditionTrue {
+			counts[providerConfigState{pc, stateReady}]++
+		}
+		synced := obj.GetCondition(xpv1.TypeSynced)
+		switch {
+		case synced.Status == v1.ConditionTrue:
+			counts[providerConfigState{pc, stateSynced}]++
+		case synced.Status == v1.ConditionFalse && synced.Reason != xpv1.ReasonReconcilePaused:
+			counts[providerConfigState{pc, stateFailed}]++
+		}
+		if obj.GetCondition(typeDrifted).Status == v1.ConditionTrue {
+			counts[providerConfigState{pc, stateDrifted}]++
+		}
+		if flapping(obj) {
+			counts[providerConfigState{pc, stateFlapping}]++
+		}
+	}
+	return counts
+}
+
+// A summaryRecorder periodically records the number of Objects in each state
+// by the ProviderConfig they use, so that the health of the Objects of every
+// target cluster can be seen without listing them.
+type summaryRecorder struct {
+	kube     client.Reader
+	log      logging.Logger
+	interval time.Duration
+}
+
+func newSummaryRecorder(kube client.Reader, log logging.Logger, interval time.Duration) *summaryRecorder {
+	return &summaryRecorder{kube: kube, log: log, interval: interval}
+}
+
+// Record the number of Objects in each state by the ProviderConfig they use.
+// Counts of ProviderConfigs no longer used by any Object in a state are
+// dropped.
+func (r *summaryRecorder) Record(ctx context.Context) error {
+	l := &v1alpha2.ObjectList{}
+	if err := r.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListSummarized)
+	}
+	counts := summarize(l.Items)
+
+	providerConfigObjects.Reset()
+	for s, n := range counts {
+		providerConfigObjects.With(prometheus.Labels{"provider_config": s.providerConfig, "state": s.state}).Set(n)
+	}
+	return nil
+}
+
+// Start recording the number of Objects in each state at the configured
+// interval until the supplied context is done. Failures to record are logged
+// and retried at the next interval.
+func (r *summaryRecorder) Start(ctx context.Context) error {
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := r.Record(ctx); err != nil {
+				r.log.Info("Cannot record the number of Objects by provider config", "error", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
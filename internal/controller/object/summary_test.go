@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestSummarize(t *testing.T) {
+	using := func(pc string, c ...xpv1.Condition) v1alpha2.Object {
+		return *kubernetesObject(func(obj *v1alpha2.Object) {
+			obj.SetProviderConfigReference(&xpv1.Reference{Name: pc})
+			obj.SetConditions(c...)
+		})
+	}
+	drifted := xpv1.Condition{Type: typeDrifted, Status: "True", Reason: reasonDriftDetected}
+
+	cases := map[string]struct {
+		reason string
+		objs   []v1alpha2.Object
+		want   map[providerConfigState]float64
+	}{
+		"Counted": {
+			reason: "Objects should be counted in each of their states by the ProviderConfig they use.",
+			objs: []v1alpha2.Object{
+				using("a", xpv1.Available(), xpv1.ReconcileSuccess()),
+				using("a", xpv1.Available(), xpv1.ReconcileSuccess(), drifted),
+				using("a", xpv1.Creating(), xpv1.ReconcileError(errors.New("boom"))),
+				using("b", xpv1.Available(), xpv1.ReconcileSuccess()),
+				*kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetProviderConfigReference(&xpv1.Reference{Name: "b"})
+					obj.Status.AtProvider.ReconcileHealth = &v1alpha2.ReconcileHealth{Flaps: 6, Flapping: true}
+				}),
+			},
+			want: map[providerConfigState]float64{
+				{"a", stateReady}:    2,
+				{"a", stateSynced}:   2,
+				{"a", stateDrifted}:  1,
+				{"a", stateFailed}:   1,
+				{"b", stateReady}:    1,
+				{"b", stateSynced}:   1,
+				{"b", stateFlapping}: 1,
+			},
+		},
+		"Paused": {
+			reason: "Objects whose reconciles are paused should not be counted as failed.",
+			objs:   []v1alpha2.Object{using("a", xpv1.ReconcilePaused())},
+			want:   map[providerConfigState]float64{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := summarize(tc.objs)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(providerConfigState{})); diff != "" {
+				t.Errorf("\n%s\nsummarize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSummaryRecorderRecord(t *testing.T) {
+	objs := []v1alpha2.Object{*kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.SetProviderConfigReference(&xpv1.Reference{Name: "summarized"})
+		obj.SetConditions(xpv1.Available(), xpv1.ReconcileSuccess())
+	})}
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+			l.(*v1alpha2.ObjectList).Items = objs
+			return nil
+		},
+	}
+	r := newSummaryRecorder(kube, nil, 0)
+	if err := r.Record(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(providerConfigObjects.WithLabelValues("summarized", stateReady)); got != 1 {
+		t.Errorf("r.Record(...): want 1 Ready Object, got %v", got)
+	}
+
+	// Counts of ProviderConfigs no longer used should be dropped.
+	objs = nil
+	if err := r.Record(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.CollectAndCount(providerConfigObjects); got != 0 {
+		t.Errorf("r.Record(...): want no counts, got %d", got)
+	}
+
+	kube.MockList = test.NewMockListFn(errors.New("boom"))
+	want := errors.Wrap(errors.New("boom"), errListSummarized)
+	if diff := cmp.Diff(want, r.Record(context.Background()), test.EquateErrors()); diff != "" {
+		t.Errorf("r.Record(...): -want error, +got error:\n%s", diff)
+	}
+}
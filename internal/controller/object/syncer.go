@@ -61,10 +61,50 @@ func (p *PatchingResourceSyncer) SyncResource(ctx context.Context, obj *v1alpha2
 	return desired, nil
 }
 
+// DryRunResourceSyncer is a ResourceSyncer that syncs objects just like the
+// PatchingResourceSyncer, but decides whether an object is up-to-date by
+// comparing the current object with the result of a server-side dry-run of the
+// patch it would apply. Since the dry-run goes through defaulting and mutating
+// webhooks on the server, fields populated by them do not show up as a diff.
+type DryRunResourceSyncer struct {
+	PatchingResourceSyncer
+}
+
+// GetObservedState returns the current object without the metadata that
+// changes on every write.
+func (d *DryRunResourceSyncer) GetObservedState(_ context.Context, _ *v1alpha2.Object, current *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return withoutVolatileMetadata(current), nil
+}
+
+// GetDesiredState returns what the object would look like if the manifest was
+// applied, by running a dry run of the patch against the server.
+func (d *DryRunResourceSyncer) GetDesiredState(ctx context.Context, obj *v1alpha2.Object, manifest *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	desired := manifest.DeepCopy()
+	meta.AddAnnotations(desired, map[string]string{
+		v1.LastAppliedConfigAnnotation: string(obj.Spec.ForProvider.Manifest.Raw),
+	})
+	if err := d.client.Patch(ctx, desired, client.Merge, client.DryRunAll); err != nil {
+		return nil, errors.Wrap(CleanErr(err), errDryRunObject)
+	}
+	return withoutVolatileMetadata(desired), nil
+}
+
+// withoutVolatileMetadata returns a copy of the supplied object without the
+// metadata fields that are updated by the server on every write.
+func withoutVolatileMetadata(u *unstructured.Unstructured) *unstructured.Unstructured {
+	out := u.DeepCopy()
+	out.SetResourceVersion("")
+	out.SetManagedFields(nil)
+	return out
+}
+
 // SSAResourceSyncer is a ResourceSyncer that syncs objects by using server-side
 // apply to apply the object's manifest to the Kubernetes API server.
 type SSAResourceSyncer struct {
-	client              client.Client
+	client client.Client
+	// local reads the Objects managing the fields a remote object conflicts
+	// on, if their priorities decide the conflict.
+	local               client.Reader
 	extractor           applymetav1.UnstructuredExtractor
 	desiredStateCacheFn func() state.Cache
 }
@@ -110,7 +150,23 @@ func (s *SSAResourceSyncer) GetDesiredState(ctx context.Context, obj *v1alpha2.O
 
 // SyncResource syncs the supplied object by using server-side apply to apply.
 func (s *SSAResourceSyncer) SyncResource(ctx context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	if err := s.client.Patch(ctx, desired, client.Apply, client.ForceOwnership, client.FieldOwner(ssaFieldOwner(obj.GetName()))); err != nil {
+	// Conflicting fields are applied again with force, if the conflict
+	// policy allows taking them over, so that the conflicts are reported.
+	owner := client.FieldOwner(ssaFieldOwner(obj.GetName()))
+	err := s.client.Patch(ctx, desired, client.Apply, owner)
+	obj.Status.AtProvider.FieldConflicts = nil
+	if causes := conflictCauses(err); len(causes) > 0 {
+		conflicts, takeOver, rerr := resolveConflicts(ctx, s.local, obj, causes)
+		if rerr != nil {
+			return nil, rerr
+		}
+		obj.Status.AtProvider.FieldConflicts = conflicts
+		if !takeOver {
+			return nil, conflictsError(causes)
+		}
+		err = s.client.Patch(ctx, desired, client.Apply, owner, client.ForceOwnership)
+	}
+	if err != nil {
 		return nil, errors.Wrap(CleanErr(err), errCreateObject)
 	}
 	return desired, nil
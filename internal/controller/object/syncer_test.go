@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestDryRunResourceSyncerGetDesiredState(t *testing.T) {
+	type args struct {
+		client client.Client
+	}
+	type want struct {
+		out *unstructured.Unstructured
+		err error
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"FailedToDryRun": {
+			args: args{
+				client: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrap(CleanErr(errBoom), errDryRunObject),
+			},
+		},
+		"Success": {
+			args: args{
+				client: &test.MockClient{
+					MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, opts ...client.PatchOption) error {
+						po := &client.PatchOptions{}
+						po.ApplyOptions(opts)
+						if len(po.DryRun) == 0 {
+							t.Errorf("Patch(...): expected a dry run")
+						}
+						// Defaulted by the server.
+						obj.(*unstructured.Unstructured).SetLabels(map[string]string{"defaulted": "true"})
+						obj.SetResourceVersion("2")
+						return nil
+					},
+				},
+			},
+			want: want{
+				out: externalResource(func(res *unstructured.Unstructured) {
+					res.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: string(externalResourceRaw)})
+					res.SetLabels(map[string]string{"defaulted": "true"})
+				}),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &DryRunResourceSyncer{
+				PatchingResourceSyncer: PatchingResourceSyncer{
+					client: resource.ClientApplicator{Client: tc.args.client},
+				},
+			}
+			got, gotErr := s.GetDesiredState(context.Background(), kubernetesObject(), externalResource())
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("s.GetDesiredState(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, got); diff != "" {
+				t.Errorf("s.GetDesiredState(...): -want out, +got out: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetObjectTemplate    = "cannot get object template"
+	errParseObjectTemplate  = "cannot parse object template"
+	errRenderObjectTemplate = "cannot render object template"
+
+	errParseConnectionTemplate  = "cannot parse connection detail template"
+	errRenderConnectionTemplate = "cannot render connection detail template"
+)
+
+// fieldPathAction matches template actions that start with a field path, e.g.
+// {{ .status.loadBalancer.ingress[0].ip }}, which text/template cannot parse
+// if it indexes into arrays.
+var fieldPathAction = regexp.MustCompile(`({{-?\s*)\.([^\s{}()|"$]+)`)
+
+// renderTemplateRef sets the JSON manifest of the supplied Object by rendering
+// the ObjectTemplate it refers to, if any, with its parameters.
+func (c *external) renderTemplateRef(ctx context.Context, obj *v1alpha2.Object) error {
+	ref := obj.Spec.ForProvider.TemplateRef
+	if ref == nil {
+		return nil
+	}
+
+	ot := &v1alpha2.ObjectTemplate{}
+	err := c.localClient.Get(ctx, types.NamespacedName{Name: ref.Name}, ot)
+	if kerrors.IsNotFound(err) && meta.WasDeleted(obj) && len(obj.Spec.ForProvider.Manifest.Raw) > 0 {
+		// Deleting the remote objects only needs their names, which are
+		// unlikely to have changed since the manifest was last rendered.
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetObjectTemplate)
+	}
+
+	rendered, err := renderObjectTemplate(ot, c.templateParameters(ref.Parameters))
+	if err != nil {
+		return err
+	}
+	raw, err := manifestFromYAML(rendered)
+	if err != nil {
+		return err
+	}
+	obj.Spec.ForProvider.Manifest.Raw = raw
+	return nil
+}
+
+// renderObjectTemplate renders the template of the supplied ObjectTemplate
+// with the supplied parameters, falling back to the defaults of the
+// ObjectTemplate for parameters that are not supplied.
+func renderObjectTemplate(ot *v1alpha2.ObjectTemplate, parameters map[string]string) (string, error) {
+	tmpl, err := template.New(ot.GetName()).Option("missingkey=error").Parse(ot.Spec.Template)
+	if err != nil {
+		return "", errors.Wrap(err, errParseObjectTemplate)
+	}
+
+	values := make(map[string]string, len(ot.Spec.Defaults)+len(parameters))
+	for k, v := range ot.Spec.Defaults {
+		values[k] = v
+	}
+	for k, v := range parameters {
+		values[k] = v
+	}
+
+	b := &strings.Builder{}
+	if err := tmpl.Execute(b, values); err != nil {
+		return "", errors.Wrap(err, errRenderObjectTemplate)
+	}
+	return b.String(), nil
+}
+
+// renderConnectionTemplate renders the supplied connection detail template
+// with the fields of the supplied object. Actions that start with a field path
+// start with the value at that path instead, which must be set.
+func renderConnectionTemplate(tmpl string, obj map[string]any) (string, error) {
+	paved := fieldpath.Pave(obj)
+	funcs := template.FuncMap{
+		"field": paved.GetValue,
+		"b64dec": func(s string) (string, error) {
+			b, err := base64.StdEncoding.DecodeString(s)
+			return string(b), err
+		},
+	}
+	t, err := template.New("connection").Funcs(funcs).Option("missingkey=error").Parse(fieldPathAction.ReplaceAllString(tmpl, `${1}(field "${2}")`))
+	if err != nil {
+		return "", errors.Wrap(err, errParseConnectionTemplate)
+	}
+
+	b := &strings.Builder{}
+	if err := t.Execute(b, obj); err != nil {
+		return "", errors.Wrap(err, errRenderConnectionTemplate)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	testObjectTemplateName = "test-template"
+)
+
+func objectTemplate(tmpl string, defaults map[string]string) func(client.Object) error {
+	return func(obj client.Object) error {
+		*obj.(*v1alpha2.ObjectTemplate) = v1alpha2.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: testObjectTemplateName},
+			Spec: v1alpha2.ObjectTemplateSpec{
+				Template: tmpl,
+				Defaults: defaults,
+			},
+		}
+		return nil
+	}
+}
+
+func TestRenderTemplateRef(t *testing.T) {
+	type want struct {
+		manifest string
+		err      error
+	}
+	cases := map[string]struct {
+		client client.Client
+		obj    *v1alpha2.Object
+		want
+	}{
+		"NoTemplateRef": {
+			obj: kubernetesObject(),
+			want: want{
+				manifest: string(externalResourceRaw),
+			},
+		},
+		"FailedToGetTemplate": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TemplateRef = &v1alpha2.TemplateReference{Name: testObjectTemplateName}
+			}),
+			want: want{
+				err: errors.Wrap(errBoom, errGetObjectTemplate),
+			},
+		},
+		"TemplateGoneWhileDeleting": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, testObjectTemplateName)),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+				obj.Spec.ForProvider.TemplateRef = &v1alpha2.TemplateReference{Name: testObjectTemplateName}
+			}),
+			want: want{
+				manifest: string(externalResourceRaw),
+			},
+		},
+		"MissingParameter": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, objectTemplate("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: {{ .name }}\n", nil)),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TemplateRef = &v1alpha2.TemplateReference{Name: testObjectTemplateName}
+			}),
+			want: want{
+				err: errors.Wrap(errors.New(`template: test-template:4:11: executing "test-template" at <.name>: map has no entry for key "name"`), errRenderObjectTemplate),
+			},
+		},
+		"Success": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, objectTemplate(
+					"apiVersion: v1\nkind: ResourceQuota\nmetadata:\n  name: quota\n  namespace: {{ .namespace }}\nspec:\n  hard:\n    pods: \"{{ .pods }}\"\n",
+					map[string]string{"namespace": "default", "pods": "10"},
+				)),
+			},
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.TemplateRef = &v1alpha2.TemplateReference{
+					Name:       testObjectTemplateName,
+					Parameters: map[string]string{"namespace": "tenant"},
+				}
+			}),
+			want: want{
+				manifest: `{"apiVersion":"v1","kind":"ResourceQuota","metadata":{"name":"quota","namespace":"tenant"},"spec":{"hard":{"pods":"10"}}}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{localClient: tc.client}
+			gotErr := e.renderTemplateRef(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.renderTemplateRef(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.manifest, string(tc.obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("e.renderTemplateRef(...): -want manifest, +got manifest: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRenderConnectionTemplate(t *testing.T) {
+	svc := map[string]any{
+		"spec": map[string]any{
+			"ports": []any{map[string]any{"port": int64(443)}},
+		},
+		"status": map[string]any{
+			"loadBalancer": map[string]any{
+				"ingress": []any{map[string]any{"ip": "10.0.0.1"}},
+			},
+		},
+		"data": map[string]any{"password": "czNjcjN0"},
+	}
+
+	type want struct {
+		rendered string
+		err      bool
+	}
+	cases := map[string]struct {
+		reason string
+		tmpl   string
+		want   want
+	}{
+		"FieldPaths": {
+			reason: "Field paths indexing into arrays should be replaced by their values.",
+			tmpl:   "https://{{ .status.loadBalancer.ingress[0].ip }}:{{ .spec.ports[0].port }}",
+			want:   want{rendered: "https://10.0.0.1:443"},
+		},
+		"Pipeline": {
+			reason: "Field paths should be usable in pipelines.",
+			tmpl:   "{{- .data.password | b64dec -}}",
+			want:   want{rendered: "s3cr3t"},
+		},
+		"Template": {
+			reason: "Other template syntax should be supported.",
+			tmpl:   `{{ if .status.loadBalancer }}{{ printf "%s" (field "status.loadBalancer.ingress[0].ip") }}{{ end }}`,
+			want:   want{rendered: "10.0.0.1"},
+		},
+		"MissingField": {
+			reason: "Rendering should fail if a field is not set.",
+			tmpl:   "{{ .status.loadBalancer.ingress[0].hostname }}",
+			want:   want{err: true},
+		},
+		"InvalidTemplate": {
+			reason: "Rendering should fail if the template cannot be parsed.",
+			tmpl:   "{{ if }}",
+			want:   want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rendered, err := renderConnectionTemplate(tc.tmpl, svc)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nrenderConnectionTemplate(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.rendered, rendered); diff != "" {
+				t.Errorf("\n%s\nrenderConnectionTemplate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
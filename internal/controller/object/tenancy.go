@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	errTenantCredentials       = "cannot use provider config %q: its %s live in namespace %q rather than in the namespace %q of the claim of the Object"
+	errTenantCredentialsSource = "cannot use provider config %q: its %s are read from source %q rather than from a secret in the namespace %q of the claim of the Object"
+	errNoCredentialsSecretRef  = "cannot tell the namespace of the %s: no secret is referenced"
+)
+
+// checkTenantCredentials returns an error if the supplied Object belongs to the
+// claim of a tenant namespace, but the supplied provider config reaches its
+// target cluster with any credentials that are not read from a secret in that
+// namespace, e.g. the injected identity or the filesystem of the provider.
+// Objects of no claim may use any provider config.
+func checkTenantCredentials(ctx context.Context, kube client.Reader, obj *v1alpha2.Object, providerConfig string, pc kconfig.ProviderConfigSpec) error {
+	tenant := obj.GetLabels()[labelKeyClaimNamespace]
+	if tenant == "" {
+		return nil
+	}
+	secrets, err := credentialSecrets(ctx, kube, pc)
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets {
+		if s.source != "" {
+			return errors.Errorf(errTenantCredentialsSource, providerConfig, s.of, s.source, tenant)
+		}
+		if s.namespace != tenant {
+			return errors.Errorf(errTenantCredentials, providerConfig, s.of, s.namespace, tenant)
+		}
+	}
+	return nil
+}
+
+// A credentialSecret is a secret a provider config reaches its target cluster
+// with.
+type credentialSecret struct {
+	// of describes what the secret holds, e.g. credentials.
+	of        string
+	namespace string
+	// source is the source of credentials that are not read from a secret,
+	// e.g. InjectedIdentity. It is empty for credentials read from secrets.
+	source xpv1.CredentialsSource
+}
+
+// credentialSecrets returns the secrets the supplied provider config reaches
+// its target cluster with, including the connection secrets of managed
+// resources it reads its credentials from. Credentials that are not read from
+// a secret are returned with their source.
+func credentialSecrets(ctx context.Context, kube client.Reader, pc kconfig.ProviderConfigSpec) ([]credentialSecret, error) {
+	var secrets []credentialSecret
+	add := func(of string, c kconfig.ProviderCredentials) error {
+		switch c.Source { //nolint:exhaustive // Every other source is not a secret.
+		case xpv1.CredentialsSourceSecret:
+			if c.SecretRef == nil {
+				return errors.Errorf(errNoCredentialsSecretRef, of)
+			}
+			secrets = append(secrets, credentialSecret{of: of, namespace: c.SecretRef.Namespace})
+		case kconfig.CredentialsSourceConnectionSecret:
+			ref, err := kubeclient.ConnectionSecretRef(ctx, kube, c.ConnectionSecretOf)
+			if err != nil {
+				return err
+			}
+			secrets = append(secrets, credentialSecret{of: of, namespace: ref.Namespace})
+		default:
+			secrets = append(secrets, credentialSecret{of: of, source: c.Source})
+		}
+		return nil
+	}
+	if err := add("credentials", pc.Credentials); err != nil {
+		return nil, err
+	}
+	if pc.Identity != nil {
+		if err := add("identity credentials", pc.Identity.ProviderCredentials); err != nil {
+			return nil, err
+		}
+	}
+	if pc.ScopedIdentity != nil {
+		secrets = append(secrets, credentialSecret{of: "scoped identity credentials", namespace: pc.ScopedIdentity.SecretRef.Namespace})
+	}
+	if pc.Tunnel != nil && pc.Tunnel.SSH != nil {
+		secrets = append(secrets, credentialSecret{of: "tunnel credentials", namespace: pc.Tunnel.SSH.PrivateKeySecretRef.Namespace})
+	}
+	return secrets, nil
+}
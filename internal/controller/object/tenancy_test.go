@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestCheckTenantCredentials(t *testing.T) {
+	claimed := func(obj *v1alpha2.Object) {
+		obj.SetLabels(map[string]string{labelKeyClaimNamespace: "tenant"})
+	}
+	secretCredentials := func(namespace string) kconfig.ProviderCredentials {
+		return kconfig.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+				SecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: namespace, Name: "kubeconfig"}, Key: "kubeconfig"},
+			},
+		}
+	}
+	// The managed resource writes its connection secret to the namespace
+	// of the claim.
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, map[string]interface{}{"name": "cluster", "namespace": "tenant"}, "spec", "writeConnectionSecretToRef")
+		}),
+	}
+
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		pc     kconfig.ProviderConfigSpec
+		want   error
+	}{
+		"NoClaim": {
+			reason: "Objects of no claim should use any provider config.",
+			obj:    kubernetesObject(),
+			pc:     kconfig.ProviderConfigSpec{Credentials: secretCredentials("crossplane-system")},
+		},
+		"CredentialsOfClaimNamespace": {
+			reason: "Objects of claims should use provider configs whose credentials live in the namespace of the claim.",
+			obj:    kubernetesObject(claimed),
+			pc: kconfig.ProviderConfigSpec{
+				Credentials: secretCredentials("tenant"),
+				Identity: &kconfig.Identity{
+					Type:                kconfig.IdentityTypeGoogleApplicationCredentials,
+					ProviderCredentials: secretCredentials("tenant"),
+				},
+			},
+		},
+		"InjectedIdentity": {
+			reason: "Objects of claims should not use provider configs that reach their target clusters with the identity of the provider.",
+			obj:    kubernetesObject(claimed),
+			pc:     kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity}},
+			want:   errors.Errorf(errTenantCredentialsSource, providerName, "credentials", xpv1.CredentialsSourceInjectedIdentity, "tenant"),
+		},
+		"FilesystemCredentials": {
+			reason: "Objects of claims should not use provider configs whose credentials are read from the filesystem of the provider.",
+			obj:    kubernetesObject(claimed),
+			pc: kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: "/etc/kubeconfig"}},
+			}},
+			want: errors.Errorf(errTenantCredentialsSource, providerName, "credentials", xpv1.CredentialsSourceFilesystem, "tenant"),
+		},
+		"EnvironmentIdentity": {
+			reason: "Objects of claims should not use provider configs whose identity credentials are read from the environment of the provider.",
+			obj:    kubernetesObject(claimed),
+			pc: kconfig.ProviderConfigSpec{
+				Credentials: secretCredentials("tenant"),
+				Identity: &kconfig.Identity{
+					Type: kconfig.IdentityTypeGoogleApplicationCredentials,
+					ProviderCredentials: kconfig.ProviderCredentials{
+						Source:                    xpv1.CredentialsSourceEnvironment,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "GOOGLE_CREDENTIALS"}},
+					},
+				},
+			},
+			want: errors.Errorf(errTenantCredentialsSource, providerName, "identity credentials", xpv1.CredentialsSourceEnvironment, "tenant"),
+		},
+		"InjectedIdentityOfNoClaim": {
+			reason: "Objects of no claim should use provider configs that reach their target clusters with the identity of the provider.",
+			obj:    kubernetesObject(),
+			pc:     kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity}},
+		},
+		"CredentialsOfOtherNamespace": {
+			reason: "Objects of claims should not use provider configs whose credentials live in another namespace.",
+			obj:    kubernetesObject(claimed),
+			pc:     kconfig.ProviderConfigSpec{Credentials: secretCredentials("crossplane-system")},
+			want:   errors.Errorf(errTenantCredentials, providerName, "credentials", "crossplane-system", "tenant"),
+		},
+		"IdentityOfOtherNamespace": {
+			reason: "Objects of claims should not use provider configs whose identity credentials live in another namespace.",
+			obj:    kubernetesObject(claimed),
+			pc: kconfig.ProviderConfigSpec{
+				Credentials: secretCredentials("tenant"),
+				Identity: &kconfig.Identity{
+					Type:                kconfig.IdentityTypeGoogleApplicationCredentials,
+					ProviderCredentials: secretCredentials("crossplane-system"),
+				},
+			},
+			want: errors.Errorf(errTenantCredentials, providerName, "identity credentials", "crossplane-system", "tenant"),
+		},
+		"ConnectionSecretOfClaimNamespace": {
+			reason: "Objects of claims should use provider configs reading the connection secrets of managed resources written to the namespace of the claim.",
+			obj:    kubernetesObject(claimed),
+			pc: kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{
+				Source:             kconfig.CredentialsSourceConnectionSecret,
+				ConnectionSecretOf: &kconfig.ConnectionSecretSelector{APIVersion: "example.org/v1", Kind: "Cluster", Name: "cluster"},
+			}},
+		},
+		"TunnelOfOtherNamespace": {
+			reason: "Objects of claims should not use provider configs whose tunnel credentials live in another namespace.",
+			obj:    kubernetesObject(claimed),
+			pc: kconfig.ProviderConfigSpec{
+				Credentials: secretCredentials("tenant"),
+				Tunnel: &kconfig.Tunnel{
+					Type: kconfig.TunnelTypeSSH,
+					SSH: &kconfig.SSHTunnel{
+						PrivateKeySecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "bastion"}, Key: "key"},
+					},
+				},
+			},
+			want: errors.Errorf(errTenantCredentials, providerName, "tunnel credentials", "crossplane-system", "tenant"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkTenantCredentials(context.Background(), kube, tc.obj, providerName, tc.pc)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncheckTenantCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+// A createThrottlingReconciler limits the rate at which the Objects of every
+// provider config that are yet to create their remote objects are reconciled
+// to the create rate limit of the provider config. Objects over the limit are
+// requeued once the limit allows them, rather than waited for, so that they do
+// not hold reconcile workers the Objects being observed could use.
+type createThrottlingReconciler struct {
+	inner reconcile.Reconciler
+	kube  client.Reader
+
+	mu       sync.Mutex
+	limiters map[string]*createLimiter
+}
+
+// A createLimiter is the rate limiter of a provider config, along with the
+// create rate limit it was built for.
+type createLimiter struct {
+	limit   kconfig.RateLimit
+	limiter *rate.Limiter
+}
+
+func newCreateThrottlingReconciler(inner reconcile.Reconciler, kube client.Reader) *createThrottlingReconciler {
+	return &createThrottlingReconciler{
+		inner:    inner,
+		kube:     kube,
+		limiters: make(map[string]*createLimiter),
+	}
+}
+
+// Reconcile the supplied request with the inner reconciler, unless its Object
+// is yet to create its remote object and the create rate limit of its provider
+// config does not allow it yet.
+func (r *createThrottlingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	pc, limit := r.createRateLimit(ctx, req)
+	if limit == nil {
+		return r.inner.Reconcile(ctx, req)
+	}
+	if d := r.reserve(pc, *limit, time.Now()); d > 0 {
+		return reconcile.Result{RequeueAfter: d + time.Duration(rand.Int63n(int64(d)))}, nil //nolint:gosec // No need for secure randomness
+	}
+	return r.inner.Reconcile(ctx, req)
+}
+
+// createRateLimit returns the name and create rate limit of the provider
+// config of the Object of the supplied request, if the Object is yet to create
+// its remote object. Any error getting the Object or its provider config is
+// left to the inner reconciler to deal with.
+func (r *createThrottlingReconciler) createRateLimit(ctx context.Context, req reconcile.Request) (string, *kconfig.RateLimit) {
+	obj := &v1alpha2.Object{}
+	if err := r.kube.Get(ctx, req.NamespacedName, obj); err != nil || !pendingCreate(obj) {
+		return "", nil
+	}
+	ref := obj.GetProviderConfigReference()
+	if ref == nil || usesInlineKubeconfig(obj) {
+		return "", nil
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return "", nil
+	}
+	return ref.Name, pc.Spec.CreateRateLimit
+}
+
+// reserve an event of the create rate limiter of the supplied provider config
+// at the supplied time, returning the delay after which it is allowed if it is
+// not allowed yet. Events that are not allowed yet are not reserved.
+func (r *createThrottlingReconciler) reserve(pc string, limit kconfig.RateLimit, now time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.limiters[pc]
+	if !ok || l.limit != limit {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = limit.Rate
+		}
+		l = &createLimiter{limit: limit, limiter: rate.NewLimiter(rate.Limit(limit.Rate), burst)}
+		r.limiters[pc] = l
+	}
+	res := l.limiter.ReserveN(now, 1)
+	d := res.DelayFrom(now)
+	if d > 0 {
+		res.CancelAt(now)
+	}
+	return d
+}
+
+// pendingCreate returns true if the supplied Object is yet to create its
+// remote object, i.e. it neither created nor observed it so far, and it is
+// allowed to create it.
+func pendingCreate(obj *v1alpha2.Object) bool {
+	if meta.WasDeleted(obj) || !meta.GetExternalCreateSucceeded(obj).IsZero() || len(obj.Status.AtProvider.Manifest.Raw) > 0 {
+		return false
+	}
+	return sets.New[xpv1.ManagementAction](obj.GetManagementPolicies()...).HasAny(xpv1.ManagementActionCreate, xpv1.ManagementActionAll)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestCreateThrottlingReconciler(t *testing.T) {
+	// Objects use the limited provider config, which allows one create per
+	// hour, unless they are prefixed with unlimited. Objects prefixed with
+	// existing observed their remote objects already.
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha2.Object:
+				pc := "limited"
+				if strings.HasPrefix(key.Name, "unlimited") {
+					pc = "unlimited"
+				}
+				o.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc}
+				o.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionAll}
+				if strings.HasPrefix(key.Name, "existing") {
+					o.Status.AtProvider.Manifest.Raw = []byte(`{}`)
+				}
+			case *apisv1alpha1.ProviderConfig:
+				if key.Name == "limited" {
+					o.Spec.CreateRateLimit = &kconfig.RateLimit{Rate: 1, Burst: 1}
+				}
+			}
+			return nil
+		},
+	}
+
+	var reconciled []string
+	r := newCreateThrottlingReconciler(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+		reconciled = append(reconciled, req.Name)
+		return reconcile.Result{}, nil
+	}), kube)
+
+	steps := []struct {
+		name      string
+		throttled bool
+	}{
+		{name: "new"},
+		{name: "other-new", throttled: true},
+		{name: "existing"},
+		{name: "unlimited-new"},
+	}
+	for _, s := range steps {
+		reconciled = nil
+		got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: s.name}})
+		if err != nil {
+			t.Fatalf("r.Reconcile(%s): %s", s.name, err)
+		}
+		if s.throttled && (got.RequeueAfter == 0 || len(reconciled) != 0) {
+			t.Errorf("r.Reconcile(%s): Objects over the create rate limit should be requeued without being reconciled", s.name)
+		}
+		if !s.throttled && len(reconciled) != 1 {
+			t.Errorf("r.Reconcile(%s): Objects within the create rate limit, or not creating, should be reconciled", s.name)
+		}
+	}
+}
+
+func TestCreateThrottlingReserve(t *testing.T) {
+	now := time.Now()
+	r := newCreateThrottlingReconciler(nil, nil)
+	limit := kconfig.RateLimit{Rate: 2}
+
+	for i := 0; i < 2; i++ {
+		if d := r.reserve("pc", limit, now); d != 0 {
+			t.Errorf("r.reserve(...): events within the burst should be allowed right away, got delay %s", d)
+		}
+	}
+	if d := r.reserve("pc", limit, now); d != 500*time.Millisecond {
+		t.Errorf("r.reserve(...): events over the burst should be delayed by the rate, got delay %s", d)
+	}
+	// Events that are not allowed yet are not reserved.
+	if d := r.reserve("pc", limit, now.Add(500*time.Millisecond)); d != 0 {
+		t.Errorf("r.reserve(...): delayed events should be allowed after the delay, got delay %s", d)
+	}
+	// A changed limit replaces the rate limiter.
+	if d := r.reserve("pc", kconfig.RateLimit{Rate: 1, Burst: 5}, now.Add(500*time.Millisecond)); d != 0 {
+		t.Errorf("r.reserve(...): events should be allowed by a changed limit, got delay %s", d)
+	}
+}
+
+func TestPendingCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		want   bool
+	}{
+		"New": {
+			reason: "An Object that neither created nor observed its remote object should be pending create.",
+			obj:    kubernetesObject(),
+			want:   true,
+		},
+		"Created": {
+			reason: "An Object that created its remote object should not be pending create.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				meta.SetExternalCreateSucceeded(obj, time.Now())
+			}),
+		},
+		"Observed": {
+			reason: "An Object that observed its remote object should not be pending create.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Status.AtProvider.Manifest.Raw = []byte(`{}`)
+			}),
+		},
+		"Deleted": {
+			reason: "An Object being deleted should not be pending create.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}),
+		},
+		"ObserveOnly": {
+			reason: "An Object that is not allowed to create its remote object should not be pending create.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := pendingCreate(tc.obj); got != tc.want {
+				t.Errorf("\n%s\npendingCreate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	usageAPIVersion = "apiextensions.crossplane.io/v1alpha1"
+	usageKind       = "Usage"
+
+	// inUseLabelKey is set by Crossplane on resources that are used by a
+	// Usage, and hence cannot be deleted.
+	inUseLabelKey = "crossplane.io/in-use"
+
+	// typeInUse is the condition reporting whether an Object is protected
+	// from deletion by a Usage.
+	typeInUse xpv1.ConditionType = "InUse"
+
+	reasonInUse    xpv1.ConditionReason = "InUse"
+	reasonNotInUse xpv1.ConditionReason = "NotInUse"
+
+	// maxUsageNamePrefixLength leaves room for the hash suffix in the name of
+	// a Usage.
+	maxUsageNamePrefixLength = 243
+)
+
+const (
+	errGetUsage    = "cannot get usage for referenced resource"
+	errCreateUsage = "cannot create usage for referenced resource"
+	errDeleteUsage = "cannot delete usage for referenced resource"
+)
+
+// usageFor returns the Usage that protects the resource referenced by the
+// supplied reference from deletion while the supplied Object exists, or nil if
+// the reference cannot be protected by a Usage, i.e. it is namespaced.
+func usageFor(obj *v1alpha2.Object, ref v1alpha2.Reference) *unstructured.Unstructured {
+	if ref.DependsOn == nil && ref.PatchesFrom == nil && ref.PatchesFromConnectionSecret == nil {
+		return nil
+	}
+	refAPIVersion, refKind, refNamespace, refName := getReferenceInfo(ref)
+	if refNamespace != "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(refAPIVersion + "/" + refKind + "/" + refName))
+	prefix := obj.GetName()
+	if len(prefix) > maxUsageNamePrefixLength {
+		prefix = prefix[:maxUsageNamePrefixLength]
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"of": map[string]any{
+				"apiVersion":  refAPIVersion,
+				"kind":        refKind,
+				"resourceRef": map[string]any{"name": refName},
+			},
+			"by": map[string]any{
+				"apiVersion":  v1alpha2.SchemeGroupVersion.String(),
+				"kind":        v1alpha2.ObjectKind,
+				"resourceRef": map[string]any{"name": obj.GetName()},
+			},
+		},
+	}}
+	u.SetAPIVersion(usageAPIVersion)
+	u.SetKind(usageKind)
+	u.SetName(prefix + "-" + hex.EncodeToString(sum[:])[:9])
+	meta.AddOwnerReference(u, meta.AsOwner(meta.TypedReferenceTo(obj, v1alpha2.ObjectGroupVersionKind)))
+	return u
+}
+
+// ensureUsages creates a Usage for every resource referenced by the supplied
+// Object, unless it already exists.
+func (f *objFinalizer) ensureUsages(ctx context.Context, obj *v1alpha2.Object) error {
+	for _, ref := range obj.Spec.References {
+		u := usageFor(obj, ref)
+		if u == nil {
+			continue
+		}
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(u.GroupVersionKind())
+		err := f.client.Get(ctx, types.NamespacedName{Name: u.GetName()}, existing)
+		if err == nil {
+			continue
+		}
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrap(err, errGetUsage)
+		}
+		if err := f.client.Create(ctx, u); err != nil && !kerrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, errCreateUsage)
+		}
+	}
+	return nil
+}
+
+// deleteUsages deletes the Usages of the resources referenced by the supplied
+// Object.
+func (f *objFinalizer) deleteUsages(ctx context.Context, obj *v1alpha2.Object) error {
+	for _, ref := range obj.Spec.References {
+		u := usageFor(obj, ref)
+		if u == nil {
+			continue
+		}
+		if err := resource.IgnoreNotFound(f.client.Delete(ctx, u)); err != nil {
+			return errors.Wrap(err, errDeleteUsage)
+		}
+	}
+	return nil
+}
+
+// setInUseCondition reports whether the supplied Object is protected from
+// deletion by a Usage. The condition is only reported as false if it was
+// previously reported as true.
+func setInUseCondition(obj *v1alpha2.Object) {
+	if obj.GetLabels()[inUseLabelKey] == "true" {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typeInUse,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonInUse,
+			Message:            "Object is used by other resources and cannot be deleted until they are deleted",
+		})
+		return
+	}
+	if obj.GetCondition(typeInUse).Status == v1.ConditionTrue {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typeInUse,
+			Status:             v1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonNotInUse,
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func clusterScopedReferences() []v1alpha2.Reference {
+	return []v1alpha2.Reference{
+		{
+			DependsOn: &v1alpha2.DependsOn{
+				APIVersion: v1alpha2.SchemeGroupVersion.String(),
+				Kind:       v1alpha2.ObjectKind,
+				Name:       testReferenceObjectName,
+			},
+		},
+		{
+			// Namespaced references cannot be protected by a Usage.
+			DependsOn: &v1alpha2.DependsOn{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       testReferenceObjectName,
+				Namespace:  testNamespace,
+			},
+		},
+	}
+}
+
+func TestEnsureUsages(t *testing.T) {
+	type want struct {
+		created []string
+		err     error
+	}
+	cases := map[string]struct {
+		client client.Client
+		want
+	}{
+		"FailedToGetUsage": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetUsage),
+			},
+		},
+		"UsageExists": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			want: want{},
+		},
+		"UsageCreated": {
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+			want: want{
+				created: []string{testReferenceObjectName},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created []string
+			if mc, ok := tc.client.(*test.MockClient); ok {
+				mc.MockCreate = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					u := obj.(*unstructured.Unstructured)
+					of, _, _ := unstructured.NestedString(u.Object, "spec", "of", "resourceRef", "name")
+					by, _, _ := unstructured.NestedString(u.Object, "spec", "by", "resourceRef", "name")
+					if by != testObjectName {
+						t.Errorf("Create(...): expected usage by %q, got %q", testObjectName, by)
+					}
+					created = append(created, of)
+					return nil
+				}
+			}
+			f := &objFinalizer{client: tc.client, usagesEnabled: true}
+			gotErr := f.ensureUsages(context.Background(), kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.References = clusterScopedReferences()
+			}))
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("f.ensureUsages(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("f.ensureUsages(...): -want created, +got created: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetInUseCondition(t *testing.T) {
+	cases := map[string]struct {
+		obj  *v1alpha2.Object
+		want corev1.ConditionStatus
+	}{
+		"NotInUse": {
+			obj:  kubernetesObject(),
+			want: corev1.ConditionUnknown,
+		},
+		"InUse": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetLabels(map[string]string{inUseLabelKey: "true"})
+			}),
+			want: corev1.ConditionTrue,
+		},
+		"NoLongerInUse": {
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.SetConditions(xpv1.Condition{Type: typeInUse, Status: corev1.ConditionTrue})
+			}),
+			want: corev1.ConditionFalse,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setInUseCondition(tc.obj)
+			if diff := cmp.Diff(tc.want, tc.obj.GetCondition(typeInUse).Status); diff != "" {
+				t.Errorf("setInUseCondition(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// typeManifestValid is the condition reporting whether the manifest of
+	// an Object matches the schemas published by its target cluster.
+	typeManifestValid xpv1.ConditionType = "ManifestValid"
+
+	reasonMatchesSchema   xpv1.ConditionReason = "MatchesSchema"
+	reasonSchemaViolation xpv1.ConditionReason = "SchemaViolation"
+
+	errSchemaViolation = "manifest violates the schema published by the target cluster: %s"
+)
+
+// validateManifest validates the supplied manifest of the supplied Object, or
+// every item of its List manifest, against the schemas published by the
+// target cluster, and reports the outcome in the ManifestValid condition. It
+// returns an error if the manifest violates the schemas, so that it is not
+// applied. Manifests whose schemas cannot be fetched, e.g. because their CRD
+// is not installed yet, are not validated.
+func (c *external) validateManifest(obj *v1alpha2.Object, manifest *unstructured.Unstructured) error {
+	if c.validator == nil || meta.WasDeleted(obj) || observeOnly(obj) {
+		return nil
+	}
+	manifests := []*unstructured.Unstructured{manifest}
+	if manifest.IsList() {
+		var err error
+		if _, manifests, err = children(obj, manifest); err != nil {
+			return err
+		}
+	}
+
+	var violations []string
+	for _, m := range manifests {
+		verrs, err := c.validator.Validate(m)
+		if err != nil {
+			c.logger.Debug("Cannot fetch the schema of the manifest, skipping validation", "gvk", m.GroupVersionKind().String(), "error", err)
+			return nil
+		}
+		for _, e := range verrs {
+			violations = append(violations, fmt.Sprintf("%s %s: %s", m.GetKind(), m.GetName(), e.Error()))
+		}
+	}
+
+	if len(violations) == 0 {
+		obj.SetConditions(xpv1.Condition{
+			Type:               typeManifestValid,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonMatchesSchema,
+		})
+		return nil
+	}
+	msg := strings.Join(violations, "; ")
+	obj.SetConditions(xpv1.Condition{
+		Type:               typeManifestValid,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonSchemaViolation,
+		Message:            msg,
+	})
+	return errors.Errorf(errSchemaViolation, msg)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+type validatorFn func(object *unstructured.Unstructured) (typed.ValidationErrors, error)
+
+func (fn validatorFn) Validate(object *unstructured.Unstructured) (typed.ValidationErrors, error) {
+	return fn(object)
+}
+
+func TestValidateManifest(t *testing.T) {
+	unknownField := typed.ValidationErrors{{Path: ".spec.replicass", ErrorMessage: "field not declared in schema"}}
+	msg := "Namespace crossplane-system: .spec.replicass: field not declared in schema"
+
+	type want struct {
+		err  error
+		cond *xpv1.Condition
+	}
+	cases := map[string]struct {
+		validator   ManifestValidator
+		observeOnly bool
+		want        want
+	}{
+		"ValidationDisabled": {},
+		"MatchesSchema": {
+			validator: validatorFn(func(_ *unstructured.Unstructured) (typed.ValidationErrors, error) { return nil, nil }),
+			want: want{
+				cond: &xpv1.Condition{Type: typeManifestValid, Status: v1.ConditionTrue, Reason: reasonMatchesSchema},
+			},
+		},
+		"SchemaViolation": {
+			validator: validatorFn(func(_ *unstructured.Unstructured) (typed.ValidationErrors, error) { return unknownField, nil }),
+			want: want{
+				err:  errors.Errorf(errSchemaViolation, msg),
+				cond: &xpv1.Condition{Type: typeManifestValid, Status: v1.ConditionFalse, Reason: reasonSchemaViolation, Message: msg},
+			},
+		},
+		"SchemaUnavailable": {
+			validator: validatorFn(func(_ *unstructured.Unstructured) (typed.ValidationErrors, error) { return nil, errBoom }),
+		},
+		"ObserveOnly": {
+			validator:   validatorFn(func(_ *unstructured.Unstructured) (typed.ValidationErrors, error) { return unknownField, nil }),
+			observeOnly: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				if tc.observeOnly {
+					obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+				}
+			})
+			manifest, err := parseManifest(obj)
+			if err != nil {
+				t.Fatalf("parseManifest(...): %s", err)
+			}
+			e := &external{logger: logging.NewNopLogger(), validator: tc.validator}
+			err = e.validateManifest(obj, manifest)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("validateManifest(...): -want error, +got error: %s", diff)
+			}
+			var got *xpv1.Condition
+			if c := obj.GetCondition(typeManifestValid); c.Status != v1.ConditionUnknown {
+				got = &c
+			}
+			if diff := cmp.Diff(tc.want.cond, got, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("validateManifest(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
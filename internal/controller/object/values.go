@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetValueConfigMap = "cannot get config map %s/%s of value %s"
+	errGetValueSecret    = "cannot get secret %s/%s of value %s"
+	errGetValueResource  = "cannot get resource %s/%s of value %s"
+	errGetValueField     = "cannot get field %s of value %s"
+	errValueKeyNotFound  = "key %s not found in %s/%s of value %s"
+	errValueNotFound     = "value %s not found"
+	errValueSource       = "value %s must select a config map key, a secret key or a field"
+	errPatchFromValue    = "cannot patch from value"
+)
+
+// resolveValues reads the values of spec.forProvider.valuesFrom of the
+// supplied Object. Values whose source, key or field does not exist are left
+// unset, recording why, so that only the templates and references using them
+// fail, e.g. unless the references are Optional.
+func (c *external) resolveValues(ctx context.Context, obj *v1alpha2.Object) error {
+	c.values, c.valueErrs = nil, nil
+	sources := obj.Spec.ForProvider.ValuesFrom
+	if len(sources) == 0 {
+		return nil
+	}
+	c.values = make(map[string]any, len(sources))
+	c.valueErrs = make(map[string]error)
+	for _, src := range sources {
+		v, err := c.value(ctx, obj, src)
+		if missingReference(err) {
+			c.valueErrs[src.Name] = err
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		c.values[src.Name] = v
+	}
+	return nil
+}
+
+// value reads the value of the supplied source. Errors getting its source keep
+// their API status, so that those that do not exist can be told apart.
+func (c *external) value(ctx context.Context, obj *v1alpha2.Object, src v1alpha2.ValueSource) (any, error) {
+	switch {
+	case src.ConfigMapKeyRef != nil:
+		ref := src.ConfigMapKeyRef
+		cm := &v1.ConfigMap{}
+		if err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, errGetValueConfigMap, ref.Namespace, ref.Name, src.Name)
+		}
+		if v, ok := cm.Data[ref.Key]; ok {
+			return v, nil
+		}
+		if v, ok := cm.BinaryData[ref.Key]; ok {
+			return string(v), nil
+		}
+		return nil, keyNotFoundError{errors.Errorf(errValueKeyNotFound, ref.Key, ref.Namespace, ref.Name, src.Name)}
+	case src.SecretKeyRef != nil:
+		ref := src.SecretKeyRef
+		if err := c.checkSecretNamespace(obj, ref.Namespace, ref.Name); err != nil {
+			return nil, err
+		}
+		s := &v1.Secret{}
+		if err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errGetValueSecret, ref.Namespace, ref.Name, src.Name)
+		}
+		if v, ok := s.Data[ref.Key]; ok {
+			return string(v), nil
+		}
+		return nil, keyNotFoundError{errors.Errorf(errValueKeyNotFound, ref.Key, ref.Namespace, ref.Name, src.Name)}
+	case src.FieldRef != nil && src.FieldRef.FieldPath != nil:
+		ref := src.FieldRef
+		if isSecret(ref.APIVersion, ref.Kind) {
+			if err := c.checkSecretNamespace(obj, ref.Namespace, ref.Name); err != nil {
+				return nil, err
+			}
+		}
+		res := &unstructured.Unstructured{}
+		res.SetAPIVersion(ref.APIVersion)
+		res.SetKind(ref.Kind)
+		if err := c.referenceCache.Get(ctx, c.localClient, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, res); err != nil {
+			return nil, errors.Wrapf(err, errGetValueResource, ref.Namespace, ref.Name, src.Name)
+		}
+		v, err := fieldpath.Pave(res.Object).GetValue(*ref.FieldPath)
+		return v, errors.Wrapf(err, errGetValueField, *ref.FieldPath, src.Name)
+	}
+	return nil, errors.Errorf(errValueSource, src.Name)
+}
+
+// templateParameters returns the values of the Object as template parameters,
+// overridden by the supplied parameters. Values that are not strings are
+// JSON encoded.
+func (c *external) templateParameters(parameters map[string]string) map[string]string {
+	if len(c.values) == 0 {
+		return parameters
+	}
+	out := make(map[string]string, len(c.values)+len(parameters))
+	for k, v := range c.values {
+		if s, ok := v.(string); ok {
+			out[k] = s
+			continue
+		}
+		if b, err := json.Marshal(v); err == nil {
+			out[k] = string(b)
+		}
+	}
+	for k, v := range parameters {
+		out[k] = v
+	}
+	return out
+}
+
+// patchFromValue patches the value the supplied reference refers to to the
+// supplied Object. Values of secrets patched to the manifest are recorded like
+// those of connection secrets, so that they are not kept in the spec.
+func (c *external) patchFromValue(obj *v1alpha2.Object, ref v1alpha2.Reference) error {
+	name := ref.PatchesFromValue.Name
+	v, ok := c.values[name]
+	if !ok {
+		if err, ok := c.valueErrs[name]; ok {
+			return err
+		}
+		return keyNotFoundError{errors.Errorf(errValueNotFound, name)}
+	}
+	if ref.PatchTo == v1alpha2.PatchToConnectionSecret {
+		return c.setReferenceConnectionDetail(ref, v)
+	}
+	if secretValue(obj, name) {
+		c.recordConnectionSecretPatch(obj, ref)
+	}
+	return errors.Wrap(ref.ApplyValuePatch(v, obj), errPatchFromValue)
+}
+
+// secretValue returns true if the value of the supplied name of the supplied
+// Object is read from a Secret.
+func secretValue(obj *v1alpha2.Object, name string) bool {
+	for _, src := range obj.Spec.ForProvider.ValuesFrom {
+		if src.Name != name {
+			continue
+		}
+		return src.ConfigMapKeyRef == nil && (src.SecretKeyRef != nil || src.FieldRef != nil && isSecret(src.FieldRef.APIVersion, src.FieldRef.Kind))
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object/fake"
+)
+
+func TestResolveValues(t *testing.T) {
+	errBoom := errors.New("boom")
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "absent")
+	// The ConfigMap absent does not exist, and reading the one named boom
+	// fails.
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch key.Name {
+			case "absent":
+				return notFound
+			case "boom":
+				return errBoom
+			}
+			switch o := obj.(type) {
+			case *v1.ConfigMap:
+				o.Data = map[string]string{"region": "eu-west-1"}
+			case *v1.Secret:
+				o.Data = map[string][]byte{"password": []byte("s3cr3t")}
+			case *unstructured.Unstructured:
+				o.Object["status"] = map[string]any{"endpoints": []any{"a", "b"}}
+			}
+			return nil
+		},
+	}
+	cm := func(name, key string) *v1alpha2.ManifestKeySelector {
+		return &v1alpha2.ManifestKeySelector{Namespace: testNamespace, Name: name, Key: key}
+	}
+
+	type want struct {
+		values    map[string]any
+		valueErrs map[string]error
+		err       error
+	}
+	cases := map[string]struct {
+		reason  string
+		sources []v1alpha2.ValueSource
+		want    want
+	}{
+		"NoValues": {
+			reason: "No values should be read if the Object has none.",
+		},
+		"Values": {
+			reason: "The values of config map keys, secret keys and fields should be read.",
+			sources: []v1alpha2.ValueSource{
+				{Name: "region", ConfigMapKeyRef: cm("cm", "region")},
+				{Name: "password", SecretKeyRef: cm("secret", "password")},
+				{Name: "endpoints", FieldRef: &v1alpha2.PatchesFrom{
+					DependsOn: v1alpha2.DependsOn{APIVersion: "kubernetes.crossplane.io/v1alpha2", Kind: "Object", Name: "other"},
+					FieldPath: ptr.To("status.endpoints"),
+				}},
+			},
+			want: want{
+				values:    map[string]any{"region": "eu-west-1", "password": "s3cr3t", "endpoints": []any{"a", "b"}},
+				valueErrs: map[string]error{},
+			},
+		},
+		"Missing": {
+			reason: "Values whose source or key does not exist should be unset, recording why.",
+			sources: []v1alpha2.ValueSource{
+				{Name: "absent", ConfigMapKeyRef: cm("absent", "region")},
+				{Name: "key", ConfigMapKeyRef: cm("cm", "zone")},
+			},
+			want: want{
+				values: map[string]any{},
+				valueErrs: map[string]error{
+					"absent": errors.Wrapf(notFound, errGetValueConfigMap, testNamespace, "absent", "absent"),
+					"key":    keyNotFoundError{errors.Errorf(errValueKeyNotFound, "zone", testNamespace, "cm", "key")},
+				},
+			},
+		},
+		"Failing": {
+			reason: "Errors reading sources that may exist should be returned.",
+			sources: []v1alpha2.ValueSource{
+				{Name: "boom", ConfigMapKeyRef: cm("boom", "region")},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetValueConfigMap, testNamespace, "boom", "boom"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ForProvider.ValuesFrom = tc.sources
+			})
+			e := &external{localClient: kube}
+			err := e.resolveValues(context.Background(), obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\ne.resolveValues(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.values, e.values); diff != "" {
+				t.Errorf("\n%s\ne.resolveValues(...): -want values, +got values:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.valueErrs, e.valueErrs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.resolveValues(...): -want value errors, +got value errors:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTemplateParameters(t *testing.T) {
+	e := &external{values: map[string]any{"region": "eu-west-1", "zone": "a", "replicas": int64(3)}}
+	got := e.templateParameters(map[string]string{"zone": "b"})
+	want := map[string]string{"region": "eu-west-1", "zone": "b", "replicas": "3"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.templateParameters(...): values should be overridden by parameters, and encoded as JSON unless they are strings: -want, +got:\n%s", diff)
+	}
+}
+
+func TestResolveReferenciesFromValues(t *testing.T) {
+	fromValue := func(name string, policy v1alpha2.ReferencePolicy) v1alpha2.Reference {
+		return v1alpha2.Reference{
+			PatchesFromValue: &v1alpha2.PatchesFromValue{Name: name},
+			ToFieldPath:      ptr.To("metadata.labels." + name),
+			Policy:           policy,
+		}
+	}
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.References = []v1alpha2.Reference{
+			fromValue("region", ""),
+			fromValue("zone", v1alpha2.ReferencePolicyOptional),
+		}
+	})
+	e := &external{
+		logger:      logging.NewNopLogger(),
+		localClient: &test.MockClient{},
+		values:      map[string]any{"region": "eu-west-1"},
+	}
+	if err := e.resolveReferencies(context.Background(), obj); err != nil {
+		t.Fatalf("e.resolveReferencies(...): %s", err)
+	}
+	m, err := parseManifest(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"region": "eu-west-1"}, m.GetLabels()); diff != "" {
+		t.Errorf("e.resolveReferencies(...): values should be patched: -want labels, +got labels:\n%s", diff)
+	}
+	wantSkipped := []v1alpha2.SkippedReference{{Name: "zone", Message: errors.Errorf(errValueNotFound, "zone").Error()}}
+	if diff := cmp.Diff(wantSkipped, obj.Status.AtProvider.SkippedReferences); diff != "" {
+		t.Errorf("e.resolveReferencies(...): missing values of Optional references should be skipped: -want, +got:\n%s", diff)
+	}
+
+	obj.Spec.References = []v1alpha2.Reference{fromValue("zone", "")}
+	want := keyNotFoundError{errors.Errorf(errValueNotFound, "zone")}
+	if diff := cmp.Diff(want, e.resolveReferencies(context.Background(), obj), test.EquateErrors()); diff != "" {
+		t.Errorf("e.resolveReferencies(...): missing values of Required references should fail: -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestSecretValuesNotPersisted(t *testing.T) {
+	declared := `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"s"},"stringData":{"user":"admin"}}`
+	e := &external{
+		logger: logging.NewNopLogger(),
+		localClient: &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*v1.Secret).Data = map[string][]byte{"password": []byte("s3cr3t")}
+				return nil
+			}),
+		},
+		client: resource.ClientApplicator{Client: &test.MockClient{
+			MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "s")),
+		}},
+	}
+	var applied string
+	e.syncer = &fake.ResourceSyncer{
+		SyncResourceFn: func(_ context.Context, obj *v1alpha2.Object, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+			applied = string(obj.Spec.ForProvider.Manifest.Raw)
+			return desired, nil
+		},
+	}
+	obj := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Manifest.Raw = []byte(declared)
+		obj.Spec.ForProvider.ValuesFrom = []v1alpha2.ValueSource{{
+			Name:         "password",
+			SecretKeyRef: &v1alpha2.ManifestKeySelector{Namespace: "default", Name: "credentials", Key: "password"},
+		}}
+		obj.Spec.References = []v1alpha2.Reference{{
+			PatchesFromValue: &v1alpha2.PatchesFromValue{Name: "password"},
+			ToFieldPath:      ptr.To("stringData.password"),
+		}}
+	})
+
+	if _, err := e.Observe(context.Background(), obj); err != nil {
+		t.Fatalf("e.Observe(...): %s", err)
+	}
+	if diff := cmp.Diff(declared, string(obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+		t.Errorf("e.Observe(...): the values patched from secrets should not be left in the spec: -want, +got:\n%s", diff)
+	}
+	if _, err := e.Create(context.Background(), obj); err != nil {
+		t.Fatalf("e.Create(...): %s", err)
+	}
+	if diff := cmp.Diff(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"s"},"stringData":{"password":"s3cr3t","user":"admin"}}`, applied); diff != "" {
+		t.Errorf("e.Create(...): -want applied manifest, +got applied manifest:\n%s", diff)
+	}
+	if diff := cmp.Diff(declared, string(obj.Spec.ForProvider.Manifest.Raw)); diff != "" {
+		t.Errorf("e.Create(...): the values patched from secrets should not be left in the spec: -want, +got:\n%s", diff)
+	}
+}
@@ -44,6 +44,8 @@ import (
 	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
 	"github.com/crossplane-contrib/provider-kubernetes/apis/observedobjectcollection/v1alpha1"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/features"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/shard"
 	kubeclient "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client"
 )
 
@@ -66,16 +68,20 @@ type Reconciler struct {
 }
 
 // Setup adds a controller that reconciles ObservedObjectCollection resources.
-func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration, s shard.Shard) error {
 	name := managed.ControllerName(v1alpha1.ObservedObjectCollectionGroupKind)
 
+	var opts []kubeclient.BuilderOption
+	if o.Features.Enabled(features.EnableAlphaPortForwardTunnels) {
+		opts = append(opts, kubeclient.WithLocalConfig(mgr.GetConfig()))
+	}
 	r := &Reconciler{
 		client: mgr.GetClient(),
 		log:    o.Logger,
 		pollInterval: func() time.Duration {
 			return o.PollInterval + +time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
 		},
-		clientBuilder:      kubeclient.NewIdentityAwareBuilder(mgr.GetClient()),
+		clientBuilder:      kubeclient.NewIdentityAwareBuilder(mgr.GetClient(), opts...),
 		observedObjectName: observedObjectName,
 	}
 
@@ -83,7 +89,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) err
 		Named(name).
 		For(&v1alpha1.ObservedObjectCollection{}).
 		WithEventFilter(resource.DesiredStateChanged()).
-		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, shard.NewReconciler(xperrors.WithSilentRequeueOnConflict(r), mgr.GetClient(), func() client.Object { return &v1alpha1.ObservedObjectCollection{} }, s), o.GlobalRateLimiter))
 }
 
 // Reconcile fetches objects specified by their GVK and label selector
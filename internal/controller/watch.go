@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	ooc "github.com/crossplane-contrib/provider-kubernetes/apis/observedobjectcollection/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+// LabelKeyClaimNamespace is the label Crossplane sets on composed resources to
+// the namespace of the claim they belong to.
+const LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+
+const (
+	errParseWatchSelector = "cannot parse watch selector"
+	errWatchNamespace     = "invalid watch namespace"
+)
+
+// WatchRestriction returns the cache options restricting the resources this
+// provider reconciles to the Objects and ObservedObjectCollections of claims
+// in the supplied namespace, and to the Objects, ObservedObjectCollections and
+// ProviderConfigs matching the supplied label selector, so that several
+// providers can share a control plane, e.g. one per tenant or environment.
+// Objects and ProviderConfigs are cluster scoped, so the namespace of an
+// Object is the one of its claim. ProviderConfigs are only restricted by the
+// label selector. Nothing is restricted if both are empty.
+func WatchRestriction(namespace, selector string) (map[client.Object]cache.ByObject, error) {
+	if namespace == "" && selector == "" {
+		return nil, nil
+	}
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseWatchSelector)
+	}
+
+	objSel := sel
+	if namespace != "" {
+		r, err := labels.NewRequirement(LabelKeyClaimNamespace, selection.Equals, []string{namespace})
+		if err != nil {
+			return nil, errors.Wrap(err, errWatchNamespace)
+		}
+		objSel = sel.Add(*r)
+	}
+	byObject := map[client.Object]cache.ByObject{
+		&v1alpha2.Object{}:              {Label: objSel},
+		&ooc.ObservedObjectCollection{}: {Label: objSel},
+	}
+	if selector != "" {
+		byObject[&apisv1alpha1.ProviderConfig{}] = cache.ByObject{Label: sel}
+	}
+	return byObject, nil
+}
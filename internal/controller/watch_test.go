@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestWatchRestriction(t *testing.T) {
+	type args struct {
+		namespace string
+		selector  string
+	}
+	type want struct {
+		selectors map[string]string
+		err       error
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Unrestricted": {
+			reason: "Nothing should be restricted without a namespace or selector.",
+		},
+		"Namespace": {
+			reason: "Objects and ObservedObjectCollections should be restricted to those of claims in the namespace.",
+			args:   args{namespace: "tenant-a"},
+			want: want{selectors: map[string]string{
+				"*v1alpha2.Object":                   "crossplane.io/claim-namespace=tenant-a",
+				"*v1alpha1.ObservedObjectCollection": "crossplane.io/claim-namespace=tenant-a",
+			}},
+		},
+		"Selector": {
+			reason: "Objects, ObservedObjectCollections and ProviderConfigs should be restricted to those matching the selector.",
+			args:   args{namespace: "tenant-a", selector: "env in (prod)"},
+			want: want{selectors: map[string]string{
+				"*v1alpha2.Object":                   "crossplane.io/claim-namespace=tenant-a,env in (prod)",
+				"*v1alpha1.ObservedObjectCollection": "crossplane.io/claim-namespace=tenant-a,env in (prod)",
+				"*v1alpha1.ProviderConfig":           "env in (prod)",
+			}},
+		},
+		"InvalidSelector": {
+			reason: "An invalid selector should be an error.",
+			args:   args{selector: "env in prod"},
+			want:   want{err: errors.Wrap(errors.New("unable to parse requirement: found 'prod' expected: '('"), errParseWatchSelector)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := WatchRestriction(tc.args.namespace, tc.args.selector)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWatchRestriction(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.selectors, selectors(got)); diff != "" {
+				t.Errorf("\n%s\nWatchRestriction(...): -want selectors, +got selectors:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func selectors(byObject map[client.Object]cache.ByObject) map[string]string {
+	if byObject == nil {
+		return nil
+	}
+	s := make(map[string]string, len(byObject))
+	for o, b := range byObject {
+		s[fmt.Sprintf("%T", o)] = b.Label.String()
+	}
+	return s
+}
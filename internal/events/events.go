@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events throttles and deduplicates the events recorded by the
+// provider, so that flapping resources do not flood the API server with
+// events.
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+// maxLimiters is the number of resources whose rate limiters are kept before
+// those that are full again are forgotten.
+const maxLimiters = 4096
+
+// Options of event throttling.
+type Options struct {
+	// DedupWindow is how long identical events of a resource are recorded
+	// only once. The first occurrence is recorded right away, and the last
+	// one, if any, at the end of the window. Events are not deduplicated if
+	// zero.
+	DedupWindow time.Duration
+	// Rate is how many distinct events per second are recorded at most for a
+	// resource. Events are not rate limited if zero.
+	Rate float64
+	// Burst is how many distinct events of a resource are recorded at once
+	// before they are rate limited.
+	Burst int
+}
+
+// Enabled returns true if the options throttle or deduplicate events.
+func (o Options) Enabled() bool {
+	return o.DedupWindow > 0 || o.Rate > 0
+}
+
+// A Broadcaster is an event broadcaster whose recorders throttle and
+// deduplicate events.
+type Broadcaster struct {
+	record.EventBroadcaster
+
+	options Options
+}
+
+// NewBroadcaster returns a Broadcaster whose recorders throttle and
+// deduplicate events according to the supplied options.
+func NewBroadcaster(o Options) *Broadcaster {
+	return &Broadcaster{EventBroadcaster: record.NewBroadcaster(), options: o}
+}
+
+// NewRecorder returns a throttling recorder for the supplied event source.
+func (b *Broadcaster) NewRecorder(s *runtime.Scheme, source corev1.EventSource) record.EventRecorderLogger {
+	return NewRecorder(b.EventBroadcaster.NewRecorder(s, source), b.options, clock.RealClock{})
+}
+
+// eventKey identifies identical events.
+type eventKey struct {
+	object    string
+	eventType string
+	reason    string
+	message   string
+}
+
+// An occurrence is the last suppressed occurrence of an event.
+type occurrence struct {
+	object      runtime.Object
+	annotations map[string]string
+	suppressed  bool
+}
+
+// A Recorder records events with another recorder, throttling and
+// deduplicating them.
+type Recorder struct {
+	inner record.EventRecorderLogger
+
+	*throttle
+}
+
+// throttle is the state of a Recorder, shared with the Recorders derived from
+// it with other loggers.
+type throttle struct {
+	options Options
+	clock   clock.WithDelayedExecution
+
+	mu       sync.Mutex
+	seen     map[eventKey]*occurrence
+	limiters map[string]*rate.Limiter
+}
+
+// NewRecorder returns a Recorder that records events with the supplied
+// recorder, throttling and deduplicating them according to the supplied
+// options.
+func NewRecorder(inner record.EventRecorderLogger, o Options, c clock.WithDelayedExecution) *Recorder {
+	return &Recorder{
+		inner: inner,
+		throttle: &throttle{
+			options:  o,
+			clock:    c,
+			seen:     make(map[eventKey]*occurrence),
+			limiters: make(map[string]*rate.Limiter),
+		},
+	}
+}
+
+// WithLogger returns a Recorder that logs with the supplied logger, sharing
+// the throttling and deduplication of this one.
+func (r *Recorder) WithLogger(logger klog.Logger) record.EventRecorderLogger {
+	return &Recorder{inner: r.inner.WithLogger(logger), throttle: r.throttle}
+}
+
+// Event records the supplied event.
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, "%s", message)
+}
+
+// Eventf records the supplied event with a formatted message.
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, messageFmt, args...)
+}
+
+// AnnotatedEventf records the supplied event with a formatted message and the
+// supplied annotations, unless an identical event of the same resource was
+// recorded within the dedup window, or too many distinct events of the
+// resource were recorded recently.
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...any) {
+	k := eventKey{object: objectKey(object), eventType: eventtype, reason: reason, message: fmt.Sprintf(messageFmt, args...)}
+
+	r.mu.Lock()
+	if o, ok := r.seen[k]; ok {
+		o.object, o.annotations, o.suppressed = object, annotations, true
+		r.mu.Unlock()
+		return
+	}
+	if !r.allow(k.object) {
+		r.mu.Unlock()
+		return
+	}
+	if r.options.DedupWindow > 0 {
+		r.seen[k] = &occurrence{}
+		r.clock.AfterFunc(r.options.DedupWindow, func() { r.flush(k) })
+	}
+	r.mu.Unlock()
+
+	r.inner.AnnotatedEventf(object, annotations, eventtype, reason, "%s", k.message)
+}
+
+// flush records the last suppressed occurrence of the supplied event, if any,
+// at the end of its dedup window. It is not rate limited, since it is
+// recorded at most once per dedup window for every event that was.
+func (r *Recorder) flush(k eventKey) {
+	r.mu.Lock()
+	o := r.seen[k]
+	delete(r.seen, k)
+	r.mu.Unlock()
+
+	if o == nil || !o.suppressed {
+		return
+	}
+	r.inner.AnnotatedEventf(o.object, o.annotations, k.eventType, k.reason, "%s", k.message)
+}
+
+// allow returns true if another distinct event of the resource of the
+// supplied key may be recorded. It must be called with the lock held.
+func (r *throttle) allow(object string) bool {
+	if r.options.Rate <= 0 {
+		return true
+	}
+	now := r.clock.Now()
+	l, ok := r.limiters[object]
+	if !ok {
+		if len(r.limiters) >= maxLimiters {
+			for o, l := range r.limiters {
+				// A full limiter is no different from a new one.
+				if l.TokensAt(now) >= float64(l.Burst()) {
+					delete(r.limiters, o)
+				}
+			}
+		}
+		l = rate.NewLimiter(rate.Limit(r.options.Rate), max(r.options.Burst, 1))
+		r.limiters[object] = l
+	}
+	return l.AllowN(now, 1)
+}
+
+// objectKey returns the key of the resource of the supplied object, i.e. its
+// UID, or its kind, namespace and name if it has no UID.
+func objectKey(object runtime.Object) string {
+	m, err := meta.Accessor(object)
+	if err != nil {
+		return ""
+	}
+	if uid := m.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return fmt.Sprintf("%s/%s/%s", object.GetObjectKind().GroupVersionKind().Kind, m.GetNamespace(), m.GetName())
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestRecorder(t *testing.T) {
+	a := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", UID: types.UID("a")}}
+	b := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b", UID: types.UID("b")}}
+
+	type step struct {
+		advance time.Duration
+		object  *corev1.ConfigMap
+		message string
+		want    []string
+	}
+	cases := map[string]struct {
+		reason  string
+		options Options
+		steps   []step
+	}{
+		"Disabled": {
+			reason: "Every event should be recorded if events are neither deduplicated nor rate limited.",
+			steps: []step{
+				{object: a, message: "boom", want: []string{"Warning Failed boom"}},
+				{object: a, message: "boom", want: []string{"Warning Failed boom"}},
+			},
+		},
+		"Deduplicated": {
+			reason:  "Identical events should be recorded once within the dedup window, and the last occurrence at its end.",
+			options: Options{DedupWindow: time.Minute},
+			steps: []step{
+				{object: a, message: "boom", want: []string{"Warning Failed boom"}},
+				{object: a, message: "boom"},
+				{object: a, message: "boom"},
+				{object: a, message: "other", want: []string{"Warning Failed other"}},
+				{object: b, message: "boom", want: []string{"Warning Failed boom"}},
+				// The end of the window records the last occurrence of
+				// the suppressed event only.
+				{advance: time.Minute, want: []string{"Warning Failed boom"}},
+				{object: a, message: "boom", want: []string{"Warning Failed boom"}},
+			},
+		},
+		"RateLimited": {
+			reason:  "Distinct events of a resource beyond the burst should be dropped until the rate allows them again.",
+			options: Options{Rate: 1, Burst: 2},
+			steps: []step{
+				{object: a, message: "one", want: []string{"Warning Failed one"}},
+				{object: a, message: "two", want: []string{"Warning Failed two"}},
+				{object: a, message: "three"},
+				{object: b, message: "one", want: []string{"Warning Failed one"}},
+				{advance: time.Second, object: a, message: "four", want: []string{"Warning Failed four"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inner := record.NewFakeRecorder(10)
+			clock := clocktesting.NewFakeClock(time.Now())
+			r := NewRecorder(inner, tc.options, clock)
+
+			for i, s := range tc.steps {
+				clock.Step(s.advance)
+				if s.object != nil {
+					r.Event(s.object, corev1.EventTypeWarning, "Failed", s.message)
+				}
+				var got []string
+				for len(inner.Events) > 0 {
+					got = append(got, <-inner.Events)
+				}
+				if diff := cmp.Diff(s.want, got); diff != "" {
+					t.Errorf("\n%s\nstep %d: -want events, +got events:\n%s", tc.reason, i, diff)
+				}
+			}
+		})
+	}
+}
@@ -25,4 +25,68 @@ const (
 	EnableAlphaWatches feature.Flag = "EnableAlphaWatches"
 	// EnableAlphaServerSideApply enables alpha support for Server Side Apply.
 	EnableAlphaServerSideApply feature.Flag = "EnableAlphaServerSideApply"
+	// EnableAlphaServerSideDryRun enables alpha support for deciding whether
+	// an Object is up-to-date with a server-side dry-run of its manifest.
+	EnableAlphaServerSideDryRun feature.Flag = "EnableAlphaServerSideDryRun"
+	// EnableAlphaUsages enables alpha support for protecting the resources
+	// referenced by Objects from deletion with Crossplane Usages.
+	EnableAlphaUsages feature.Flag = "EnableAlphaUsages"
+	// EnableAlphaProtobuf enables alpha support for reading the remote objects
+	// of built-in kinds with protobuf encoding.
+	EnableAlphaProtobuf feature.Flag = "EnableAlphaProtobuf"
+	// EnableAlphaSchemaValidation enables alpha support for validating the
+	// manifests of Objects against the OpenAPI schemas published by their
+	// target clusters before applying them.
+	EnableAlphaSchemaValidation feature.Flag = "EnableAlphaSchemaValidation"
+	// EnableAlphaStructuredDiff enables alpha support for reporting the
+	// fields at which the remote objects of Objects differ from their
+	// desired state in the status of the Objects.
+	EnableAlphaStructuredDiff feature.Flag = "EnableAlphaStructuredDiff"
+	// EnableAlphaRequiredPermissions enables alpha support for reporting the
+	// permissions on their target clusters Objects require in the status of
+	// the Objects.
+	EnableAlphaRequiredPermissions feature.Flag = "EnableAlphaRequiredPermissions"
+	// EnableAlphaTenantCredentials enables alpha support for restricting the
+	// Objects of claims to the provider configs whose credentials live in
+	// the namespace of the claim.
+	EnableAlphaTenantCredentials feature.Flag = "EnableAlphaTenantCredentials"
+	// EnableAlphaPortForwardTunnels enables alpha support for reaching target
+	// clusters through tunnels of type PortForward, i.e. through pods of the
+	// control plane.
+	EnableAlphaPortForwardTunnels feature.Flag = "EnableAlphaPortForwardTunnels"
 )
+
+// all are the feature flags that can be enabled by name.
+var all = []feature.Flag{
+	feature.EnableBetaManagementPolicies,
+	EnableAlphaWatches,
+	EnableAlphaServerSideApply,
+	EnableAlphaServerSideDryRun,
+	EnableAlphaUsages,
+	EnableAlphaProtobuf,
+	EnableAlphaSchemaValidation,
+	EnableAlphaStructuredDiff,
+	EnableAlphaRequiredPermissions,
+	EnableAlphaTenantCredentials,
+	EnableAlphaPortForwardTunnels,
+}
+
+// Lookup returns the feature flag of the supplied name, e.g.
+// EnableAlphaWatches, and whether it exists.
+func Lookup(name string) (feature.Flag, bool) {
+	for _, f := range all {
+		if string(f) == name {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the names of all feature flags.
+func Names() []string {
+	names := make([]string, 0, len(all))
+	for _, f := range all {
+		names = append(names, string(f))
+	}
+	return names
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shard shards the resources reconciled by the provider across its
+// replicas, so that large installations can scale reconcile throughput
+// horizontally rather than being reconciled by a single leader.
+package shard
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// LabelKey is the label assigning a resource to the shard of its value
+// explicitly, rather than by the hash of its name.
+const LabelKey = "kubernetes.crossplane.io/shard"
+
+const (
+	errShardCount    = "shard count must be at least 1"
+	errShardIndex    = "shard index must be at least 0 and less than the shard count"
+	errShardHostname = "cannot derive the shard index from hostname %q, which does not end with an ordinal"
+)
+
+// A Shard is the part of the resources a replica of the provider reconciles.
+// The zero value reconciles every resource.
+type Shard struct {
+	// Index of the shard, from 0 to Count-1.
+	Index int
+	// Count is the number of shards.
+	Count int
+}
+
+// New returns the shard of the supplied index out of the supplied count. The
+// index is derived from the ordinal suffix of the supplied hostname, e.g. the
+// name of a StatefulSet pod, if it is negative.
+func New(index, count int, hostname string) (Shard, error) {
+	if count < 1 {
+		return Shard{}, errors.New(errShardCount)
+	}
+	if index < 0 && count > 1 {
+		i := strings.LastIndex(hostname, "-")
+		ordinal, err := strconv.Atoi(hostname[i+1:])
+		if err != nil || ordinal < 0 {
+			return Shard{}, errors.Errorf(errShardHostname, hostname)
+		}
+		index = ordinal
+	}
+	if count == 1 {
+		index = 0
+	}
+	if index >= count {
+		return Shard{}, errors.New(errShardIndex)
+	}
+	return Shard{Index: index, Count: count}, nil
+}
+
+// Enabled returns true if resources are sharded across several replicas.
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns returns true if the supplied resource belongs to the shard, i.e. its
+// shard label, if it is an integer, or else the hash of its name, modulo the
+// shard count is the shard index.
+func (s Shard) Owns(o metav1.Object) bool {
+	if !s.Enabled() {
+		return true
+	}
+	if v, err := strconv.Atoi(o.GetLabels()[LabelKey]); err == nil && v >= 0 {
+		return v%s.Count == s.Index
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(o.GetName()))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index //nolint:gosec // The shard count is positive.
+}
+
+// A Reconciler reconciles only the resources of a shard with another
+// reconciler, ignoring those of other shards.
+type Reconciler struct {
+	inner     reconcile.Reconciler
+	kube      client.Reader
+	newObject func() client.Object
+	shard     Shard
+}
+
+// NewReconciler returns a Reconciler that reconciles the resources of the
+// supplied shard with the supplied reconciler. The resources of requests are
+// read with the supplied reader into objects returned by the supplied
+// function.
+func NewReconciler(inner reconcile.Reconciler, kube client.Reader, newObject func() client.Object, s Shard) reconcile.Reconciler {
+	if !s.Enabled() {
+		return inner
+	}
+	return &Reconciler{inner: inner, kube: kube, newObject: newObject, shard: s}
+}
+
+// Reconcile the supplied request with the inner reconciler, if its resource
+// belongs to the shard. Any error getting the resource is left to the inner
+// reconciler to deal with.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	o := r.newObject()
+	if err := r.kube.Get(ctx, req.NamespacedName, o); err == nil && !r.shard.Owns(o) {
+		return reconcile.Result{}, nil
+	}
+	return r.inner.Reconcile(ctx, req)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		index    int
+		count    int
+		hostname string
+	}
+	type want struct {
+		shard Shard
+		err   error
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Explicit": {
+			reason: "An explicit index should be used as is.",
+			args:   args{index: 2, count: 3, hostname: "provider-kubernetes-0"},
+			want:   want{shard: Shard{Index: 2, Count: 3}},
+		},
+		"FromHostname": {
+			reason: "A negative index should be derived from the ordinal of the hostname.",
+			args:   args{index: -1, count: 3, hostname: "provider-kubernetes-1"},
+			want:   want{shard: Shard{Index: 1, Count: 3}},
+		},
+		"Unsharded": {
+			reason: "A single shard should not need an index.",
+			args:   args{index: -1, count: 1, hostname: "provider-kubernetes-6d4b9c7f5d-x2x7q"},
+			want:   want{shard: Shard{Index: 0, Count: 1}},
+		},
+		"NoOrdinal": {
+			reason: "A hostname without an ordinal should be an error.",
+			args:   args{index: -1, count: 3, hostname: "provider-kubernetes-6d4b9c7f5d-x2x7q"},
+			want:   want{err: errors.Errorf(errShardHostname, "provider-kubernetes-6d4b9c7f5d-x2x7q")},
+		},
+		"IndexOutOfRange": {
+			reason: "An index beyond the shard count should be an error.",
+			args:   args{index: 3, count: 3},
+			want:   want{err: errors.New(errShardIndex)},
+		},
+		"InvalidCount": {
+			reason: "A shard count below one should be an error.",
+			args:   args{index: 0, count: 0},
+			want:   want{err: errors.New(errShardCount)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(tc.args.index, tc.args.count, tc.args.hostname)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNew(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.shard, got); diff != "" {
+				t.Errorf("\n%s\nNew(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOwns(t *testing.T) {
+	named := func(name string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	cases := map[string]struct {
+		reason string
+		shard  Shard
+		object metav1.Object
+		want   bool
+	}{
+		"Unsharded": {
+			reason: "Every resource should belong to the zero shard.",
+			object: named("a", nil),
+			want:   true,
+		},
+		"Label": {
+			reason: "A resource should belong to the shard of its label.",
+			shard:  Shard{Index: 1, Count: 3},
+			object: named("a", map[string]string{LabelKey: "4"}),
+			want:   true,
+		},
+		"LabelOfOtherShard": {
+			reason: "A resource should not belong to a shard other than the one of its label.",
+			shard:  Shard{Index: 1, Count: 3},
+			object: named("a", map[string]string{LabelKey: "2"}),
+			want:   false,
+		},
+		"Hash": {
+			reason: "A resource without a valid shard label should belong to the shard of the hash of its name.",
+			shard:  Shard{Index: 1, Count: 2},
+			object: named("b", map[string]string{LabelKey: "any"}),
+			want:   true,
+		},
+		"HashOfOtherShard": {
+			reason: "A resource should not belong to a shard other than the one of the hash of its name.",
+			shard:  Shard{Index: 0, Count: 2},
+			object: named("b", nil),
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.shard.Owns(tc.object)); diff != "" {
+				t.Errorf("\n%s\ns.Owns(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		labels map[string]string
+		getErr error
+		want   bool
+	}{
+		"Owned": {
+			reason: "Resources of the shard should be reconciled.",
+			labels: map[string]string{LabelKey: "1"},
+			want:   true,
+		},
+		"NotOwned": {
+			reason: "Resources of other shards should be ignored.",
+			labels: map[string]string{LabelKey: "0"},
+			want:   false,
+		},
+		"GetError": {
+			reason: "Errors getting the resource should be left to the inner reconciler.",
+			getErr: errors.New("boom"),
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reconciled := false
+			inner := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			})
+			kube := &test.MockClient{MockGet: test.NewMockGetFn(tc.getErr, func(obj client.Object) error {
+				obj.SetLabels(tc.labels)
+				return nil
+			})}
+			r := NewReconciler(inner, kube, func() client.Object { return &corev1.ConfigMap{} }, Shard{Index: 1, Count: 2})
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "a"}}); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, reconciled); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want reconciled, +got reconciled:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
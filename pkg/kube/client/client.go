@@ -24,7 +24,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client/azure"
 	"github.com/crossplane-contrib/provider-kubernetes/pkg/kube/client/gke"
@@ -35,6 +34,8 @@ import (
 
 const (
 	errGetCreds                  = "cannot get credentials"
+	errNoFsPath                  = "no filesystem path was specified for the credentials"
+	errLoadKubeconfig            = "failed to load kubeconfig"
 	errCreateRestConfig          = "cannot create new REST config using provider secret"
 	errExtractGoogleCredentials  = "cannot extract Google Application Credentials"
 	errInjectGoogleCredentials   = "cannot wrap REST client with Google Application Credentials"
@@ -61,13 +62,42 @@ func (fn BuilderFn) KubeForProviderConfig(ctx context.Context, pc kconfig.Provid
 // IdentityAwareBuilder is a Builder that can inject identity credentials into
 // the REST config of a Kubernetes client.
 type IdentityAwareBuilder struct {
-	local client.Client
-	store *token.ReuseSourceStore
+	local    client.Client
+	store    *token.ReuseSourceStore
+	protobuf bool
+	mappers  *RESTMapperCache
+
+	localConfig *rest.Config
+	tunnels     *tunnelStore
+}
+
+// A BuilderOption configures an IdentityAwareBuilder.
+type BuilderOption func(*IdentityAwareBuilder)
+
+// WithProtobuf makes the built clients read objects of built-in kinds with
+// protobuf encoding. See ProtobufClient.
+func WithProtobuf() BuilderOption {
+	return func(b *IdentityAwareBuilder) {
+		b.protobuf = true
+	}
+}
+
+// WithRESTMapperCache makes the clients built for a provider config share the
+// RESTMapper cached for it by the supplied cache. See RESTMapperCache and
+// WithProviderConfigName.
+func WithRESTMapperCache(c *RESTMapperCache) BuilderOption {
+	return func(b *IdentityAwareBuilder) {
+		b.mappers = c
+	}
 }
 
 // NewIdentityAwareBuilder returns a new IdentityAwareBuilder.
-func NewIdentityAwareBuilder(local client.Client) *IdentityAwareBuilder {
-	return &IdentityAwareBuilder{local: local, store: token.NewReuseSourceStore()}
+func NewIdentityAwareBuilder(local client.Client, opts ...BuilderOption) *IdentityAwareBuilder {
+	b := &IdentityAwareBuilder{local: local, store: token.NewReuseSourceStore(), tunnels: newTunnelStore()}
+	for _, o := range opts {
+		o(b)
+	}
+	return b
 }
 
 // KubeForProviderConfig returns the kube client and *rest.config for the given
@@ -77,10 +107,19 @@ func (b *IdentityAwareBuilder) KubeForProviderConfig(ctx context.Context, pc kco
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "cannot get REST config for provider")
 	}
-	k, err := client.New(rc, client.Options{})
+	o := client.Options{}
+	if name, ok := providerConfigName(ctx); ok && b.mappers != nil {
+		if o.Mapper, err = b.mappers.MapperFor(name, rc); err != nil {
+			return nil, nil, errors.Wrap(err, "cannot create REST mapper for provider")
+		}
+	}
+	k, err := client.New(rc, o)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "cannot create Kubernetes client for provider")
 	}
+	if b.protobuf {
+		k = NewProtobufClient(k)
+	}
 	return k, rc, nil
 }
 
@@ -89,21 +128,45 @@ func (b *IdentityAwareBuilder) restForProviderConfig(ctx context.Context, pc kco
 	var rc *rest.Config
 	var err error
 
-	switch cd := pc.Credentials; cd.Source { //nolint:exhaustive
+	creds := pc.Credentials
+	if pc.ScopedIdentity != nil {
+		creds = scopedCredentials(pc.ScopedIdentity)
+	}
+
+	switch cd := creds; cd.Source { //nolint:exhaustive
 	case xpv1.CredentialsSourceInjectedIdentity:
 		rc, err = rest.InClusterConfig()
 		if err != nil {
 			return nil, errors.Wrap(err, errCreateRestConfig)
 		}
+	case xpv1.CredentialsSourceFilesystem:
+		// A kubeconfig mounted from the filesystem, e.g. by a CSI secret
+		// driver, may refer to certificate and token files next to it.
+		if cd.Fs == nil {
+			return nil, errors.New(errNoFsPath)
+		}
+		ac, err := clientcmd.LoadFromFile(cd.Fs.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, errLoadKubeconfig)
+		}
+		if err := clientcmd.ResolveLocalPaths(ac); err != nil {
+			return nil, errors.Wrap(err, errLoadKubeconfig)
+		}
+		if rc, err = fromAPIConfig(ac); err != nil {
+			return nil, errors.Wrap(err, errCreateRestConfig)
+		}
 	default:
-		kc, err := resource.CommonCredentialExtractor(ctx, cd.Source, b.local, cd.CommonCredentialSelectors)
+		kc, err := b.extractCredentials(ctx, cd)
 		if err != nil {
 			return nil, errors.Wrap(err, errGetCreds)
 		}
 
 		ac, err := clientcmd.Load(kc)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to load kubeconfig")
+			return nil, errors.Wrap(err, errLoadKubeconfig)
+		}
+		if err := b.resolveKubeconfigFiles(ctx, cd, ac); err != nil {
+			return nil, errors.Wrap(err, errLoadKubeconfig)
 		}
 
 		if rc, err = fromAPIConfig(ac); err != nil {
@@ -111,7 +174,9 @@ func (b *IdentityAwareBuilder) restForProviderConfig(ctx context.Context, pc kco
 		}
 	}
 
-	if id := pc.Identity; id != nil {
+	// The tokens of a scoped identity replace the identity, which is only
+	// used to create the scoped identity.
+	if id := pc.Identity; id != nil && pc.ScopedIdentity == nil {
 		switch id.Type {
 		case kconfig.IdentityTypeGoogleApplicationCredentials:
 			switch id.Source { //nolint:exhaustive
@@ -120,7 +185,7 @@ func (b *IdentityAwareBuilder) restForProviderConfig(ctx context.Context, pc kco
 					return nil, errors.Wrap(err, errInjectGoogleCredentials)
 				}
 			default:
-				creds, err := resource.CommonCredentialExtractor(ctx, id.Source, b.local, id.CommonCredentialSelectors)
+				creds, err := b.extractCredentials(ctx, id.ProviderCredentials)
 				if err != nil {
 					return nil, errors.Wrap(err, errExtractGoogleCredentials)
 				}
@@ -135,7 +200,7 @@ func (b *IdentityAwareBuilder) restForProviderConfig(ctx context.Context, pc kco
 				return nil, errors.Errorf("%s is not supported as identity source for identity type %s",
 					xpv1.CredentialsSourceInjectedIdentity, kconfig.IdentityTypeAzureServicePrincipalCredentials)
 			default:
-				creds, err := resource.CommonCredentialExtractor(ctx, id.Source, b.local, id.CommonCredentialSelectors)
+				creds, err := b.extractCredentials(ctx, id.ProviderCredentials)
 				if err != nil {
 					return nil, errors.Wrap(err, errExtractAzureCredentials)
 				}
@@ -150,7 +215,7 @@ func (b *IdentityAwareBuilder) restForProviderConfig(ctx context.Context, pc kco
 				return nil, errors.Errorf("%s is not supported as identity source for identity type %s",
 					xpv1.CredentialsSourceInjectedIdentity, kconfig.IdentityTypeUpboundTokens)
 			default:
-				staticToken, err := resource.CommonCredentialExtractor(ctx, id.Source, b.local, id.CommonCredentialSelectors)
+				staticToken, err := b.extractCredentials(ctx, id.ProviderCredentials)
 				if err != nil {
 					return nil, errors.Wrap(err, errExtractUpboundCredentials)
 				}
@@ -167,6 +232,11 @@ func (b *IdentityAwareBuilder) restForProviderConfig(ctx context.Context, pc kco
 		}
 	}
 
+	withEnv(rc, pc.Env)
+	withRequestIdentity(ctx, rc, pc.RequestIdentity)
+	if err := b.withTunnel(ctx, rc, pc.Tunnel); err != nil {
+		return nil, err
+	}
 	return rc, nil
 }
 
@@ -203,8 +273,11 @@ func fromAPIConfig(c *api.Config) (*rest.Config, error) {
 			Insecure:   cluster.InsecureSkipTLSVerify,
 			ServerName: cluster.TLSServerName,
 			CertData:   user.ClientCertificateData,
+			CertFile:   user.ClientCertificate,
 			KeyData:    user.ClientKeyData,
+			KeyFile:    user.ClientKey,
 			CAData:     cluster.CertificateAuthorityData,
+			CAFile:     cluster.CertificateAuthority,
 		},
 	}
 
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: target
+contexts:
+- name: target
+  context:
+    cluster: target
+    user: target
+clusters:
+- name: target
+  cluster:
+    server: https://target.example.org
+    certificate-authority: ca.crt
+users:
+- name: target
+  user:
+    tokenFile: token
+`
+
+func TestRestForProviderConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_KUBECONFIG", testKubeconfig)
+
+	type want struct {
+		host      string
+		caFile    string
+		caData    string
+		tokenFile string
+		token     string
+		err       error
+	}
+	cases := map[string]struct {
+		creds kconfig.ProviderCredentials
+		// files are the keys of the connection secret other than the
+		// kubeconfig.
+		files map[string][]byte
+		want
+	}{
+		"Filesystem": {
+			creds: kconfig.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: path}},
+			},
+			want: want{
+				host:      "https://target.example.org",
+				caFile:    filepath.Join(dir, "ca.crt"),
+				tokenFile: filepath.Join(dir, "token"),
+			},
+		},
+		"FilesystemWithoutPath": {
+			creds: kconfig.ProviderCredentials{
+				Source: xpv1.CredentialsSourceFilesystem,
+			},
+			want: want{
+				err: errors.New(errNoFsPath),
+			},
+		},
+		"ConnectionSecret": {
+			creds: kconfig.ProviderCredentials{
+				Source: kconfig.CredentialsSourceConnectionSecret,
+				ConnectionSecretOf: &kconfig.ConnectionSecretSelector{
+					APIVersion: "example.org/v1",
+					Kind:       "Cluster",
+					Name:       "target",
+				},
+			},
+			want: want{
+				host:      "https://target.example.org",
+				caFile:    "ca.crt",
+				tokenFile: "token",
+			},
+		},
+		"ConnectionSecretWithFiles": {
+			creds: kconfig.ProviderCredentials{
+				Source: kconfig.CredentialsSourceConnectionSecret,
+				ConnectionSecretOf: &kconfig.ConnectionSecretSelector{
+					APIVersion: "example.org/v1",
+					Kind:       "Cluster",
+					Name:       "target",
+				},
+			},
+			files: map[string][]byte{"ca.crt": []byte("ca"), "token": []byte("secret-token\n")},
+			want: want{
+				host:   "https://target.example.org",
+				caData: "ca",
+				token:  "secret-token",
+			},
+		},
+		"ConnectionSecretWithFilesPath": {
+			creds: kconfig.ProviderCredentials{
+				Source: kconfig.CredentialsSourceConnectionSecret,
+				ConnectionSecretOf: &kconfig.ConnectionSecretSelector{
+					APIVersion: "example.org/v1",
+					Kind:       "Cluster",
+					Name:       "target",
+				},
+				FilesPath: "/etc/target",
+			},
+			files: map[string][]byte{"token": []byte("secret-token")},
+			want: want{
+				host:   "https://target.example.org",
+				caFile: "/etc/target/ca.crt",
+				token:  "secret-token",
+			},
+		},
+		"ConnectionSecretWithoutKey": {
+			creds: kconfig.ProviderCredentials{
+				Source: kconfig.CredentialsSourceConnectionSecret,
+				ConnectionSecretOf: &kconfig.ConnectionSecretSelector{
+					APIVersion: "example.org/v1",
+					Kind:       "Cluster",
+					Name:       "target",
+					Key:        "missing",
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errNoConnectionKey, "missing"), errGetCreds),
+			},
+		},
+		"Environment": {
+			creds: kconfig.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "TEST_KUBECONFIG"}},
+			},
+			want: want{
+				host:      "https://target.example.org",
+				caFile:    "ca.crt",
+				tokenFile: "token",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := NewIdentityAwareBuilder(&test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *unstructured.Unstructured:
+						o.Object["spec"] = map[string]any{"writeConnectionSecretToRef": map[string]any{"namespace": "crossplane-system", "name": "target-conn"}}
+					case *corev1.Secret:
+						if key.Namespace != "crossplane-system" || key.Name != "target-conn" {
+							t.Errorf("Unexpected connection secret %s", key)
+						}
+						o.Data = map[string][]byte{"kubeconfig": []byte(testKubeconfig)}
+						for k, v := range tc.files {
+							o.Data[k] = v
+						}
+					}
+					return nil
+				},
+			})
+			rc, err := b.restForProviderConfig(context.Background(), kconfig.ProviderConfigSpec{Credentials: tc.creds})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("b.restForProviderConfig(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			got := want{host: rc.Host, caFile: rc.CAFile, caData: string(rc.CAData), tokenFile: rc.BearerTokenFile, token: rc.BearerToken}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("b.restForProviderConfig(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
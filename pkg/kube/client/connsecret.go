@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	errNoConnectionSecretOf = "no managed resource was specified for the connection secret credentials"
+	errGetManagedResource   = "cannot get managed resource publishing the connection secret"
+	errNoConnectionSecret   = "managed resource does not write a connection secret"
+	errGetConnectionSecret  = "cannot get connection secret"
+	errNoConnectionKey      = "connection secret has no key %q"
+)
+
+// ConnectionSecretRef returns the reference to the connection secret of the
+// managed resource selected by the supplied selector.
+func ConnectionSecretRef(ctx context.Context, kube client.Reader, sel *kconfig.ConnectionSecretSelector) (*xpv1.SecretReference, error) {
+	if sel == nil {
+		return nil, errors.New(errNoConnectionSecretOf)
+	}
+	mr := &unstructured.Unstructured{}
+	mr.SetAPIVersion(sel.APIVersion)
+	mr.SetKind(sel.Kind)
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}, mr); err != nil {
+		return nil, errors.Wrap(err, errGetManagedResource)
+	}
+	ref := &xpv1.SecretReference{}
+	if err := fieldpath.Pave(mr.Object).GetValueInto("spec.writeConnectionSecretToRef", ref); err != nil || ref.Name == "" {
+		return nil, errors.New(errNoConnectionSecret)
+	}
+	if ref.Namespace == "" {
+		// Namespaced managed resources write their connection secrets to
+		// their own namespace.
+		ref.Namespace = sel.Namespace
+	}
+	return ref, nil
+}
+
+// extractCredentials extracts the supplied credentials, including those read
+// from the connection secret of a managed resource.
+func (b *IdentityAwareBuilder) extractCredentials(ctx context.Context, creds kconfig.ProviderCredentials) ([]byte, error) {
+	if creds.Source != kconfig.CredentialsSourceConnectionSecret {
+		return resource.CommonCredentialExtractor(ctx, creds.Source, b.local, creds.CommonCredentialSelectors)
+	}
+	s, err := b.connectionSecret(ctx, creds.ConnectionSecretOf)
+	if err != nil {
+		return nil, err
+	}
+	key := creds.ConnectionSecretOf.Key
+	if key == "" {
+		key = "kubeconfig"
+	}
+	v, ok := s.Data[key]
+	if !ok {
+		return nil, errors.Errorf(errNoConnectionKey, key)
+	}
+	return v, nil
+}
+
+// connectionSecret returns the connection secret of the managed resource
+// selected by the supplied selector.
+func (b *IdentityAwareBuilder) connectionSecret(ctx context.Context, sel *kconfig.ConnectionSecretSelector) (*corev1.Secret, error) {
+	ref, err := ConnectionSecretRef(ctx, b.local, sel)
+	if err != nil {
+		return nil, err
+	}
+	s := &corev1.Secret{}
+	if err := b.local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetConnectionSecret)
+	}
+	return s, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+// withEnv configures the supplied REST config with the supplied environment
+// variables. The proxy variables among them replace those of the provider,
+// and all of them are passed to the exec plugin of the REST config, if any.
+func withEnv(rc *rest.Config, env []kconfig.EnvVar) {
+	if len(env) == 0 {
+		return
+	}
+
+	pc := &httpproxy.Config{}
+	proxied := false
+	for _, e := range env {
+		switch strings.ToUpper(e.Name) {
+		case "HTTPS_PROXY":
+			pc.HTTPSProxy, proxied = e.Value, true
+		case "HTTP_PROXY":
+			pc.HTTPProxy, proxied = e.Value, true
+		case "NO_PROXY":
+			pc.NoProxy, proxied = e.Value, true
+		}
+	}
+	if proxied {
+		proxy := pc.ProxyFunc()
+		rc.Proxy = func(r *http.Request) (*url.URL, error) {
+			return proxy(r.URL)
+		}
+	}
+
+	if rc.ExecProvider == nil {
+		return
+	}
+	// Variables of the exec plugin in the kubeconfig take precedence.
+	exec := rc.ExecProvider.DeepCopy()
+	set := make(map[string]bool, len(exec.Env))
+	for _, e := range exec.Env {
+		set[e.Name] = true
+	}
+	for _, e := range env {
+		if !set[e.Name] {
+			exec.Env = append(exec.Env, api.ExecEnvVar{Name: e.Name, Value: e.Value})
+		}
+	}
+	rc.ExecProvider = exec
+}
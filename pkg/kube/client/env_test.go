@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestWithEnv(t *testing.T) {
+	env := []kconfig.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.example.org:3128"},
+		{Name: "NO_PROXY", Value: "internal.example.org"},
+		{Name: "TOKEN_FILE", Value: "/var/run/token"},
+	}
+
+	type want struct {
+		proxies map[string]string
+		exec    *api.ExecConfig
+	}
+	cases := map[string]struct {
+		reason string
+		rc     *rest.Config
+		env    []kconfig.EnvVar
+		want   want
+	}{
+		"NoEnv": {
+			reason: "A REST config should be left alone without environment variables.",
+			rc:     &rest.Config{ExecProvider: &api.ExecConfig{Command: "login"}},
+			want: want{
+				exec: &api.ExecConfig{Command: "login"},
+			},
+		},
+		"Env": {
+			reason: "Requests should be proxied according to the proxy variables, and all variables should be passed to the exec plugin, unless it sets them itself.",
+			rc: &rest.Config{ExecProvider: &api.ExecConfig{
+				Command: "login",
+				Env:     []api.ExecEnvVar{{Name: "TOKEN_FILE", Value: "/etc/token"}},
+			}},
+			env: env,
+			want: want{
+				proxies: map[string]string{
+					"https://target.example.org":   "http://proxy.example.org:3128",
+					"https://internal.example.org": "",
+					"http://target.example.org":    "",
+				},
+				exec: &api.ExecConfig{
+					Command: "login",
+					Env: []api.ExecEnvVar{
+						{Name: "TOKEN_FILE", Value: "/etc/token"},
+						{Name: "HTTPS_PROXY", Value: "http://proxy.example.org:3128"},
+						{Name: "NO_PROXY", Value: "internal.example.org"},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			withEnv(tc.rc, tc.env)
+			if tc.want.proxies == nil && tc.rc.Proxy != nil {
+				t.Errorf("\n%s\nwithEnv(...): want no proxy, got one", tc.reason)
+			}
+			for target, want := range tc.want.proxies {
+				u, _ := url.Parse(target)
+				got, err := tc.rc.Proxy(&http.Request{URL: u})
+				if err != nil {
+					t.Fatalf("\n%s\nrc.Proxy(%s): %v", tc.reason, target, err)
+				}
+				gotProxy := ""
+				if got != nil {
+					gotProxy = got.String()
+				}
+				if diff := cmp.Diff(want, gotProxy); diff != "" {
+					t.Errorf("\n%s\nrc.Proxy(%s): -want, +got:\n%s", tc.reason, target, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.exec, tc.rc.ExecProvider); diff != "" {
+				t.Errorf("\n%s\nwithEnv(...): -want exec provider, +got exec provider:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
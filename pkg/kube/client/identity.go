@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"k8s.io/client-go/rest"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+type objectNameKey struct{}
+
+// WithObjectName returns a context whose clients built by an
+// IdentityAwareBuilder identify the Object with the supplied name in the user
+// agent of their requests.
+func WithObjectName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, objectNameKey{}, name)
+}
+
+// withRequestIdentity configures the supplied REST config to identify its
+// requests as the supplied request identity asks for, and as made for the
+// Object of the supplied context, if any.
+func withRequestIdentity(ctx context.Context, rc *rest.Config, id *kconfig.RequestIdentity) {
+	if id != nil && id.UserAgent != "" {
+		rc.UserAgent = id.UserAgent
+	}
+	if id != nil && id.Impersonate != nil {
+		rc.Impersonate = rest.ImpersonationConfig{
+			UserName: id.Impersonate.User,
+			Groups:   id.Impersonate.Groups,
+		}
+	}
+	if name, ok := ctx.Value(objectNameKey{}).(string); ok && name != "" {
+		if rc.UserAgent == "" {
+			rc.UserAgent = rest.DefaultKubernetesUserAgent()
+		}
+		rc.UserAgent += " object/" + name
+	}
+}
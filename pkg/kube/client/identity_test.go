@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/rest"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestWithRequestIdentity(t *testing.T) {
+	kubeconfig := func() *rest.Config {
+		return &rest.Config{Impersonate: rest.ImpersonationConfig{UserName: "kubeconfig"}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		id     *kconfig.RequestIdentity
+		want   *rest.Config
+	}{
+		"NoIdentity": {
+			reason: "A REST config should be left alone without a request identity or Object.",
+			ctx:    context.Background(),
+			want:   kubeconfig(),
+		},
+		"Object": {
+			reason: "The name of the Object should be appended to the default user agent.",
+			ctx:    WithObjectName(context.Background(), "my-object"),
+			want: &rest.Config{
+				UserAgent:   rest.DefaultKubernetesUserAgent() + " object/my-object",
+				Impersonate: rest.ImpersonationConfig{UserName: "kubeconfig"},
+			},
+		},
+		"Identity": {
+			reason: "The configured user agent and impersonated identity should replace those of the kubeconfig, and the Object be appended to the user agent.",
+			ctx:    WithObjectName(context.Background(), "my-object"),
+			id: &kconfig.RequestIdentity{
+				UserAgent:   "provider-kubernetes",
+				Impersonate: &kconfig.Impersonation{User: "batch", Groups: []string{"low-priority"}},
+			},
+			want: &rest.Config{
+				UserAgent:   "provider-kubernetes object/my-object",
+				Impersonate: rest.ImpersonationConfig{UserName: "batch", Groups: []string{"low-priority"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rc := kubeconfig()
+			withRequestIdentity(tc.ctx, rc, tc.id)
+			if diff := cmp.Diff(tc.want, rc); diff != "" {
+				t.Errorf("\n%s\nwithRequestIdentity(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
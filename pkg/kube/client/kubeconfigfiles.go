@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const errGetKubeconfigSecret = "cannot get kubeconfig secret"
+
+// A kubeconfigFile is a file a kubeconfig refers to instead of specifying its
+// data inline.
+type kubeconfigFile struct {
+	path *string
+	set  func(data []byte)
+}
+
+// resolveKubeconfigFiles resolves the files the supplied kubeconfig, read with
+// the supplied credentials, refers to, e.g. the client certificates and keys of
+// path-based kubeconfigs emitted by cluster installers. Files named after a key
+// of the secret the kubeconfig is read from are replaced by the data of that
+// key. Other files are looked up in the files path of the credentials, if any,
+// and are left as is otherwise.
+func (b *IdentityAwareBuilder) resolveKubeconfigFiles(ctx context.Context, creds kconfig.ProviderCredentials, ac *api.Config) error {
+	files := kubeconfigFiles(ac)
+	if len(files) == 0 {
+		return nil
+	}
+	data, err := b.credentialsSecretData(ctx, creds)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		name := filepath.Base(*f.path)
+		if d, ok := data[name]; ok {
+			f.set(d)
+			*f.path = ""
+			continue
+		}
+		if creds.FilesPath != "" {
+			*f.path = filepath.Join(creds.FilesPath, name)
+		}
+	}
+	return nil
+}
+
+// kubeconfigFiles returns the files the supplied kubeconfig refers to without
+// specifying their data inline.
+func kubeconfigFiles(ac *api.Config) []kubeconfigFile {
+	var files []kubeconfigFile
+	for _, c := range ac.Clusters {
+		if c.CertificateAuthority != "" && len(c.CertificateAuthorityData) == 0 {
+			files = append(files, kubeconfigFile{path: &c.CertificateAuthority, set: func(d []byte) { c.CertificateAuthorityData = d }})
+		}
+	}
+	for _, u := range ac.AuthInfos {
+		if u.ClientCertificate != "" && len(u.ClientCertificateData) == 0 {
+			files = append(files, kubeconfigFile{path: &u.ClientCertificate, set: func(d []byte) { u.ClientCertificateData = d }})
+		}
+		if u.ClientKey != "" && len(u.ClientKeyData) == 0 {
+			files = append(files, kubeconfigFile{path: &u.ClientKey, set: func(d []byte) { u.ClientKeyData = d }})
+		}
+		if u.TokenFile != "" && u.Token == "" {
+			files = append(files, kubeconfigFile{path: &u.TokenFile, set: func(d []byte) { u.Token = strings.TrimSpace(string(d)) }})
+		}
+	}
+	return files
+}
+
+// credentialsSecretData returns the data of the secret the supplied
+// credentials are read from, if they are read from a secret.
+func (b *IdentityAwareBuilder) credentialsSecretData(ctx context.Context, creds kconfig.ProviderCredentials) (map[string][]byte, error) {
+	switch creds.Source { //nolint:exhaustive
+	case xpv1.CredentialsSourceSecret:
+		ref := creds.SecretRef
+		if ref == nil {
+			return nil, nil
+		}
+		s := &corev1.Secret{}
+		if err := b.local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrap(err, errGetKubeconfigSecret)
+		}
+		return s.Data, nil
+	case kconfig.CredentialsSourceConnectionSecret:
+		s, err := b.connectionSecret(ctx, creds.ConnectionSecretOf)
+		if err != nil {
+			return nil, err
+		}
+		return s.Data, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errConvertToUnstructured = "cannot convert typed object to unstructured"
+)
+
+// A ProtobufClient is a client.Client that reads unstructured objects of the
+// kinds known to its scheme, i.e. the built-in kinds by default, as typed
+// objects, so that they are transferred with protobuf rather than JSON
+// encoding. Objects of other kinds, e.g. custom resources, which can only be
+// encoded as JSON, and all writes go through the wrapped client unchanged.
+//
+// Fields unknown to the typed objects, e.g. those added by a newer version of
+// the target cluster, are dropped from the objects that are read.
+type ProtobufClient struct {
+	client.Client
+}
+
+// NewProtobufClient returns a ProtobufClient wrapping the supplied client.
+func NewProtobufClient(c client.Client) *ProtobufClient {
+	return &ProtobufClient{Client: c}
+}
+
+// Get reads the supplied unstructured object as a typed object, if its kind
+// is known to the scheme of the wrapped client.
+func (c *ProtobufClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	gvk := u.GroupVersionKind()
+	ro, err := c.Scheme().New(gvk)
+	if err != nil {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	typed, ok := ro.(client.Object)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+
+	if err := c.Client.Get(ctx, key, typed, opts...); err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+	if err != nil {
+		return errors.Wrap(err, errConvertToUnstructured)
+	}
+	u.SetUnstructuredContent(content)
+	u.SetGroupVersionKind(gvk)
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestProtobufClientGet(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		content map[string]any
+		err     error
+	}
+	cases := map[string]struct {
+		gvk schema.GroupVersionKind
+		get test.MockGetFn
+		want
+	}{
+		"BuiltInKind": {
+			gvk: corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+			get: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				cm, ok := obj.(*corev1.ConfigMap)
+				if !ok {
+					t.Fatalf("Expected a typed ConfigMap, got %T", obj)
+				}
+				cm.SetName("cm")
+				cm.Data = map[string]string{"key": "value"}
+				return nil
+			},
+			want: want{
+				content: map[string]any{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]any{
+						"name":              "cm",
+						"creationTimestamp": nil,
+					},
+					"data": map[string]any{"key": "value"},
+				},
+			},
+		},
+		"FailedToGetBuiltInKind": {
+			gvk: corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+			get: test.NewMockGetFn(errBoom),
+			want: want{
+				content: map[string]any{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+				},
+				err: errBoom,
+			},
+		},
+		"CustomResource": {
+			gvk: schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Custom"},
+			get: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				u, ok := obj.(*unstructured.Unstructured)
+				if !ok {
+					t.Fatalf("Expected an unstructured object, got %T", obj)
+				}
+				u.SetName("custom")
+				return nil
+			},
+			want: want{
+				content: map[string]any{
+					"apiVersion": "example.org/v1",
+					"kind":       "Custom",
+					"metadata": map[string]any{
+						"name": "custom",
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewProtobufClient(&test.MockClient{
+				MockGet:    tc.get,
+				MockScheme: test.NewMockSchemeFn(scheme.Scheme),
+			})
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(tc.gvk)
+			err := c.Get(context.Background(), client.ObjectKey{Name: "any"}, u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("c.Get(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.content, u.Object); diff != "" {
+				t.Errorf("c.Get(...): -want content, +got content: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// minMapperAge is how old a cached RESTMapper must be before it is reset,
+// so that Objects whose kinds do not exist do not keep the discovery
+// information of their target cluster from being cached.
+const minMapperAge = 10 * time.Second
+
+// A RESTMapperCache caches a RESTMapper per provider config, so that the
+// discovery information of its target cluster is not fetched again for every
+// client built for it. A cached RESTMapper is reset, and its discovery
+// information fetched again, once it does not know a kind, e.g. of a newly
+// installed CRD, even after discovering the group of the kind again.
+type RESTMapperCache struct {
+	mu        sync.Mutex
+	mappers   map[string]cachedMapper
+	newMapper func(rc *rest.Config) (meta.RESTMapper, error)
+}
+
+// A cachedMapper is the RESTMapper of a provider config, and the target
+// cluster it discovers.
+type cachedMapper struct {
+	cluster string
+	mapper  *resettingMapper
+}
+
+// NewRESTMapperCache returns a new, empty RESTMapperCache.
+func NewRESTMapperCache() *RESTMapperCache {
+	return &RESTMapperCache{
+		mappers: map[string]cachedMapper{},
+		newMapper: func(rc *rest.Config) (meta.RESTMapper, error) {
+			hc, err := rest.HTTPClientFor(rc)
+			if err != nil {
+				return nil, err
+			}
+			return apiutil.NewDynamicRESTMapper(rc, hc)
+		},
+	}
+}
+
+// MapperFor returns the cached RESTMapper of the supplied provider config. The
+// RESTMapper discovers the target cluster with the supplied REST config from
+// now on, so that it uses the latest credentials. The cached RESTMapper is
+// replaced once the supplied REST config targets another cluster.
+func (c *RESTMapperCache) MapperFor(providerConfig string, rc *rest.Config) (meta.RESTMapper, error) {
+	k := mapperKey(rc)
+	c.mu.Lock()
+	cm, ok := c.mappers[providerConfig]
+	if !ok || cm.cluster != k {
+		cm = cachedMapper{cluster: k, mapper: &resettingMapper{newMapper: c.newMapper}}
+		c.mappers[providerConfig] = cm
+	}
+	c.mu.Unlock()
+	return cm.mapper, cm.mapper.setConfig(rc)
+}
+
+// Forget drops the cached RESTMapper of the supplied provider config, e.g.
+// once it was changed or deleted.
+func (c *RESTMapperCache) Forget(providerConfig string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.mappers, providerConfig)
+}
+
+// mapperKey identifies the target cluster of the supplied REST config by its
+// host, its CA and whether its certificate is verified.
+func mapperKey(rc *rest.Config) string {
+	sum := sha256.Sum256(rc.CAData)
+	return rc.Host + "/" + rc.CAFile + "/" + hex.EncodeToString(sum[:]) + "/" + strconv.FormatBool(rc.Insecure)
+}
+
+type providerConfigNameKey struct{}
+
+// WithProviderConfigName returns a context whose clients built by an
+// IdentityAwareBuilder share the RESTMapper cached for the provider config
+// with the supplied name. Clients built without one do not share a RESTMapper.
+func WithProviderConfigName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, providerConfigNameKey{}, name)
+}
+
+// providerConfigName returns the name of the provider config of the supplied
+// context, if any.
+func providerConfigName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(providerConfigNameKey{}).(string)
+	return name, ok && name != ""
+}
+
+// A resettingMapper is a RESTMapper that replaces the RESTMapper it wraps
+// with a new one once it does not know a kind.
+type resettingMapper struct {
+	newMapper func(rc *rest.Config) (meta.RESTMapper, error)
+
+	mu      sync.RWMutex
+	rc      *rest.Config
+	mapper  meta.RESTMapper
+	created time.Time
+}
+
+func (m *resettingMapper) setConfig(rc *rest.Config) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rc = rc
+	if m.mapper != nil {
+		return nil
+	}
+	mapper, err := m.newMapper(rc)
+	if err != nil {
+		return err
+	}
+	m.mapper, m.created = mapper, time.Now()
+	return nil
+}
+
+func (m *resettingMapper) current() meta.RESTMapper {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mapper
+}
+
+// reset replaces the supplied stale RESTMapper with a new one, unless it was
+// already replaced or is too young to be stale, and returns the RESTMapper
+// to use from now on.
+func (m *resettingMapper) reset(stale meta.RESTMapper) (meta.RESTMapper, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mapper != stale {
+		return m.mapper, true
+	}
+	if time.Since(m.created) < minMapperAge {
+		return nil, false
+	}
+	mapper, err := m.newMapper(m.rc)
+	if err != nil {
+		return nil, false
+	}
+	m.mapper, m.created = mapper, time.Now()
+	return mapper, true
+}
+
+// isStale returns true if the supplied error of a RESTMapper may be fixed by
+// discovering the target cluster again from scratch.
+func isStale(err error) bool {
+	var derr *apiutil.ErrResourceDiscoveryFailed
+	return meta.IsNoMatchError(err) || errors.As(err, &derr)
+}
+
+// withReset calls the supplied function with the current RESTMapper, and once
+// more with a new one if it fails with a stale RESTMapper.
+func withReset[T any](m *resettingMapper, fn func(meta.RESTMapper) (T, error)) (T, error) {
+	mapper := m.current()
+	res, err := fn(mapper)
+	if !isStale(err) {
+		return res, err
+	}
+	fresh, ok := m.reset(mapper)
+	if !ok {
+		return res, err
+	}
+	return fn(fresh)
+}
+
+// KindFor implements meta.RESTMapper.
+func (m *resettingMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return withReset(m, func(mapper meta.RESTMapper) (schema.GroupVersionKind, error) { return mapper.KindFor(resource) })
+}
+
+// KindsFor implements meta.RESTMapper.
+func (m *resettingMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return withReset(m, func(mapper meta.RESTMapper) ([]schema.GroupVersionKind, error) { return mapper.KindsFor(resource) })
+}
+
+// ResourceFor implements meta.RESTMapper.
+func (m *resettingMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return withReset(m, func(mapper meta.RESTMapper) (schema.GroupVersionResource, error) { return mapper.ResourceFor(input) })
+}
+
+// ResourcesFor implements meta.RESTMapper.
+func (m *resettingMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return withReset(m, func(mapper meta.RESTMapper) ([]schema.GroupVersionResource, error) { return mapper.ResourcesFor(input) })
+}
+
+// RESTMapping implements meta.RESTMapper.
+func (m *resettingMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return withReset(m, func(mapper meta.RESTMapper) (*meta.RESTMapping, error) { return mapper.RESTMapping(gk, versions...) })
+}
+
+// RESTMappings implements meta.RESTMapper.
+func (m *resettingMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return withReset(m, func(mapper meta.RESTMapper) ([]*meta.RESTMapping, error) { return mapper.RESTMappings(gk, versions...) })
+}
+
+// ResourceSingularizer implements meta.RESTMapper.
+func (m *resettingMapper) ResourceSingularizer(resource string) (string, error) {
+	return m.current().ResourceSingularizer(resource)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func TestRESTMapperCache(t *testing.T) {
+	widget := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Widget"}
+
+	type want struct {
+		mapped  bool
+		created int
+	}
+	cases := map[string]struct {
+		reason string
+		// installed is whether the kind is installed once the mapper is
+		// first created.
+		installed bool
+		age       time.Duration
+		want      want
+	}{
+		"Known": {
+			reason:    "A kind known to the cached mapper should be mapped without discovering the target cluster again.",
+			installed: true,
+			want:      want{mapped: true, created: 1},
+		},
+		"NewlyInstalled": {
+			reason: "A kind unknown to the cached mapper should be mapped once the target cluster was discovered again.",
+			age:    minMapperAge,
+			want:   want{mapped: true, created: 2},
+		},
+		"RecentlyDiscovered": {
+			reason: "A mapper that was created only recently should not be reset.",
+			want:   want{created: 1},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			created := 0
+			c := NewRESTMapperCache()
+			c.newMapper = func(_ *rest.Config) (meta.RESTMapper, error) {
+				m := meta.NewDefaultRESTMapper(nil)
+				if created > 0 || tc.installed {
+					m.Add(widget, meta.RESTScopeNamespace)
+				}
+				created++
+				return m, nil
+			}
+
+			rc := &rest.Config{Host: "https://target.example.org"}
+			m, err := c.MapperFor("default", rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.(*resettingMapper).created = time.Now().Add(-tc.age)
+			again, err := c.MapperFor("default", rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again != m {
+				t.Errorf("\n%s\nc.MapperFor(...): want the cached mapper, got a new one", tc.reason)
+			}
+
+			_, err = m.RESTMapping(widget.GroupKind(), widget.Version)
+			if diff := cmp.Diff(tc.want, want{mapped: err == nil, created: created}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nm.RESTMapping(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRESTMapperCacheSharing(t *testing.T) {
+	target := &rest.Config{Host: "https://target.example.org"}
+	other := &rest.Config{Host: "https://other.example.org"}
+
+	cases := map[string]struct {
+		reason string
+		// forget is whether the RESTMapper of the first provider config is
+		// forgotten before the second RESTMapper is requested.
+		forget bool
+		pc     string
+		rc     *rest.Config
+		want   bool
+	}{
+		"SameProviderConfig": {
+			reason: "Clients of the same provider config and target cluster should share a RESTMapper.",
+			pc:     "default",
+			rc:     target,
+			want:   true,
+		},
+		"OtherProviderConfig": {
+			reason: "Clients of different provider configs should not share a RESTMapper, even if they target the same host, e.g. through tunnels.",
+			pc:     "other",
+			rc:     target,
+		},
+		"OtherTargetCluster": {
+			reason: "A provider config that targets another cluster should get a new RESTMapper.",
+			pc:     "default",
+			rc:     other,
+		},
+		"Forgotten": {
+			reason: "A provider config whose RESTMapper was forgotten should get a new RESTMapper.",
+			forget: true,
+			pc:     "default",
+			rc:     target,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewRESTMapperCache()
+			c.newMapper = func(_ *rest.Config) (meta.RESTMapper, error) {
+				return meta.NewDefaultRESTMapper(nil), nil
+			}
+
+			m, err := c.MapperFor("default", target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.forget {
+				c.Forget("default")
+			}
+			got, err := c.MapperFor(tc.pc, tc.rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got == m); diff != "" {
+				t.Errorf("\n%s\nc.MapperFor(...): -want shared, +got shared:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMapperKey(t *testing.T) {
+	a := mapperKey(&rest.Config{Host: "https://127.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("a")}})
+	b := mapperKey(&rest.Config{Host: "https://127.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("b")}})
+	if a == b {
+		t.Errorf("mapperKey(...): want different keys for clusters with different CAs at the same host, got %q", a)
+	}
+	insecure := mapperKey(&rest.Config{Host: "https://127.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{Insecure: true}})
+	verified := mapperKey(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if insecure == verified {
+		t.Errorf("mapperKey(...): want different keys for insecure and verified clusters at the same host, got %q", insecure)
+	}
+}
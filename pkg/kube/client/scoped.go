@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+// ScopedIdentityKubeconfigKey is the key of the Secret of a scoped identity
+// its kubeconfig is written to.
+const ScopedIdentityKubeconfigKey = "kubeconfig"
+
+// scopedCredentials returns the credentials of the supplied scoped identity,
+// i.e. the kubeconfig written to its Secret.
+func scopedCredentials(si *kconfig.ScopedIdentity) kconfig.ProviderCredentials {
+	return kconfig.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			SecretRef: &xpv1.SecretKeySelector{SecretReference: si.SecretRef, Key: ScopedIdentityKubeconfigKey},
+		},
+	}
+}
+
+// BootstrapSpec returns the supplied provider config spec without its scoped
+// identity, i.e. a spec whose clients use the credentials of the provider
+// config to create its scoped identity.
+func BootstrapSpec(spec kconfig.ProviderConfigSpec) kconfig.ProviderConfigSpec {
+	bootstrap := *spec.DeepCopy()
+	bootstrap.ScopedIdentity = nil
+	return bootstrap
+}
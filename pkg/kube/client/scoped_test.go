@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+func TestScopedCredentials(t *testing.T) {
+	si := &kconfig.ScopedIdentity{Namespace: "apps", SecretRef: xpv1.SecretReference{Namespace: "crossplane-system", Name: "scoped"}}
+	want := kconfig.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			SecretRef: &xpv1.SecretKeySelector{SecretReference: si.SecretRef, Key: ScopedIdentityKubeconfigKey},
+		},
+	}
+	if diff := cmp.Diff(want, scopedCredentials(si)); diff != "" {
+		t.Errorf("scopedCredentials(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestBootstrapSpec(t *testing.T) {
+	spec := kconfig.ProviderConfigSpec{
+		Credentials:    kconfig.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity},
+		ScopedIdentity: &kconfig.ScopedIdentity{Namespace: "apps"},
+	}
+	want := kconfig.ProviderConfigSpec{Credentials: kconfig.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity}}
+	if diff := cmp.Diff(want, BootstrapSpec(spec)); diff != "" {
+		t.Errorf("BootstrapSpec(...): -want, +got:\n%s", diff)
+	}
+	if spec.ScopedIdentity == nil {
+		t.Errorf("BootstrapSpec(...): the supplied spec should not be changed")
+	}
+}
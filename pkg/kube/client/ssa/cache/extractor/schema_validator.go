@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+)
+
+// A SchemaValidator validates objects against the OpenAPI v3 schemas published
+// by a cluster, caching the schemas per GroupVersion like the caching
+// unstructured extractor does.
+type SchemaValidator struct {
+	extractor *cachingUnstructuredExtractor
+}
+
+// NewCachingSchemaValidator returns a new SchemaValidator.
+func NewCachingSchemaValidator(ctx context.Context, dc discovery.DiscoveryInterface, cache *GVKParserCache) *SchemaValidator {
+	return &SchemaValidator{extractor: &cachingUnstructuredExtractor{
+		dc:    dc,
+		cache: cache,
+		ctx:   ctx,
+	}}
+}
+
+// Validate returns the violations of the schema of its kind by the supplied
+// object, e.g. fields that are not declared in the schema. It returns an error
+// if the schema cannot be fetched, and no violations if the cluster does not
+// publish a schema for the kind.
+func (v *SchemaValidator) Validate(object *unstructured.Unstructured) (typed.ValidationErrors, error) {
+	gvk := object.GroupVersionKind()
+	parser, err := v.extractor.getParserForGV(v.extractor.ctx, gvk.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	t := parser.Type(gvk)
+	if t == nil {
+		return nil, nil
+	}
+	_, err = t.FromUnstructured(object.Object)
+	if verrs, ok := err.(typed.ValidationErrors); ok { //nolint:errorlint // FromUnstructured returns them unwrapped.
+		return verrs, nil
+	}
+	return nil, err
+}
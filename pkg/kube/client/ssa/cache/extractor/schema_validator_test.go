@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2024 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		object map[string]any
+		want   []string
+		err    bool
+	}{
+		"Valid": {
+			object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]any{"name": "svc"},
+				"spec": map[string]any{
+					"ports": []any{map[string]any{"port": int64(80), "protocol": "TCP"}},
+				},
+			},
+		},
+		"UnknownField": {
+			object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]any{"name": "svc"},
+				"spec": map[string]any{
+					"portz": []any{map[string]any{"port": int64(80)}},
+				},
+			},
+			want: []string{".spec.portz: field not declared in schema"},
+		},
+		"WrongType": {
+			object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]any{"name": "svc"},
+				"spec": map[string]any{
+					"ports": "80",
+				},
+			},
+			want: []string{`.spec.ports: expected list, got &{80}`},
+		},
+		"UnknownGroupVersion": {
+			object: map[string]any{
+				"apiVersion": "unknown.example.org/v1",
+				"kind":       "Unknown",
+				"metadata":   map[string]any{"name": "unknown"},
+			},
+			err: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			as, err := newMockAPIServer()
+			if err != nil {
+				t.Fatalf("cannot initialize mock API server: %v", err)
+			}
+			defer as.server.Close()
+			dc, err := discovery.NewDiscoveryClientForConfig(&rest.Config{
+				Host: as.server.URL,
+				ContentConfig: rest.ContentConfig{
+					NegotiatedSerializer: scheme.Codecs,
+					GroupVersion:         &appsv1.SchemeGroupVersion,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := NewCachingSchemaValidator(context.TODO(), dc, &GVKParserCache{store: map[schema.GroupVersion]*gvkParserCacheEntry{}})
+
+			verrs, err := v.Validate(&unstructured.Unstructured{Object: tc.object})
+			if tc.err != (err != nil) {
+				t.Fatalf("v.Validate(...): want error %t, got %v", tc.err, err)
+			}
+			var got []string
+			for _, e := range verrs {
+				got = append(got, e.Error())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("v.Validate(...): -want violations, +got violations: %s", diff)
+			}
+		})
+	}
+}
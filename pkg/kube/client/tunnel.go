@@ -0,0 +1,513 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+const (
+	errNoSSHTunnel           = "tunnel of type SSH has no ssh configuration"
+	errNoPortForwardTunnel   = "tunnel of type PortForward has no portForward configuration"
+	errUnknownTunnelType     = "unknown tunnel type: %s"
+	errPortForwardNotAllowed = "tunnels of type PortForward are not enabled"
+	errGetSSHKeySecret       = "cannot get the private key secret of the SSH tunnel"
+	errNoSSHKey              = "the private key secret of the SSH tunnel has no key %s"
+	errParseSSHKey           = "cannot parse the private key of the SSH tunnel"
+	errParseSSHHostKey       = "cannot parse the host key of the SSH tunnel"
+	errDialSSH               = "cannot connect to SSH jump host %s"
+	errDialThroughSSH        = "cannot connect to %s through SSH jump host %s"
+	errCreatePodsClient      = "cannot create the pods client of the PortForward tunnel"
+	errListTunnelPods        = "cannot list the pods of the PortForward tunnel"
+	errNoTunnelPod           = "no running and ready pod in namespace %s matches the selector of the PortForward tunnel"
+	errPortForward           = "cannot port-forward to pod %s/%s"
+	errCreateStream          = "cannot create port-forward stream to pod %s/%s"
+	errForwarded             = "cannot forward to port %d of pod %s/%s: %s"
+)
+
+// sshDialTimeout is how long connecting to an SSH jump host may take.
+const sshDialTimeout = 30 * time.Second
+
+// WithLocalConfig makes the built clients support tunnels of type PortForward,
+// which port-forward to pods of the control plane using the supplied REST
+// config of the control plane. Tunnels of type PortForward are refused
+// without it.
+func WithLocalConfig(rc *rest.Config) BuilderOption {
+	return func(b *IdentityAwareBuilder) {
+		b.localConfig = rc
+	}
+}
+
+// withTunnel configures the supplied REST config to reach the target cluster
+// through the supplied tunnel, if any.
+func (b *IdentityAwareBuilder) withTunnel(ctx context.Context, rc *rest.Config, t *kconfig.Tunnel) error {
+	if t == nil {
+		return nil
+	}
+	switch t.Type {
+	case kconfig.TunnelTypeSSH:
+		if t.SSH == nil {
+			return errors.New(errNoSSHTunnel)
+		}
+		cfg, key, err := b.sshClientConfig(ctx, *t.SSH)
+		if err != nil {
+			return err
+		}
+		b.tunnels.useSSHKey(sshTunnelKey(*t.SSH), key)
+		address := t.SSH.Address
+		rc.Dial = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return b.tunnels.dialSSH(ctx, key, address, cfg, network, addr)
+		}
+	case kconfig.TunnelTypePortForward:
+		if t.PortForward == nil {
+			return errors.New(errNoPortForwardTunnel)
+		}
+		if b.localConfig == nil {
+			return errors.New(errPortForwardNotAllowed)
+		}
+		local, pf := b.localConfig, *t.PortForward
+		rc.Dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return b.tunnels.dialPortForward(ctx, local, pf)
+		}
+	default:
+		return errors.Errorf(errUnknownTunnelType, t.Type)
+	}
+	return nil
+}
+
+// sshClientConfig returns the configuration of the SSH client of the supplied
+// tunnel, and a key identifying its jump host, private key secret and
+// credentials, so that rotating the credentials connects to the jump host
+// again.
+func (b *IdentityAwareBuilder) sshClientConfig(ctx context.Context, t kconfig.SSHTunnel) (*ssh.ClientConfig, string, error) {
+	ref := t.PrivateKeySecretRef
+	s := &corev1.Secret{}
+	if err := b.local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, "", errors.Wrap(err, errGetSSHKeySecret)
+	}
+	pem, ok := s.Data[ref.Key]
+	if !ok {
+		return nil, "", errors.Errorf(errNoSSHKey, ref.Key)
+	}
+	cfg, err := newSSHClientConfig(t.User, pem, t.HostKey)
+	if err != nil {
+		return nil, "", err
+	}
+	h := sha256.New()
+	for _, v := range [][]byte{[]byte(sshTunnelKey(t)), []byte(t.HostKey), pem} {
+		h.Write(v)
+		h.Write([]byte{0})
+	}
+	return cfg, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sshTunnelKey identifies the jump host and private key secret of the supplied
+// tunnel, which keep identifying it when its credentials are rotated.
+func sshTunnelKey(t kconfig.SSHTunnel) string {
+	ref := t.PrivateKeySecretRef
+	return fmt.Sprintf("%s@%s/%s/%s/%s", t.User, t.Address, ref.Namespace, ref.Name, ref.Key)
+}
+
+// newSSHClientConfig returns the configuration of an SSH client logging in as
+// the supplied user with the supplied PEM encoded private key to a jump host
+// with the supplied public key in authorized_keys format.
+func newSSHClientConfig(user string, pem []byte, hostKey string) (*ssh.ClientConfig, error) {
+	signer, err := ssh.ParsePrivateKey(pem)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseSSHKey)
+	}
+	hk, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+	if err != nil {
+		return nil, errors.Wrap(err, errParseSSHHostKey)
+	}
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.FixedHostKey(hk),
+		Timeout:         sshDialTimeout,
+	}, nil
+}
+
+// A tunnelStore shares the connections of tunnels, i.e. SSH connections to jump
+// hosts and port-forward connections to pods, between the clients built for
+// a target cluster. The clients of tunneled target clusters do not share their
+// HTTP transports, so every client connects to the target cluster anew, but
+// only opens a channel or stream of the shared tunnel connection to do so.
+// Tunnel connections are forgotten once they are closed, e.g. because the
+// jump host or pod went away, and established again by the next client.
+type tunnelStore struct {
+	mu       sync.Mutex
+	ssh      map[string]*tunnelConnection[*ssh.Client]
+	forwards map[string]*tunnelConnection[*forwardConnection]
+	// sshKeys are the keys of the SSH connections of SSH tunnels by the
+	// jump host and private key secret they use, so that the connections
+	// using rotated credentials are closed.
+	sshKeys map[string]string
+}
+
+func newTunnelStore() *tunnelStore {
+	return &tunnelStore{
+		ssh:      make(map[string]*tunnelConnection[*ssh.Client]),
+		forwards: make(map[string]*tunnelConnection[*forwardConnection]),
+		sshKeys:  make(map[string]string),
+	}
+}
+
+// A tunnelConnection is a tunnel connection that is being established, or
+// was.
+type tunnelConnection[T any] struct {
+	established chan struct{}
+	conn        T
+	err         error
+	closed      bool
+}
+
+// connect returns the tunnel connection of the supplied key from the supplied
+// connections of the store, establishing it with the supplied dial function if
+// there is none. Connections are established outside of the lock of the store,
+// so that connecting to one jump host or pod does not hold up the clients of
+// the others. Concurrent callers wait for the same connection to be
+// established, or for their context to be done. The connection is forgotten
+// once the supplied wait function returns, i.e. once it is closed.
+func connect[T any](ctx context.Context, s *tunnelStore, conns map[string]*tunnelConnection[T], key string, dial func(context.Context) (T, error), wait func(T), closeConn func(T)) (T, error) {
+	s.mu.Lock()
+	tc, ok := conns[key]
+	if !ok {
+		tc = &tunnelConnection[T]{established: make(chan struct{})}
+		conns[key] = tc
+	}
+	s.mu.Unlock()
+
+	if ok {
+		select {
+		case <-tc.established:
+			return tc.conn, tc.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+
+	tc.conn, tc.err = dial(ctx)
+	s.mu.Lock()
+	forgotten := conns[key] != tc
+	if tc.err != nil && !forgotten {
+		// The next caller connects again.
+		delete(conns, key)
+	}
+	if tc.err == nil && forgotten {
+		// The connection was closed while it was being established,
+		// e.g. because its credentials were rotated.
+		tc.closed = true
+	}
+	s.mu.Unlock()
+	close(tc.established)
+	if tc.err != nil {
+		return tc.conn, tc.err
+	}
+	if tc.closed {
+		closeConn(tc.conn)
+		return tc.conn, tc.err
+	}
+	go func() {
+		wait(tc.conn)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if conns[key] == tc {
+			delete(conns, key)
+		}
+	}()
+	return tc.conn, nil
+}
+
+// dialSSH connects to the supplied address through the SSH jump host of the
+// supplied key, connecting to the jump host first if needed.
+func (s *tunnelStore) dialSSH(ctx context.Context, key, address string, cfg *ssh.ClientConfig, network, addr string) (net.Conn, error) {
+	c, err := connect(ctx, s, s.ssh, key, func(ctx context.Context) (*ssh.Client, error) {
+		return dialSSHClient(ctx, address, cfg)
+	}, func(c *ssh.Client) { _ = c.Wait() }, func(c *ssh.Client) { _ = c.Close() })
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.DialContext(ctx, network, addr)
+	return conn, errors.Wrapf(err, errDialThroughSSH, addr, address)
+}
+
+// useSSHKey records that the SSH tunnel of the supplied jump host and private
+// key secret connects with the SSH connection of the supplied key, and closes
+// the SSH connection it used before, if any, e.g. because its credentials were
+// rotated.
+func (s *tunnelStore) useSSHKey(tunnel, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.sshKeys[tunnel]
+	s.sshKeys[tunnel] = key
+	if !ok || old == key {
+		return
+	}
+	tc, ok := s.ssh[old]
+	if !ok {
+		return
+	}
+	delete(s.ssh, old)
+	select {
+	case <-tc.established:
+		if tc.err == nil {
+			_ = tc.conn.Close()
+		}
+	default:
+		// The connection is closed once it is established.
+	}
+}
+
+// dialSSHClient connects to the SSH jump host of the supplied address, giving
+// up once the supplied context is done.
+func dialSSHClient(ctx context.Context, address string, cfg *ssh.ClientConfig) (*ssh.Client, error) {
+	d := &net.Dialer{Timeout: cfg.Timeout}
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, errDialSSH, address)
+	}
+	if cfg.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(cfg.Timeout))
+	}
+	// The handshake fails once the connection is closed.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, cfg)
+	if !stop() {
+		if err == nil {
+			_ = c.Close()
+		}
+		return nil, errors.Wrapf(ctx.Err(), errDialSSH, address)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, errDialSSH, address)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// dialPortForward connects to the port of a pod of the supplied tunnel,
+// port-forwarding to the pod first if needed.
+func (s *tunnelStore) dialPortForward(ctx context.Context, local *rest.Config, pf kconfig.PortForwardTunnel) (net.Conn, error) {
+	fc, err := connect(ctx, s, s.forwards, forwardKey(pf), func(ctx context.Context) (*forwardConnection, error) {
+		return newForwardConnection(ctx, local, pf)
+	}, func(fc *forwardConnection) { <-fc.conn.CloseChan() }, func(fc *forwardConnection) { _ = fc.conn.Close() })
+	if err != nil {
+		return nil, err
+	}
+	return fc.dial()
+}
+
+// forwardKey identifies the pods and port of the supplied tunnel.
+func forwardKey(pf kconfig.PortForwardTunnel) string {
+	return fmt.Sprintf("%s/%s:%d", pf.Namespace, labels.SelectorFromSet(pf.Selector).String(), pf.Port)
+}
+
+// A forwardConnection is a port-forward connection to a pod.
+type forwardConnection struct {
+	conn      httpstream.Connection
+	namespace string
+	pod       string
+	port      int32
+	requests  atomic.Int64
+}
+
+func newForwardConnection(ctx context.Context, local *rest.Config, pf kconfig.PortForwardTunnel) (*forwardConnection, error) {
+	cs, err := kubernetes.NewForConfig(local)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreatePodsClient)
+	}
+	l, err := cs.CoreV1().Pods(pf.Namespace).List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(pf.Selector).String()})
+	if err != nil {
+		return nil, errors.Wrap(err, errListTunnelPods)
+	}
+	pod := tunnelPod(l.Items)
+	if pod == nil {
+		return nil, errors.Errorf(errNoTunnelPod, pf.Namespace)
+	}
+
+	rt, upgrader, err := spdy.RoundTripperFor(local)
+	if err != nil {
+		return nil, errors.Wrapf(err, errPortForward, pod.Namespace, pod.Name)
+	}
+	u := cs.CoreV1().RESTClient().Post().Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+	conn, _, err := spdy.NewDialer(upgrader, &http.Client{Transport: rt}, http.MethodPost, u).Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, errPortForward, pod.Namespace, pod.Name)
+	}
+	return &forwardConnection{conn: conn, namespace: pod.Namespace, pod: pod.Name, port: pf.Port}, nil
+}
+
+// tunnelPod returns the running and ready pod to forward to among the supplied
+// pods, preferring the oldest one so that every client forwards to the same
+// pod, or nil if there is none.
+func tunnelPod(pods []corev1.Pod) *corev1.Pod {
+	var ready []*corev1.Pod
+	for i := range pods {
+		p := &pods[i]
+		if p.DeletionTimestamp != nil || p.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range p.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				ready = append(ready, p)
+				break
+			}
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		if !ready[i].CreationTimestamp.Equal(&ready[j].CreationTimestamp) {
+			return ready[i].CreationTimestamp.Before(&ready[j].CreationTimestamp)
+		}
+		return ready[i].Name < ready[j].Name
+	})
+	return ready[0]
+}
+
+// dial opens a connection to the port of the pod, as a pair of error and data
+// streams of the port-forward connection.
+func (c *forwardConnection) dial() (net.Conn, error) {
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(int(c.port)))
+	headers.Set(corev1.PortForwardRequestIDHeader, strconv.FormatInt(c.requests.Add(1), 10))
+	errs, err := c.conn.CreateStream(headers)
+	if err != nil {
+		return nil, errors.Wrapf(err, errCreateStream, c.namespace, c.pod)
+	}
+	// Nothing is written to the error stream.
+	_ = errs.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	data, err := c.conn.CreateStream(headers)
+	if err != nil {
+		c.conn.RemoveStreams(errs)
+		return nil, errors.Wrapf(err, errCreateStream, c.namespace, c.pod)
+	}
+
+	sc := &streamConn{Stream: data, errs: errs, fc: c}
+	go sc.watchErrors()
+	return sc, nil
+}
+
+// A streamConn is a connection to a port of a pod through a data stream of a
+// port-forward connection.
+type streamConn struct {
+	httpstream.Stream
+
+	errs httpstream.Stream
+	fc   *forwardConnection
+	once sync.Once
+	mu   sync.Mutex
+	err  error
+}
+
+// watchErrors resets the data stream once the pod reports an error forwarding
+// to its port, e.g. because nothing listens on it.
+func (c *streamConn) watchErrors() {
+	msg, err := io.ReadAll(c.errs)
+	if err != nil || len(msg) == 0 {
+		return
+	}
+	c.mu.Lock()
+	c.err = errors.Errorf(errForwarded, c.fc.port, c.fc.namespace, c.fc.pod, strings.TrimSpace(string(msg)))
+	c.mu.Unlock()
+	_ = c.Stream.Reset()
+}
+
+// Read from the data stream, returning the error the pod reported, if any.
+func (c *streamConn) Read(b []byte) (int, error) {
+	n, err := c.Stream.Read(b)
+	if err != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.err != nil {
+			return n, c.err
+		}
+	}
+	return n, err
+}
+
+// Close the data stream and remove both streams from the port-forward
+// connection.
+func (c *streamConn) Close() error {
+	var err error
+	c.once.Do(func() {
+		err = c.Stream.Reset()
+		c.fc.conn.RemoveStreams(c.Stream, c.errs)
+	})
+	return err
+}
+
+// LocalAddr returns the address of the port-forward connection.
+func (c *streamConn) LocalAddr() net.Addr {
+	return tunnelAddr("port-forward")
+}
+
+// RemoteAddr returns the address of the pod forwarded to.
+func (c *streamConn) RemoteAddr() net.Addr {
+	return tunnelAddr(fmt.Sprintf("%s/%s:%d", c.fc.namespace, c.fc.pod, c.fc.port))
+}
+
+// SetDeadline does nothing. Port-forward streams do not support deadlines.
+func (c *streamConn) SetDeadline(time.Time) error { return nil }
+
+// SetReadDeadline does nothing. Port-forward streams do not support deadlines.
+func (c *streamConn) SetReadDeadline(time.Time) error { return nil }
+
+// SetWriteDeadline does nothing. Port-forward streams do not support deadlines.
+func (c *streamConn) SetWriteDeadline(time.Time) error { return nil }
+
+// A tunnelAddr is the address of an end of a tunneled connection.
+type tunnelAddr string
+
+// Network of the address.
+func (a tunnelAddr) Network() string { return "tunnel" }
+
+// String returns the address.
+func (a tunnelAddr) String() string { return string(a) }
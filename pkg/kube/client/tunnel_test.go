@@ -0,0 +1,410 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	kconfig "github.com/crossplane-contrib/provider-kubernetes/pkg/kube/config"
+)
+
+// sshKeys returns a new signer and its PEM encoded private key.
+func sshKeys(t *testing.T) (ssh.Signer, []byte) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(b)
+}
+
+// keySecret returns a client that gets a Secret with the supplied data.
+func keySecret(data map[string][]byte) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}),
+	}
+}
+
+func TestWithTunnel(t *testing.T) {
+	hostKey, _ := sshKeys(t)
+	_, key := sshKeys(t)
+	sshTunnel := &kconfig.SSHTunnel{
+		Address:             "jump.example.org:22",
+		User:                "tunnel",
+		PrivateKeySecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "ssh", Namespace: "crossplane-system"}, Key: "id_ed25519"},
+		HostKey:             string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())),
+	}
+	portForward := &kconfig.PortForwardTunnel{Namespace: "tunnels", Selector: map[string]string{"app": "tunnel"}, Port: 6443}
+
+	type want struct {
+		dial bool
+		err  error
+	}
+	cases := map[string]struct {
+		reason      string
+		local       client.Client
+		localConfig *rest.Config
+		tunnel      *kconfig.Tunnel
+		want        want
+	}{
+		"NoTunnel": {
+			reason: "The target cluster should be dialed directly without a tunnel.",
+		},
+		"NoSSH": {
+			reason: "An SSH tunnel without its configuration should be refused.",
+			tunnel: &kconfig.Tunnel{Type: kconfig.TunnelTypeSSH},
+			want:   want{err: errors.New(errNoSSHTunnel)},
+		},
+		"NoKey": {
+			reason: "An SSH tunnel whose secret lacks the private key should be refused.",
+			local:  keySecret(map[string][]byte{}),
+			tunnel: &kconfig.Tunnel{Type: kconfig.TunnelTypeSSH, SSH: sshTunnel},
+			want:   want{err: errors.Errorf(errNoSSHKey, "id_ed25519")},
+		},
+		"SSH": {
+			reason: "The target cluster should be dialed through the jump host of an SSH tunnel.",
+			local:  keySecret(map[string][]byte{"id_ed25519": key}),
+			tunnel: &kconfig.Tunnel{Type: kconfig.TunnelTypeSSH, SSH: sshTunnel},
+			want:   want{dial: true},
+		},
+		"NoPortForward": {
+			reason: "A PortForward tunnel without its configuration should be refused.",
+			tunnel: &kconfig.Tunnel{Type: kconfig.TunnelTypePortForward},
+			want:   want{err: errors.New(errNoPortForwardTunnel)},
+		},
+		"PortForwardNotAllowed": {
+			reason: "A PortForward tunnel should be refused if the builder has no REST config of the control plane.",
+			tunnel: &kconfig.Tunnel{Type: kconfig.TunnelTypePortForward, PortForward: portForward},
+			want:   want{err: errors.New(errPortForwardNotAllowed)},
+		},
+		"PortForward": {
+			reason:      "The target cluster should be dialed through a pod of a PortForward tunnel.",
+			localConfig: &rest.Config{Host: "https://control-plane"},
+			tunnel:      &kconfig.Tunnel{Type: kconfig.TunnelTypePortForward, PortForward: portForward},
+			want:        want{dial: true},
+		},
+		"UnknownType": {
+			reason: "A tunnel of an unknown type should be refused.",
+			tunnel: &kconfig.Tunnel{Type: "VPN"},
+			want:   want{err: errors.Errorf(errUnknownTunnelType, "VPN")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := NewIdentityAwareBuilder(tc.local, WithLocalConfig(tc.localConfig))
+			rc := &rest.Config{}
+			err := b.withTunnel(context.Background(), rc, tc.tunnel)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nb.withTunnel(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.dial, rc.Dial != nil); diff != "" {
+				t.Errorf("\n%s\nb.withTunnel(...): -want dial, +got dial:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSSHTunnel(t *testing.T) {
+	hostKey, _ := sshKeys(t)
+	clientKey, key := sshKeys(t)
+	rotatedClientKey, rotatedKey := sshKeys(t)
+
+	// An echo server stands in for the API server of the target cluster.
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+	go func() {
+		for {
+			c, err := target.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+
+	jump, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer jump.Close()
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, k ssh.PublicKey) (*ssh.Permissions, error) {
+			if m := string(k.Marshal()); m != string(clientKey.PublicKey().Marshal()) && m != string(rotatedClientKey.PublicKey().Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+			return &ssh.Permissions{}, nil
+		},
+	}
+	cfg.AddHostKey(hostKey)
+	go serveSSH(jump, cfg)
+
+	data := map[string][]byte{"key": key}
+	b := NewIdentityAwareBuilder(keySecret(data))
+	tunnel := &kconfig.Tunnel{
+		Type: kconfig.TunnelTypeSSH,
+		SSH: &kconfig.SSHTunnel{
+			Address:             jump.Addr().String(),
+			User:                "tunnel",
+			PrivateKeySecretRef: xpv1.SecretKeySelector{Key: "key"},
+			HostKey:             string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())),
+		},
+	}
+	rc := &rest.Config{}
+	if err := b.withTunnel(context.Background(), rc, tunnel); err != nil {
+		t.Fatal(err)
+	}
+
+	echo := func(i int) {
+		t.Helper()
+		conn, err := rc.Dial(context.Background(), "tcp", target.Addr().String())
+		if err != nil {
+			t.Fatalf("rc.Dial(...): %v", err)
+		}
+		defer conn.Close()
+		msg := "hello " + strconv.Itoa(i)
+		if _, err := conn.Write([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, len(msg))
+		_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		if _, err := io.ReadFull(conn, got); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(msg, string(got)); diff != "" {
+			t.Errorf("conn.Read(...): -want, +got:\n%s", diff)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		echo(i)
+	}
+	if diff := cmp.Diff(1, len(b.tunnels.ssh)); diff != "" {
+		t.Errorf("The connections should share the SSH connection to the jump host: -want, +got:\n%s", diff)
+	}
+
+	var old *ssh.Client
+	for _, tc := range b.tunnels.ssh {
+		old = tc.conn
+	}
+	data["key"] = rotatedKey
+	rc = &rest.Config{}
+	if err := b.withTunnel(context.Background(), rc, tunnel); err != nil {
+		t.Fatal(err)
+	}
+	closed := make(chan struct{})
+	go func() {
+		_ = old.Wait()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("The SSH connection using the rotated private key should be closed")
+	}
+	echo(2)
+	if diff := cmp.Diff(1, len(b.tunnels.ssh)); diff != "" {
+		t.Errorf("Only the SSH connection using the current private key should be kept: -want, +got:\n%s", diff)
+	}
+}
+
+func TestSSHTunnelDialContext(t *testing.T) {
+	// A jump host that never completes the SSH handshake.
+	jump, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer jump.Close()
+	go func() {
+		for {
+			c, err := jump.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = c.Close() })
+		}
+	}()
+
+	hostKey, _ := sshKeys(t)
+	_, key := sshKeys(t)
+	cfg, err := newSSHClientConfig("tunnel", key, string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTunnelStore()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := s.dialSSH(ctx, "jump", jump.Addr().String(), cfg, "tcp", "target:443")
+			errs <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("s.dialSSH(...): want context deadline exceeded, got: %v", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("s.dialSSH(...): connecting to the jump host should give up once the context is done")
+		}
+	}
+	if diff := cmp.Diff(0, len(s.ssh)); diff != "" {
+		t.Errorf("s.dialSSH(...): failed SSH connections should be forgotten: -want, +got:\n%s", diff)
+	}
+}
+
+// serveSSH serves the direct-tcpip channels of SSH clients, i.e. forwards
+// their connections.
+func serveSSH(l net.Listener, cfg *ssh.ServerConfig) {
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(c, cfg)
+			if err != nil {
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+			for nc := range chans {
+				if nc.ChannelType() != "direct-tcpip" {
+					_ = nc.Reject(ssh.UnknownChannelType, "unsupported")
+					continue
+				}
+				var to struct {
+					Host     string
+					Port     uint32
+					OrigHost string
+					OrigPort uint32
+				}
+				if err := ssh.Unmarshal(nc.ExtraData(), &to); err != nil {
+					_ = nc.Reject(ssh.ConnectionFailed, err.Error())
+					continue
+				}
+				t, err := net.Dial("tcp", net.JoinHostPort(to.Host, strconv.Itoa(int(to.Port))))
+				if err != nil {
+					_ = nc.Reject(ssh.ConnectionFailed, err.Error())
+					continue
+				}
+				ch, creqs, err := nc.Accept()
+				if err != nil {
+					_ = t.Close()
+					continue
+				}
+				go ssh.DiscardRequests(creqs)
+				go func() {
+					defer ch.Close()
+					defer t.Close()
+					go func() { _, _ = io.Copy(t, ch) }()
+					_, _ = io.Copy(ch, t)
+				}()
+			}
+		}()
+	}
+}
+
+func TestTunnelPod(t *testing.T) {
+	now := metav1.Now()
+	pod := func(name string, created time.Time, phase corev1.PodPhase, ready corev1.ConditionStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	deleting := pod("deleting", now.Add(-time.Hour), corev1.PodRunning, corev1.ConditionTrue)
+	deleting.DeletionTimestamp = &now
+
+	cases := map[string]struct {
+		reason string
+		pods   []corev1.Pod
+		want   string
+	}{
+		"NoPods": {
+			reason: "No pod should be forwarded to if there are none.",
+		},
+		"NoneReady": {
+			reason: "Pods that are not running and ready, or are being deleted, should not be forwarded to.",
+			pods: []corev1.Pod{
+				pod("pending", now.Time, corev1.PodPending, corev1.ConditionFalse),
+				pod("unready", now.Time, corev1.PodRunning, corev1.ConditionFalse),
+				deleting,
+			},
+		},
+		"Oldest": {
+			reason: "The oldest running and ready pod should be forwarded to.",
+			pods: []corev1.Pod{
+				deleting,
+				pod("new", now.Time, corev1.PodRunning, corev1.ConditionTrue),
+				pod("old", now.Add(-time.Minute), corev1.PodRunning, corev1.ConditionTrue),
+			},
+			want: "old",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			if p := tunnelPod(tc.pods); p != nil {
+				got = p.Name
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ntunnelPod(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
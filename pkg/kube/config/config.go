@@ -19,7 +19,12 @@ limitations under the License.
 // +kubebuilder:object:generate=true
 package config
 
-import xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
 
 // IdentityType used to authenticate to the Kubernetes API.
 // +kubebuilder:validation:Enum=GoogleApplicationCredentials;AzureServicePrincipalCredentials;AzureWorkloadIdentityCredentials;UpboundTokens
@@ -36,13 +41,50 @@ const (
 	IdentityTypeUpboundTokens = "UpboundTokens"
 )
 
+// CredentialsSourceConnectionSecret reads the credentials from the connection
+// secret published by a managed resource.
+const CredentialsSourceConnectionSecret xpv1.CredentialsSource = "ConnectionSecret"
+
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
 	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
+	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem;ConnectionSecret
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// ConnectionSecretOf selects the managed resource, e.g. a cluster
+	// provisioned by another provider, whose connection secret the
+	// credentials are read from. Required if the source is ConnectionSecret.
+	// The provider must be allowed to get the managed resource.
+	// +optional
+	ConnectionSecretOf *ConnectionSecretSelector `json:"connectionSecretOf,omitempty"`
+
+	// FilesPath is a directory of the provider's filesystem, e.g. a mounted
+	// volume, containing the files a kubeconfig read from a secret refers to,
+	// e.g. client certificates and keys. Files are looked up by their base
+	// names, first among the keys of the secret the kubeconfig is read from,
+	// then in this directory. Only used for kubeconfig credentials.
+	// +optional
+	FilesPath string `json:"filesPath,omitempty"`
+}
+
+// A ConnectionSecretSelector selects a key of the connection secret of a
+// managed resource.
+type ConnectionSecretSelector struct {
+	// APIVersion of the managed resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the managed resource.
+	Kind string `json:"kind"`
+	// Name of the managed resource.
+	Name string `json:"name"`
+	// Namespace of the managed resource, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Key of the connection secret to read the credentials from.
+	// +optional
+	// +kubebuilder:default=kubeconfig
+	Key string `json:"key,omitempty"`
 }
 
 // Identity used to authenticate.
@@ -63,4 +105,298 @@ type ProviderConfigSpec struct {
 	// example by configuring a bearer token source such as OAuth.
 	// +optional
 	Identity *Identity `json:"identity,omitempty"`
+	// DefaultNamespace is the namespace of namespaced remote objects whose
+	// manifests do not specify one.
+	// +optional
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+	// Attribution stamps the remote objects with labels and annotations
+	// identifying the Object managing them, so that target cluster admins can
+	// trace them back to it.
+	// +optional
+	Attribution *Attribution `json:"attribution,omitempty"`
+	// ObjectDefaults are the defaults of the Objects using this provider
+	// config, e.g. to observe the remote objects of every Object pointing at
+	// a read-only cluster.
+	// +optional
+	ObjectDefaults *ObjectDefaults `json:"objectDefaults,omitempty"`
+	// MaxConcurrentReconciles limits the number of Objects using this provider
+	// config that are reconciled at the same time, so that the Objects of a
+	// large target cluster cannot take up all reconcile workers of the
+	// provider. Objects over the limit are requeued. There is no limit if it is
+	// unset.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+	// CreateRateLimit limits the rate at which the Objects using this provider
+	// config that did not create their remote objects yet are reconciled, so
+	// that onboarding thousands of Objects at once does not overwhelm the
+	// target API server and its admission webhooks. Objects over the limit
+	// are requeued, while the Objects whose remote objects exist are observed
+	// at full speed. There is no limit if it is unset.
+	// +optional
+	CreateRateLimit *RateLimit `json:"createRateLimit,omitempty"`
+	// PermissionChecks are the permissions the credentials must grant on the
+	// target cluster. They are verified with SelfSubjectAccessReviews, and
+	// missing permissions are reported by the MissingPermissions condition,
+	// so that misconfigured credentials are diagnosed before Objects fail.
+	// Permissions are not verified, and no condition is reported, if unset.
+	// +optional
+	PermissionChecks []PermissionCheck `json:"permissionChecks,omitempty"`
+	// Env are environment variables for the clients of the target cluster,
+	// so that target clusters behind different egress paths can be reached
+	// by the same provider. HTTPS_PROXY, HTTP_PROXY and NO_PROXY select the
+	// proxy the target cluster is reached through, instead of those of the
+	// provider, and all of them are passed to the exec plugins of the
+	// kubeconfig, e.g. paths of token files.
+	// +optional
+	Env []EnvVar `json:"env,omitempty"`
+	// PauseWindows are recurring periods of time, e.g. change freezes, during
+	// which the remote objects of the Objects using this provider config are
+	// observed, but neither created, updated nor deleted. The corrections
+	// that are skipped are reported in the ChangesPending condition of the
+	// Objects, and deletions are retried until the window ends.
+	// +optional
+	PauseWindows []MaintenanceWindow `json:"pauseWindows,omitempty"`
+	// SecretPolicy restricts the namespaces of the Secrets the Objects using
+	// this provider config may read through their references and manifest
+	// sources, so that tenants cannot patch arbitrary Secrets of the control
+	// plane into their remote objects. Secrets are read from any namespace if
+	// it is unset.
+	// +optional
+	SecretPolicy *SecretPolicy `json:"secretPolicy,omitempty"`
+	// RequestIdentity configures how the requests of the provider identify
+	// themselves to the target cluster, so that its API Priority and
+	// Fairness FlowSchemas can classify, e.g. deprioritize, them and its
+	// audit logs can attribute them.
+	// +optional
+	RequestIdentity *RequestIdentity `json:"requestIdentity,omitempty"`
+	// Tunnel the target cluster is reached through, if the control plane
+	// has no direct network route to its API server, e.g. a private cluster.
+	// The server of the kubeconfig is still the address of the API server,
+	// and its certificate is verified as usual.
+	// +optional
+	Tunnel *Tunnel `json:"tunnel,omitempty"`
+	// ScopedIdentity makes the provider use the credentials only to create
+	// a ServiceAccount with a Role on the target cluster and to request its
+	// tokens, and use the ServiceAccount for every other request to the
+	// target cluster, so that broad credentials are not used to manage
+	// resources. The ServiceAccount, Role and RoleBinding are deleted from
+	// the target cluster again once they move to another namespace, are no
+	// longer asked for or the provider config is deleted.
+	// +optional
+	ScopedIdentity *ScopedIdentity `json:"scopedIdentity,omitempty"`
+}
+
+// A ScopedIdentity is a ServiceAccount on a target cluster that is allowed to
+// do what a Role allows in a namespace.
+type ScopedIdentity struct {
+	// Namespace of the target cluster the ServiceAccount, Role and
+	// RoleBinding are created in. It must exist.
+	Namespace string `json:"namespace"`
+	// Rules of the Role of the ServiceAccount.
+	Rules []rbacv1.PolicyRule `json:"rules"`
+	// SecretRef is the Secret of the control plane the kubeconfig of the
+	// ServiceAccount is written to, at the key kubeconfig.
+	SecretRef xpv1.SecretReference `json:"secretRef"`
+	// TokenExpiration is how long the tokens of the ServiceAccount are valid.
+	// Tokens are renewed once two thirds of it elapsed.
+	// +optional
+	// +kubebuilder:default="24h"
+	TokenExpiration *metav1.Duration `json:"tokenExpiration,omitempty"`
+}
+
+// A TunnelType is a kind of tunnel to a target cluster.
+// +kubebuilder:validation:Enum=SSH;PortForward
+type TunnelType string
+
+// Supported tunnel types.
+const (
+	// TunnelTypeSSH reaches the target cluster through an SSH jump host.
+	TunnelTypeSSH TunnelType = "SSH"
+	// TunnelTypePortForward reaches the target cluster through a port of a
+	// pod of the control plane, e.g. a Konnectivity or cluster-gateway style
+	// proxy, by port-forwarding to it. It requires the provider to be started
+	// with the EnableAlphaPortForwardTunnels feature flag.
+	TunnelTypePortForward TunnelType = "PortForward"
+)
+
+// A Tunnel to a target cluster.
+type Tunnel struct {
+	// Type of the tunnel.
+	Type TunnelType `json:"type"`
+	// SSH configures the SSH jump host of SSH tunnels.
+	// +optional
+	SSH *SSHTunnel `json:"ssh,omitempty"`
+	// PortForward configures the pods of the control plane that
+	// PortForward tunnels forward to.
+	// +optional
+	PortForward *PortForwardTunnel `json:"portForward,omitempty"`
+}
+
+// An SSHTunnel reaches a target cluster through an SSH jump host.
+type SSHTunnel struct {
+	// Address of the jump host, as host:port.
+	Address string `json:"address"`
+	// User to log in to the jump host as.
+	User string `json:"user"`
+	// PrivateKeySecretRef selects the key of a Secret holding the PEM encoded
+	// private key to log in to the jump host with.
+	PrivateKeySecretRef xpv1.SecretKeySelector `json:"privateKeySecretRef"`
+	// HostKey is the public key of the jump host in authorized_keys format,
+	// e.g. "ssh-ed25519 AAAA...". Jump hosts with other keys are refused.
+	HostKey string `json:"hostKey"`
+}
+
+// A PortForwardTunnel reaches a target cluster through a port of a pod of the
+// control plane that forwards the connections to it to the API server of the
+// target cluster. The provider needs to be allowed to list the pods and to
+// create pods/portforward in the namespace of the pods.
+type PortForwardTunnel struct {
+	// Namespace of the pods.
+	Namespace string `json:"namespace"`
+	// Selector matches the labels of the pods. A running and ready pod among
+	// them is forwarded to.
+	Selector map[string]string `json:"selector"`
+	// Port of the pods to forward to.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// RequestIdentity configures the identity of the requests to a target cluster.
+type RequestIdentity struct {
+	// UserAgent of the requests, instead of the user agent of the provider.
+	// The name of the Object a request is made for is appended to the user
+	// agent in any case, e.g. "provider-kubernetes object/my-object".
+	// +optional
+	UserAgent string `json:"userAgent,omitempty"`
+	// Impersonate is the identity the requests impersonate, instead of the
+	// one the kubeconfig impersonates, if any, e.g. a user or group a
+	// FlowSchema matches. The credentials must be allowed to impersonate it.
+	// +optional
+	Impersonate *Impersonation `json:"impersonate,omitempty"`
+}
+
+// Impersonation is an identity requests impersonate.
+type Impersonation struct {
+	// User to impersonate.
+	User string `json:"user"`
+	// Groups to impersonate.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// A SecretPolicy restricts the namespaces Objects may read Secrets from.
+type SecretPolicy struct {
+	// AllowedNamespaces are the namespaces Secrets may be read from.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowClaimNamespace allows reading Secrets from the namespace of the
+	// claim of an Object, as labeled crossplane.io/claim-namespace, so that
+	// tenants can read their own Secrets only.
+	// +optional
+	AllowClaimNamespace bool `json:"allowClaimNamespace,omitempty"`
+}
+
+// A Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type Weekday string
+
+// A MaintenanceWindow is a recurring period of time.
+type MaintenanceWindow struct {
+	// Days of the week the window starts on. The window starts every day if
+	// there are none.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+	// Start is the time of day the window starts at, as HH:MM.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window, e.g. 4h.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone of the start time, as an IANA time zone name, e.g.
+	// Europe/Helsinki.
+	// +optional
+	// +kubebuilder:default=UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// A RateLimit is a token bucket rate limit.
+type RateLimit struct {
+	// Rate is the number of events allowed per second.
+	// +kubebuilder:validation:Minimum=1
+	Rate int `json:"rate"`
+	// Burst is the number of events allowed at once. Defaults to the rate.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int `json:"burst,omitempty"`
+}
+
+// An EnvVar is an environment variable.
+type EnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+	// Value of the environment variable.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// A PermissionCheck is a set of verbs the credentials of a provider config
+// must allow on a kind of resources of the target cluster.
+type PermissionCheck struct {
+	// Group of the resources, e.g. apps, or * for all groups. Empty for the
+	// core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Resource is the plural name of the resources, e.g. deployments, or *
+	// for all resources of the group.
+	Resource string `json:"resource"`
+	// Namespace the verbs must be allowed in. Empty for all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Verbs that must be allowed, e.g. get or patch.
+	// +kubebuilder:validation:MinItems=1
+	Verbs []string `json:"verbs"`
+}
+
+// ObjectDefaults are inherited by the Objects using a provider config. Since
+// Objects always have management and deletion policies, those of an Object are
+// only replaced if they are the defaults of [*] and Delete, unless enforced.
+// They are replaced whenever an Object is reconciled, rather than written to
+// its spec, so that changes to the defaults take effect on every Object.
+type ObjectDefaults struct {
+	// ManagementPolicies of the Objects with the default management
+	// policies. They only have an effect if management policies are enabled.
+	// +optional
+	ManagementPolicies xpv1.ManagementPolicies `json:"managementPolicies,omitempty"`
+	// DeletionPolicy of the Objects with the default deletion policy.
+	// +optional
+	// +kubebuilder:validation:Enum=Orphan;Delete
+	DeletionPolicy xpv1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// IgnoreFields are field paths that are not considered when deciding
+	// whether the remote objects are up-to-date, in addition to those of the
+	// Objects.
+	// +optional
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+	// Enforce the management and deletion policies for every Object, even
+	// for those setting their own.
+	// +optional
+	Enforce bool `json:"enforce,omitempty"`
+}
+
+// Attribution configures the labels and annotations identifying the Object
+// managing a remote object. The remote object is labeled with the UID of the
+// Object and annotated with its name and provider config.
+type Attribution struct {
+	// Identity identifies this provider, e.g. the control plane it runs in,
+	// on the remote objects. If set, it is a label of the remote objects.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	// +optional
+	Identity string `json:"identity,omitempty"`
+	// Labels are additional labels of the remote objects.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are additional annotations of the remote objects.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }